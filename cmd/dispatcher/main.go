@@ -0,0 +1,133 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/dispatcher"
+	"github.com/dhima/event-trigger-platform/internal/logging"
+	"github.com/dhima/event-trigger-platform/internal/storage/backend"
+	"github.com/dhima/event-trigger-platform/pkg/config"
+	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
+	"go.uber.org/zap"
+)
+
+func main() {
+	// Load configuration from environment
+	cfg := config.FromEnv()
+
+	// Initialize logger
+	logger, err := logging.NewLogger(cfg.Environment, cfg.LogLevel)
+	if err != nil {
+		log.Fatalf("failed to initialize logger: %v", err)
+	}
+	defer logger.Sync()
+
+	// Create zap logger for the Kafka consumer (needs *zap.Logger, not our Logger interface)
+	var zapLogger *zap.Logger
+	var zapErr error
+	if cfg.Environment == "production" {
+		zapLogger, zapErr = zap.NewProduction()
+	} else {
+		zapLogger, zapErr = zap.NewDevelopment()
+	}
+	if zapErr != nil {
+		log.Fatalf("failed to initialize zap logger for Kafka: %v", zapErr)
+	}
+
+	// Consume the brokers and topic the scheduler and API publish to; the dispatcher only reads Kafka
+	brokers, topic, err := platformEvents.KafkaTarget(cfg.PublisherURL, parseKafkaBrokers(cfg.KafkaBrokers))
+	if err != nil {
+		zapLogger.Fatal("dispatcher requires a Kafka PUBLISHER_URL", zap.Error(err))
+	}
+	zapLogger.Info("starting dispatcher service",
+		zap.String("environment", cfg.Environment),
+		zap.String("database_url", maskPassword(cfg.DatabaseURL)),
+		zap.Strings("kafka_brokers", brokers),
+		zap.String("topic", topic),
+		zap.String("group_id", cfg.DispatcherGroupID))
+
+	// Connect to the database selected by DATABASE_URL (MySQL or embedded SQLite): the dispatcher
+	// reads triggers and records deliveries in the same database as the API and scheduler
+	clk := clock.System()
+	store, err := backend.Open(context.Background(), cfg.DatabaseURL, clk)
+	if err != nil {
+		zapLogger.Fatal("failed to connect to database", zap.Error(err))
+	}
+	defer store.Close()
+	zapLogger.Info("database connection established", zap.String("driver", store.Driver))
+
+	eventDispatcher := dispatcher.NewDispatcher(dispatcher.Config{
+		Timeout:        cfg.DispatcherTimeout,
+		MaxAttempts:    cfg.DispatcherMaxAttempts,
+		RetryBaseDelay: cfg.DispatcherRetryBaseDelay,
+		RetryMaxDelay:  cfg.DispatcherRetryMaxDelay,
+	}, store, zapLogger)
+
+	// Setup graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Handle shutdown signals
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-sigChan
+		zapLogger.Info("received shutdown signal",
+			zap.String("signal", sig.String()))
+		cancel()
+	}()
+
+	// One consumer per worker, all in the same group: Kafka spreads the topic's partitions across
+	// them (and across the other dispatcher instances), preserving per-trigger order
+	zapLogger.Info("dispatcher starting",
+		zap.Int("workers", cfg.DispatcherWorkers),
+		zap.Duration("timeout", cfg.DispatcherTimeout),
+		zap.Int("max_attempts", cfg.DispatcherMaxAttempts))
+
+	var wg sync.WaitGroup
+	for i := 0; i < cfg.DispatcherWorkers; i++ {
+		consumer := platformEvents.NewKafkaConsumer(brokers, topic, cfg.DispatcherGroupID, zapLogger)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := consumer.Run(ctx, eventDispatcher.Dispatch); err != nil && err != context.Canceled {
+				zapLogger.Error("dispatcher consumer stopped with error", zap.Error(err))
+			}
+			if err := consumer.Close(); err != nil {
+				zapLogger.Error("failed to close dispatcher consumer", zap.Error(err))
+			}
+		}()
+	}
+	wg.Wait()
+
+	zapLogger.Info("dispatcher service shut down successfully")
+}
+
+// parseKafkaBrokers parses comma-separated Kafka broker list.
+func parseKafkaBrokers(brokers string) []string {
+	// Split by comma and trim whitespace
+	brokerList := strings.Split(brokers, ",")
+	for i, broker := range brokerList {
+		brokerList[i] = strings.TrimSpace(broker)
+	}
+	return brokerList
+}
+
+// maskPassword masks the password in the database URL for logging.
+func maskPassword(dsn string) string {
+	// Format: user:password@tcp(host:port)/dbname
+	if idx := strings.Index(dsn, "@"); idx > 0 {
+		if colonIdx := strings.Index(dsn, ":"); colonIdx > 0 && colonIdx < idx {
+			return dsn[:colonIdx+1] + "****" + dsn[idx:]
+		}
+	}
+	return dsn
+}
@@ -2,21 +2,20 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"log"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
-	"time"
 
+	"github.com/dhima/event-trigger-platform/internal/clock"
 	"github.com/dhima/event-trigger-platform/internal/events"
 	"github.com/dhima/event-trigger-platform/internal/logging"
 	"github.com/dhima/event-trigger-platform/internal/scheduler"
-	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/backend"
 	"github.com/dhima/event-trigger-platform/pkg/config"
 	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
@@ -48,41 +47,43 @@ func main() {
 		zap.String("database_url", maskPassword(cfg.DatabaseURL)),
 		zap.String("kafka_brokers", cfg.KafkaBrokers))
 
-	// Connect to MySQL database
-	db, err := sql.Open("mysql", cfg.DatabaseURL)
+	// Connect to the database selected by DATABASE_URL (MySQL or embedded SQLite)
+	clk := clock.System()
+	store, err := backend.Open(context.Background(), cfg.DatabaseURL, clk)
 	if err != nil {
 		zapLogger.Fatal("failed to connect to database", zap.Error(err))
 	}
-	defer db.Close()
+	defer store.Close()
+	zapLogger.Info("database connection established", zap.String("driver", store.Driver))
 
-	// Verify database connection
-	if err := db.Ping(); err != nil {
-		zapLogger.Fatal("failed to ping database", zap.Error(err))
+	// Initialize the event publisher selected by PUBLISHER_URL (Kafka by default)
+	publisher, sink, err := platformEvents.Open(cfg.PublisherURL, parseKafkaBrokers(cfg.KafkaBrokers), zapLogger)
+	if err != nil {
+		zapLogger.Fatal("failed to initialize event publisher", zap.Error(err))
 	}
-	zapLogger.Info("database connection established")
-
-	// Initialize MySQL client
-	mysqlClient := storage.NewMySQLClient(db)
-
-	// Initialize Kafka publisher
-	kafkaBrokers := parseKafkaBrokers(cfg.KafkaBrokers)
-	kafkaPublisher := platformEvents.NewPublisher(kafkaBrokers, "trigger-events", zapLogger)
 	defer func() {
-		if err := kafkaPublisher.Close(); err != nil {
-			zapLogger.Error("failed to close Kafka publisher", zap.Error(err))
+		if err := publisher.Close(); err != nil {
+			zapLogger.Error("failed to close event publisher", zap.Error(err))
 		}
 	}()
-	zapLogger.Info("Kafka publisher initialized",
-		zap.Strings("brokers", kafkaBrokers),
-		zap.String("topic", "trigger-events"))
+	zapLogger.Info("event publisher initialized", zap.String("sink", sink))
 
 	// Initialize EventService
-	eventService := events.NewService(mysqlClient, kafkaPublisher, zapLogger)
+	eventService := events.NewService(store, publisher, clk, zapLogger)
 	zapLogger.Info("event service initialized")
 
-	// Initialize Scheduler Engine (5 second polling interval)
-	tickInterval := 5 * time.Second
-	engine := scheduler.NewEngine(tickInterval, mysqlClient, eventService, zapLogger)
+	// Initialize Scheduler Engine (polls at most every SCHEDULER_INTERVAL, sooner when schedules are due)
+	tickInterval := cfg.SchedulerInterval
+	instanceID := resolveInstanceID(cfg.SchedulerInstanceID)
+	engineConfig := scheduler.Config{
+		Tick:             tickInterval,
+		InstanceID:       instanceID,
+		LeaseDuration:    cfg.SchedulerLeaseDuration,
+		Workers:          cfg.SchedulerWorkers,
+		BatchSize:        cfg.SchedulerBatchSize,
+		WakePollInterval: cfg.SchedulerWakeInterval,
+		Clock:            clk,
+	}
 
 	// Setup graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
@@ -99,15 +100,46 @@ func main() {
 		cancel()
 	}()
 
+	// Backend housekeeping: SQLite retention (MySQL runs it in its EVENT scheduler)
+	go store.RunMaintenance(ctx, zapLogger)
+
+	// Outbox relay: publishes the events whose publish failed (or never ran) right after they were
+	// recorded, including the API's webhook and test-run events
+	relay := events.NewRelay(events.RelayConfig{
+		InstanceID:   instanceID,
+		PollInterval: cfg.OutboxRelayInterval,
+		BatchSize:    cfg.OutboxRelayBatchSize,
+		Clock:        clk,
+	}, store, publisher, zapLogger)
+	go relay.Run(ctx)
+
 	// Run scheduler engine
 	zapLogger.Info("scheduler engine starting",
-		zap.Duration("tick_interval", tickInterval))
+		zap.Duration("tick_interval", tickInterval),
+		zap.String("instance_id", instanceID),
+		zap.Bool("leader_election", cfg.SchedulerLeaderElection))
+
+	var runErr error
+	if cfg.SchedulerLeaderElection {
+		// Only the elected leader runs the engine, with a fresh engine per term
+		elector := scheduler.NewLeaderElector(scheduler.LeaderConfig{
+			InstanceID:    instanceID,
+			LeaseDuration: cfg.SchedulerLeaderLease,
+			RenewInterval: cfg.SchedulerLeaderRenewInterval,
+			Clock:         clk,
+		}, store, zapLogger)
+		runErr = elector.Run(ctx, func(ctx context.Context, term scheduler.LeaderTerm) error {
+			return scheduler.NewEngine(engineConfig, elector.Fence(store, term), eventService, zapLogger).Run(ctx)
+		})
+	} else {
+		runErr = scheduler.NewEngine(engineConfig, store, eventService, zapLogger).Run(ctx)
+	}
 
-	if err := engine.Run(ctx); err != nil {
-		if err == context.Canceled {
+	if runErr != nil {
+		if runErr == context.Canceled {
 			zapLogger.Info("scheduler engine stopped gracefully")
 		} else {
-			zapLogger.Fatal("scheduler engine stopped with error", zap.Error(err))
+			zapLogger.Fatal("scheduler engine stopped with error", zap.Error(runErr))
 		}
 	}
 
@@ -124,6 +156,19 @@ func parseKafkaBrokers(brokers string) []string {
 	return brokerList
 }
 
+// resolveInstanceID returns the configured scheduler instance ID, or derives a unique one
+// from the hostname (the pod name in Kubernetes) plus a random suffix.
+func resolveInstanceID(configured string) string {
+	if configured != "" {
+		return configured
+	}
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "scheduler"
+	}
+	return hostname + "-" + uuid.New().String()[:8]
+}
+
 // maskPassword masks the password in the database URL for logging.
 func maskPassword(dsn string) string {
 	// Format: user:password@tcp(host:port)/dbname
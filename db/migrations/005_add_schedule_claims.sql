@@ -0,0 +1,6 @@
+-- Track which scheduler instance claimed a schedule so that multiple
+-- scheduler replicas never fire the same trigger_schedules row twice.
+ALTER TABLE trigger_schedules
+    ADD COLUMN claimed_by VARCHAR(255) NULL AFTER last_attempt_at,
+    ADD COLUMN claimed_at DATETIME NULL AFTER claimed_by,
+    ADD INDEX idx_status_fire_at (status, fire_at);
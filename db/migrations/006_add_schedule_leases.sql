@@ -0,0 +1,11 @@
+-- Claimed schedules hold a lease that the owning scheduler instance renews while it
+-- processes the row. If the instance crashes, the lease expires and the reaper returns
+-- the schedule to 'pending' so the trigger (and its cron chain) is not lost.
+ALTER TABLE trigger_schedules
+    ADD COLUMN lease_expires_at DATETIME NULL AFTER claimed_at,
+    ADD INDEX idx_status_lease_expires_at (status, lease_expires_at);
+
+-- Rows left in 'processing' before leases existed are recovered on the next reaper pass.
+UPDATE trigger_schedules
+SET lease_expires_at = NOW()
+WHERE status = 'processing';
@@ -0,0 +1,8 @@
+-- CRON occurrences that fell behind may be skipped under the "skip" misfire policy.
+ALTER TABLE trigger_schedules
+    MODIFY COLUMN status ENUM('pending', 'processing', 'completed', 'cancelled', 'skipped') NOT NULL DEFAULT 'pending';
+
+-- Scheduled events record the occurrence they were fired for, which differs from
+-- fired_at when the scheduler was behind (downtime, retries, catch-up).
+ALTER TABLE event_logs
+    ADD COLUMN scheduled_for DATETIME NULL AFTER fired_at;
@@ -0,0 +1,4 @@
+-- Failed schedule attempts are retried with exponential backoff: a reverted schedule
+-- is not claimable again before next_attempt_at (NULL means "as soon as fire_at is due").
+ALTER TABLE trigger_schedules
+    ADD COLUMN next_attempt_at DATETIME NULL AFTER last_attempt_at;
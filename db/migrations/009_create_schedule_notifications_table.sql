@@ -0,0 +1,21 @@
+-- Append-only feed of schedules created through the API. Schedulers tail it by id to wake
+-- up early when a new schedule is due before their next poll.
+CREATE TABLE IF NOT EXISTS schedule_notifications (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    fire_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_created_at (created_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Notifications are only useful for a few seconds; prune them hourly.
+DELIMITER $$
+
+CREATE EVENT IF NOT EXISTS cleanup_schedule_notifications
+ON SCHEDULE EVERY 1 HOUR
+DO
+BEGIN
+    DELETE FROM schedule_notifications
+    WHERE created_at < DATE_SUB(NOW(), INTERVAL 1 HOUR);
+END$$
+
+DELIMITER ;
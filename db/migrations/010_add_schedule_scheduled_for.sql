@@ -0,0 +1,4 @@
+-- CRON triggers may fire each occurrence a little after it (jitter/spread). fire_at is when
+-- the schedule fires; scheduled_for keeps the occurrence it stands for (NULL means fire_at).
+ALTER TABLE trigger_schedules
+    ADD COLUMN scheduled_for DATETIME NULL AFTER fire_at;
@@ -0,0 +1,6 @@
+-- Fixed-interval triggers: fire every `every` duration counted from an anchor time.
+ALTER TABLE triggers
+    MODIFY COLUMN type ENUM('webhook', 'time_scheduled', 'cron_scheduled', 'interval_scheduled') NOT NULL;
+
+ALTER TABLE event_logs
+    MODIFY COLUMN trigger_type ENUM('webhook', 'time_scheduled', 'cron_scheduled', 'interval_scheduled') NOT NULL;
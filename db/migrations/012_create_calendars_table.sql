@@ -0,0 +1,18 @@
+-- Business calendars: named holiday lists and blackout windows that recurring triggers
+-- reference by name (config "calendars") to skip or defer occurrences.
+CREATE TABLE IF NOT EXISTS calendars (
+    id VARCHAR(36) PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    holidays JSON NOT NULL,  -- ["2025-12-25", ...], whole days in timezone
+    windows JSON NOT NULL,   -- [{"start": ..., "end": ...} or {"rrule": ..., "duration": ...}, ...]
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_calendars_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- Occurrences suppressed by a calendar (or the "skip" misfire policy) are logged as skipped,
+-- with the reason.
+ALTER TABLE event_logs
+    MODIFY COLUMN execution_status ENUM('success', 'failure', 'skipped') NOT NULL DEFAULT 'success',
+    ADD COLUMN skip_reason TEXT NULL AFTER error_message;
@@ -0,0 +1,3 @@
+-- Number of occurrences the scheduler fired for a trigger, enforced against the config's max_fires.
+ALTER TABLE triggers
+    ADD COLUMN fire_count INT NOT NULL DEFAULT 0 AFTER config;
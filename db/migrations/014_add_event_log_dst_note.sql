@@ -0,0 +1,4 @@
+-- How a daylight saving transition affected a CRON occurrence (shifted out of a skipped hour,
+-- or one of the runs of a repeated hour), per the trigger's dst_policy.
+ALTER TABLE event_logs
+    ADD COLUMN dst_note TEXT NULL AFTER skip_reason;
@@ -0,0 +1,7 @@
+-- Backfill schedules replay past occurrences of a trigger (POST /triggers/:id/backfill). They sit
+-- beside the trigger's regular schedule and fire as source 'backfill'.
+ALTER TABLE trigger_schedules
+    ADD COLUMN backfill BOOLEAN NOT NULL DEFAULT FALSE AFTER scheduled_for;
+
+ALTER TABLE event_logs
+    MODIFY COLUMN source ENUM('webhook', 'scheduler', 'manual-test', 'backfill') NOT NULL;
@@ -0,0 +1,14 @@
+-- Pause/resume history of triggers (POST /triggers/:id/pause and /resume). A resume records the
+-- resume_policy applied, how many occurrences came due while paused and the first schedule after it.
+CREATE TABLE IF NOT EXISTS trigger_pause_events (
+    id VARCHAR(36) PRIMARY KEY,
+    trigger_id VARCHAR(36) NOT NULL,
+    action ENUM('pause', 'resume') NOT NULL,
+    resume_policy ENUM('fire_once', 'skip') NULL,
+    missed_occurrences INT NOT NULL DEFAULT 0,
+    next_fire_at DATETIME NULL,
+    reason TEXT NULL,
+    created_at DATETIME(6) NOT NULL,  -- sub-second, so a pause and a quick resume keep their order
+    INDEX idx_trigger_pause_events_trigger_id_created_at (trigger_id, created_at),
+    FOREIGN KEY (trigger_id) REFERENCES triggers(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,7 @@
+-- Events of triggers with concurrency_policy forbid or replace stay 'running' until their consumer
+-- acknowledges them (POST /events/:id/ack) as success or failure; 'replace' marks a running event
+-- 'replaced' when the next occurrence fires.
+ALTER TABLE event_logs
+    MODIFY COLUMN execution_status ENUM('success', 'failure', 'skipped', 'running', 'replaced') NOT NULL DEFAULT 'success',
+    ADD COLUMN acknowledged_at DATETIME NULL AFTER dst_note,
+    ADD INDEX idx_event_logs_trigger_id_execution_status (trigger_id, execution_status);
@@ -0,0 +1,10 @@
+-- Leader lease of the scheduler's leader-election mode (SCHEDULER_LEADER_ELECTION). Only the holder
+-- of an unexpired lease runs the engine; fencing_token grows with every acquisition.
+CREATE TABLE IF NOT EXISTS scheduler_leases (
+    name VARCHAR(64) PRIMARY KEY,
+    holder VARCHAR(255) NOT NULL,
+    fencing_token BIGINT NOT NULL,
+    acquired_at DATETIME(6) NOT NULL,
+    renewed_at DATETIME(6) NOT NULL,
+    expires_at DATETIME(6) NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,39 @@
+-- Transactional outbox: each event's Kafka message is written in the same transaction as its event
+-- log (and, for scheduler events, the completion of its schedule). The firing process publishes it
+-- right after commit; relays lease rows still pending (claimed_by, lease_expires_at) and retry them
+-- with backoff (next_attempt_at) until Kafka accepts them.
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL,
+    message_key VARCHAR(36) NOT NULL,          -- Kafka message key (trigger_id)
+    payload JSON NOT NULL,                     -- Kafka message value (the trigger event)
+    status ENUM('pending', 'sent') NOT NULL DEFAULT 'pending',
+    attempt_count INT NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    claimed_by VARCHAR(255) NULL,
+    lease_expires_at DATETIME NULL,
+    last_error TEXT NULL,
+    created_at DATETIME NOT NULL,
+    sent_at DATETIME NULL,
+    UNIQUE KEY uk_event_outbox_event_id (event_id),
+    INDEX idx_event_outbox_status_next_attempt_at (status, next_attempt_at),
+    INDEX idx_event_outbox_sent_at (sent_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+-- When the event's message was accepted by Kafka (NULL while it waits in the outbox).
+ALTER TABLE event_logs
+    ADD COLUMN published_at DATETIME NULL AFTER acknowledged_at;
+
+-- Sent messages are kept a day for inspection; prune them hourly.
+DELIMITER $$
+
+CREATE EVENT IF NOT EXISTS cleanup_event_outbox
+ON SCHEDULE EVERY 1 HOUR
+DO
+BEGIN
+    DELETE FROM event_outbox
+    WHERE status = 'sent'
+    AND sent_at < DATE_SUB(NOW(), INTERVAL 24 HOUR);
+END$$
+
+DELIMITER ;
@@ -0,0 +1,9 @@
+-- Outcome of the HTTP call the dispatcher (cmd/dispatcher) makes for an event: delivered or failed
+-- after delivery_attempts attempts, with the endpoint's last status code or the last error.
+-- NULL delivery_status: not dispatched (yet).
+ALTER TABLE event_logs
+    ADD COLUMN delivery_status ENUM('delivered', 'failed') NULL AFTER published_at,
+    ADD COLUMN delivery_attempts INT NOT NULL DEFAULT 0 AFTER delivery_status,
+    ADD COLUMN delivery_status_code INT NULL AFTER delivery_attempts,
+    ADD COLUMN delivery_error TEXT NULL AFTER delivery_status_code,
+    ADD COLUMN delivery_completed_at DATETIME NULL AFTER delivery_error;
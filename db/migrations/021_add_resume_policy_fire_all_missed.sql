@@ -0,0 +1,3 @@
+-- Resuming a trigger may replay all the occurrences it missed while paused (resume_policy fire_all_missed).
+ALTER TABLE trigger_pause_events
+    MODIFY COLUMN resume_policy ENUM('fire_once', 'fire_all_missed', 'skip') NULL;
@@ -23,67 +23,21 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/triggers": {
+        "/api/v1/calendars": {
             "get": {
-                "description": "Retrieves a list of triggers with optional filtering and pagination",
+                "description": "Retrieves every calendar, ordered by name",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Triggers"
-                ],
-                "summary": "List all triggers",
-                "parameters": [
-                    {
-                        "enum": [
-                            "webhook",
-                            "time_scheduled",
-                            "cron_scheduled"
-                        ],
-                        "type": "string",
-                        "description": "Filter by trigger type",
-                        "name": "type",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "active",
-                            "inactive"
-                        ],
-                        "type": "string",
-                        "description": "Filter by trigger status",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
+                    "Calendars"
                 ],
+                "summary": "List business calendars",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/TriggerListResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid query parameters",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                            "$ref": "#/definitions/CalendarListResponse"
                         }
                     },
                     "500": {
@@ -95,7 +49,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Creates a trigger with configuration. Webhook triggers return a webhook URL.",
+                "description": "Creates a named calendar of holidays and blackout windows. Recurring triggers list calendar names in their \"calendars\" config to skip or defer occurrences inside them.",
                 "consumes": [
                     "application/json"
                 ],
@@ -103,17 +57,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Triggers"
+                    "Calendars"
                 ],
-                "summary": "Create a new trigger",
+                "summary": "Create a business calendar",
                 "parameters": [
                     {
-                        "description": "Trigger configuration",
-                        "name": "trigger",
+                        "description": "Calendar definition",
+                        "name": "calendar",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/CreateTriggerRequest"
+                            "$ref": "#/definitions/CreateCalendarRequest"
                         }
                     }
                 ],
@@ -121,7 +75,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/TriggerResponse"
+                            "$ref": "#/definitions/CalendarResponse"
                         }
                     },
                     "400": {
@@ -130,6 +84,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
                         }
                     },
+                    "409": {
+                        "description": "Calendar name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -139,20 +99,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/triggers/{id}": {
+        "/api/v1/calendars/{id}": {
             "get": {
-                "description": "Retrieves details of a specific trigger by ID",
+                "description": "Retrieves a calendar by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Triggers"
+                    "Calendars"
                 ],
-                "summary": "Get trigger details",
+                "summary": "Get calendar details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Trigger ID",
+                        "description": "Calendar ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -162,11 +122,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/TriggerResponse"
+                            "$ref": "#/definitions/CalendarResponse"
                         }
                     },
                     "404": {
-                        "description": "Trigger not found",
+                        "description": "Calendar not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
                         }
@@ -180,7 +140,7 @@ const docTemplate = `{
                 }
             },
             "put": {
-                "description": "Updates an existing trigger's metadata or configuration. Only affects future trigger firings.",
+                "description": "Replaces a calendar's timezone, holidays or windows. Triggers referencing the calendar apply the change from their next occurrence.",
                 "consumes": [
                     "application/json"
                 ],
@@ -188,24 +148,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Triggers"
+                    "Calendars"
                 ],
-                "summary": "Update a trigger",
+                "summary": "Update a calendar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Trigger ID",
+                        "description": "Calendar ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated trigger data",
-                        "name": "trigger",
+                        "description": "Updated calendar fields",
+                        "name": "calendar",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/UpdateTriggerRequest"
+                            "$ref": "#/definitions/UpdateCalendarRequest"
                         }
                     }
                 ],
@@ -213,7 +173,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/TriggerResponse"
+                            "$ref": "#/definitions/CalendarResponse"
                         }
                     },
                     "400": {
@@ -223,7 +183,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Trigger not found",
+                        "description": "Calendar not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
                         }
@@ -237,18 +197,18 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Deletes a trigger. Event logs are not deleted and follow their retention lifecycle.",
+                "description": "Deletes a calendar. Triggers that still reference it fire as if it had no exclusions.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Triggers"
+                    "Calendars"
                 ],
-                "summary": "Delete a trigger",
+                "summary": "Delete a calendar",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Trigger ID",
+                        "description": "Calendar ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -256,10 +216,10 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "204": {
-                        "description": "Trigger deleted successfully"
+                        "description": "Calendar deleted successfully"
                     },
                     "404": {
-                        "description": "Trigger not found",
+                        "description": "Calendar not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
                         }
@@ -273,34 +233,65 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/triggers/{id}/test": {
+        "/api/v1/cron/preview": {
             "post": {
-                "description": "Fires a trigger once for testing. Creates an event log with is_test_run=true.",
+                "description": "Validates a CRON expression and timezone and returns the next runs, annotated with their UTC offset and daylight saving changes. Nothing is stored.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Triggers"
                 ],
-                "summary": "Test a trigger (manual/test run)",
+                "summary": "Preview a CRON expression",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Trigger ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "CRON expression and timezone",
+                        "name": "preview",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CronPreviewRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "202": {
-                        "description": "Test trigger fired successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.SuccessResponse"
+                            "$ref": "#/definitions/CronPreviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid expression or timezone",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/scheduler/leader": {
+            "get": {
+                "description": "Returns the scheduler instance elected leader when the schedulers run with SCHEDULER_LEADER_ELECTION. \"active\" is false once its lease has expired and no standby has taken over yet.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get the scheduler leader",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SchedulerLeaderResponse"
                         }
                     },
                     "404": {
-                        "description": "Trigger not found",
+                        "description": "No leader was ever elected",
                         "schema": {
                             "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
                         }
@@ -314,53 +305,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/events": {
+        "/api/v1/triggers": {
             "get": {
-                "description": "Retrieves event logs with filtering and pagination. By default shows only active events (last 2 hours).",
+                "description": "Retrieves a list of triggers with optional filtering and pagination",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Triggers"
                 ],
-                "summary": "List event logs",
+                "summary": "List all triggers",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Filter by trigger ID",
-                        "name": "trigger_id",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "active",
-                            "archived"
-                        ],
-                        "type": "string",
-                        "default": "active",
-                        "description": "Filter by retention status",
-                        "name": "retention_status",
-                        "in": "query"
-                    },
                     {
                         "enum": [
-                            "success",
-                            "failure"
+                            "webhook",
+                            "time_scheduled",
+                            "cron_scheduled",
+                            "interval_scheduled"
                         ],
                         "type": "string",
-                        "description": "Filter by execution status",
-                        "name": "execution_status",
+                        "description": "Filter by trigger type",
+                        "name": "type",
                         "in": "query"
                     },
                     {
                         "enum": [
-                            "webhook",
-                            "scheduler",
-                            "manual-test"
+                            "active",
+                            "inactive"
                         ],
                         "type": "string",
-                        "description": "Filter by event source",
-                        "name": "source",
+                        "description": "Filter by trigger status",
+                        "name": "status",
                         "in": "query"
                     },
                     {
@@ -385,7 +360,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/EventLogListResponse"
+                            "$ref": "#/definitions/TriggerListResponse"
                         }
                     },
                     "400": {
@@ -401,36 +376,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/events/{id}": {
-            "get": {
-                "description": "Retrieves details of a specific event log by ID, including full payload and error message if failed",
+            },
+            "post": {
+                "description": "Creates a trigger with configuration. Webhook triggers return a webhook URL.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Triggers"
                 ],
-                "summary": "Get event log details",
+                "summary": "Create a new trigger",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Event ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Trigger configuration",
+                        "name": "trigger",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateTriggerRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/EventLogResponse"
+                            "$ref": "#/definitions/TriggerResponse"
                         }
                     },
-                    "404": {
-                        "description": "Event not found",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
                         }
@@ -444,49 +422,712 @@ const docTemplate = `{
                 }
             }
         },
-        "/health": {
+        "/api/v1/triggers/{id}": {
             "get": {
-                "description": "Returns the health status of the API service",
+                "description": "Retrieves details of a specific trigger by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "System"
+                    "Triggers"
                 ],
-                "summary": "Health check endpoint",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/HealthResponse"
-                        }
+                "summary": "Get trigger details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
-                }
-            }
-        },
-        "/metrics": {
-            "get": {
-                "description": "Returns metrics about trigger execution, event counts, and performance",
-                "produces": [
-                    "application/json"
                 ],
-                "tags": [
-                    "System"
-                ],
-                "summary": "Get platform metrics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/MetricsResponse"
+                            "$ref": "#/definitions/TriggerResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/webhook/{trigger_id}": {
-            "post": {
-                "description": "Receives a payload from external systems, validates it against the trigger's JSON schema, and fires the trigger",
+            },
+            "put": {
+                "description": "Updates an existing trigger's metadata or configuration. Only affects future trigger firings. Changing status pauses or resumes the trigger like the pause/resume endpoints, skipping missed occurrences.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "Update a trigger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated trigger data",
+                        "name": "trigger",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateTriggerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TriggerResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes a trigger. Event logs are not deleted and follow their retention lifecycle.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "Delete a trigger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Trigger deleted successfully"
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/triggers/{id}/backfill": {
+            "post": {
+                "description": "Enqueues the occurrences a recurring trigger would have fired in [from, to) as backfill schedules, which fire at rate_per_minute starting now. The trigger's active window and calendars apply; max_fires does not. Events are published with source \"backfill\" and the replayed occurrence as scheduled_for.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "Backfill past occurrences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Time range and rate",
+                        "name": "backfill",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/BackfillRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/BackfillResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range, inactive or non-recurring trigger",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/triggers/{id}/occurrences": {
+            "get": {
+                "description": "Computes the next occurrences of a scheduled trigger from its stored config, applying its active window, max_fires and business calendars. Inactive triggers have none.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "Preview upcoming occurrences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of occurrences",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "List no occurrence after this RFC 3339 time",
+                        "name": "until",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/OccurrenceListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or webhook trigger",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/triggers/{id}/pause": {
+            "post": {
+                "description": "Deactivates an active trigger and cancels its pending schedule, recording the pause in its history. Pending backfill schedules are kept and fire once the trigger is resumed. The body is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "Pause a trigger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Pause reason",
+                        "name": "pause",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/PauseTriggerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TriggerPauseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Trigger is already inactive",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/triggers/{id}/pause-history": {
+            "get": {
+                "description": "Returns the trigger's pause and resume events, newest first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "List a trigger's pause history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/PauseHistoryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/triggers/{id}/resume": {
+            "post": {
+                "description": "Reactivates an inactive trigger and recalculates its schedule. resume_policy decides what happens to the occurrences missed while paused: fire_once fires the most recent of them right away, fire_all_missed fires up to misfire_catchup_limit of them one after the other, skip (the default) continues with the next future occurrence. The body is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "Resume a trigger",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resume policy and reason",
+                        "name": "resume",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/ResumeTriggerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/TriggerPauseResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or nothing left to schedule",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Trigger is already active",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/triggers/{id}/test": {
+            "post": {
+                "description": "Fires a trigger once for testing. Creates an event log with is_test_run=true.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Triggers"
+                ],
+                "summary": "Test a trigger (manual/test run)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Trigger ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Test trigger fired successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.SuccessResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trigger not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "description": "Retrieves event logs with filtering and pagination. By default shows only active events (last 2 hours).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "List event logs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by trigger ID",
+                        "name": "trigger_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "archived"
+                        ],
+                        "type": "string",
+                        "default": "active",
+                        "description": "Filter by retention status",
+                        "name": "retention_status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "success",
+                            "failure",
+                            "skipped",
+                            "running",
+                            "replaced"
+                        ],
+                        "type": "string",
+                        "description": "Filter by execution status",
+                        "name": "execution_status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "webhook",
+                            "scheduler",
+                            "manual-test",
+                            "backfill"
+                        ],
+                        "type": "string",
+                        "description": "Filter by event source",
+                        "name": "source",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/EventLogListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "description": "Retrieves details of a specific event log by ID, including full payload and error message if failed",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get event log details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/EventLogResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/ack": {
+            "post": {
+                "description": "Reports the outcome of a running event, i.e. one published with ack_required for a trigger with concurrency_policy forbid or replace. Until then the trigger's next occurrences are skipped (forbid) or replace it (replace), unless the trigger's running_timeout passes first and the event is marked failed. The body is optional; the status defaults to success.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Acknowledge an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Outcome of the run",
+                        "name": "ack",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/AckEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/EventLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event is not running (already acknowledged, replaced, timed out, or not awaiting acknowledgement)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Returns the health status of the API service",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Health check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/metrics": {
+            "get": {
+                "description": "Returns metrics about trigger execution, event counts, and performance",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "System"
+                ],
+                "summary": "Get platform metrics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/MetricsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook/{trigger_id}": {
+            "post": {
+                "description": "Receives a payload from external systems, validates it against the trigger's JSON schema, and fires the trigger.\nA request repeating the Idempotency-Key (or the value of the trigger's idempotency_key_field) of an earlier one returns that request's event_id with 200 instead of firing again.",
                 "consumes": [
                     "application/json"
                 ],
@@ -505,6 +1146,12 @@ const docTemplate = `{
                         "in": "path",
                         "required": true
                     },
+                    {
+                        "type": "string",
+                        "description": "Deduplicates retried deliveries (at most 255 characters); takes precedence over the trigger's idempotency_key_field",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "description": "Webhook payload (validated against trigger's schema)",
                         "name": "payload",
@@ -517,6 +1164,27 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
+                    "200": {
+                        "description": "Repeated request: the original event, not fired again (Idempotent-Replayed: true)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_api_response.SuccessResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "202": {
                         "description": "Webhook accepted and trigger queued",
                         "schema": {
@@ -558,36 +1226,328 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "CreateTriggerRequest": {
+        }
+    },
+    "definitions": {
+        "AckEventRequest": {
+            "type": "object",
+            "properties": {
+                "error_message": {
+                    "description": "Recorded with a failure",
+                    "type": "string",
+                    "example": "report generation timed out"
+                },
+                "status": {
+                    "description": "Outcome of the run; defaults to success",
+                    "enum": [
+                        "success",
+                        "failure"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.ExecutionStatus"
+                        }
+                    ],
+                    "example": "success"
+                }
+            }
+        },
+        "BackfillRequest": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
+                    "description": "Inclusive",
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "rate_per_minute": {
+                    "description": "Defaults to 60",
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 1,
+                    "example": 30
+                },
+                "to": {
+                    "description": "Exclusive; not in the future",
+                    "type": "string",
+                    "example": "2025-11-08T00:00:00Z"
+                }
+            }
+        },
+        "BackfillResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "example": "2025-11-01T00:00:00Z"
+                },
+                "rate_per_minute": {
+                    "type": "integer",
+                    "example": 60
+                },
+                "schedules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/BackfillScheduleResponse"
+                    }
+                },
+                "to": {
+                    "type": "string",
+                    "example": "2025-11-08T00:00:00Z"
+                },
+                "trigger_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "BackfillScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "fire_at": {
+                    "type": "string",
+                    "example": "2025-11-10T12:00:01Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "6f1c2e4a-8b7d-4c3e-9a1f-2d5e6b7c8d9e"
+                },
+                "scheduled_for": {
+                    "description": "The past occurrence replayed",
+                    "type": "string",
+                    "example": "2025-11-01T09:00:00Z"
+                }
+            }
+        },
+        "CalendarListResponse": {
+            "type": "object",
+            "properties": {
+                "calendars": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CalendarResponse"
+                    }
+                }
+            }
+        },
+        "CalendarResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:00:00Z"
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-12-25",
+                        "2026-01-01"
+                    ]
+                },
+                "id": {
+                    "type": "string",
+                    "example": "770e8400-e29b-41d4-a716-446655440000"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "us-holidays"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:00:00Z"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExclusionWindow"
+                    }
+                }
+            }
+        },
+        "CreateCalendarRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-12-25",
+                        "2026-01-01"
+                    ]
+                },
+                "name": {
+                    "type": "string",
+                    "example": "us-holidays"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExclusionWindow"
+                    }
+                }
+            }
+        },
+        "CreateTriggerRequest": {
+            "type": "object",
+            "required": [
+                "config",
+                "name",
+                "type"
+            ],
+            "properties": {
+                "config": {
+                    "type": "object"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Daily metrics push"
+                },
+                "type": {
+                    "enum": [
+                        "webhook",
+                        "time_scheduled",
+                        "cron_scheduled",
+                        "interval_scheduled"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.TriggerType"
+                        }
+                    ],
+                    "example": "time_scheduled"
+                }
+            }
+        },
+        "CronPreviewRequest": {
+            "type": "object",
+            "required": [
+                "cron"
+            ],
+            "properties": {
+                "count": {
+                    "type": "integer",
+                    "maximum": 100,
+                    "minimum": 1,
+                    "example": 5
+                },
+                "cron": {
+                    "type": "string",
+                    "example": "30 2 * * *"
+                },
+                "dst_policy": {
+                    "description": "Defaults to skip / run_both",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.DSTPolicy"
+                        }
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                }
+            }
+        },
+        "CronPreviewResponse": {
+            "type": "object",
+            "properties": {
+                "cron": {
+                    "type": "string",
+                    "example": "30 2 * * *"
+                },
+                "dst_policy": {
+                    "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.DSTPolicy"
+                },
+                "runs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/CronPreviewRun"
+                    }
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                }
+            }
+        },
+        "CronPreviewRun": {
+            "type": "object",
+            "properties": {
+                "dst_note": {
+                    "type": "string",
+                    "example": "02:30 does not exist on 2026-03-08 in America/New_York (DST starts); skipped"
+                },
+                "is_dst": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "local_time": {
+                    "type": "string",
+                    "example": "2026-03-09T02:30:00-04:00"
+                },
+                "time": {
+                    "type": "string",
+                    "example": "2026-03-09T06:30:00Z"
+                },
+                "utc_offset": {
+                    "type": "string",
+                    "example": "-04:00"
+                }
+            }
+        },
+        "EventDelivery": {
             "type": "object",
-            "required": [
-                "config",
-                "name",
-                "type"
-            ],
             "properties": {
-                "config": {
-                    "type": "object"
+                "attempts": {
+                    "description": "HTTP calls made, retries included",
+                    "type": "integer",
+                    "example": 1
                 },
-                "name": {
+                "completed_at": {
                     "type": "string",
-                    "example": "Daily metrics push"
+                    "example": "2025-11-05T10:30:01Z"
                 },
-                "type": {
+                "error": {
+                    "description": "Why the last attempt failed",
+                    "type": "string",
+                    "example": "unexpected status 503 Service Unavailable"
+                },
+                "status": {
                     "enum": [
-                        "webhook",
-                        "time_scheduled",
-                        "cron_scheduled"
+                        "delivered",
+                        "failed"
                     ],
                     "allOf": [
                         {
-                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.TriggerType"
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.DeliveryStatus"
                         }
                     ],
-                    "example": "time_scheduled"
+                    "example": "delivered"
+                },
+                "status_code": {
+                    "description": "Status of the last response; absent when no response was received",
+                    "type": "integer",
+                    "example": 200
                 }
             }
         },
@@ -608,10 +1568,26 @@ const docTemplate = `{
         "EventLogResponse": {
             "type": "object",
             "properties": {
+                "acknowledged_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:32:10Z"
+                },
                 "created_at": {
                     "type": "string",
                     "example": "2025-11-05T10:30:00Z"
                 },
+                "delivery": {
+                    "description": "Outcome of the dispatcher's HTTP call to the trigger's endpoint; absent until dispatched",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/EventDelivery"
+                        }
+                    ]
+                },
+                "dst_note": {
+                    "type": "string",
+                    "example": "01:30 occurs twice on 2025-11-02 in America/New_York (DST ends); first of two runs, at 01:30 EDT"
+                },
                 "error_message": {
                     "type": "string",
                     "example": "connection timeout"
@@ -639,6 +1615,11 @@ const docTemplate = `{
                 "payload": {
                     "type": "object"
                 },
+                "published_at": {
+                    "description": "When Kafka accepted the event; absent while it waits in the outbox for (re)delivery",
+                    "type": "string",
+                    "example": "2025-11-05T10:30:00Z"
+                },
                 "retention_status": {
                     "allOf": [
                         {
@@ -647,6 +1628,14 @@ const docTemplate = `{
                     ],
                     "example": "active"
                 },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-11-05T10:30:00Z"
+                },
+                "skip_reason": {
+                    "type": "string",
+                    "example": "holiday 2025-12-25 in calendar us-holidays"
+                },
                 "source": {
                     "allOf": [
                         {
@@ -669,6 +1658,31 @@ const docTemplate = `{
                 }
             }
         },
+        "ExclusionWindow": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string",
+                    "example": "48h"
+                },
+                "end": {
+                    "type": "string",
+                    "example": "2026-01-02T00:00:00Z"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Year-end freeze"
+                },
+                "rrule": {
+                    "type": "string",
+                    "example": "RRULE:FREQ=WEEKLY;BYDAY=SA"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2025-12-22T00:00:00Z"
+                }
+            }
+        },
         "HealthResponse": {
             "type": "object",
             "properties": {
@@ -723,6 +1737,47 @@ const docTemplate = `{
                 }
             }
         },
+        "OccurrenceListResponse": {
+            "type": "object",
+            "properties": {
+                "occurrences": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/OccurrenceResponse"
+                    }
+                },
+                "trigger_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "OccurrenceResponse": {
+            "type": "object",
+            "properties": {
+                "fire_at": {
+                    "description": "Includes the spread offset; jitter adds a random delay on top",
+                    "type": "string",
+                    "example": "2025-11-05T09:02:30Z"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "holiday 2025-12-25 in calendar us-holidays"
+                },
+                "scheduled_for": {
+                    "type": "string",
+                    "example": "2025-11-05T09:00:00Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.OccurrenceStatus"
+                        }
+                    ],
+                    "example": "scheduled"
+                }
+            }
+        },
         "Pagination": {
             "type": "object",
             "properties": {
@@ -744,6 +1799,128 @@ const docTemplate = `{
                 }
             }
         },
+        "PauseEventResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.PauseAction"
+                        }
+                    ],
+                    "example": "resume"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:30:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "7d3f5a1e-2c4b-4e8a-9f6d-1b2c3d4e5f60"
+                },
+                "missed_occurrences": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "next_fire_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:30:00Z"
+                },
+                "reason": {
+                    "type": "string",
+                    "example": "Maintenance done"
+                },
+                "resume_policy": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.ResumePolicy"
+                        }
+                    ],
+                    "example": "fire_once"
+                }
+            }
+        },
+        "PauseHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/PauseEventResponse"
+                    }
+                },
+                "trigger_id": {
+                    "type": "string",
+                    "example": "550e8400-e29b-41d4-a716-446655440000"
+                }
+            }
+        },
+        "PauseTriggerRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Downstream maintenance"
+                }
+            }
+        },
+        "ResumeTriggerRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "example": "Maintenance done"
+                },
+                "resume_policy": {
+                    "description": "Defaults to skip",
+                    "enum": [
+                        "fire_once",
+                        "fire_all_missed",
+                        "skip"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.ResumePolicy"
+                        }
+                    ],
+                    "example": "fire_once"
+                }
+            }
+        },
+        "SchedulerLeaderResponse": {
+            "type": "object",
+            "properties": {
+                "acquired_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:30:00Z"
+                },
+                "active": {
+                    "description": "False when the lease expired and no standby has taken over yet",
+                    "type": "boolean",
+                    "example": true
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:35:15Z"
+                },
+                "fencing_token": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "instance_id": {
+                    "type": "string",
+                    "example": "scheduler-7f9c-3a1b2c4d"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "scheduler"
+                },
+                "renewed_at": {
+                    "type": "string",
+                    "example": "2025-11-05T10:35:00Z"
+                }
+            }
+        },
         "TriggerListResponse": {
             "type": "object",
             "properties": {
@@ -758,6 +1935,17 @@ const docTemplate = `{
                 }
             }
         },
+        "TriggerPauseResponse": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/PauseEventResponse"
+                },
+                "trigger": {
+                    "$ref": "#/definitions/TriggerResponse"
+                }
+            }
+        },
         "TriggerResponse": {
             "type": "object",
             "properties": {
@@ -768,6 +1956,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2025-11-05T10:00:00Z"
                 },
+                "expires_at": {
+                    "description": "The config's end_at",
+                    "type": "string",
+                    "example": "2025-12-31T23:59:59Z"
+                },
                 "id": {
                     "type": "string",
                     "example": "550e8400-e29b-41d4-a716-446655440000"
@@ -780,6 +1973,11 @@ const docTemplate = `{
                     "type": "string",
                     "example": "2025-11-05T15:00:00Z"
                 },
+                "remaining_fires": {
+                    "description": "Set when the config has max_fires",
+                    "type": "integer",
+                    "example": 7
+                },
                 "status": {
                     "allOf": [
                         {
@@ -806,6 +2004,31 @@ const docTemplate = `{
                 }
             }
         },
+        "UpdateCalendarRequest": {
+            "type": "object",
+            "properties": {
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "2025-12-25",
+                        "2026-01-01"
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "America/New_York"
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ExclusionWindow"
+                    }
+                }
+            }
+        },
         "UpdateTriggerRequest": {
             "type": "object",
             "properties": {
@@ -817,6 +2040,7 @@ const docTemplate = `{
                     "example": "Daily metrics push"
                 },
                 "status": {
+                    "description": "Changing it pauses or resumes the trigger (resume_policy skip)",
                     "enum": [
                         "active",
                         "inactive"
@@ -851,28 +2075,163 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_dhima_event-trigger-platform_internal_models.DSTGapPolicy": {
+            "type": "string",
+            "enum": [
+                "skip",
+                "shift_forward"
+            ],
+            "x-enum-varnames": [
+                "DSTGapSkip",
+                "DSTGapShiftForward"
+            ]
+        },
+        "github_com_dhima_event-trigger-platform_internal_models.DSTOverlapPolicy": {
+            "type": "string",
+            "enum": [
+                "run_both",
+                "run_once"
+            ],
+            "x-enum-varnames": [
+                "DSTOverlapRunBoth",
+                "DSTOverlapRunOnce"
+            ]
+        },
+        "github_com_dhima_event-trigger-platform_internal_models.DSTPolicy": {
+            "type": "object",
+            "properties": {
+                "fall_back": {
+                    "enum": [
+                        "run_both",
+                        "run_once"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.DSTOverlapPolicy"
+                        }
+                    ],
+                    "example": "run_once"
+                },
+                "spring_forward": {
+                    "enum": [
+                        "skip",
+                        "shift_forward"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_dhima_event-trigger-platform_internal_models.DSTGapPolicy"
+                        }
+                    ],
+                    "example": "skip"
+                }
+            }
+        },
+        "github_com_dhima_event-trigger-platform_internal_models.DeliveryStatus": {
+            "type": "string",
+            "enum": [
+                "delivered",
+                "failed"
+            ],
+            "x-enum-comments": {
+                "DeliveryStatusDelivered": "The endpoint answered with a 2xx status",
+                "DeliveryStatusFailed": "Every attempt failed, or the endpoint rejected the request"
+            },
+            "x-enum-descriptions": [
+                "The endpoint answered with a 2xx status",
+                "Every attempt failed, or the endpoint rejected the request"
+            ],
+            "x-enum-varnames": [
+                "DeliveryStatusDelivered",
+                "DeliveryStatusFailed"
+            ]
+        },
         "github_com_dhima_event-trigger-platform_internal_models.EventSource": {
             "type": "string",
             "enum": [
                 "webhook",
                 "scheduler",
-                "manual-test"
+                "manual-test",
+                "backfill"
             ],
             "x-enum-varnames": [
                 "EventSourceWebhook",
                 "EventSourceScheduler",
-                "EventSourceManualTest"
+                "EventSourceManualTest",
+                "EventSourceBackfill"
             ]
         },
         "github_com_dhima_event-trigger-platform_internal_models.ExecutionStatus": {
             "type": "string",
             "enum": [
                 "success",
-                "failure"
+                "failure",
+                "skipped",
+                "running",
+                "replaced"
+            ],
+            "x-enum-comments": {
+                "ExecutionStatusReplaced": "Running event superseded by a newer occurrence (concurrency_policy replace)",
+                "ExecutionStatusSkipped": "Occurrence not fired; SkipReason says why"
+            },
+            "x-enum-descriptions": [
+                "",
+                "",
+                "Occurrence not fired; SkipReason says why",
+                "",
+                "Running event superseded by a newer occurrence (concurrency_policy replace)"
             ],
             "x-enum-varnames": [
                 "ExecutionStatusSuccess",
-                "ExecutionStatusFailure"
+                "ExecutionStatusFailure",
+                "ExecutionStatusSkipped",
+                "ExecutionStatusRunning",
+                "ExecutionStatusReplaced"
+            ]
+        },
+        "github_com_dhima_event-trigger-platform_internal_models.OccurrenceStatus": {
+            "type": "string",
+            "enum": [
+                "scheduled",
+                "skipped",
+                "deferred"
+            ],
+            "x-enum-comments": {
+                "OccurrenceStatusDeferred": "Inside a calendar exclusion, fires when it ends",
+                "OccurrenceStatusSkipped": "Inside a calendar exclusion (calendar_policy skip)"
+            },
+            "x-enum-descriptions": [
+                "",
+                "Inside a calendar exclusion (calendar_policy skip)",
+                "Inside a calendar exclusion, fires when it ends"
+            ],
+            "x-enum-varnames": [
+                "OccurrenceStatusScheduled",
+                "OccurrenceStatusSkipped",
+                "OccurrenceStatusDeferred"
+            ]
+        },
+        "github_com_dhima_event-trigger-platform_internal_models.PauseAction": {
+            "type": "string",
+            "enum": [
+                "pause",
+                "resume"
+            ],
+            "x-enum-varnames": [
+                "PauseActionPause",
+                "PauseActionResume"
+            ]
+        },
+        "github_com_dhima_event-trigger-platform_internal_models.ResumePolicy": {
+            "type": "string",
+            "enum": [
+                "fire_once",
+                "fire_all_missed",
+                "skip"
+            ],
+            "x-enum-varnames": [
+                "ResumePolicyFireOnce",
+                "ResumePolicyFireAllMissed",
+                "ResumePolicySkip"
             ]
         },
         "github_com_dhima_event-trigger-platform_internal_models.RetentionStatus": {
@@ -904,12 +2263,14 @@ const docTemplate = `{
             "enum": [
                 "webhook",
                 "time_scheduled",
-                "cron_scheduled"
+                "cron_scheduled",
+                "interval_scheduled"
             ],
             "x-enum-varnames": [
                 "TriggerTypeWebhook",
                 "TriggerTypeTimeScheduled",
-                "TriggerTypeCronScheduled"
+                "TriggerTypeCronScheduled",
+                "TriggerTypeIntervalScheduled"
             ]
         }
     },
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/dhima/event-trigger-platform/internal/api/response"
+	"github.com/dhima/event-trigger-platform/internal/logging"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// CalendarHandler handles business calendar management requests.
+type CalendarHandler struct {
+	logger  logging.Logger
+	service *triggers.Service
+}
+
+// NewCalendarHandler creates a new calendar handler.
+func NewCalendarHandler(logger logging.Logger, service *triggers.Service) *CalendarHandler {
+	return &CalendarHandler{
+		logger:  logger.With(zap.String("handler", "calendar")),
+		service: service,
+	}
+}
+
+// CreateCalendar godoc
+// @Summary Create a business calendar
+// @Description Creates a named calendar of holidays and blackout windows. Recurring triggers list calendar names in their "calendars" config to skip or defer occurrences inside them.
+// @Tags Calendars
+// @Accept json
+// @Produce json
+// @Param calendar body models.CreateCalendarRequest true "Calendar definition"
+// @Success 201 {object} models.CalendarResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid request"
+// @Failure 409 {object} response.ErrorResponse "Calendar name already exists"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/calendars [post]
+func (h *CalendarHandler) CreateCalendar(c *gin.Context) {
+	var req models.CreateCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid create calendar request",
+			zap.Error(err),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	result, err := h.service.CreateCalendar(c.Request.Context(), req)
+	if h.handleServiceError(c, err, "create calendar") {
+		return
+	}
+
+	h.logger.Info("calendar created",
+		zap.String("calendar_id", result.ID),
+		zap.String("name", result.Name),
+		zap.String("request_id", response.GetRequestID(c)),
+	)
+
+	response.Created(c, result, "calendar created successfully")
+}
+
+// ListCalendars godoc
+// @Summary List business calendars
+// @Description Retrieves every calendar, ordered by name
+// @Tags Calendars
+// @Produce json
+// @Success 200 {object} models.CalendarListResponse
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/calendars [get]
+func (h *CalendarHandler) ListCalendars(c *gin.Context) {
+	result, err := h.service.ListCalendars(c.Request.Context())
+	if h.handleServiceError(c, err, "list calendars") {
+		return
+	}
+
+	response.Success(c, http.StatusOK, result, "")
+}
+
+// GetCalendar godoc
+// @Summary Get calendar details
+// @Description Retrieves a calendar by ID
+// @Tags Calendars
+// @Produce json
+// @Param id path string true "Calendar ID"
+// @Success 200 {object} models.CalendarResponse
+// @Failure 404 {object} response.ErrorResponse "Calendar not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/calendars/{id} [get]
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	result, err := h.service.GetCalendar(c.Request.Context(), c.Param("id"))
+	if h.handleServiceError(c, err, "get calendar") {
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// UpdateCalendar godoc
+// @Summary Update a calendar
+// @Description Replaces a calendar's timezone, holidays or windows. Triggers referencing the calendar apply the change from their next occurrence.
+// @Tags Calendars
+// @Accept json
+// @Produce json
+// @Param id path string true "Calendar ID"
+// @Param calendar body models.UpdateCalendarRequest true "Updated calendar fields"
+// @Success 200 {object} models.CalendarResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid request"
+// @Failure 404 {object} response.ErrorResponse "Calendar not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/calendars/{id} [put]
+func (h *CalendarHandler) UpdateCalendar(c *gin.Context) {
+	calendarID := c.Param("id")
+
+	var req models.UpdateCalendarRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid update calendar request",
+			zap.Error(err),
+			zap.String("calendar_id", calendarID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	result, err := h.service.UpdateCalendar(c.Request.Context(), calendarID, req)
+	if h.handleServiceError(c, err, "update calendar") {
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// DeleteCalendar godoc
+// @Summary Delete a calendar
+// @Description Deletes a calendar. Triggers that still reference it fire as if it had no exclusions.
+// @Tags Calendars
+// @Produce json
+// @Param id path string true "Calendar ID"
+// @Success 204 "Calendar deleted successfully"
+// @Failure 404 {object} response.ErrorResponse "Calendar not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/calendars/{id} [delete]
+func (h *CalendarHandler) DeleteCalendar(c *gin.Context) {
+	if h.handleServiceError(c, h.service.DeleteCalendar(c.Request.Context(), c.Param("id")), "delete calendar") {
+		return
+	}
+
+	response.NoContent(c)
+}
+
+func (h *CalendarHandler) handleServiceError(c *gin.Context, err error, operation string) bool {
+	if err == nil {
+		return false
+	}
+
+	var validationErr triggers.ValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		response.BadRequest(c, "validation failed", validationErr.Error())
+	case errors.Is(err, storage.ErrCalendarNotFound):
+		response.NotFound(c, "calendar not found")
+	case errors.Is(err, storage.ErrCalendarNameTaken):
+		response.Conflict(c, "calendar name already exists", nil)
+	default:
+		h.logger.Error(operation+" failed",
+			zap.Error(err),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.InternalServerError(c, "internal server error")
+	}
+	return true
+}
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
+	"io"
+
 	"github.com/dhima/event-trigger-platform/internal/api/response"
 	"github.com/dhima/event-trigger-platform/internal/events"
 	"github.com/dhima/event-trigger-platform/internal/logging"
 	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
@@ -30,8 +34,8 @@ func NewEventHandler(eventService *events.Service, logger logging.Logger) *Event
 // @Produce json
 // @Param trigger_id query string false "Filter by trigger ID"
 // @Param retention_status query string false "Filter by retention status" Enums(active, archived) default(active)
-// @Param execution_status query string false "Filter by execution status" Enums(success, failure)
-// @Param source query string false "Filter by event source" Enums(webhook, scheduler, manual-test)
+// @Param execution_status query string false "Filter by execution status" Enums(success, failure, skipped, running, replaced)
+// @Param source query string false "Filter by event source" Enums(webhook, scheduler, manual-test, backfill)
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
 // @Success 200 {object} models.EventLogListResponse
@@ -83,20 +87,8 @@ func (h *EventHandler) ListEvents(c *gin.Context) {
 
 	// Convert to response format
 	eventResponses := make([]models.EventLogResponse, len(eventLogs))
-	for i, event := range eventLogs {
-		eventResponses[i] = models.EventLogResponse{
-			ID:              event.ID,
-			TriggerID:       event.TriggerID,
-			TriggerType:     event.TriggerType,
-			FiredAt:         event.FiredAt,
-			Payload:         event.Payload,
-			Source:          event.Source,
-			ExecutionStatus: event.ExecutionStatus,
-			ErrorMessage:    event.ErrorMessage,
-			RetentionStatus: event.RetentionStatus,
-			IsTestRun:       event.IsTestRun,
-			CreatedAt:       event.CreatedAt,
-		}
+	for i := range eventLogs {
+		eventResponses[i] = buildEventLogResponse(&eventLogs[i])
 	}
 
 	result := models.EventLogListResponse{
@@ -153,24 +145,91 @@ func (h *EventHandler) GetEvent(c *gin.Context) {
 	}
 
 	// Convert to response format
-	eventResponse := models.EventLogResponse{
+	eventResponse := buildEventLogResponse(event)
+
+	h.logger.Info("event retrieved successfully",
+		zap.String("event_id", eventID),
+		zap.String("request_id", response.GetRequestID(c)),
+	)
+
+	response.OK(c, eventResponse)
+}
+
+// AckEvent godoc
+// @Summary Acknowledge an event
+// @Description Reports the outcome of a running event, i.e. one published with ack_required for a trigger with concurrency_policy forbid or replace. Until then the trigger's next occurrences are skipped (forbid) or replace it (replace), unless the trigger's running_timeout passes first and the event is marked failed. The body is optional; the status defaults to success.
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param id path string true "Event ID"
+// @Param ack body models.AckEventRequest false "Outcome of the run"
+// @Success 200 {object} models.EventLogResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid request"
+// @Failure 404 {object} response.ErrorResponse "Event not found"
+// @Failure 409 {object} response.ErrorResponse "Event is not running (already acknowledged, replaced, timed out, or not awaiting acknowledgement)"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /events/{id}/ack [post]
+func (h *EventHandler) AckEvent(c *gin.Context) {
+	eventID := c.Param("id")
+
+	var req models.AckEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("invalid ack request",
+			zap.Error(err),
+			zap.String("event_id", eventID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	event, err := h.eventService.AcknowledgeEvent(c.Request.Context(), eventID, req)
+	if errors.Is(err, storage.ErrEventLogNotRunning) {
+		response.Conflict(c, "event is not running", err.Error())
+		return
+	}
+	if err != nil {
+		h.logger.Error("failed to acknowledge event",
+			zap.Error(err),
+			zap.String("event_id", eventID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.InternalServerError(c, "failed to acknowledge event")
+		return
+	}
+
+	if event == nil {
+		response.NotFound(c, "event not found")
+		return
+	}
+
+	h.logger.Info("event acknowledged",
+		zap.String("event_id", eventID),
+		zap.String("execution_status", string(event.ExecutionStatus)),
+		zap.String("request_id", response.GetRequestID(c)),
+	)
+
+	response.OK(c, buildEventLogResponse(event))
+}
+
+func buildEventLogResponse(event *models.EventLog) models.EventLogResponse {
+	return models.EventLogResponse{
 		ID:              event.ID,
 		TriggerID:       event.TriggerID,
 		TriggerType:     event.TriggerType,
 		FiredAt:         event.FiredAt,
+		ScheduledFor:    event.ScheduledFor,
 		Payload:         event.Payload,
 		Source:          event.Source,
 		ExecutionStatus: event.ExecutionStatus,
 		ErrorMessage:    event.ErrorMessage,
+		SkipReason:      event.SkipReason,
+		DSTNote:         event.DSTNote,
+		AcknowledgedAt:  event.AcknowledgedAt,
+		PublishedAt:     event.PublishedAt,
+		Delivery:        event.Delivery,
 		RetentionStatus: event.RetentionStatus,
 		IsTestRun:       event.IsTestRun,
 		CreatedAt:       event.CreatedAt,
 	}
-
-	h.logger.Info("event retrieved successfully",
-		zap.String("event_id", eventID),
-		zap.String("request_id", response.GetRequestID(c)),
-	)
-
-	response.OK(c, eventResponse)
 }
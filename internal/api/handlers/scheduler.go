@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"github.com/dhima/event-trigger-platform/internal/api/response"
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/logging"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+// SchedulerHandler handles scheduler status requests.
+type SchedulerHandler struct {
+	logger  logging.Logger
+	leaders storage.LeaderStore
+	clock   clock.Clock
+}
+
+// NewSchedulerHandler creates a new scheduler status handler.
+func NewSchedulerHandler(logger logging.Logger, leaders storage.LeaderStore, clk clock.Clock) *SchedulerHandler {
+	return &SchedulerHandler{
+		logger:  logger.With(zap.String("handler", "scheduler")),
+		leaders: leaders,
+		clock:   clk,
+	}
+}
+
+// GetLeader godoc
+// @Summary Get the scheduler leader
+// @Description Returns the scheduler instance elected leader when the schedulers run with SCHEDULER_LEADER_ELECTION. "active" is false once its lease has expired and no standby has taken over yet.
+// @Tags System
+// @Produce json
+// @Success 200 {object} models.SchedulerLeaderResponse
+// @Failure 404 {object} response.ErrorResponse "No leader was ever elected"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/scheduler/leader [get]
+func (h *SchedulerHandler) GetLeader(c *gin.Context) {
+	lease, err := h.leaders.GetLeader(c.Request.Context(), storage.SchedulerLeaseName)
+	if err != nil {
+		h.logger.Error("failed to get scheduler leader",
+			zap.Error(err),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.InternalServerError(c, "failed to get scheduler leader")
+		return
+	}
+	if lease == nil {
+		response.NotFound(c, "no scheduler leader has been elected")
+		return
+	}
+
+	response.OK(c, models.SchedulerLeaderResponse{
+		Name:         lease.Name,
+		InstanceID:   lease.Holder,
+		FencingToken: lease.FencingToken,
+		Active:       lease.ExpiresAt.After(h.clock.Now()),
+		AcquiredAt:   lease.AcquiredAt,
+		RenewedAt:    lease.RenewedAt,
+		ExpiresAt:    lease.ExpiresAt,
+	})
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"strings"
 
@@ -71,7 +72,7 @@ func (h *TriggerHandler) CreateTrigger(c *gin.Context) {
 // @Description Retrieves a list of triggers with optional filtering and pagination
 // @Tags Triggers
 // @Produce json
-// @Param type query string false "Filter by trigger type" Enums(webhook, time_scheduled, cron_scheduled)
+// @Param type query string false "Filter by trigger type" Enums(webhook, time_scheduled, cron_scheduled, interval_scheduled)
 // @Param status query string false "Filter by trigger status" Enums(active, inactive)
 // @Param page query int false "Page number" default(1) minimum(1)
 // @Param limit query int false "Items per page" default(20) minimum(1) maximum(100)
@@ -125,7 +126,7 @@ func (h *TriggerHandler) GetTrigger(c *gin.Context) {
 
 // UpdateTrigger godoc
 // @Summary Update a trigger
-// @Description Updates an existing trigger's metadata or configuration. Only affects future trigger firings.
+// @Description Updates an existing trigger's metadata or configuration. Only affects future trigger firings. Changing status pauses or resumes the trigger like the pause/resume endpoints, skipping missed occurrences.
 // @Tags Triggers
 // @Accept json
 // @Produce json
@@ -179,6 +180,216 @@ func (h *TriggerHandler) DeleteTrigger(c *gin.Context) {
 	response.NoContent(c)
 }
 
+// ListOccurrences godoc
+// @Summary Preview upcoming occurrences
+// @Description Computes the next occurrences of a scheduled trigger from its stored config, applying its active window, max_fires and business calendars. Inactive triggers have none.
+// @Tags Triggers
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Param count query int false "Number of occurrences" default(10) minimum(1) maximum(100)
+// @Param until query string false "List no occurrence after this RFC 3339 time"
+// @Success 200 {object} models.OccurrenceListResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid query parameters or webhook trigger"
+// @Failure 404 {object} response.ErrorResponse "Trigger not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/triggers/{id}/occurrences [get]
+func (h *TriggerHandler) ListOccurrences(c *gin.Context) {
+	triggerID := c.Param("id")
+
+	var query models.ListOccurrencesQuery
+	if err := c.ShouldBindQuery(&query); err != nil {
+		h.logger.Warn("invalid list occurrences query",
+			zap.Error(err),
+			zap.String("trigger_id", triggerID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid query parameters", err.Error())
+		return
+	}
+
+	result, err := h.service.ListOccurrences(c.Request.Context(), triggerID, query)
+	if h.handleServiceError(c, err, "list occurrences") {
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// BackfillTrigger godoc
+// @Summary Backfill past occurrences
+// @Description Enqueues the occurrences a recurring trigger would have fired in [from, to) as backfill schedules, which fire at rate_per_minute starting now. The trigger's active window and calendars apply; max_fires does not. Events are published with source "backfill" and the replayed occurrence as scheduled_for.
+// @Tags Triggers
+// @Accept json
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Param backfill body models.BackfillRequest true "Time range and rate"
+// @Success 202 {object} models.BackfillResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid range, inactive or non-recurring trigger"
+// @Failure 404 {object} response.ErrorResponse "Trigger not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/triggers/{id}/backfill [post]
+func (h *TriggerHandler) BackfillTrigger(c *gin.Context) {
+	triggerID := c.Param("id")
+
+	var req models.BackfillRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warn("invalid backfill request",
+			zap.Error(err),
+			zap.String("trigger_id", triggerID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	result, err := h.service.Backfill(c.Request.Context(), triggerID, req)
+	if h.handleServiceError(c, err, "backfill trigger") {
+		return
+	}
+
+	h.logger.Info("trigger backfill enqueued",
+		zap.String("trigger_id", triggerID),
+		zap.Time("from", result.From),
+		zap.Time("to", result.To),
+		zap.Int("schedules", len(result.Schedules)),
+		zap.String("request_id", response.GetRequestID(c)),
+	)
+
+	response.Success(c, http.StatusAccepted, result, "backfill enqueued")
+}
+
+// PauseTrigger godoc
+// @Summary Pause a trigger
+// @Description Deactivates an active trigger and cancels its pending schedule, recording the pause in its history. Pending backfill schedules are kept and fire once the trigger is resumed. The body is optional.
+// @Tags Triggers
+// @Accept json
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Param pause body models.PauseTriggerRequest false "Pause reason"
+// @Success 200 {object} models.TriggerPauseResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid request"
+// @Failure 404 {object} response.ErrorResponse "Trigger not found"
+// @Failure 409 {object} response.ErrorResponse "Trigger is already inactive"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/triggers/{id}/pause [post]
+func (h *TriggerHandler) PauseTrigger(c *gin.Context) {
+	triggerID := c.Param("id")
+
+	var req models.PauseTriggerRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("invalid pause request",
+			zap.Error(err),
+			zap.String("trigger_id", triggerID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	result, err := h.service.PauseTrigger(c.Request.Context(), triggerID, req)
+	if h.handleServiceError(c, err, "pause trigger") {
+		return
+	}
+
+	h.decorateWebhookURL(c, &result.Trigger)
+
+	h.logger.Info("trigger paused",
+		zap.String("trigger_id", triggerID),
+		zap.String("request_id", response.GetRequestID(c)),
+	)
+
+	response.OK(c, result)
+}
+
+// ResumeTrigger godoc
+// @Summary Resume a trigger
+// @Description Reactivates an inactive trigger and recalculates its schedule. resume_policy decides what happens to the occurrences missed while paused: fire_once fires the most recent of them right away, fire_all_missed fires up to misfire_catchup_limit of them one after the other, skip (the default) continues with the next future occurrence. The body is optional.
+// @Tags Triggers
+// @Accept json
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Param resume body models.ResumeTriggerRequest false "Resume policy and reason"
+// @Success 200 {object} models.TriggerPauseResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid request or nothing left to schedule"
+// @Failure 404 {object} response.ErrorResponse "Trigger not found"
+// @Failure 409 {object} response.ErrorResponse "Trigger is already active"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/triggers/{id}/resume [post]
+func (h *TriggerHandler) ResumeTrigger(c *gin.Context) {
+	triggerID := c.Param("id")
+
+	var req models.ResumeTriggerRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.logger.Warn("invalid resume request",
+			zap.Error(err),
+			zap.String("trigger_id", triggerID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	result, err := h.service.ResumeTrigger(c.Request.Context(), triggerID, req)
+	if h.handleServiceError(c, err, "resume trigger") {
+		return
+	}
+
+	h.decorateWebhookURL(c, &result.Trigger)
+
+	h.logger.Info("trigger resumed",
+		zap.String("trigger_id", triggerID),
+		zap.String("resume_policy", string(result.Event.ResumePolicy)),
+		zap.Int("missed_occurrences", result.Event.MissedOccurrences),
+		zap.String("request_id", response.GetRequestID(c)),
+	)
+
+	response.OK(c, result)
+}
+
+// ListPauseHistory godoc
+// @Summary List a trigger's pause history
+// @Description Returns the trigger's pause and resume events, newest first.
+// @Tags Triggers
+// @Produce json
+// @Param id path string true "Trigger ID"
+// @Success 200 {object} models.PauseHistoryResponse
+// @Failure 404 {object} response.ErrorResponse "Trigger not found"
+// @Failure 500 {object} response.ErrorResponse "Internal server error"
+// @Router /api/v1/triggers/{id}/pause-history [get]
+func (h *TriggerHandler) ListPauseHistory(c *gin.Context) {
+	result, err := h.service.ListPauseHistory(c.Request.Context(), c.Param("id"))
+	if h.handleServiceError(c, err, "list pause history") {
+		return
+	}
+
+	response.OK(c, result)
+}
+
+// PreviewCron godoc
+// @Summary Preview a CRON expression
+// @Description Validates a CRON expression and timezone and returns the next runs, annotated with their UTC offset and daylight saving changes. Nothing is stored.
+// @Tags Triggers
+// @Accept json
+// @Produce json
+// @Param preview body models.CronPreviewRequest true "CRON expression and timezone"
+// @Success 200 {object} models.CronPreviewResponse
+// @Failure 400 {object} response.ErrorResponse "Invalid expression or timezone"
+// @Router /api/v1/cron/preview [post]
+func (h *TriggerHandler) PreviewCron(c *gin.Context) {
+	var req models.CronPreviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		response.BadRequest(c, "invalid request body", err.Error())
+		return
+	}
+
+	result, err := h.service.PreviewCron(req)
+	if h.handleServiceError(c, err, "preview cron") {
+		return
+	}
+
+	response.OK(c, result)
+}
+
 // TestTrigger godoc
 // @Summary Test a trigger (manual/test run)
 // @Description Fires a trigger once for testing. Creates an event log with is_test_run=true.
@@ -213,6 +424,8 @@ func (h *TriggerHandler) handleServiceError(c *gin.Context, err error, operation
 		response.BadRequest(c, "validation failed", validationErr.Error())
 	case errors.Is(err, storage.ErrTriggerNotFound):
 		response.NotFound(c, "trigger not found")
+	case errors.Is(err, storage.ErrTriggerStatusConflict):
+		response.Conflict(c, "trigger status conflict", err.Error())
 	default:
 		h.logger.Error(operation+" failed",
 			zap.Error(err),
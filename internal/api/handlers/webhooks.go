@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/dhima/event-trigger-platform/internal/api/response"
 	"github.com/dhima/event-trigger-platform/internal/events"
@@ -34,12 +37,15 @@ func NewWebhookHandler(triggerService *triggers.Service, eventService *events.Se
 
 // ReceiveWebhook godoc
 // @Summary Receive webhook payload for webhook trigger
-// @Description Receives a payload from external systems, validates it against the trigger's JSON schema, and fires the trigger
+// @Description Receives a payload from external systems, validates it against the trigger's JSON schema, and fires the trigger.
+// @Description A request repeating the Idempotency-Key (or the value of the trigger's idempotency_key_field) of an earlier one returns that request's event_id with 200 instead of firing again.
 // @Tags Webhooks
 // @Accept json
 // @Produce json
 // @Param trigger_id path string true "Trigger ID"
+// @Param Idempotency-Key header string false "Deduplicates retried deliveries (at most 255 characters); takes precedence over the trigger's idempotency_key_field"
 // @Param payload body map[string]interface{} true "Webhook payload (validated against trigger's schema)"
+// @Success 200 {object} response.SuccessResponse{data=map[string]string} "Repeated request: the original event, not fired again (Idempotent-Replayed: true)"
 // @Success 202 {object} response.SuccessResponse{data=map[string]string} "Webhook accepted and trigger queued"
 // @Failure 400 {object} response.ErrorResponse "Invalid payload or schema validation failed"
 // @Failure 404 {object} response.ErrorResponse "Trigger not found"
@@ -172,7 +178,19 @@ func (h *WebhookHandler) ReceiveWebhook(c *gin.Context) {
 		)
 	}
 
-	// Step 4: Fire trigger via EventService (creates event log + publishes to Kafka)
+	// Step 4: Resolve the idempotency key deduplicating retried deliveries
+	idempotencyKey, err := webhookIdempotencyKey(c, webhookConfig, payload)
+	if err != nil {
+		h.logger.Warn("invalid idempotency key",
+			zap.Error(err),
+			zap.String("trigger_id", triggerID),
+			zap.String("request_id", response.GetRequestID(c)),
+		)
+		response.BadRequest(c, "invalid idempotency key", err.Error())
+		return
+	}
+
+	// Step 5: Fire trigger via EventService (records the event, published to Kafka through the outbox)
 	// Reconstruct trigger from response to pass to event service
 	triggerModel := &models.Trigger{
 		ID:     trigger.ID,
@@ -182,7 +200,7 @@ func (h *WebhookHandler) ReceiveWebhook(c *gin.Context) {
 		Config: trigger.Config,
 	}
 
-	eventID, err := h.eventService.FireTrigger(c.Request.Context(), triggerModel, models.EventSourceWebhook, payload, false)
+	eventID, replayed, err := h.eventService.FireWebhook(c.Request.Context(), triggerModel, payload, idempotencyKey)
 	if err != nil {
 		h.logger.Error("failed to fire trigger",
 			zap.Error(err),
@@ -193,15 +211,64 @@ func (h *WebhookHandler) ReceiveWebhook(c *gin.Context) {
 		return
 	}
 
+	if replayed {
+		// Step 6: A retried delivery: return the original event with 200 OK
+		c.Header("Idempotent-Replayed", "true")
+		response.Success(c, http.StatusOK, gin.H{
+			"event_id":   eventID,
+			"trigger_id": triggerID,
+		}, "webhook already accepted, returning the original event")
+		return
+	}
+
 	h.logger.Info("trigger fired successfully",
 		zap.String("trigger_id", triggerID),
 		zap.String("event_id", eventID),
 		zap.String("request_id", response.GetRequestID(c)),
 	)
 
-	// Step 5: Return 202 Accepted with event_id
+	// Step 6: Return 202 Accepted with event_id
 	response.Success(c, 202, gin.H{
 		"event_id":   eventID,
 		"trigger_id": triggerID,
 	}, "webhook accepted and trigger queued")
 }
+
+// maxIdempotencyKeyLength bounds the idempotency key of a webhook request.
+const maxIdempotencyKeyLength = 255
+
+// webhookIdempotencyKey returns the key deduplicating a webhook request: its Idempotency-Key header,
+// else the value (a string or a number) of the trigger's idempotency_key_field in the payload, else "".
+func webhookIdempotencyKey(c *gin.Context, config models.WebhookTriggerConfig, payload map[string]interface{}) (string, error) {
+	key := strings.TrimSpace(c.GetHeader("Idempotency-Key"))
+	source := "Idempotency-Key header"
+
+	if key == "" && config.IdempotencyKeyField != "" {
+		source = "idempotency_key_field " + config.IdempotencyKeyField
+		var value interface{} = payload
+		for _, field := range strings.Split(config.IdempotencyKeyField, ".") {
+			object, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = object[field]
+		}
+
+		switch v := value.(type) {
+		case nil:
+			// Field absent: the request is not deduplicated
+		case string:
+			key = strings.TrimSpace(v)
+		case float64:
+			key = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return "", fmt.Errorf("%s must be a string or a number", source)
+		}
+	}
+
+	if len(key) > maxIdempotencyKeyLength {
+		return "", fmt.Errorf("%s must be at most %d characters", source, maxIdempotencyKeyLength)
+	}
+	return key, nil
+}
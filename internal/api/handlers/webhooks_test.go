@@ -0,0 +1,126 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/api/handlers"
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/logging"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// racingStore holds the results of the first n event log lookups until all n have happened, so n
+// concurrent webhook deliveries all reserve their event ID and find it unrecorded before any records it.
+type racingStore struct {
+	*memory.Store
+	n       int32
+	lookups atomic.Int32
+	arrived sync.WaitGroup
+}
+
+func newRacingStore(store *memory.Store, n int) *racingStore {
+	s := &racingStore{Store: store, n: int32(n)}
+	s.arrived.Add(n)
+	return s
+}
+
+func (s *racingStore) GetEventLog(ctx context.Context, eventID string) (*models.EventLog, error) {
+	eventLog, err := s.Store.GetEventLog(ctx, eventID)
+	if s.lookups.Add(1) <= s.n {
+		s.arrived.Done()
+		s.arrived.Wait()
+	}
+	return eventLog, err
+}
+
+func TestReceiveWebhookConcurrentDuplicates(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const deliveries = 8
+
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	eventService := events.NewService(newRacingStore(store, deliveries), platformEvents.NewMemoryPublisher(deliveries), clk, zap.NewNop())
+	handler := handlers.NewWebhookHandler(triggers.NewService(store, clk, zap.NewNop()), eventService, logging.NewNoOpLogger())
+
+	router := gin.New()
+	router.POST("/api/v1/webhook/:trigger_id", handler.ReceiveWebhook)
+
+	trigger := &models.Trigger{
+		ID:     uuid.New().String(),
+		Name:   "orders",
+		Type:   models.TriggerTypeWebhook,
+		Status: models.TriggerStatusActive,
+		Config: json.RawMessage(`{"endpoint":"https://example.com/hook","http_method":"POST"}`),
+	}
+	if err := store.CreateTrigger(ctx, trigger, nil); err != nil {
+		t.Fatalf("CreateTrigger: %v", err)
+	}
+
+	type result struct {
+		status  int
+		eventID string
+	}
+	results := make([]result, deliveries)
+	var wg sync.WaitGroup
+	for i := range results {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/webhook/"+trigger.ID, strings.NewReader(`{"order_id":42}`))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Idempotency-Key", "order-42")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			var body struct {
+				Data struct {
+					EventID string `json:"event_id"`
+				} `json:"data"`
+			}
+			_ = json.Unmarshal(rec.Body.Bytes(), &body)
+			results[i] = result{status: rec.Code, eventID: body.Data.EventID}
+		}()
+	}
+	wg.Wait()
+
+	logs, _, err := store.ListEventLogs(ctx, models.ListEventsQuery{TriggerID: trigger.ID, Limit: 100})
+	if err != nil {
+		t.Fatalf("ListEventLogs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("recorded %d events, want 1", len(logs))
+	}
+
+	accepted := 0
+	for i, r := range results {
+		switch r.status {
+		case http.StatusAccepted:
+			accepted++
+		case http.StatusOK:
+		default:
+			t.Errorf("delivery %d: status %d, want 200 or 202", i, r.status)
+		}
+		if r.eventID != logs[0].ID {
+			t.Errorf("delivery %d: event_id %q, want the recorded %s", i, r.eventID, logs[0].ID)
+		}
+	}
+	if accepted != 1 {
+		t.Errorf("%d deliveries were accepted as new, want 1", accepted)
+	}
+}
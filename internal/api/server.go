@@ -2,7 +2,6 @@ package api
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,16 +12,16 @@ import (
 
 	"github.com/dhima/event-trigger-platform/internal/api/handlers"
 	"github.com/dhima/event-trigger-platform/internal/api/middleware"
+	"github.com/dhima/event-trigger-platform/internal/clock"
 	"github.com/dhima/event-trigger-platform/internal/events"
 	"github.com/dhima/event-trigger-platform/internal/logging"
-	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/backend"
 	"github.com/dhima/event-trigger-platform/internal/triggers"
 	"github.com/dhima/event-trigger-platform/pkg/config"
 	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
 	"github.com/gin-contrib/cors"
 	ginzap "github.com/gin-contrib/zap"
 	"github.com/gin-gonic/gin"
-	_ "github.com/go-sql-driver/mysql"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"go.uber.org/zap"
@@ -33,11 +32,12 @@ type Server struct {
 	config config.App
 	logger logging.Logger
 	router *gin.Engine
-	db     *sql.DB
+	store  *backend.Backend
+	clock  clock.Clock
 
 	triggerService *triggers.Service
 	eventService   *events.Service
-	kafkaPublisher *platformEvents.Publisher
+	publisher      platformEvents.Publisher
 }
 
 // NewServer wires the API dependencies together.
@@ -57,8 +57,8 @@ func NewServer() *Server {
 		gin.SetMode(gin.DebugMode)
 	}
 
-	db := connectDatabase(cfg, logger)
-	mysqlClient := storage.NewMySQLClient(db)
+	clk := clock.System()
+	store := connectDatabase(cfg, clk, logger)
 
 	// Create zap logger for Kafka publisher (needs *zap.Logger, not our Logger interface)
 	var zapLogger *zap.Logger
@@ -72,26 +72,30 @@ func NewServer() *Server {
 		panic(fmt.Sprintf("failed to initialize zap logger for Kafka: %v", zapErr))
 	}
 
-	// Initialize Kafka publisher
+	// Initialize the event publisher selected by PUBLISHER_URL (Kafka by default)
 	kafkaBrokers := strings.Split(cfg.KafkaBrokers, ",")
 	for i, broker := range kafkaBrokers {
 		kafkaBrokers[i] = strings.TrimSpace(broker)
 	}
-	kafkaPublisher := platformEvents.NewPublisher(kafkaBrokers, "trigger-events", zapLogger)
-	logger.Info("Kafka publisher initialized for API server",
-		zap.Strings("brokers", kafkaBrokers))
+	publisher, sink, err := platformEvents.Open(cfg.PublisherURL, kafkaBrokers, zapLogger)
+	if err != nil {
+		panic(fmt.Sprintf("failed to initialize event publisher: %v", err))
+	}
+	logger.Info("event publisher initialized for API server",
+		zap.String("sink", sink))
 
 	// Initialize services
-	triggerService := triggers.NewService(mysqlClient)
-	eventService := events.NewService(mysqlClient, kafkaPublisher, zapLogger)
+	triggerService := triggers.NewService(store, clk, zapLogger)
+	eventService := events.NewService(store, publisher, clk, zapLogger)
 
 	server := &Server{
 		config:         cfg,
 		logger:         logger,
-		db:             db,
+		store:          store,
+		clock:          clk,
 		triggerService: triggerService,
 		eventService:   eventService,
-		kafkaPublisher: kafkaPublisher,
+		publisher:      publisher,
 	}
 
 	server.setupRouter()
@@ -145,6 +149,23 @@ func (s *Server) setupRouter() {
 			triggers.PUT("/:id", triggerHandler.UpdateTrigger)
 			triggers.DELETE("/:id", triggerHandler.DeleteTrigger)
 			triggers.POST("/:id/test", triggerHandler.TestTrigger)
+			triggers.GET("/:id/occurrences", triggerHandler.ListOccurrences)
+			triggers.POST("/:id/backfill", triggerHandler.BackfillTrigger)
+			triggers.POST("/:id/pause", triggerHandler.PauseTrigger)
+			triggers.POST("/:id/resume", triggerHandler.ResumeTrigger)
+			triggers.GET("/:id/pause-history", triggerHandler.ListPauseHistory)
+		}
+		v1.POST("/cron/preview", triggerHandler.PreviewCron)
+
+		// Business calendars referenced by recurring triggers
+		calendarHandler := handlers.NewCalendarHandler(s.logger, s.triggerService)
+		calendars := v1.Group("/calendars")
+		{
+			calendars.POST("", calendarHandler.CreateCalendar)
+			calendars.GET("", calendarHandler.ListCalendars)
+			calendars.GET("/:id", calendarHandler.GetCalendar)
+			calendars.PUT("/:id", calendarHandler.UpdateCalendar)
+			calendars.DELETE("/:id", calendarHandler.DeleteCalendar)
 		}
 
 		// Event log queries
@@ -153,8 +174,13 @@ func (s *Server) setupRouter() {
 		{
 			events.GET("", eventHandler.ListEvents)
 			events.GET("/:id", eventHandler.GetEvent)
+			events.POST("/:id/ack", eventHandler.AckEvent)
 		}
 
+		// Scheduler status (leader-election mode)
+		schedulerHandler := handlers.NewSchedulerHandler(s.logger, s.store, s.clock)
+		v1.GET("/scheduler/leader", schedulerHandler.GetLeader)
+
 		// Webhook receiver
 		webhookHandler := handlers.NewWebhookHandler(s.triggerService, s.eventService, s.logger)
 		v1.POST("/webhook/:trigger_id", webhookHandler.ReceiveWebhook)
@@ -217,16 +243,16 @@ func (s *Server) Serve() error {
 		return err
 	}
 
-	// Close Kafka publisher
-	if s.kafkaPublisher != nil {
-		if err := s.kafkaPublisher.Close(); err != nil {
-			s.logger.Error("failed to close Kafka publisher", zap.Error(err))
+	// Close event publisher
+	if s.publisher != nil {
+		if err := s.publisher.Close(); err != nil {
+			s.logger.Error("failed to close event publisher", zap.Error(err))
 		}
 	}
 
 	// Close database connection
-	if s.db != nil {
-		if err := s.db.Close(); err != nil {
+	if s.store != nil {
+		if err := s.store.Close(); err != nil {
 			s.logger.Error("failed to close database connection", zap.Error(err))
 		}
 	}
@@ -244,23 +270,16 @@ func (s *Server) Serve() error {
 	return nil
 }
 
-func connectDatabase(cfg config.App, logger logging.Logger) *sql.DB {
+func connectDatabase(cfg config.App, clk clock.Clock, logger logging.Logger) *backend.Backend {
 	if cfg.DatabaseURL == "" {
 		logger.Fatal("DATABASE_URL is required")
 	}
 
-	db, err := sql.Open("mysql", cfg.DatabaseURL)
+	store, err := backend.Open(context.Background(), cfg.DatabaseURL, clk)
 	if err != nil {
-		logger.Fatal("failed to open database connection", zap.Error(err))
-	}
-
-	db.SetMaxIdleConns(5)
-	db.SetMaxOpenConns(20)
-	db.SetConnMaxLifetime(60 * time.Minute)
-
-	if err := db.Ping(); err != nil {
 		logger.Fatal("failed to connect to database", zap.Error(err))
 	}
 
-	return db
+	logger.Info("database connection established", zap.String("driver", store.Driver))
+	return store
 }
@@ -0,0 +1,360 @@
+// Package dispatcher delivers trigger events to the HTTP endpoints of their triggers and records
+// the outcome of each delivery against the event.
+package dispatcher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"github.com/dhima/event-trigger-platform/platform/events"
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultTimeout bounds each HTTP call when Config.Timeout is unset.
+	DefaultTimeout = 10 * time.Second
+	// DefaultMaxAttempts is how many HTTP calls are made per event when Config.MaxAttempts is unset.
+	DefaultMaxAttempts = 3
+	// DefaultRetryBaseDelay is the delay before the first retry when Config.RetryBaseDelay is unset.
+	DefaultRetryBaseDelay = time.Second
+	// DefaultRetryMaxDelay caps the delay between two retries when Config.RetryMaxDelay is unset.
+	DefaultRetryMaxDelay = 30 * time.Second
+)
+
+// UserAgent is sent with every delivery unless the trigger configures its own.
+const UserAgent = "event-trigger-platform-dispatcher/1.0"
+
+// maxErrorBodyLength is how much of a failed response's body is kept in the delivery error.
+const maxErrorBodyLength = 512
+
+// Config holds the tunables of a dispatcher.
+type Config struct {
+	// Timeout bounds each HTTP call, from connecting to reading the response.
+	Timeout time.Duration
+	// MaxAttempts is how many HTTP calls are made per event before its delivery fails.
+	MaxAttempts int
+	// RetryBaseDelay is the delay before the first retry; it doubles with every further retry.
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the delay between two retries, including one requested by Retry-After.
+	RetryMaxDelay time.Duration
+	// Client makes the HTTP calls; defaults to a client with Timeout.
+	Client *http.Client
+	// Jitter returns a random number in [0, 1) used to spread retry backoffs; defaults to math/rand.
+	Jitter func() float64
+}
+
+// Store is the persistence the dispatcher needs. Any storage.Store satisfies it.
+type Store interface {
+	GetTrigger(ctx context.Context, triggerID string) (*models.Trigger, *time.Time, error)
+	GetEventLog(ctx context.Context, eventID string) (*models.EventLog, error)
+	RecordEventDelivery(ctx context.Context, eventID string, delivery *models.EventDelivery) error
+	AcknowledgeEventLog(ctx context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error
+}
+
+// Dispatcher calls the endpoint configured on an event's trigger with the event's payload,
+// retrying failed calls with backoff, and records the outcome as the event's delivery.
+type Dispatcher struct {
+	client *http.Client
+	retry  triggers.RetryPolicy
+	jitter func() float64
+	db     Store
+	logger *zap.Logger
+}
+
+// NewDispatcher constructs a dispatcher with the provided configuration and dependencies.
+func NewDispatcher(cfg Config, db Store, logger *zap.Logger) *Dispatcher {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = DefaultMaxAttempts
+	}
+	if cfg.RetryBaseDelay <= 0 {
+		cfg.RetryBaseDelay = DefaultRetryBaseDelay
+	}
+	if cfg.RetryMaxDelay < cfg.RetryBaseDelay {
+		cfg.RetryMaxDelay = max(DefaultRetryMaxDelay, cfg.RetryBaseDelay)
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: cfg.Timeout}
+	}
+	if cfg.Jitter == nil {
+		cfg.Jitter = rand.Float64
+	}
+
+	return &Dispatcher{
+		client: cfg.Client,
+		retry: triggers.RetryPolicy{
+			MaxAttempts: cfg.MaxAttempts,
+			BaseDelay:   cfg.RetryBaseDelay,
+			MaxDelay:    cfg.RetryMaxDelay,
+		},
+		jitter: cfg.Jitter,
+		db:     db,
+		logger: logger,
+	}
+}
+
+// endpointConfig is the part of a trigger's config describing the HTTP call; every trigger type has it.
+type endpointConfig struct {
+	Endpoint   string            `json:"endpoint"`
+	HTTPMethod string            `json:"http_method"`
+	Headers    map[string]string `json:"headers"`
+}
+
+// Dispatch delivers one trigger event; it is the events.Handler of a consumer. Events already
+// delivered (the consumer is at-least-once), replaced before delivery or no longer logged are
+// skipped. The outcome is recorded on the event, and an event that must be acknowledged is
+// acknowledged with it. Dispatch returns an error only when the outcome could not be recorded or
+// ctx was cancelled; the event should then be dispatched again.
+func (d *Dispatcher) Dispatch(ctx context.Context, event events.TriggerEvent) error {
+	logger := d.logger.With(zap.String("event_id", event.EventID), zap.String("trigger_id", event.TriggerID))
+
+	eventLog, err := d.db.GetEventLog(ctx, event.EventID)
+	if err != nil {
+		return fmt.Errorf("get event log: %w", err)
+	}
+	switch {
+	case eventLog == nil:
+		logger.Warn("skipping event without an event log (deleted by retention?)")
+		return nil
+	case eventLog.Delivery != nil:
+		logger.Info("skipping event already dispatched", zap.String("delivery_status", string(eventLog.Delivery.Status)))
+		return nil
+	case eventLog.ExecutionStatus == models.ExecutionStatusReplaced:
+		return d.record(ctx, logger, event, failedDelivery(0, nil, "event was replaced by a newer occurrence before delivery"))
+	}
+
+	request, err := d.buildRequest(ctx, event)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		var rejected requestError
+		if !errors.As(err, &rejected) {
+			return err
+		}
+		return d.record(ctx, logger, event, failedDelivery(0, nil, err.Error()))
+	}
+
+	delivery, err := d.deliver(ctx, logger, request)
+	if err != nil {
+		return err
+	}
+	return d.record(ctx, logger, event, delivery)
+}
+
+// requestError means the event cannot be delivered: its trigger is gone or misconfigured.
+type requestError string
+
+func (e requestError) Error() string {
+	return string(e)
+}
+
+// preparedRequest is an event's HTTP call; a new http.Request is built from it for every attempt.
+type preparedRequest struct {
+	method   string
+	endpoint string
+	header   http.Header
+	body     []byte
+}
+
+// buildRequest prepares the HTTP call for an event from its trigger's endpoint, method and headers.
+func (d *Dispatcher) buildRequest(ctx context.Context, event events.TriggerEvent) (*preparedRequest, error) {
+	if event.TriggerID == "" {
+		return nil, requestError("event has no trigger")
+	}
+	trigger, _, err := d.db.GetTrigger(ctx, event.TriggerID)
+	if errors.Is(err, storage.ErrTriggerNotFound) {
+		return nil, requestError("trigger not found (deleted after the event fired?)")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get trigger: %w", err)
+	}
+
+	var config endpointConfig
+	if err := json.Unmarshal(trigger.Config, &config); err != nil {
+		return nil, requestError(fmt.Sprintf("invalid trigger config: %v", err))
+	}
+	if config.Endpoint == "" {
+		return nil, requestError("trigger has no endpoint")
+	}
+	method := strings.ToUpper(config.HTTPMethod)
+	if method == "" {
+		method = http.MethodPost
+	}
+	// Validate the endpoint and method once, rather than on every attempt
+	probe, err := http.NewRequest(method, config.Endpoint, nil)
+	if err != nil {
+		return nil, requestError(fmt.Sprintf("invalid endpoint: %v", err))
+	}
+	if probe.URL.Scheme != "http" && probe.URL.Scheme != "https" {
+		return nil, requestError(fmt.Sprintf("invalid endpoint %q: must be an http or https URL", config.Endpoint))
+	}
+
+	request := &preparedRequest{method: method, endpoint: config.Endpoint, header: http.Header{}}
+	if method != http.MethodGet && method != http.MethodHead {
+		payload := event.Payload
+		if payload == nil {
+			payload = map[string]interface{}{}
+		}
+		if request.body, err = json.Marshal(payload); err != nil {
+			return nil, requestError(fmt.Sprintf("failed to marshal payload: %v", err))
+		}
+		request.header.Set("Content-Type", "application/json")
+	}
+	request.header.Set("User-Agent", UserAgent)
+	request.header.Set("X-Event-ID", event.EventID)
+	request.header.Set("X-Trigger-ID", event.TriggerID)
+	// Retries and redeliveries reuse the event ID, so the endpoint can deduplicate them
+	request.header.Set("Idempotency-Key", event.EventID)
+	for name, value := range config.Headers {
+		request.header.Set(name, value)
+	}
+
+	return request, nil
+}
+
+// deliver makes the HTTP call until it succeeds, fails permanently or runs out of attempts. It only
+// returns an error when ctx is cancelled.
+func (d *Dispatcher) deliver(ctx context.Context, logger *zap.Logger, request *preparedRequest) (*models.EventDelivery, error) {
+	for attempt := 1; ; attempt++ {
+		statusCode, retryAfter, err := d.call(ctx, request)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err == nil {
+			logger.Info("event delivered",
+				zap.String("endpoint", request.endpoint),
+				zap.Int("status_code", statusCode),
+				zap.Int("attempts", attempt))
+			return &models.EventDelivery{Status: models.DeliveryStatusDelivered, Attempts: attempt, StatusCode: &statusCode}, nil
+		}
+
+		var code *int
+		if statusCode != 0 {
+			code = &statusCode
+		}
+		if !retryable(statusCode) || attempt >= d.retry.MaxAttempts {
+			logger.Warn("event delivery failed",
+				zap.String("endpoint", request.endpoint),
+				zap.Int("attempts", attempt),
+				zap.Error(err))
+			return failedDelivery(attempt, code, err.Error()), nil
+		}
+
+		backoff := d.retry.Backoff(attempt, d.jitter())
+		if retryAfter > backoff {
+			backoff = min(retryAfter, d.retry.MaxDelay)
+		}
+		logger.Warn("event delivery attempt failed, will retry",
+			zap.String("endpoint", request.endpoint),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// call makes one HTTP call. It returns the response status (0 when there was no response) and, with
+// an error, how long the endpoint asked to wait before retrying (Retry-After, in seconds).
+func (d *Dispatcher) call(ctx context.Context, request *preparedRequest) (int, time.Duration, error) {
+	httpRequest, err := http.NewRequestWithContext(ctx, request.method, request.endpoint, bytes.NewReader(request.body))
+	if err != nil {
+		return 0, 0, err
+	}
+	httpRequest.Header = request.header.Clone()
+
+	response, err := d.client.Do(httpRequest)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 64<<10)) // lets the connection be reused
+		return response.StatusCode, 0, nil
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, maxErrorBodyLength))
+	err = fmt.Errorf("unexpected status %s", response.Status)
+	if text := strings.TrimSpace(string(body)); text != "" {
+		err = fmt.Errorf("unexpected status %s: %s", response.Status, text)
+	}
+	var retryAfter time.Duration
+	if seconds, parseErr := strconv.Atoi(response.Header.Get("Retry-After")); parseErr == nil && seconds > 0 {
+		retryAfter = time.Duration(seconds) * time.Second
+	}
+	return response.StatusCode, retryAfter, err
+}
+
+// retryable reports whether a failed call may succeed when retried: no response at all, a timeout,
+// rate limiting or a server error. Other client errors fail the delivery right away.
+func retryable(statusCode int) bool {
+	switch {
+	case statusCode == 0:
+		return true
+	case statusCode == http.StatusRequestTimeout, statusCode == http.StatusTooManyRequests:
+		return true
+	default:
+		return statusCode >= 500
+	}
+}
+
+// record stores the outcome of an event's delivery and acknowledges the event when it requires it:
+// its run is the HTTP call, so it succeeded or failed with the delivery.
+func (d *Dispatcher) record(ctx context.Context, logger *zap.Logger, event events.TriggerEvent, delivery *models.EventDelivery) error {
+	if err := d.db.RecordEventDelivery(ctx, event.EventID, delivery); err != nil {
+		if errors.Is(err, storage.ErrEventLogNotFound) {
+			logger.Warn("event log deleted before its delivery was recorded")
+			return nil
+		}
+		return fmt.Errorf("record event delivery: %w", err)
+	}
+
+	if !event.AckRequired {
+		return nil
+	}
+	status, errorMessage := models.ExecutionStatusSuccess, (*string)(nil)
+	if delivery.Status != models.DeliveryStatusDelivered {
+		status, errorMessage = models.ExecutionStatusFailure, delivery.Error
+	}
+	err := d.db.AcknowledgeEventLog(ctx, event.EventID, status, errorMessage)
+	switch {
+	case errors.Is(err, storage.ErrEventLogNotRunning):
+		// Replaced meanwhile, or acknowledged by the endpoint itself
+		logger.Info("event no longer running; not acknowledged")
+	case err != nil:
+		// The delivery is recorded, so a retry would skip the event: log rather than fail it
+		logger.Error("failed to acknowledge dispatched event", zap.Error(err))
+	}
+	return nil
+}
+
+func failedDelivery(attempts int, statusCode *int, message string) *models.EventDelivery {
+	return &models.EventDelivery{
+		Status:     models.DeliveryStatusFailed,
+		Attempts:   attempts,
+		StatusCode: statusCode,
+		Error:      &message,
+	}
+}
@@ -0,0 +1,325 @@
+package dispatcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/dispatcher"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/platform/events"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// retryMaxDelay caps every backoff of the test dispatcher, including one requested by Retry-After.
+const retryMaxDelay = 200 * time.Millisecond
+
+// response is one reply of the test endpoint.
+type response struct {
+	status     int
+	retryAfter string
+}
+
+// endpoint is an httptest server replying with responses in turn (repeating the last one) and
+// recording every call it receives.
+type endpoint struct {
+	*httptest.Server
+	responses []response
+
+	mu    sync.Mutex
+	calls []call
+}
+
+type call struct {
+	at     time.Time
+	method string
+	header http.Header
+	body   string
+}
+
+func newEndpoint(t *testing.T, responses ...response) *endpoint {
+	t.Helper()
+
+	e := &endpoint{responses: responses}
+	e.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		e.mu.Lock()
+		e.calls = append(e.calls, call{at: time.Now(), method: r.Method, header: r.Header.Clone(), body: string(body)})
+		reply := e.responses[min(len(e.calls), len(e.responses))-1]
+		e.mu.Unlock()
+
+		if reply.retryAfter != "" {
+			w.Header().Set("Retry-After", reply.retryAfter)
+		}
+		w.WriteHeader(reply.status)
+		if reply.status >= 300 {
+			_, _ = io.WriteString(w, "upstream down")
+		}
+	}))
+	t.Cleanup(e.Close)
+	return e
+}
+
+func (e *endpoint) received() []call {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]call(nil), e.calls...)
+}
+
+// fixture is a dispatcher over a memory store holding one trigger that calls an endpoint.
+type fixture struct {
+	ctx        context.Context
+	store      *memory.Store
+	dispatcher *dispatcher.Dispatcher
+	triggerID  string
+}
+
+func newFixture(t *testing.T, endpointURL string) *fixture {
+	t.Helper()
+
+	ctx := context.Background()
+	store := memory.NewStore(clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)))
+	trigger := &models.Trigger{
+		ID:     uuid.New().String(),
+		Name:   "orders",
+		Type:   models.TriggerTypeWebhook,
+		Status: models.TriggerStatusActive,
+		Config: json.RawMessage(`{"endpoint":"` + endpointURL + `","http_method":"PUT","headers":{"X-Token":"secret"}}`),
+	}
+	if err := store.CreateTrigger(ctx, trigger, nil); err != nil {
+		t.Fatalf("CreateTrigger: %v", err)
+	}
+
+	d := dispatcher.NewDispatcher(dispatcher.Config{
+		Timeout:        time.Second,
+		MaxAttempts:    3,
+		RetryBaseDelay: time.Millisecond,
+		RetryMaxDelay:  retryMaxDelay,
+		Jitter:         func() float64 { return 0 },
+	}, store, zap.NewNop())
+
+	return &fixture{ctx: ctx, store: store, dispatcher: d, triggerID: trigger.ID}
+}
+
+// fire stores an event log of the trigger in status and returns the event the consumer would hand
+// to the dispatcher.
+func (f *fixture) fire(t *testing.T, status models.ExecutionStatus, delivery *models.EventDelivery) events.TriggerEvent {
+	t.Helper()
+
+	eventLog := &models.EventLog{
+		ID:              uuid.New().String(),
+		TriggerID:       &f.triggerID,
+		TriggerType:     models.TriggerTypeWebhook,
+		FiredAt:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Source:          models.EventSourceWebhook,
+		ExecutionStatus: status,
+		Delivery:        delivery,
+	}
+	if err := f.store.CreateEventLog(f.ctx, eventLog); err != nil {
+		t.Fatalf("CreateEventLog: %v", err)
+	}
+	return events.TriggerEvent{
+		EventID:     eventLog.ID,
+		TriggerID:   f.triggerID,
+		Type:        string(models.TriggerTypeWebhook),
+		Payload:     map[string]interface{}{"order_id": 42},
+		FiredAt:     eventLog.FiredAt,
+		Source:      string(models.EventSourceWebhook),
+		AckRequired: status == models.ExecutionStatusRunning,
+	}
+}
+
+func (f *fixture) eventLog(t *testing.T, eventID string) *models.EventLog {
+	t.Helper()
+
+	eventLog, err := f.store.GetEventLog(f.ctx, eventID)
+	if err != nil || eventLog == nil {
+		t.Fatalf("GetEventLog(%s) = %v, %v", eventID, eventLog, err)
+	}
+	return eventLog
+}
+
+func TestDispatchRetries(t *testing.T) {
+	cases := []struct {
+		name         string
+		responses    []response
+		wantStatus   models.DeliveryStatus
+		wantAttempts int
+		wantCode     int
+		wantError    string
+		minBackoff   time.Duration // the shortest wait between two calls
+	}{
+		{
+			name:         "delivered",
+			responses:    []response{{status: http.StatusNoContent}},
+			wantStatus:   models.DeliveryStatusDelivered,
+			wantAttempts: 1,
+			wantCode:     http.StatusNoContent,
+		},
+		{
+			name:         "server errors are retried",
+			responses:    []response{{status: http.StatusBadGateway}, {status: http.StatusServiceUnavailable}, {status: http.StatusOK}},
+			wantStatus:   models.DeliveryStatusDelivered,
+			wantAttempts: 3,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "server errors exhaust the attempts",
+			responses:    []response{{status: http.StatusInternalServerError}},
+			wantStatus:   models.DeliveryStatusFailed,
+			wantAttempts: 3,
+			wantCode:     http.StatusInternalServerError,
+			wantError:    "unexpected status 500 Internal Server Error: upstream down",
+		},
+		{
+			name:         "client errors are not retried",
+			responses:    []response{{status: http.StatusNotFound}},
+			wantStatus:   models.DeliveryStatusFailed,
+			wantAttempts: 1,
+			wantCode:     http.StatusNotFound,
+			wantError:    "unexpected status 404 Not Found: upstream down",
+		},
+		{
+			name:         "rate limiting is retried after Retry-After, capped by the max delay",
+			responses:    []response{{status: http.StatusTooManyRequests, retryAfter: "1"}, {status: http.StatusOK}},
+			wantStatus:   models.DeliveryStatusDelivered,
+			wantAttempts: 2,
+			wantCode:     http.StatusOK,
+			minBackoff:   retryMaxDelay,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newEndpoint(t, tc.responses...)
+			f := newFixture(t, server.URL)
+			event := f.fire(t, models.ExecutionStatusSuccess, nil)
+
+			if err := f.dispatcher.Dispatch(f.ctx, event); err != nil {
+				t.Fatalf("Dispatch: %v", err)
+			}
+
+			calls := server.received()
+			if len(calls) != tc.wantAttempts {
+				t.Fatalf("endpoint received %d calls, want %d", len(calls), tc.wantAttempts)
+			}
+			for i, c := range calls {
+				if c.method != http.MethodPut || c.body != `{"order_id":42}` {
+					t.Errorf("call %d = %s %s, want PUT with the payload", i, c.method, c.body)
+				}
+				if c.header.Get("Idempotency-Key") != event.EventID || c.header.Get("X-Event-ID") != event.EventID || c.header.Get("X-Trigger-ID") != f.triggerID {
+					t.Errorf("call %d headers %v, want the event and trigger IDs", i, c.header)
+				}
+				if c.header.Get("X-Token") != "secret" || c.header.Get("User-Agent") != dispatcher.UserAgent {
+					t.Errorf("call %d headers %v, want the trigger's headers and the dispatcher's user agent", i, c.header)
+				}
+				if i > 0 && tc.minBackoff > 0 {
+					if waited := c.at.Sub(calls[i-1].at); waited < tc.minBackoff {
+						t.Errorf("call %d came %s after the previous one, want at least %s", i, waited, tc.minBackoff)
+					}
+				}
+			}
+
+			delivery := f.eventLog(t, event.EventID).Delivery
+			if delivery == nil {
+				t.Fatal("no delivery recorded")
+			}
+			if delivery.Status != tc.wantStatus || delivery.Attempts != tc.wantAttempts {
+				t.Errorf("delivery = %s after %d attempts, want %s after %d", delivery.Status, delivery.Attempts, tc.wantStatus, tc.wantAttempts)
+			}
+			if delivery.StatusCode == nil || *delivery.StatusCode != tc.wantCode {
+				t.Errorf("delivery status code = %v, want %d", delivery.StatusCode, tc.wantCode)
+			}
+			switch {
+			case tc.wantError == "" && delivery.Error != nil:
+				t.Errorf("delivery error = %q, want none", *delivery.Error)
+			case tc.wantError != "" && (delivery.Error == nil || *delivery.Error != tc.wantError):
+				t.Errorf("delivery error = %v, want %q", delivery.Error, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestDispatchSkipsDeliveredEvents(t *testing.T) {
+	server := newEndpoint(t, response{status: http.StatusOK})
+	f := newFixture(t, server.URL)
+
+	statusCode := http.StatusOK
+	event := f.fire(t, models.ExecutionStatusSuccess, &models.EventDelivery{Status: models.DeliveryStatusDelivered, Attempts: 2, StatusCode: &statusCode})
+
+	if err := f.dispatcher.Dispatch(f.ctx, event); err != nil {
+		t.Fatalf("Dispatch: %v", err)
+	}
+	if calls := server.received(); len(calls) != 0 {
+		t.Errorf("endpoint received %d calls for an event already delivered, want 0", len(calls))
+	}
+	if delivery := f.eventLog(t, event.EventID).Delivery; delivery.Attempts != 2 {
+		t.Errorf("delivery attempts = %d, want the recorded 2", delivery.Attempts)
+	}
+
+	// A redelivered message is skipped once its first dispatch was recorded
+	event = f.fire(t, models.ExecutionStatusSuccess, nil)
+	for i := 0; i < 2; i++ {
+		if err := f.dispatcher.Dispatch(f.ctx, event); err != nil {
+			t.Fatalf("Dispatch %d: %v", i, err)
+		}
+	}
+	if calls := server.received(); len(calls) != 1 {
+		t.Errorf("endpoint received %d calls for a redelivered event, want 1", len(calls))
+	}
+}
+
+func TestDispatchAcknowledgesEvents(t *testing.T) {
+	cases := []struct {
+		name       string
+		status     int
+		running    bool // the event requires acknowledgement
+		wantStatus models.ExecutionStatus
+		wantError  string
+	}{
+		{name: "delivered", status: http.StatusOK, running: true, wantStatus: models.ExecutionStatusSuccess},
+		{name: "failed", status: http.StatusBadRequest, running: true, wantStatus: models.ExecutionStatusFailure, wantError: "unexpected status 400 Bad Request: upstream down"},
+		{name: "not required", status: http.StatusOK, wantStatus: models.ExecutionStatusSuccess},
+		{name: "not required and failed", status: http.StatusBadRequest, wantStatus: models.ExecutionStatusSuccess},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newEndpoint(t, response{status: tc.status})
+			f := newFixture(t, server.URL)
+			status := models.ExecutionStatusSuccess
+			if tc.running {
+				status = models.ExecutionStatusRunning
+			}
+			event := f.fire(t, status, nil)
+
+			if err := f.dispatcher.Dispatch(f.ctx, event); err != nil {
+				t.Fatalf("Dispatch: %v", err)
+			}
+
+			eventLog := f.eventLog(t, event.EventID)
+			if eventLog.ExecutionStatus != tc.wantStatus {
+				t.Errorf("execution status = %q, want %q", eventLog.ExecutionStatus, tc.wantStatus)
+			}
+			if tc.running != (eventLog.AcknowledgedAt != nil) {
+				t.Errorf("acknowledged_at = %v, want it set only for events requiring acknowledgement", eventLog.AcknowledgedAt)
+			}
+			switch {
+			case tc.wantError == "" && eventLog.ErrorMessage != nil:
+				t.Errorf("error message = %q, want none", *eventLog.ErrorMessage)
+			case tc.wantError != "" && (eventLog.ErrorMessage == nil || !strings.Contains(*eventLog.ErrorMessage, tc.wantError)):
+				t.Errorf("error message = %v, want %q", eventLog.ErrorMessage, tc.wantError)
+			}
+		})
+	}
+}
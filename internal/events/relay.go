@@ -0,0 +1,166 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/platform/events"
+	"go.uber.org/zap"
+)
+
+// DefaultRelayInterval is how often a relay polls the outbox when RelayConfig.PollInterval is unset.
+const DefaultRelayInterval = time.Second
+
+// DefaultRelayBatchSize is how many outbox messages a relay claims per query when RelayConfig.BatchSize is unset.
+const DefaultRelayBatchSize = 100
+
+// outboxLease is how long a deliverer holds an outbox message. It outlasts the publisher's
+// 10s write timeout, so a live deliverer never loses a message to a relay mid-publish.
+const outboxLease = 30 * time.Second
+
+// Outbox retry backoff: doubles from outboxBaseBackoff per failed attempt, up to outboxMaxBackoff.
+// Messages are retried until Kafka accepts them.
+const (
+	outboxBaseBackoff = time.Second
+	outboxMaxBackoff  = 5 * time.Minute
+)
+
+// RelayConfig holds the tunables of an outbox relay.
+type RelayConfig struct {
+	// InstanceID identifies the relay as the owner of the messages it claims.
+	InstanceID string
+	// PollInterval is how long the relay sleeps when the outbox has nothing left to deliver.
+	PollInterval time.Duration
+	// BatchSize is how many messages are claimed per query; full batches are drained back to back.
+	BatchSize int
+	// Clock provides the current time; defaults to the system clock.
+	Clock clock.Clock
+}
+
+// Relay delivers the outbox messages that were not published right after their event was recorded:
+// the publish failed, or the process died before it finished. Any number of relays may run against
+// the same database; each message is leased to one of them at a time.
+type Relay struct {
+	instanceID   string
+	pollInterval time.Duration
+	batchSize    int
+	clock        clock.Clock
+	db           storage.OutboxStore
+	publisher    events.Publisher
+	logger       *zap.Logger
+}
+
+// NewRelay constructs an outbox relay with the provided configuration and dependencies.
+func NewRelay(cfg RelayConfig, db storage.OutboxStore, publisher events.Publisher, logger *zap.Logger) *Relay {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultRelayInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultRelayBatchSize
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.System()
+	}
+
+	return &Relay{
+		instanceID:   cfg.InstanceID,
+		pollInterval: cfg.PollInterval,
+		batchSize:    cfg.BatchSize,
+		clock:        cfg.Clock,
+		db:           db,
+		publisher:    publisher,
+		logger:       logger,
+	}
+}
+
+// Run relays outbox messages until the context is cancelled.
+func (r *Relay) Run(ctx context.Context) error {
+	r.logger.Info("outbox relay started",
+		zap.String("instance_id", r.instanceID),
+		zap.Duration("poll_interval", r.pollInterval),
+		zap.Int("batch_size", r.batchSize))
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-timer.C:
+			delay := r.pollInterval
+			claimed, err := r.RunOnce(ctx)
+			if err != nil && ctx.Err() == nil {
+				r.logger.Error("failed to relay outbox messages", zap.Error(err))
+			}
+			if claimed == r.batchSize {
+				// More may be waiting: drain the backlog back to back
+				delay = 0
+			}
+			timer.Reset(delay)
+		case <-ctx.Done():
+			r.logger.Info("outbox relay stopped")
+			return ctx.Err()
+		}
+	}
+}
+
+// RunOnce claims one batch of due outbox messages and delivers them, returning how many it claimed.
+func (r *Relay) RunOnce(ctx context.Context) (int, error) {
+	messages, err := r.db.ClaimOutboxMessages(ctx, r.instanceID, r.batchSize, outboxLease)
+	if err != nil {
+		return 0, fmt.Errorf("claim outbox messages: %w", err)
+	}
+
+	for i := range messages {
+		deliverOutboxMessage(ctx, r.db, r.publisher, r.clock, r.logger, r.instanceID, &messages[i])
+	}
+
+	if len(messages) > 0 {
+		r.logger.Info("relayed outbox messages", zap.Int("count", len(messages)))
+	}
+	return len(messages), nil
+}
+
+// deliverOutboxMessage publishes a message held by owner and records the outcome: sent, or released
+// for another attempt after a backoff. Returns whether Kafka accepted the message. Recording failures
+// are logged only: the lease runs out and a relay delivers the message again.
+func deliverOutboxMessage(ctx context.Context, db storage.OutboxStore, publisher events.Publisher, clk clock.Clock, logger *zap.Logger, owner string, message *models.OutboxMessage) bool {
+	publishErr := publisher.PublishMessage(ctx, message.EventID, message.MessageKey, message.Payload)
+	if publishErr == nil {
+		if err := db.MarkOutboxMessageSent(ctx, message.ID, owner); err != nil {
+			logger.Error("failed to mark outbox message sent",
+				zap.String("event_id", message.EventID),
+				zap.Bool("lease_lost", errors.Is(err, storage.ErrOutboxLeaseLost)),
+				zap.Error(err))
+		}
+		return true
+	}
+
+	backoff := outboxBackoff(message.AttemptCount)
+	nextAttemptAt := clk.Now().UTC().Add(backoff)
+	logger.Warn("failed to publish outbox message, will retry",
+		zap.String("event_id", message.EventID),
+		zap.Int("attempt", message.AttemptCount),
+		zap.Duration("backoff", backoff),
+		zap.Error(publishErr))
+
+	if err := db.RetryOutboxMessage(ctx, message.ID, owner, nextAttemptAt, publishErr.Error()); err != nil {
+		logger.Error("failed to release outbox message for retry",
+			zap.String("event_id", message.EventID),
+			zap.Error(err))
+	}
+	return false
+}
+
+// outboxBackoff returns the delay before delivery attempt attempt+1.
+func outboxBackoff(attempt int) time.Duration {
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempt && backoff < outboxMaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, outboxMaxBackoff)
+}
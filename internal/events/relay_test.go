@@ -0,0 +1,191 @@
+package events_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var errBrokerDown = errors.New("kafka: leader not available")
+
+// recordingPublisher records the events it publishes, failing while failing is set.
+type recordingPublisher struct {
+	mu        sync.Mutex
+	failing   bool
+	published []string
+	attempts  int
+}
+
+func (p *recordingPublisher) PublishMessage(_ context.Context, eventID, _ string, _ []byte) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.attempts++
+	if p.failing {
+		return errBrokerDown
+	}
+	p.published = append(p.published, eventID)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error { return nil }
+
+// leaseLosingStore reports every outbox message as taken over by another relay when it is marked sent.
+type leaseLosingStore struct {
+	*memory.Store
+}
+
+func (s *leaseLosingStore) MarkOutboxMessageSent(context.Context, int64, string) error {
+	return storage.ErrOutboxLeaseLost
+}
+
+// queueOutboxMessage records an event whose message waits in the outbox, unclaimed.
+func queueOutboxMessage(t *testing.T, store storage.OutboxStore, clk clock.Clock, eventID string) {
+	t.Helper()
+
+	now := clk.Now().UTC()
+	triggerID := "trigger-" + eventID
+	eventLog := &models.EventLog{
+		ID:              eventID,
+		TriggerID:       &triggerID,
+		TriggerType:     models.TriggerTypeWebhook,
+		FiredAt:         now,
+		Source:          models.EventSourceWebhook,
+		ExecutionStatus: models.ExecutionStatusSuccess,
+		RetentionStatus: models.RetentionStatusActive,
+		CreatedAt:       now,
+	}
+	message := &models.OutboxMessage{
+		EventID:       eventID,
+		MessageKey:    triggerID,
+		Payload:       json.RawMessage(`{"event_id":"` + eventID + `"}`),
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+	}
+	if err := store.RecordEvent(context.Background(), eventLog, message, nil); err != nil {
+		t.Fatalf("RecordEvent: %v", err)
+	}
+}
+
+func newTestRelay(store storage.OutboxStore, publisher *recordingPublisher, clk clock.Clock, logger *zap.Logger) *events.Relay {
+	return events.NewRelay(events.RelayConfig{InstanceID: "relay-1", BatchSize: 10, Clock: clk}, store, publisher, logger)
+}
+
+// runOnce runs one relay pass and checks how many messages it claimed.
+func runOnce(t *testing.T, relay *events.Relay, wantClaimed int) {
+	t.Helper()
+	claimed, err := relay.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce: %v", err)
+	}
+	if claimed != wantClaimed {
+		t.Fatalf("RunOnce claimed %d messages, want %d", claimed, wantClaimed)
+	}
+}
+
+func TestRelayPublishesOutboxMessages(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	publisher := &recordingPublisher{}
+	relay := newTestRelay(store, publisher, clk, zap.NewNop())
+
+	queueOutboxMessage(t, store, clk, "event-1")
+	queueOutboxMessage(t, store, clk, "event-2")
+
+	runOnce(t, relay, 2)
+	if len(publisher.published) != 2 || publisher.published[0] != "event-1" || publisher.published[1] != "event-2" {
+		t.Fatalf("published %v, want [event-1 event-2]", publisher.published)
+	}
+	eventLog, err := store.GetEventLog(ctx, "event-1")
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if eventLog.PublishedAt == nil || !eventLog.PublishedAt.Equal(clk.Now()) {
+		t.Errorf("published_at = %v, want %s", eventLog.PublishedAt, clk.Now())
+	}
+
+	// Sent messages are not delivered again, not even once their lease would have run out
+	clk.Advance(time.Hour)
+	runOnce(t, relay, 0)
+	if len(publisher.published) != 2 {
+		t.Errorf("published %v, want each message once", publisher.published)
+	}
+}
+
+func TestRelayBacksOffFailedPublishes(t *testing.T) {
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	publisher := &recordingPublisher{failing: true}
+	relay := newTestRelay(store, publisher, clk, zap.NewNop())
+
+	queueOutboxMessage(t, store, clk, "event-1")
+
+	// The delay after each failed attempt doubles from 1s and stays at 5m
+	backoffs := []time.Duration{
+		time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 32 * time.Second,
+		64 * time.Second, 128 * time.Second, 256 * time.Second, 5 * time.Minute, 5 * time.Minute, 5 * time.Minute,
+	}
+	for i, backoff := range backoffs {
+		runOnce(t, relay, 1)
+		if publisher.attempts != i+1 {
+			t.Fatalf("publish attempts = %d, want %d", publisher.attempts, i+1)
+		}
+
+		// Released until the backoff has passed, not held until its lease expires
+		clk.Advance(backoff - time.Second)
+		runOnce(t, relay, 0)
+		clk.Advance(time.Second)
+	}
+
+	publisher.failing = false
+	runOnce(t, relay, 1)
+	if len(publisher.published) != 1 || publisher.published[0] != "event-1" {
+		t.Errorf("published %v, want event-1 once the broker is back", publisher.published)
+	}
+}
+
+func TestRelayLeaseLostAfterPublish(t *testing.T) {
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := &leaseLosingStore{Store: memory.NewStore(clk)}
+	publisher := &recordingPublisher{}
+	core, logs := observer.New(zapcore.InfoLevel)
+	relay := newTestRelay(store, publisher, clk, zap.New(core))
+
+	queueOutboxMessage(t, store, clk, "event-1")
+
+	runOnce(t, relay, 1)
+	if publisher.attempts != 1 {
+		t.Fatalf("publish attempts = %d, want 1: the message was accepted and must not be retried", publisher.attempts)
+	}
+
+	entries := logs.FilterMessage("failed to mark outbox message sent").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d mark failures, want 1", len(entries))
+	}
+	if fields := entries[0].ContextMap(); fields["lease_lost"] != true || fields["event_id"] != "event-1" {
+		t.Errorf("mark failure fields = %v, want lease_lost for event-1", fields)
+	}
+	if n := logs.FilterMessage("failed to publish outbox message, will retry").Len(); n != 0 {
+		t.Errorf("logged %d publish retries, want none", n)
+	}
+
+	// The message is not released for a retry: it stays with whoever holds its lease
+	runOnce(t, relay, 0)
+	if publisher.attempts != 1 {
+		t.Errorf("publish attempts = %d, want 1", publisher.attempts)
+	}
+}
@@ -3,41 +3,187 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/dhima/event-trigger-platform/internal/clock"
 	"github.com/dhima/event-trigger-platform/internal/models"
 	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
 	"github.com/dhima/event-trigger-platform/platform/events"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// Store is the persistence the event service needs: event logs, the outbox they are recorded
+// with and the idempotency keys of scheduled and webhook firings. Any storage.Store satisfies it.
+type Store interface {
+	storage.EventLogStore
+	storage.OutboxStore
+	storage.IdempotencyStore
+}
+
 // Service provides business logic for event handling and firing triggers.
 type Service struct {
-	db        *storage.MySQLClient
-	publisher *events.Publisher
-	logger    *zap.Logger
+	db         Store
+	publisher  events.Publisher
+	clock      clock.Clock
+	logger     *zap.Logger
+	instanceID string // Owner of the outbox messages this service publishes itself
 }
 
 // NewService creates a new EventService instance.
-func NewService(db *storage.MySQLClient, publisher *events.Publisher, logger *zap.Logger) *Service {
+func NewService(db Store, publisher events.Publisher, clk clock.Clock, logger *zap.Logger) *Service {
 	return &Service{
-		db:        db,
-		publisher: publisher,
-		logger:    logger,
+		db:         db,
+		publisher:  publisher,
+		clock:      clk,
+		logger:     logger,
+		instanceID: "events-" + uuid.New().String()[:8],
 	}
 }
 
-// FireTrigger creates an event log entry and publishes the trigger event to Kafka.
-// This method implements at-least-once semantics:
-// 1. Write event_log to database (inside transaction)
-// 2. Publish to Kafka (outside transaction)
-// 3. If Kafka fails, update event_log status to 'failure'
+// FireTrigger records an event for the trigger and publishes it to Kafka, with at-least-once
+// semantics through a transactional outbox:
+// 1. Write the event log and its Kafka message (the outbox row) in one transaction
+// 2. Publish the message right after commit, and mark it sent
+// 3. If Kafka fails (or the process dies first), the message stays in the outbox and a Relay
+// publishes it later; the event's published_at stays empty until then
+// An error means nothing was recorded; once an event ID is returned, the event will be published.
 func (s *Service) FireTrigger(ctx context.Context, trigger *models.Trigger, source models.EventSource, payload map[string]interface{}, isTestRun bool) (string, error) {
-	// Generate unique event ID
-	eventID := uuid.New().String()
+	return s.fire(ctx, uuid.New().String(), trigger, source, payload, isTestRun, nil, nil, false, nil)
+}
+
+// FireScheduledTrigger fires a trigger for one of its schedule rows. The event log and the Kafka
+// message are tagged with the occurrence (schedule.Occurrence()) the event corresponds to, which
+// differs from fired_at by the trigger's jitter or spread offset, and more when the scheduler is
+// catching up on missed occurrences. The event log also notes how a DST transition affected the
+// occurrence of a CRON trigger, if it did (see the trigger's dst_policy). Backfill schedules fire
+// with source "backfill", so consumers can tell replayed occurrences from live ones.
+// Scheduler events of triggers with concurrency_policy forbid or replace are logged as running
+// until their consumer acknowledges them (see AcknowledgeEvent); backfill events never are.
+// The schedule must be claimed (ClaimDueSchedules): it is completed in the same transaction that
+// records the event, so a schedule is completed if and only if its event will be published.
+// The event ID is reserved under the schedule ID (idempotency_keys) before anything is recorded, so
+// every retry of the schedule fires with the same event ID, and an event that was recorded already
+// is returned rather than recorded twice.
+func (s *Service) FireScheduledTrigger(ctx context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule, payload map[string]interface{}) (string, error) {
+	if schedule.ClaimedBy == nil {
+		return "", fmt.Errorf("schedule %s is not claimed", schedule.ID)
+	}
+	completion := &storage.ScheduleCompletion{ScheduleID: schedule.ID, Owner: *schedule.ClaimedBy}
+
+	eventID, recorded, err := s.reserveEventID(ctx, schedule.ID)
+	if err != nil {
+		return "", err
+	}
+	if recorded {
+		// An earlier attempt recorded the event (its transaction completed the schedule) but did not
+		// report back; the outbox publishes it, so there is nothing left to fire
+		s.logger.Warn("schedule already fired, reusing its event",
+			zap.String("event_id", eventID),
+			zap.String("schedule_id", schedule.ID),
+			zap.String("trigger_id", trigger.ID))
+		return eventID, nil
+	}
+
+	source := models.EventSourceScheduler
+	if schedule.Backfill {
+		source = models.EventSourceBackfill
+	}
+
+	scheduledFor := schedule.Occurrence().UTC()
+	ackRequired := !schedule.Backfill && triggers.ConcurrencyPolicyOf(trigger).RequiresAck()
+	return s.fire(ctx, eventID, trigger, source, payload, false, &scheduledFor, triggers.DSTNote(trigger, scheduledFor), ackRequired, completion)
+}
+
+// FireWebhook fires a webhook trigger, deduplicating on idempotencyKey when it is not empty: a
+// request repeating the key of an earlier one returns that request's event ID with replayed set,
+// instead of firing again. Keys are scoped to the trigger and honored as long as the event they
+// fired is kept (storage.EventLogDeleteAfter); a key repeated after that fires a new event under a
+// new event ID. A key whose event was never recorded (the first request failed) fires with the
+// event ID reserved for it. Concurrent requests with the same key
+// share that reserved ID: the first to record it fires, the others are replays.
+func (s *Service) FireWebhook(ctx context.Context, trigger *models.Trigger, payload map[string]interface{}, idempotencyKey string) (string, bool, error) {
+	if idempotencyKey == "" {
+		eventID, err := s.FireTrigger(ctx, trigger, models.EventSourceWebhook, payload, false)
+		return eventID, false, err
+	}
+
+	jobID := uuid.NewSHA1(webhookIdempotencyNamespace, []byte(trigger.ID+"\n"+idempotencyKey)).String()
+	eventID, recorded, err := s.reserveEventID(ctx, jobID)
+	if err != nil {
+		return "", false, err
+	}
+	if recorded {
+		s.logger.Info("webhook replayed, returning the original event",
+			zap.String("event_id", eventID),
+			zap.String("trigger_id", trigger.ID))
+		return eventID, true, nil
+	}
+
+	firedID, err := s.fire(ctx, eventID, trigger, models.EventSourceWebhook, payload, false, nil, nil, false, nil)
+	if errors.Is(err, storage.ErrEventLogExists) {
+		// A concurrent delivery with the same key reserved the same event ID and recorded it first
+		s.logger.Info("webhook replayed concurrently, returning the original event",
+			zap.String("event_id", eventID),
+			zap.String("trigger_id", trigger.ID))
+		return eventID, true, nil
+	}
+	return firedID, false, err
+}
+
+// webhookIdempotencyNamespace derives the idempotency_keys job ID of a webhook request (a name-based
+// UUID of its trigger ID and Idempotency-Key), so any key fits the table and cannot collide with
+// another trigger's.
+var webhookIdempotencyNamespace = uuid.MustParse("91fb88ee-fc12-41e9-a923-d5a4081c21a3")
+
+// reserveEventID returns the event ID reserved for jobID (reserving a new one the first time) and
+// whether an event with that ID was recorded already. A reservation older than
+// storage.EventLogDeleteAfter whose event is gone has expired: its event may have been recorded,
+// published and deleted since, so jobID is bound to a new event ID rather than firing under the old one.
+func (s *Service) reserveEventID(ctx context.Context, jobID string) (string, bool, error) {
+	candidate := uuid.New().String()
+	eventID, err := s.db.ReserveIdempotencyKey(ctx, jobID, candidate)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to reserve event ID: %w", err)
+	}
+	existing, err := s.db.GetEventLog(ctx, eventID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look up event log: %w", err)
+	}
+	if existing != nil || eventID == candidate {
+		return eventID, existing != nil, nil
+	}
+
+	reservedBefore := s.clock.Now().UTC().Add(-storage.EventLogDeleteAfter)
+	rebound, err := s.db.RebindIdempotencyKey(ctx, jobID, eventID, candidate, reservedBefore)
+	if err == nil && rebound == "" {
+		// Deleted by retention meanwhile
+		rebound, err = s.db.ReserveIdempotencyKey(ctx, jobID, candidate)
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to reserve event ID: %w", err)
+	}
+	if rebound == eventID {
+		// Reserved recently, by an attempt that has not recorded its event (yet)
+		return eventID, false, nil
+	}
 
+	s.logger.Info("idempotency key outlived its event, reserving a new event ID",
+		zap.String("job_id", jobID),
+		zap.String("expired_event_id", eventID),
+		zap.String("event_id", rebound))
+	// A concurrent request may have rebound the key first and recorded its event
+	existing, err = s.db.GetEventLog(ctx, rebound)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to look up event log: %w", err)
+	}
+	return rebound, existing != nil, nil
+}
+
+func (s *Service) fire(ctx context.Context, eventID string, trigger *models.Trigger, source models.EventSource, payload map[string]interface{}, isTestRun bool, scheduledFor *time.Time, dstNote *string, ackRequired bool, completion *storage.ScheduleCompletion) (string, error) {
 	// Prepare payload JSON
 	var payloadBytes json.RawMessage
 	if payload != nil {
@@ -51,18 +197,25 @@ func (s *Service) FireTrigger(ctx context.Context, trigger *models.Trigger, sour
 		}
 	}
 
-	// Create event log entry with 'success' status initially
+	// Create event log entry with 'success' status initially ('running' until acknowledged when required)
+	status := models.ExecutionStatusSuccess
+	if ackRequired {
+		status = models.ExecutionStatusRunning
+	}
+	now := s.clock.Now().UTC()
 	eventLog := &models.EventLog{
 		ID:              eventID,
 		TriggerID:       &trigger.ID,
 		TriggerType:     trigger.Type,
-		FiredAt:         time.Now().UTC(),
+		FiredAt:         now,
+		ScheduledFor:    scheduledFor,
+		DSTNote:         dstNote,
 		Payload:         payloadBytes,
 		Source:          source,
-		ExecutionStatus: models.ExecutionStatusSuccess,
+		ExecutionStatus: status,
 		RetentionStatus: models.RetentionStatusActive,
 		IsTestRun:       isTestRun,
-		CreatedAt:       time.Now().UTC(),
+		CreatedAt:       now,
 	}
 
 	// For pure manual test runs without persisted trigger, trigger_id can be nil
@@ -70,23 +223,7 @@ func (s *Service) FireTrigger(ctx context.Context, trigger *models.Trigger, sour
 		eventLog.TriggerID = nil
 	}
 
-	// Insert event log into database
-	err := s.db.CreateEventLog(ctx, eventLog)
-	if err != nil {
-		s.logger.Error("failed to create event log",
-			zap.String("event_id", eventID),
-			zap.String("trigger_id", trigger.ID),
-			zap.Error(err))
-		return "", fmt.Errorf("failed to create event log: %w", err)
-	}
-
-	s.logger.Info("event log created successfully",
-		zap.String("event_id", eventID),
-		zap.String("trigger_id", trigger.ID),
-		zap.String("source", string(source)),
-		zap.Bool("is_test_run", isTestRun))
-
-	// Publish to Kafka (outside transaction for at-least-once semantics)
+	// The Kafka message, stored in the outbox with the event log
 	triggerEvent := events.TriggerEvent{
 		EventID:   eventID,
 		TriggerID: trigger.ID,
@@ -94,25 +231,50 @@ func (s *Service) FireTrigger(ctx context.Context, trigger *models.Trigger, sour
 		Payload:   payload,
 		FiredAt:   eventLog.FiredAt,
 		Source:    string(source),
-	}
 
-	err = s.publisher.Publish(ctx, triggerEvent)
+		ScheduledFor: scheduledFor,
+		AckRequired:  ackRequired,
+	}
+	messageBytes, err := json.Marshal(triggerEvent)
 	if err != nil {
-		// Kafka publish failed - update event log status to 'failure'
-		s.logger.Error("failed to publish event to Kafka, marking as failed",
+		return "", fmt.Errorf("failed to marshal trigger event: %w", err)
+	}
+
+	// Claimed by this service for the publish right after commit; a relay takes over if the lease runs out
+	leaseExpiresAt := now.Add(outboxLease)
+	message := &models.OutboxMessage{
+		EventID:        eventID,
+		MessageKey:     trigger.ID,
+		Payload:        messageBytes,
+		Status:         models.OutboxStatusPending,
+		AttemptCount:   1,
+		NextAttemptAt:  now,
+		ClaimedBy:      &s.instanceID,
+		LeaseExpiresAt: &leaseExpiresAt,
+		CreatedAt:      now,
+	}
+
+	// Insert event log and outbox message (and complete the schedule) in one transaction
+	if err := s.db.RecordEvent(ctx, eventLog, message, completion); err != nil {
+		s.logger.Error("failed to record event",
 			zap.String("event_id", eventID),
 			zap.String("trigger_id", trigger.ID),
 			zap.Error(err))
+		return "", fmt.Errorf("failed to record event: %w", err)
+	}
 
-		errorMsg := fmt.Sprintf("Kafka publish failed: %s", err.Error())
-		updateErr := s.db.UpdateEventLogStatus(ctx, eventID, models.ExecutionStatusFailure, &errorMsg)
-		if updateErr != nil {
-			s.logger.Error("failed to update event log status after Kafka failure",
-				zap.String("event_id", eventID),
-				zap.Error(updateErr))
-		}
+	s.logger.Info("event log created successfully",
+		zap.String("event_id", eventID),
+		zap.String("trigger_id", trigger.ID),
+		zap.String("source", string(source)),
+		zap.Bool("is_test_run", isTestRun))
 
-		return eventID, fmt.Errorf("failed to publish event to Kafka: %w", err)
+	// Publish to Kafka (after commit); on failure the message waits in the outbox for the relay
+	if !deliverOutboxMessage(ctx, s.db, s.publisher, s.clock, s.logger, s.instanceID, message) {
+		s.logger.Warn("event left in the outbox for the relay",
+			zap.String("event_id", eventID),
+			zap.String("trigger_id", trigger.ID))
+		return eventID, nil
 	}
 
 	s.logger.Info("trigger fired successfully",
@@ -189,3 +351,44 @@ func (s *Service) GetEvent(ctx context.Context, eventID string) (*models.EventLo
 
 	return eventLog, nil
 }
+
+// AcknowledgeEvent records the outcome a consumer reports for a running event: success (the
+// default) or failure, with an optional error message. Returns nil (and no error) for unknown
+// events and storage.ErrEventLogNotRunning for events that are not running, e.g. because a newer
+// occurrence replaced them.
+func (s *Service) AcknowledgeEvent(ctx context.Context, eventID string, req models.AckEventRequest) (*models.EventLog, error) {
+	eventLog, err := s.db.GetEventLog(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event log: %w", err)
+	}
+	if eventLog == nil {
+		return nil, nil
+	}
+
+	if eventLog.ExecutionStatus != models.ExecutionStatusRunning {
+		return nil, fmt.Errorf("event is %s: %w", eventLog.ExecutionStatus, storage.ErrEventLogNotRunning)
+	}
+
+	status := req.Status
+	if status == "" {
+		status = models.ExecutionStatusSuccess
+	}
+	var errorMessage *string
+	if status == models.ExecutionStatusFailure && req.ErrorMessage != "" {
+		errorMessage = &req.ErrorMessage
+	}
+
+	// The store checks the status again: the event may have been replaced meanwhile
+	if err := s.db.AcknowledgeEventLog(ctx, eventID, status, errorMessage); err != nil {
+		if errors.Is(err, storage.ErrEventLogNotRunning) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to acknowledge event log: %w", err)
+	}
+
+	s.logger.Info("event acknowledged",
+		zap.String("event_id", eventID),
+		zap.String("execution_status", string(status)))
+
+	return s.db.GetEventLog(ctx, eventID)
+}
@@ -0,0 +1,234 @@
+package events_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/storage/sqlite"
+	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var errRecordFailed = errors.New("connection reset")
+
+// flakyStore fails the next RecordEvent call, either before the transaction (nothing is written)
+// or after it committed (the caller sees an error although the event was recorded).
+type flakyStore struct {
+	*sqlite.Client
+	failBeforeCommit bool
+	failAfterCommit  bool
+}
+
+func (s *flakyStore) RecordEvent(ctx context.Context, eventLog *models.EventLog, message *models.OutboxMessage, completion *storage.ScheduleCompletion) error {
+	if s.failBeforeCommit {
+		s.failBeforeCommit = false
+		return errRecordFailed
+	}
+	if err := s.Client.RecordEvent(ctx, eventLog, message, completion); err != nil {
+		return err
+	}
+	if s.failAfterCommit {
+		s.failAfterCommit = false
+		return errRecordFailed
+	}
+	return nil
+}
+
+// TestFireScheduledTriggerRetryReusesEventID fails the first fire of a schedule after its event ID
+// was reserved, retries it and checks that exactly one event is recorded and published, under the
+// reserved ID.
+func TestFireScheduledTriggerRetryReusesEventID(t *testing.T) {
+	cases := []struct {
+		name             string
+		failBeforeCommit bool
+		failAfterCommit  bool
+	}{
+		{name: "record fails", failBeforeCommit: true},
+		{name: "record commits but reports an error", failAfterCommit: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			db, err := sqlite.Open(ctx, ":memory:")
+			if err != nil {
+				t.Fatalf("open sqlite: %v", err)
+			}
+			t.Cleanup(func() { db.Close() })
+			store := &flakyStore{Client: sqlite.NewClient(db, clk), failBeforeCommit: tc.failBeforeCommit, failAfterCommit: tc.failAfterCommit}
+
+			publisher := platformEvents.NewMemoryPublisher(10)
+			service := events.NewService(store, publisher, clk, zap.NewNop())
+
+			claimed := claimDueSchedule(t, store, clk)
+			trigger, schedule := &claimed.Trigger, &claimed.Schedule
+
+			if _, err := service.FireScheduledTrigger(ctx, trigger, schedule, nil); !errors.Is(err, errRecordFailed) {
+				t.Fatalf("first FireScheduledTrigger err = %v, want %v", err, errRecordFailed)
+			}
+			reserved, err := store.GetIdempotencyKey(ctx, schedule.ID)
+			if err != nil || reserved == "" {
+				t.Fatalf("GetIdempotencyKey = %q, %v; want the reserved event ID", reserved, err)
+			}
+
+			clk.Advance(10 * time.Second)
+			eventID, err := service.FireScheduledTrigger(ctx, trigger, schedule, nil)
+			if err != nil {
+				t.Fatalf("retried FireScheduledTrigger: %v", err)
+			}
+			if eventID != reserved {
+				t.Errorf("retry fired event %s, want the reserved %s", eventID, reserved)
+			}
+
+			// An event recorded by the failed attempt is left to the relay once the service's lease expires
+			clk.Advance(time.Minute)
+			relay := events.NewRelay(events.RelayConfig{InstanceID: "relay", Clock: clk}, store, publisher, zap.NewNop())
+			if _, err := relay.RunOnce(ctx); err != nil {
+				t.Fatalf("relay: %v", err)
+			}
+
+			assertCount(t, db, "event logs", `SELECT COUNT(*) FROM event_logs WHERE trigger_id = ?`, trigger.ID, 1)
+			assertCount(t, db, "outbox rows", `SELECT COUNT(*) FROM event_outbox WHERE event_id = ?`, reserved, 1)
+			assertCount(t, db, "sent outbox rows", `SELECT COUNT(*) FROM event_outbox WHERE event_id = ? AND status = 'sent'`, reserved, 1)
+
+			if err := publisher.Close(); err != nil {
+				t.Fatalf("close publisher: %v", err)
+			}
+			var published []string
+			for message := range publisher.Messages() {
+				event, err := message.Event()
+				if err != nil {
+					t.Fatalf("decode published message: %v", err)
+				}
+				published = append(published, event.EventID)
+			}
+			if len(published) != 1 || published[0] != reserved {
+				t.Errorf("published events %v, want exactly [%s]", published, reserved)
+			}
+		})
+	}
+}
+
+// claimDueSchedule stores a time-scheduled trigger due now and claims its schedule.
+func claimDueSchedule(t *testing.T, store storage.Store, clk clock.Clock) storage.ScheduleWithTrigger {
+	t.Helper()
+	ctx := context.Background()
+
+	now := clk.Now().UTC()
+	config, err := json.Marshal(map[string]interface{}{"run_at": now.Format(time.RFC3339), "endpoint": "https://example.com/hook"})
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+	trigger := &models.Trigger{
+		ID:     uuid.New().String(),
+		Name:   "once",
+		Type:   models.TriggerTypeTimeScheduled,
+		Status: models.TriggerStatusActive,
+		Config: config,
+	}
+	schedule := &models.TriggerSchedule{
+		ID:        uuid.New().String(),
+		TriggerID: trigger.ID,
+		FireAt:    now,
+		Status:    models.ScheduleStatusPending,
+	}
+	if err := store.CreateTrigger(ctx, trigger, schedule); err != nil {
+		t.Fatalf("CreateTrigger: %v", err)
+	}
+
+	claimed, err := store.ClaimDueSchedules(ctx, "scheduler", 10, time.Minute)
+	if err != nil {
+		t.Fatalf("ClaimDueSchedules: %v", err)
+	}
+	if len(claimed) != 1 {
+		t.Fatalf("claimed %d schedules, want 1", len(claimed))
+	}
+	return claimed[0]
+}
+
+func assertCount(t *testing.T, db *sql.DB, what, query, arg string, want int) {
+	t.Helper()
+
+	var got int
+	if err := db.QueryRow(query, arg).Scan(&got); err != nil {
+		t.Fatalf("count %s: %v", what, err)
+	}
+	if got != want {
+		t.Errorf("%s = %d, want %d", what, got, want)
+	}
+}
+
+// TestFireWebhookIdempotencyKeyExpiry repeats a webhook's Idempotency-Key before and after its
+// event is deleted by retention: the key is honored while the event is kept, then fires a new event
+// under a new ID, since consumers may have deduplicated the old one.
+func TestFireWebhookIdempotencyKeyExpiry(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	publisher := platformEvents.NewMemoryPublisher(10)
+	service := events.NewService(store, publisher, clk, zap.NewNop())
+
+	trigger := &models.Trigger{
+		ID:     uuid.New().String(),
+		Name:   "orders",
+		Type:   models.TriggerTypeWebhook,
+		Status: models.TriggerStatusActive,
+		Config: json.RawMessage(`{"endpoint":"https://example.com/hook","http_method":"POST"}`),
+	}
+	if err := store.CreateTrigger(ctx, trigger, nil); err != nil {
+		t.Fatalf("CreateTrigger: %v", err)
+	}
+	payload := map[string]interface{}{"order_id": 42}
+
+	fire := func(wantReplayed bool) string {
+		t.Helper()
+		eventID, replayed, err := service.FireWebhook(ctx, trigger, payload, "order-42")
+		if err != nil {
+			t.Fatalf("FireWebhook: %v", err)
+		}
+		if replayed != wantReplayed {
+			t.Fatalf("FireWebhook at %s replayed = %v, want %v", clk.Now(), replayed, wantReplayed)
+		}
+		return eventID
+	}
+
+	first := fire(false)
+	clk.Advance(storage.EventLogDeleteAfter - time.Hour)
+	if replay := fire(true); replay != first {
+		t.Errorf("replay before the event expired = %s, want %s", replay, first)
+	}
+
+	clk.Advance(2 * time.Hour)
+	if eventLog, err := store.GetEventLog(ctx, first); err != nil || eventLog != nil {
+		t.Fatalf("GetEventLog(%s) past the retention cutoff = %v, %v; want it deleted", first, eventLog, err)
+	}
+	second := fire(false)
+	if second == first {
+		t.Fatalf("webhook fired again under the expired event ID %s", first)
+	}
+	if replay := fire(true); replay != second {
+		t.Errorf("replay of the new event = %s, want %s", replay, second)
+	}
+
+	if err := publisher.Close(); err != nil {
+		t.Fatalf("close publisher: %v", err)
+	}
+	var published []string
+	for message := range publisher.Messages() {
+		published = append(published, message.EventID)
+	}
+	if len(published) != 2 || published[0] != first || published[1] != second {
+		t.Errorf("published events %v, want [%s %s]", published, first, second)
+	}
+}
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// CalendarPolicy decides what the scheduler does with occurrences that fall inside a calendar exclusion.
+type CalendarPolicy string
+
+const (
+	// CalendarPolicySkip drops the occurrence and records the reason in the event log.
+	CalendarPolicySkip CalendarPolicy = "skip"
+	// CalendarPolicyDefer fires the occurrence once the exclusion ends.
+	CalendarPolicyDefer CalendarPolicy = "defer"
+)
+
+// Calendar is a named set of exclusions (holidays and blackout windows) that recurring triggers
+// reference by name, so they can be maintained in one place.
+type Calendar struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Timezone  string            `json:"timezone"`
+	Holidays  []string          `json:"holidays"` // YYYY-MM-DD, whole days in Timezone
+	Windows   []ExclusionWindow `json:"windows"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ExclusionWindow is a blackout period: either fixed (start and end, RFC 3339) or recurring
+// (an RRULE whose occurrences each open a window lasting duration).
+type ExclusionWindow struct {
+	Name     string `json:"name,omitempty" example:"Year-end freeze"`
+	Start    string `json:"start,omitempty" example:"2025-12-22T00:00:00Z"`
+	End      string `json:"end,omitempty" example:"2026-01-02T00:00:00Z"`
+	RRule    string `json:"rrule,omitempty" example:"RRULE:FREQ=WEEKLY;BYDAY=SA"`
+	Duration string `json:"duration,omitempty" example:"48h"`
+} // @name ExclusionWindow
+
+// CreateCalendarRequest represents the request to create a calendar.
+type CreateCalendarRequest struct {
+	Name     string            `json:"name" binding:"required" example:"us-holidays"`
+	Timezone string            `json:"timezone,omitempty" example:"America/New_York"`
+	Holidays []string          `json:"holidays,omitempty" example:"2025-12-25,2026-01-01"`
+	Windows  []ExclusionWindow `json:"windows,omitempty"`
+} // @name CreateCalendarRequest
+
+// UpdateCalendarRequest represents the request to update a calendar. Omitted fields are kept;
+// holidays and windows are replaced as a whole. Names are immutable since triggers reference them.
+type UpdateCalendarRequest struct {
+	Timezone *string           `json:"timezone,omitempty" example:"America/New_York"`
+	Holidays []string          `json:"holidays,omitempty" example:"2025-12-25,2026-01-01"`
+	Windows  []ExclusionWindow `json:"windows,omitempty"`
+} // @name UpdateCalendarRequest
+
+// CalendarResponse represents the response for a single calendar.
+type CalendarResponse struct {
+	ID        string            `json:"id" example:"770e8400-e29b-41d4-a716-446655440000"`
+	Name      string            `json:"name" example:"us-holidays"`
+	Timezone  string            `json:"timezone" example:"America/New_York"`
+	Holidays  []string          `json:"holidays" example:"2025-12-25,2026-01-01"`
+	Windows   []ExclusionWindow `json:"windows"`
+	CreatedAt time.Time         `json:"created_at" example:"2025-11-05T10:00:00Z"`
+	UpdatedAt time.Time         `json:"updated_at" example:"2025-11-05T10:00:00Z"`
+} // @name CalendarResponse
+
+// CalendarListResponse represents the response for listing calendars.
+type CalendarListResponse struct {
+	Calendars []CalendarResponse `json:"calendars"`
+} // @name CalendarListResponse
@@ -12,6 +12,7 @@ const (
 	EventSourceWebhook    EventSource = "webhook"
 	EventSourceScheduler  EventSource = "scheduler"
 	EventSourceManualTest EventSource = "manual-test"
+	EventSourceBackfill   EventSource = "backfill"
 )
 
 // ExecutionStatus represents the execution status of an event.
@@ -20,6 +21,19 @@ type ExecutionStatus string
 const (
 	ExecutionStatusSuccess ExecutionStatus = "success"
 	ExecutionStatusFailure ExecutionStatus = "failure"
+	ExecutionStatusSkipped ExecutionStatus = "skipped" // Occurrence not fired; SkipReason says why
+	// ExecutionStatusRunning marks a published event of a trigger with concurrency_policy forbid or
+	// replace until its consumer acknowledges it as success or failure.
+	ExecutionStatusRunning  ExecutionStatus = "running"
+	ExecutionStatusReplaced ExecutionStatus = "replaced" // Running event superseded by a newer occurrence (concurrency_policy replace)
+)
+
+// DeliveryStatus is the outcome of the dispatcher's HTTP call for an event.
+type DeliveryStatus string
+
+const (
+	DeliveryStatusDelivered DeliveryStatus = "delivered" // The endpoint answered with a 2xx status
+	DeliveryStatusFailed    DeliveryStatus = "failed"    // Every attempt failed, or the endpoint rejected the request
 )
 
 // RetentionStatus represents the retention lifecycle status.
@@ -37,10 +51,16 @@ type EventLog struct {
 	TriggerID       *string         `json:"trigger_id,omitempty"` // NULL for manual test runs
 	TriggerType     TriggerType     `json:"trigger_type"`
 	FiredAt         time.Time       `json:"fired_at"`
+	ScheduledFor    *time.Time      `json:"scheduled_for,omitempty"` // Occurrence a scheduler event corresponds to
 	Payload         json.RawMessage `json:"payload,omitempty"`
 	Source          EventSource     `json:"source"`
 	ExecutionStatus ExecutionStatus `json:"execution_status"`
 	ErrorMessage    *string         `json:"error_message,omitempty"`
+	SkipReason      *string         `json:"skip_reason,omitempty"` // Populated for skipped occurrences
+	DSTNote         *string         `json:"dst_note,omitempty"`    // How a DST transition affected the occurrence
+	AcknowledgedAt  *time.Time      `json:"acknowledged_at,omitempty"`
+	PublishedAt     *time.Time      `json:"published_at,omitempty"` // When Kafka accepted the event; nil while it waits in the outbox
+	Delivery        *EventDelivery  `json:"delivery,omitempty"`     // Outcome of the dispatcher's HTTP call; nil until dispatched
 	RetentionStatus RetentionStatus `json:"retention_status"`
 	IsTestRun       bool            `json:"is_test_run"`
 	CreatedAt       time.Time       `json:"created_at"`
@@ -52,21 +72,36 @@ type EventLogResponse struct {
 	TriggerID       *string         `json:"trigger_id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
 	TriggerType     TriggerType     `json:"trigger_type" example:"time_scheduled"`
 	FiredAt         time.Time       `json:"fired_at" example:"2025-11-05T10:30:00Z"`
+	ScheduledFor    *time.Time      `json:"scheduled_for,omitempty" example:"2025-11-05T10:30:00Z"`
 	Payload         json.RawMessage `json:"payload,omitempty" swaggertype:"object"`
 	Source          EventSource     `json:"source" example:"scheduler"`
 	ExecutionStatus ExecutionStatus `json:"execution_status" example:"success"`
 	ErrorMessage    *string         `json:"error_message,omitempty" example:"connection timeout"`
+	SkipReason      *string         `json:"skip_reason,omitempty" example:"holiday 2025-12-25 in calendar us-holidays"`
+	DSTNote         *string         `json:"dst_note,omitempty" example:"01:30 occurs twice on 2025-11-02 in America/New_York (DST ends); first of two runs, at 01:30 EDT"`
+	AcknowledgedAt  *time.Time      `json:"acknowledged_at,omitempty" example:"2025-11-05T10:32:10Z"`
+	PublishedAt     *time.Time      `json:"published_at,omitempty" example:"2025-11-05T10:30:00Z"` // When Kafka accepted the event; absent while it waits in the outbox for (re)delivery
+	Delivery        *EventDelivery  `json:"delivery,omitempty"`                                    // Outcome of the dispatcher's HTTP call to the trigger's endpoint; absent until dispatched
 	RetentionStatus RetentionStatus `json:"retention_status" example:"active"`
 	IsTestRun       bool            `json:"is_test_run" example:"false"`
 	CreatedAt       time.Time       `json:"created_at" example:"2025-11-05T10:30:00Z"`
 } // @name EventLogResponse
 
+// EventDelivery records how the dispatcher delivered an event to its trigger's endpoint.
+type EventDelivery struct {
+	Status      DeliveryStatus `json:"status" enums:"delivered,failed" example:"delivered"`
+	Attempts    int            `json:"attempts" example:"1"`                                                // HTTP calls made, retries included
+	StatusCode  *int           `json:"status_code,omitempty" example:"200"`                                 // Status of the last response; absent when no response was received
+	Error       *string        `json:"error,omitempty" example:"unexpected status 503 Service Unavailable"` // Why the last attempt failed
+	CompletedAt time.Time      `json:"completed_at" example:"2025-11-05T10:30:01Z"`
+} // @name EventDelivery
+
 // ListEventsQuery represents query parameters for listing event logs.
 type ListEventsQuery struct {
 	TriggerID       string `form:"trigger_id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	RetentionStatus string `form:"retention_status" binding:"omitempty,oneof=active archived" example:"active"`
-	ExecutionStatus string `form:"execution_status" binding:"omitempty,oneof=success failure" example:"success"`
-	Source          string `form:"source" binding:"omitempty,oneof=webhook scheduler manual-test" example:"scheduler"`
+	ExecutionStatus string `form:"execution_status" binding:"omitempty,oneof=success failure skipped running replaced" example:"success"`
+	Source          string `form:"source" binding:"omitempty,oneof=webhook scheduler manual-test backfill" example:"scheduler"`
 	Page            int    `form:"page" binding:"omitempty,min=1" example:"1"`
 	Limit           int    `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
 } // @name ListEventsQuery
@@ -77,6 +112,12 @@ type EventLogListResponse struct {
 	Pagination Pagination         `json:"pagination"`
 } // @name EventLogListResponse
 
+// AckEventRequest represents the (optional) body of an event acknowledgement.
+type AckEventRequest struct {
+	Status       ExecutionStatus `json:"status,omitempty" binding:"omitempty,oneof=success failure" enums:"success,failure" example:"success"` // Outcome of the run; defaults to success
+	ErrorMessage string          `json:"error_message,omitempty" example:"report generation timed out"`                                        // Recorded with a failure
+} // @name AckEventRequest
+
 // WebhookPayload represents the payload sent to webhook endpoint.
 type WebhookPayload struct {
 	TriggerID string                 `json:"trigger_id" example:"550e8400-e29b-41d4-a716-446655440000"`
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// SchedulerLease is the lease of a scheduler leader (leader-election mode). Each acquisition
+// issues a fencing token greater than all earlier ones, so a deposed leader's work can be told
+// apart from the current leader's.
+type SchedulerLease struct {
+	Name         string    `json:"name"`
+	Holder       string    `json:"holder"` // Instance ID of the leader
+	FencingToken int64     `json:"fencing_token"`
+	AcquiredAt   time.Time `json:"acquired_at"`
+	RenewedAt    time.Time `json:"renewed_at"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+// SchedulerLeaderResponse represents the current scheduler leader.
+type SchedulerLeaderResponse struct {
+	Name         string    `json:"name" example:"scheduler"`
+	InstanceID   string    `json:"instance_id" example:"scheduler-7f9c-3a1b2c4d"`
+	FencingToken int64     `json:"fencing_token" example:"42"`
+	Active       bool      `json:"active" example:"true"` // False when the lease expired and no standby has taken over yet
+	AcquiredAt   time.Time `json:"acquired_at" example:"2025-11-05T10:30:00Z"`
+	RenewedAt    time.Time `json:"renewed_at" example:"2025-11-05T10:35:00Z"`
+	ExpiresAt    time.Time `json:"expires_at" example:"2025-11-05T10:35:15Z"`
+} // @name SchedulerLeaderResponse
@@ -0,0 +1,80 @@
+package models
+
+import "time"
+
+// OccurrenceStatus says what the scheduler will do with an upcoming occurrence.
+type OccurrenceStatus string
+
+const (
+	OccurrenceStatusScheduled OccurrenceStatus = "scheduled"
+	OccurrenceStatusSkipped   OccurrenceStatus = "skipped"  // Inside a calendar exclusion (calendar_policy skip)
+	OccurrenceStatusDeferred  OccurrenceStatus = "deferred" // Inside a calendar exclusion, fires when it ends
+)
+
+// ListOccurrencesQuery represents query parameters for previewing a trigger's upcoming occurrences.
+type ListOccurrencesQuery struct {
+	Count int    `form:"count" binding:"omitempty,min=1,max=100" example:"10"`
+	Until string `form:"until" example:"2025-12-31T23:59:59Z"` // RFC 3339; no occurrence after it is listed
+} // @name ListOccurrencesQuery
+
+// OccurrenceResponse is one upcoming occurrence of a trigger.
+type OccurrenceResponse struct {
+	ScheduledFor time.Time        `json:"scheduled_for" example:"2025-11-05T09:00:00Z"`
+	FireAt       time.Time        `json:"fire_at" example:"2025-11-05T09:02:30Z"` // Includes the spread offset; jitter adds a random delay on top
+	Status       OccurrenceStatus `json:"status" example:"scheduled"`
+	Reason       *string          `json:"reason,omitempty" example:"holiday 2025-12-25 in calendar us-holidays"`
+} // @name OccurrenceResponse
+
+// OccurrenceListResponse represents the response for previewing a trigger's upcoming occurrences.
+type OccurrenceListResponse struct {
+	TriggerID   string               `json:"trigger_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Occurrences []OccurrenceResponse `json:"occurrences"`
+} // @name OccurrenceListResponse
+
+// BackfillRequest represents the request to replay the past occurrences of a trigger.
+type BackfillRequest struct {
+	From          time.Time `json:"from" binding:"required" example:"2025-11-01T00:00:00Z"`                  // Inclusive
+	To            time.Time `json:"to" binding:"required" example:"2025-11-08T00:00:00Z"`                    // Exclusive; not in the future
+	RatePerMinute int       `json:"rate_per_minute,omitempty" binding:"omitempty,min=1,max=60" example:"30"` // Defaults to 60
+} // @name BackfillRequest
+
+// BackfillScheduleResponse is one enqueued backfill schedule.
+type BackfillScheduleResponse struct {
+	ID           string    `json:"id" example:"6f1c2e4a-8b7d-4c3e-9a1f-2d5e6b7c8d9e"`
+	ScheduledFor time.Time `json:"scheduled_for" example:"2025-11-01T09:00:00Z"` // The past occurrence replayed
+	FireAt       time.Time `json:"fire_at" example:"2025-11-10T12:00:01Z"`
+} // @name BackfillScheduleResponse
+
+// BackfillResponse represents the response for backfilling a trigger.
+type BackfillResponse struct {
+	TriggerID     string                     `json:"trigger_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	From          time.Time                  `json:"from" example:"2025-11-01T00:00:00Z"`
+	To            time.Time                  `json:"to" example:"2025-11-08T00:00:00Z"`
+	RatePerMinute int                        `json:"rate_per_minute" example:"60"`
+	Schedules     []BackfillScheduleResponse `json:"schedules"`
+} // @name BackfillResponse
+
+// CronPreviewRequest represents the request to preview the runs of a CRON expression.
+type CronPreviewRequest struct {
+	Cron      string     `json:"cron" binding:"required" example:"30 2 * * *"`
+	Timezone  string     `json:"timezone,omitempty" example:"America/New_York"`
+	Count     int        `json:"count,omitempty" binding:"omitempty,min=1,max=100" example:"5"`
+	DSTPolicy *DSTPolicy `json:"dst_policy,omitempty"` // Defaults to skip / run_both
+} // @name CronPreviewRequest
+
+// CronPreviewRun is one upcoming run of a CRON expression.
+type CronPreviewRun struct {
+	Time      time.Time `json:"time" example:"2026-03-09T06:30:00Z"`
+	LocalTime string    `json:"local_time" example:"2026-03-09T02:30:00-04:00"`
+	UTCOffset string    `json:"utc_offset" example:"-04:00"`
+	IsDST     bool      `json:"is_dst" example:"true"`
+	DSTNote   string    `json:"dst_note,omitempty" example:"02:30 does not exist on 2026-03-08 in America/New_York (DST starts); skipped"`
+} // @name CronPreviewRun
+
+// CronPreviewResponse represents the response for previewing a CRON expression.
+type CronPreviewResponse struct {
+	Cron      string           `json:"cron" example:"30 2 * * *"`
+	Timezone  string           `json:"timezone" example:"America/New_York"`
+	DSTPolicy DSTPolicy        `json:"dst_policy"`
+	Runs      []CronPreviewRun `json:"runs"`
+} // @name CronPreviewResponse
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// OutboxStatus is the delivery state of an outbox message.
+type OutboxStatus string
+
+const (
+	OutboxStatusPending OutboxStatus = "pending"
+	OutboxStatusSent    OutboxStatus = "sent"
+)
+
+// OutboxMessage is the Kafka message of an event, written in the same transaction as its event log
+// and kept until Kafka accepts it. A pending message is owned by whoever holds its lease (the
+// firing process right after commit, later a relay); it becomes claimable again once the lease
+// expires or a failed delivery is released for retry at next_attempt_at.
+type OutboxMessage struct {
+	ID             int64           `json:"id"`
+	EventID        string          `json:"event_id"`
+	MessageKey     string          `json:"message_key"` // Kafka message key: the trigger ID
+	Payload        json.RawMessage `json:"payload"`     // Kafka message value: the trigger event
+	Status         OutboxStatus    `json:"status"`
+	AttemptCount   int             `json:"attempt_count"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	ClaimedBy      *string         `json:"claimed_by,omitempty"`
+	LeaseExpiresAt *time.Time      `json:"lease_expires_at,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	SentAt         *time.Time      `json:"sent_at,omitempty"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// PauseAction is what an entry of a trigger's pause history records.
+type PauseAction string
+
+const (
+	PauseActionPause  PauseAction = "pause"
+	PauseActionResume PauseAction = "resume"
+)
+
+// ResumePolicy decides what a resumed trigger does with the occurrences it missed while paused.
+type ResumePolicy string
+
+const (
+	// ResumePolicyFireOnce fires the most recent missed occurrence right away, then continues
+	// with the next future occurrence.
+	ResumePolicyFireOnce ResumePolicy = "fire_once"
+	// ResumePolicyFireAllMissed fires the missed occurrences one after the other, at most the
+	// trigger's misfire_catchup_limit of them (the most recent ones), then continues with the next
+	// future occurrence.
+	ResumePolicyFireAllMissed ResumePolicy = "fire_all_missed"
+	// ResumePolicySkip drops the missed occurrences and continues with the next future occurrence.
+	ResumePolicySkip ResumePolicy = "skip"
+)
+
+// TriggerPauseEvent is an entry of a trigger's pause/resume history.
+type TriggerPauseEvent struct {
+	ID                string       `json:"id"`
+	TriggerID         string       `json:"trigger_id"`
+	Action            PauseAction  `json:"action"`
+	ResumePolicy      ResumePolicy `json:"resume_policy,omitempty"` // Resume only
+	MissedOccurrences int          `json:"missed_occurrences"`      // Resume only: occurrences that came due while paused
+	NextFireAt        *time.Time   `json:"next_fire_at,omitempty"`  // Resume only: first schedule after resuming
+	Reason            *string      `json:"reason,omitempty"`
+	CreatedAt         time.Time    `json:"created_at"`
+}
+
+// PauseTriggerRequest represents the (optional) body of a pause request.
+type PauseTriggerRequest struct {
+	Reason string `json:"reason,omitempty" example:"Downstream maintenance"`
+} // @name PauseTriggerRequest
+
+// ResumeTriggerRequest represents the (optional) body of a resume request.
+type ResumeTriggerRequest struct {
+	ResumePolicy ResumePolicy `json:"resume_policy,omitempty" binding:"omitempty,oneof=fire_once fire_all_missed skip" enums:"fire_once,fire_all_missed,skip" example:"fire_once"` // Defaults to skip
+	Reason       string       `json:"reason,omitempty" example:"Maintenance done"`
+} // @name ResumeTriggerRequest
+
+// PauseEventResponse is one entry of a trigger's pause history.
+type PauseEventResponse struct {
+	ID                string       `json:"id" example:"7d3f5a1e-2c4b-4e8a-9f6d-1b2c3d4e5f60"`
+	Action            PauseAction  `json:"action" example:"resume"`
+	ResumePolicy      ResumePolicy `json:"resume_policy,omitempty" example:"fire_once"`
+	MissedOccurrences int          `json:"missed_occurrences" example:"3"`
+	NextFireAt        *time.Time   `json:"next_fire_at,omitempty" example:"2025-11-05T10:30:00Z"`
+	Reason            *string      `json:"reason,omitempty" example:"Maintenance done"`
+	CreatedAt         time.Time    `json:"created_at" example:"2025-11-05T10:30:00Z"`
+} // @name PauseEventResponse
+
+// TriggerPauseResponse represents the response for pausing or resuming a trigger.
+type TriggerPauseResponse struct {
+	Trigger TriggerResponse    `json:"trigger"`
+	Event   PauseEventResponse `json:"event"`
+} // @name TriggerPauseResponse
+
+// PauseHistoryResponse represents a trigger's pause/resume history, newest first.
+type PauseHistoryResponse struct {
+	TriggerID string               `json:"trigger_id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Events    []PauseEventResponse `json:"events"`
+} // @name PauseHistoryResponse
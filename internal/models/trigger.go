@@ -9,9 +9,10 @@ import (
 type TriggerType string
 
 const (
-	TriggerTypeWebhook       TriggerType = "webhook"
-	TriggerTypeTimeScheduled TriggerType = "time_scheduled"
-	TriggerTypeCronScheduled TriggerType = "cron_scheduled"
+	TriggerTypeWebhook           TriggerType = "webhook"
+	TriggerTypeTimeScheduled     TriggerType = "time_scheduled"
+	TriggerTypeCronScheduled     TriggerType = "cron_scheduled"
+	TriggerTypeIntervalScheduled TriggerType = "interval_scheduled"
 )
 
 // TriggerStatus represents the status of a trigger.
@@ -30,8 +31,75 @@ const (
 	ScheduleStatusProcessing ScheduleStatus = "processing"
 	ScheduleStatusCompleted  ScheduleStatus = "completed"
 	ScheduleStatusCancelled  ScheduleStatus = "cancelled"
+	ScheduleStatusSkipped    ScheduleStatus = "skipped" // Occurrence deliberately not fired (e.g. misfire policy)
 )
 
+// MisfirePolicy decides what the scheduler does with cron occurrences missed while it was behind.
+type MisfirePolicy string
+
+const (
+	// MisfirePolicyFireOnce fires the overdue occurrence once and resumes from the next future occurrence.
+	MisfirePolicyFireOnce MisfirePolicy = "fire_once"
+	// MisfirePolicyFireAllMissed replays every missed occurrence, up to the trigger's catch-up limit.
+	MisfirePolicyFireAllMissed MisfirePolicy = "fire_all_missed"
+	// MisfirePolicySkip drops overdue occurrences and resumes from the next future occurrence.
+	MisfirePolicySkip MisfirePolicy = "skip"
+)
+
+// ConcurrencyPolicy decides whether a recurring trigger fires an occurrence while the event of a
+// previous one is still running, i.e. has not been acknowledged by its consumer.
+type ConcurrencyPolicy string
+
+const (
+	// ConcurrencyPolicyAllow fires every occurrence; events need no acknowledgement.
+	ConcurrencyPolicyAllow ConcurrencyPolicy = "allow"
+	// ConcurrencyPolicyForbid skips occurrences while the previous event is running.
+	ConcurrencyPolicyForbid ConcurrencyPolicy = "forbid"
+	// ConcurrencyPolicyReplace marks the running event as replaced and fires the new occurrence.
+	ConcurrencyPolicyReplace ConcurrencyPolicy = "replace"
+)
+
+// RequiresAck reports whether events fired under the policy stay running until their consumer acknowledges them.
+func (p ConcurrencyPolicy) RequiresAck() bool {
+	return p == ConcurrencyPolicyForbid || p == ConcurrencyPolicyReplace
+}
+
+// DSTPolicy decides what a CRON trigger does with local times that a daylight saving
+// transition skips (clocks spring forward) or repeats (clocks fall back).
+type DSTPolicy struct {
+	SpringForward DSTGapPolicy     `json:"spring_forward,omitempty" enums:"skip,shift_forward" example:"skip"`
+	FallBack      DSTOverlapPolicy `json:"fall_back,omitempty" enums:"run_both,run_once" example:"run_once"`
+}
+
+// DSTGapPolicy handles occurrences whose local time does not exist because clocks sprang forward.
+type DSTGapPolicy string
+
+const (
+	// DSTGapSkip drops the occurrence and records it in the event log as skipped.
+	DSTGapSkip DSTGapPolicy = "skip"
+	// DSTGapShiftForward runs the occurrence after the gap, moved forward by its length (02:30 runs at 03:30).
+	DSTGapShiftForward DSTGapPolicy = "shift_forward"
+)
+
+// DSTOverlapPolicy handles occurrences whose local time happens twice because clocks fell back.
+type DSTOverlapPolicy string
+
+const (
+	// DSTOverlapRunBoth runs the occurrence at both instants the local time denotes.
+	DSTOverlapRunBoth DSTOverlapPolicy = "run_both"
+	// DSTOverlapRunOnce runs the occurrence only at the first of them (still on daylight time).
+	DSTOverlapRunOnce DSTOverlapPolicy = "run_once"
+)
+
+// RetryPolicy configures how a scheduled trigger that failed to fire is retried.
+// Delays are Go duration strings (e.g. "5s", "10m"); retries back off exponentially from
+// BaseDelay, capped at MaxDelay, with jitter.
+type RetryPolicy struct {
+	MaxAttempts int    `json:"max_attempts,omitempty" example:"5"`
+	BaseDelay   string `json:"base_delay,omitempty" example:"5s"`
+	MaxDelay    string `json:"max_delay,omitempty" example:"10m"`
+}
+
 // Trigger represents a trigger entity from the database.
 type Trigger struct {
 	ID        string          `json:"id"`
@@ -39,33 +107,49 @@ type Trigger struct {
 	Type      TriggerType     `json:"type"`
 	Status    TriggerStatus   `json:"status"`
 	Config    json.RawMessage `json:"config"`
+	FireCount int             `json:"fire_count"` // Occurrences fired by the scheduler, checked against max_fires
 	CreatedAt time.Time       `json:"created_at"`
 	UpdatedAt time.Time       `json:"updated_at"`
 }
 
 // TriggerSchedule represents pending or processed occurrences for a trigger.
 type TriggerSchedule struct {
-	ID            string         `json:"id"`
-	TriggerID     string         `json:"trigger_id"`
-	FireAt        time.Time      `json:"fire_at"`
-	Status        ScheduleStatus `json:"status"`
-	AttemptCount  int            `json:"attempt_count"`
-	LastAttemptAt *time.Time     `json:"last_attempt_at,omitempty"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
+	ID             string         `json:"id"`
+	TriggerID      string         `json:"trigger_id"`
+	FireAt         time.Time      `json:"fire_at"`
+	ScheduledFor   *time.Time     `json:"scheduled_for,omitempty"` // CRON occurrence; fire_at adds the jitter/spread offset
+	Backfill       bool           `json:"backfill"`                // Replays a past occurrence (see the backfill endpoint)
+	Status         ScheduleStatus `json:"status"`
+	AttemptCount   int            `json:"attempt_count"`
+	LastAttemptAt  *time.Time     `json:"last_attempt_at,omitempty"`
+	NextAttemptAt  *time.Time     `json:"next_attempt_at,omitempty"` // Retry backoff: not claimable before this
+	ClaimedBy      *string        `json:"claimed_by,omitempty"`      // Scheduler instance currently processing the row
+	ClaimedAt      *time.Time     `json:"claimed_at,omitempty"`
+	LeaseExpiresAt *time.Time     `json:"lease_expires_at,omitempty"` // Claim is reclaimable once this passes
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// Occurrence returns the occurrence the schedule stands for: ScheduledFor when recorded,
+// FireAt otherwise (one-shot schedules, CRON schedules created before offsets existed).
+func (s TriggerSchedule) Occurrence() time.Time {
+	if s.ScheduledFor != nil {
+		return *s.ScheduledFor
+	}
+	return s.FireAt
 }
 
 // CreateTriggerRequest represents the request to create a trigger.
 type CreateTriggerRequest struct {
 	Name   string          `json:"name" binding:"required" example:"Daily metrics push"`
-	Type   TriggerType     `json:"type" binding:"required,oneof=webhook time_scheduled cron_scheduled" example:"time_scheduled"`
+	Type   TriggerType     `json:"type" binding:"required,oneof=webhook time_scheduled cron_scheduled interval_scheduled" example:"time_scheduled"`
 	Config json.RawMessage `json:"config" binding:"required" swaggertype:"object"`
 } // @name CreateTriggerRequest
 
 // UpdateTriggerRequest represents the request to update a trigger.
 type UpdateTriggerRequest struct {
 	Name   *string         `json:"name,omitempty" example:"Daily metrics push"`
-	Status *TriggerStatus  `json:"status,omitempty" binding:"omitempty,oneof=active inactive" example:"active"`
+	Status *TriggerStatus  `json:"status,omitempty" binding:"omitempty,oneof=active inactive" example:"active"` // Changing it pauses or resumes the trigger (resume_policy skip)
 	Config json.RawMessage `json:"config,omitempty" swaggertype:"object"`
 } // @name UpdateTriggerRequest
 
@@ -77,6 +161,8 @@ type TriggerResponse struct {
 	Status           TriggerStatus   `json:"status" example:"active"`
 	Config           json.RawMessage `json:"config" swaggertype:"object"`
 	NextScheduledRun *time.Time      `json:"next_scheduled_run,omitempty" example:"2025-11-05T15:00:00Z"`
+	RemainingFires   *int            `json:"remaining_fires,omitempty" example:"7"`               // Set when the config has max_fires
+	ExpiresAt        *time.Time      `json:"expires_at,omitempty" example:"2025-12-31T23:59:59Z"` // The config's end_at
 	WebhookURL       string          `json:"webhook_url,omitempty" example:"http://localhost:8080/api/v1/webhook/550e8400-e29b-41d4-a716-446655440000"`
 	CreatedAt        time.Time       `json:"created_at" example:"2025-11-05T10:00:00Z"`
 	UpdatedAt        time.Time       `json:"updated_at" example:"2025-11-05T10:00:00Z"`
@@ -84,35 +170,75 @@ type TriggerResponse struct {
 
 // WebhookTriggerConfig holds configuration for webhook triggers that run on inbound HTTP calls.
 type WebhookTriggerConfig struct {
-	Schema     map[string]interface{} `json:"schema"` // JSON schema for payload validation
-	Endpoint   string                 `json:"endpoint" example:"https://webhook.site/xyz"`
-	HTTPMethod string                 `json:"http_method" example:"POST"`
-	Headers    map[string]string      `json:"headers,omitempty"`
+	Schema              map[string]interface{} `json:"schema"` // JSON schema for payload validation
+	Endpoint            string                 `json:"endpoint" example:"https://webhook.site/xyz"`
+	HTTPMethod          string                 `json:"http_method" example:"POST"`
+	Headers             map[string]string      `json:"headers,omitempty"`
+	IdempotencyKeyField string                 `json:"idempotency_key_field,omitempty" example:"delivery.id"` // Payload field (dot path) deduplicating requests without an Idempotency-Key header
 }
 
 // TimeScheduledTriggerConfig configures a one-shot trigger.
 type TimeScheduledTriggerConfig struct {
-	RunAt      time.Time              `json:"run_at" example:"2025-11-05T15:00:00Z"`
-	Endpoint   string                 `json:"endpoint" example:"https://webhook.site/xyz"`
-	HTTPMethod string                 `json:"http_method" example:"POST"`
-	Headers    map[string]string      `json:"headers,omitempty"`
-	Payload    map[string]interface{} `json:"payload,omitempty"`
-	Timezone   string                 `json:"timezone,omitempty" example:"America/New_York"`
+	RunAt       time.Time              `json:"run_at" example:"2025-11-05T15:00:00Z"`
+	Endpoint    string                 `json:"endpoint" example:"https://webhook.site/xyz"`
+	HTTPMethod  string                 `json:"http_method" example:"POST"`
+	Headers     map[string]string      `json:"headers,omitempty"`
+	Payload     map[string]interface{} `json:"payload,omitempty"`
+	Timezone    string                 `json:"timezone,omitempty" example:"America/New_York"`
+	RetryPolicy *RetryPolicy           `json:"retry_policy,omitempty"`
 }
 
-// CronScheduledTriggerConfig configures a recurring trigger based on a cron expression.
+// CronScheduledTriggerConfig configures a recurring trigger based on a cron expression, or on an
+// RFC 5545 recurrence set (RRULE/EXRULE/RDATE/EXDATE lines) for rules cron cannot express.
 type CronScheduledTriggerConfig struct {
-	Cron       string                 `json:"cron" example:"0 9 * * *"`
-	Timezone   string                 `json:"timezone,omitempty" example:"America/New_York"`
-	Endpoint   string                 `json:"endpoint" example:"https://webhook.site/xyz"`
-	HTTPMethod string                 `json:"http_method" example:"POST"`
-	Headers    map[string]string      `json:"headers,omitempty"`
-	Payload    map[string]interface{} `json:"payload,omitempty"`
+	Cron                string                 `json:"cron,omitempty" example:"0 9 * * *"`
+	RRule               string                 `json:"rrule,omitempty" example:"RRULE:FREQ=MONTHLY;BYDAY=2TU;BYHOUR=9;BYMINUTE=0;BYSECOND=0"` // Alternative to cron
+	Timezone            string                 `json:"timezone,omitempty" example:"America/New_York"`
+	Endpoint            string                 `json:"endpoint" example:"https://webhook.site/xyz"`
+	HTTPMethod          string                 `json:"http_method" example:"POST"`
+	Headers             map[string]string      `json:"headers,omitempty"`
+	Payload             map[string]interface{} `json:"payload,omitempty"`
+	MisfirePolicy       MisfirePolicy          `json:"misfire_policy,omitempty" enums:"fire_once,fire_all_missed,skip" example:"fire_once"`
+	MisfireCatchupLimit int                    `json:"misfire_catchup_limit,omitempty" example:"10"` // Only used by fire_all_missed
+	RetryPolicy         *RetryPolicy           `json:"retry_policy,omitempty"`
+	Jitter              string                 `json:"jitter,omitempty" example:"30s"` // Fire each occurrence a random delay in [0, jitter) late
+	Spread              string                 `json:"spread,omitempty" example:"5m"`  // Fire at a fixed delay in [0, spread) derived from the trigger ID
+	Calendars           []string               `json:"calendars,omitempty" example:"us-holidays,release-freeze"`
+	CalendarPolicy      CalendarPolicy         `json:"calendar_policy,omitempty" enums:"skip,defer" example:"skip"`
+	StartAt             string                 `json:"start_at,omitempty" example:"2025-12-01T00:00:00Z"` // No occurrence before start_at
+	EndAt               string                 `json:"end_at,omitempty" example:"2025-12-31T23:59:59Z"`   // No occurrence after end_at
+	MaxFires            int                    `json:"max_fires,omitempty" example:"10"`                  // Deactivate after this many fires
+	DSTPolicy           *DSTPolicy             `json:"dst_policy,omitempty"`                              // Only for cron expressions; defaults to skip / run_both
+	ConcurrencyPolicy   ConcurrencyPolicy      `json:"concurrency_policy,omitempty" enums:"allow,forbid,replace" example:"forbid"`
+	RunningTimeout      string                 `json:"running_timeout,omitempty" example:"15m"` // Fail events left unacknowledged this long (forbid and replace only)
+}
+
+// IntervalScheduledTriggerConfig configures a recurring trigger that fires every fixed duration.
+// Occurrences are anchor, anchor+every, anchor+2*every, ...; the anchor defaults to the creation time.
+type IntervalScheduledTriggerConfig struct {
+	Every               string                 `json:"every" example:"90s"`
+	Anchor              string                 `json:"anchor,omitempty" example:"2025-11-05T09:00:00Z"`
+	Endpoint            string                 `json:"endpoint" example:"https://webhook.site/xyz"`
+	HTTPMethod          string                 `json:"http_method" example:"POST"`
+	Headers             map[string]string      `json:"headers,omitempty"`
+	Payload             map[string]interface{} `json:"payload,omitempty"`
+	MisfirePolicy       MisfirePolicy          `json:"misfire_policy,omitempty" enums:"fire_once,fire_all_missed,skip" example:"fire_once"`
+	MisfireCatchupLimit int                    `json:"misfire_catchup_limit,omitempty" example:"10"` // Only used by fire_all_missed
+	RetryPolicy         *RetryPolicy           `json:"retry_policy,omitempty"`
+	Jitter              string                 `json:"jitter,omitempty" example:"5s"`
+	Spread              string                 `json:"spread,omitempty" example:"30s"`
+	Calendars           []string               `json:"calendars,omitempty" example:"us-holidays"`
+	CalendarPolicy      CalendarPolicy         `json:"calendar_policy,omitempty" enums:"skip,defer" example:"skip"`
+	StartAt             string                 `json:"start_at,omitempty" example:"2025-12-01T00:00:00Z"`
+	EndAt               string                 `json:"end_at,omitempty" example:"2025-12-31T23:59:59Z"`
+	MaxFires            int                    `json:"max_fires,omitempty" example:"100"`
+	ConcurrencyPolicy   ConcurrencyPolicy      `json:"concurrency_policy,omitempty" enums:"allow,forbid,replace" example:"allow"`
+	RunningTimeout      string                 `json:"running_timeout,omitempty" example:"10m"` // Fail events left unacknowledged this long (forbid and replace only)
 }
 
 // ListTriggersQuery represents query parameters for listing triggers.
 type ListTriggersQuery struct {
-	Type   string `form:"type" binding:"omitempty,oneof=webhook time_scheduled cron_scheduled" example:"time_scheduled"`
+	Type   string `form:"type" binding:"omitempty,oneof=webhook time_scheduled cron_scheduled interval_scheduled" example:"time_scheduled"`
 	Status string `form:"status" binding:"omitempty,oneof=active inactive" example:"active"`
 	Page   int    `form:"page" binding:"omitempty,min=1" example:"1"`
 	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100" example:"20"`
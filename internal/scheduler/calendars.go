@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// applyCalendars checks a claimed recurring schedule against the business calendars its trigger
+// references. When the schedule's fire time falls inside an exclusion, the schedule is skipped
+// or deferred (per the trigger's calendar_policy) instead of fired, and applyCalendars returns true.
+// Calendars are read on every occurrence, so calendar edits apply without touching the triggers.
+func (e *Engine) applyCalendars(ctx context.Context, trigger *models.Trigger, schedule models.TriggerSchedule, plan recurrencePlan) (bool, error) {
+	calendars := make([]models.Calendar, 0, len(plan.options.Calendars))
+	for _, name := range plan.options.Calendars {
+		calendar, err := e.db.GetCalendarByName(ctx, name)
+		if errors.Is(err, storage.ErrCalendarNotFound) {
+			// Deleted after the trigger was configured: it no longer excludes anything
+			e.logger.Warn("trigger references a missing calendar",
+				zap.String("trigger_id", trigger.ID),
+				zap.String("calendar", name))
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to load calendar %s: %w", name, err)
+		}
+		calendars = append(calendars, *calendar)
+	}
+
+	set, err := triggers.NewCalendarSet(calendars)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse calendars: %w", err)
+	}
+
+	exclusion := set.ExclusionAt(schedule.FireAt)
+	if exclusion == nil {
+		return false, nil
+	}
+
+	if plan.options.CalendarPolicy == models.CalendarPolicyDefer {
+		return true, e.deferSchedule(ctx, trigger, schedule, plan, exclusion)
+	}
+	return true, e.skipSchedule(ctx, trigger, schedule, plan, exclusion.Reason)
+}
+
+// deferSchedule replaces a schedule that falls inside an exclusion with one firing when the
+// exclusion ends. Later occurrences inside the same exclusion are folded into it: the deferred
+// schedule stands for the last of them, so each exclusion fires the trigger at most once.
+func (e *Engine) deferSchedule(ctx context.Context, trigger *models.Trigger, schedule models.TriggerSchedule, plan recurrencePlan, exclusion *triggers.Exclusion) error {
+	last := schedule.Occurrence()
+	following := plan.recurrence.Next(last)
+	for i := 0; i < maxMisfireScan && !following.IsZero() && following.Before(exclusion.End); i++ {
+		last, following = following, plan.recurrence.Next(following)
+	}
+
+	// An occurrence is due right as the exclusion ends: it stands in for the deferred ones
+	if following.Equal(exclusion.End) {
+		plan.next = following
+		return e.skipSchedule(ctx, trigger, schedule, plan, exclusion.Reason+" (deferred into the occurrence at its end)")
+	}
+
+	if err := e.db.UpdateScheduleStatus(ctx, schedule.ID, e.instanceID, models.ScheduleStatusSkipped); err != nil {
+		return fmt.Errorf("failed to mark schedule as skipped: %w", err)
+	}
+
+	if trigger.Status != models.TriggerStatusActive {
+		return nil
+	}
+
+	// Keep the trigger's spread slot; jitter would only push the deferred fire time further out
+	deferred := &models.TriggerSchedule{
+		ID:           uuid.New().String(),
+		TriggerID:    trigger.ID,
+		FireAt:       exclusion.End.Add(plan.options.Offset(trigger.ID, noJitter)),
+		ScheduledFor: &last,
+		Status:       models.ScheduleStatusPending,
+	}
+	if err := e.db.CreateNextSchedule(ctx, deferred); err != nil {
+		return fmt.Errorf("failed to insert deferred schedule: %w", err)
+	}
+
+	e.logger.Info("deferred occurrence to the end of a calendar exclusion",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("trigger_id", trigger.ID),
+		zap.String("deferred_schedule_id", deferred.ID),
+		zap.Time("scheduled_for", last),
+		zap.Time("fire_at", deferred.FireAt),
+		zap.String("reason", exclusion.Reason))
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"go.uber.org/zap"
+)
+
+// applyConcurrencyPolicy enforces the concurrency policy of a recurring trigger before a claimed
+// schedule fires, based on the trigger's running events (published, not yet acknowledged):
+//   - allow: fire regardless
+//   - forbid: skip the occurrence while an event is running, and record the skip
+//   - replace: mark the running events as replaced, then fire
+//
+// With a running_timeout, events left unacknowledged for longer are first marked failed, so they
+// no longer count as running. It returns true when the schedule was skipped instead of fired.
+func (e *Engine) applyConcurrencyPolicy(ctx context.Context, trigger *models.Trigger, schedule models.TriggerSchedule, plan recurrencePlan) (bool, error) {
+	if err := e.expireRunningEvents(ctx, trigger, schedule, plan); err != nil {
+		return false, err
+	}
+
+	switch plan.options.ConcurrencyPolicy {
+	case models.ConcurrencyPolicyForbid:
+		running, err := e.db.GetRunningEventLog(ctx, trigger.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check for a running event: %w", err)
+		}
+		if running == nil {
+			return false, nil
+		}
+		reason := fmt.Sprintf("previous event %s is still running (concurrency_policy forbid)", running.ID)
+		return true, e.skipSchedule(ctx, trigger, schedule, plan, reason)
+
+	case models.ConcurrencyPolicyReplace:
+		reason := fmt.Sprintf("replaced by the occurrence at %s (concurrency_policy replace)", schedule.Occurrence().UTC().Format(time.RFC3339))
+		replaced, err := e.db.ReplaceRunningEventLogs(ctx, trigger.ID, reason)
+		if err != nil {
+			return false, fmt.Errorf("failed to replace running events: %w", err)
+		}
+		if replaced > 0 {
+			e.logger.Info("replaced running events of trigger",
+				zap.String("schedule_id", schedule.ID),
+				zap.String("trigger_id", trigger.ID),
+				zap.Int("replaced", replaced))
+		}
+	}
+
+	return false, nil
+}
+
+// expireRunningEvents marks the trigger's running events fired at least running_timeout ago as failed.
+func (e *Engine) expireRunningEvents(ctx context.Context, trigger *models.Trigger, schedule models.TriggerSchedule, plan recurrencePlan) error {
+	timeout := plan.options.RunningTimeoutDuration()
+	if timeout == 0 || !plan.options.ConcurrencyPolicy.RequiresAck() {
+		return nil
+	}
+
+	reason := fmt.Sprintf("not acknowledged within running_timeout %s", timeout)
+	expired, err := e.db.ExpireRunningEventLogs(ctx, trigger.ID, e.clock.Now().UTC().Add(-timeout), reason)
+	if err != nil {
+		return fmt.Errorf("failed to expire running events: %w", err)
+	}
+	if expired > 0 {
+		e.logger.Warn("marked unacknowledged running events of trigger as failed",
+			zap.String("schedule_id", schedule.ID),
+			zap.String("trigger_id", trigger.ID),
+			zap.Duration("running_timeout", timeout),
+			zap.Int("expired", expired))
+	}
+	return nil
+}
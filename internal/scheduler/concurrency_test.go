@@ -0,0 +1,85 @@
+package scheduler_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/scheduler"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
+	"go.uber.org/zap"
+)
+
+// TestConcurrencyPolicy fires a trigger every minute for three minutes without acknowledging any
+// event, and checks the status each occurrence's event ends up with.
+func TestConcurrencyPolicy(t *testing.T) {
+	cases := []struct {
+		name    string
+		options map[string]interface{}
+		want    []models.ExecutionStatus // oldest first
+	}{
+		{
+			name:    "allow fires every occurrence without acknowledgement",
+			options: map[string]interface{}{"concurrency_policy": "allow"},
+			want:    []models.ExecutionStatus{models.ExecutionStatusSuccess, models.ExecutionStatusSuccess, models.ExecutionStatusSuccess},
+		},
+		{
+			name:    "forbid skips while the first event runs",
+			options: map[string]interface{}{"concurrency_policy": "forbid"},
+			want:    []models.ExecutionStatus{models.ExecutionStatusRunning, models.ExecutionStatusSkipped, models.ExecutionStatusSkipped},
+		},
+		{
+			name:    "forbid fires again once the running event times out",
+			options: map[string]interface{}{"concurrency_policy": "forbid", "running_timeout": "90s"},
+			want:    []models.ExecutionStatus{models.ExecutionStatusFailure, models.ExecutionStatusSkipped, models.ExecutionStatusRunning},
+		},
+		{
+			name:    "replace supersedes the running event",
+			options: map[string]interface{}{"concurrency_policy": "replace"},
+			want:    []models.ExecutionStatus{models.ExecutionStatusReplaced, models.ExecutionStatusReplaced, models.ExecutionStatusRunning},
+		},
+		{
+			name:    "replace fails timed out events instead of replacing them",
+			options: map[string]interface{}{"concurrency_policy": "replace", "running_timeout": "30s"},
+			want:    []models.ExecutionStatus{models.ExecutionStatusFailure, models.ExecutionStatusFailure, models.ExecutionStatusRunning},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			store := memory.NewStore(clk)
+			service := events.NewService(store, platformEvents.NewMemoryPublisher(10), clk, zap.NewNop())
+			engine := scheduler.NewEngine(scheduler.Config{InstanceID: "engine", Workers: 1, Clock: clk}, store, service, zap.NewNop())
+
+			triggerID := createCronTrigger(t, store, clk, "reports", tc.options)
+			for minute := 0; minute < len(tc.want); minute++ {
+				clk.Advance(time.Minute)
+				engine.RunOnce(ctx)
+			}
+
+			logs, _, err := store.ListEventLogs(ctx, models.ListEventsQuery{TriggerID: triggerID, Limit: 100})
+			if err != nil {
+				t.Fatalf("ListEventLogs: %v", err)
+			}
+			if len(logs) != len(tc.want) {
+				t.Fatalf("got %d events, want %d", len(logs), len(tc.want))
+			}
+			for i, want := range tc.want {
+				eventLog := logs[len(logs)-1-i]
+				if eventLog.ExecutionStatus != want {
+					t.Errorf("event %d status = %q, want %q", i, eventLog.ExecutionStatus, want)
+				}
+				if want == models.ExecutionStatusFailure && (eventLog.ErrorMessage == nil || !strings.Contains(*eventLog.ErrorMessage, "running_timeout")) {
+					t.Errorf("event %d error_message = %v, want a running_timeout failure", i, eventLog.ErrorMessage)
+				}
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package scheduler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
 	"time"
 
-	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/clock"
 	"github.com/dhima/event-trigger-platform/internal/models"
 	"github.com/dhima/event-trigger-platform/internal/storage"
 	"github.com/dhima/event-trigger-platform/internal/triggers"
@@ -13,37 +15,130 @@ import (
 	"go.uber.org/zap"
 )
 
-// Engine periodically scans for triggers that are due to fire and enqueues them.
+// DefaultLeaseDuration is how long a claimed schedule stays owned by an instance without a heartbeat.
+const DefaultLeaseDuration = 30 * time.Second
+
+// DefaultBatchSize is how many schedules are claimed per query when Config.BatchSize is unset.
+const DefaultBatchSize = 100
+
+// minPollDelay keeps the engine from spinning when a due schedule cannot be claimed yet
+// (e.g. it is momentarily locked by another instance).
+const minPollDelay = 100 * time.Millisecond
+
+// Config holds the tunables of a scheduler engine.
+type Config struct {
+	// Tick is the longest the engine sleeps between polls. It sleeps less when a schedule
+	// is known to be due sooner.
+	Tick time.Duration
+	// InstanceID must be unique per running scheduler (e.g. the pod name).
+	InstanceID string
+	// LeaseDuration bounds how long a crashed instance can hold a schedule before it is reclaimed.
+	LeaseDuration time.Duration
+	// Workers is the number of schedules fired in parallel (per-trigger order is preserved).
+	Workers int
+	// BatchSize is how many schedules are claimed per query; full batches are drained back to back.
+	BatchSize int
+	// WakePollInterval is how often the schedule notification feed is checked for new schedules.
+	WakePollInterval time.Duration
+	// Clock provides the current time; defaults to the system clock.
+	Clock clock.Clock
+	// Jitter returns a random number in [0, 1) used to spread retry backoffs and recurring trigger jitter; defaults to math/rand.
+	Jitter func() float64
+}
+
+// Engine scans for triggers that are due to fire and enqueues them. Between scans it sleeps
+// until the earliest known fire_at (at most Tick), and wakes up early when a new schedule is announced.
+// Several engines may run against the same database: each one claims schedules
+// under its own instanceID so a schedule is only ever fired by one of them.
 type Engine struct {
-	tick         time.Duration
-	db           *storage.MySQLClient
-	eventService *events.Service
-	logger       *zap.Logger
+	tick             time.Duration
+	instanceID       string
+	leaseDuration    time.Duration
+	workers          int
+	batchSize        int
+	wakePollInterval time.Duration
+	counters         poolCounters
+	wake             *wakeup
+	clock            clock.Clock
+	jitter           func() float64
+	db               Store
+	firer            TriggerFirer
+	logger           *zap.Logger
 }
 
-// NewEngine constructs a scheduler with the provided polling cadence and dependencies.
-func NewEngine(tick time.Duration, db *storage.MySQLClient, eventService *events.Service, logger *zap.Logger) *Engine {
+// NewEngine constructs a scheduler with the provided configuration and dependencies.
+func NewEngine(cfg Config, db Store, firer TriggerFirer, logger *zap.Logger) *Engine {
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = DefaultLeaseDuration
+	}
+	if cfg.Workers <= 0 {
+		cfg.Workers = DefaultWorkers
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = DefaultBatchSize
+	}
+	if cfg.WakePollInterval <= 0 {
+		cfg.WakePollInterval = DefaultWakePollInterval
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.System()
+	}
+	if cfg.Jitter == nil {
+		cfg.Jitter = rand.Float64
+	}
+
 	return &Engine{
-		tick:         tick,
-		db:           db,
-		eventService: eventService,
-		logger:       logger,
+		tick:             cfg.Tick,
+		instanceID:       cfg.InstanceID,
+		leaseDuration:    cfg.LeaseDuration,
+		workers:          cfg.Workers,
+		batchSize:        cfg.BatchSize,
+		wakePollInterval: cfg.WakePollInterval,
+		wake:             newWakeup(),
+		clock:            cfg.Clock,
+		jitter:           cfg.Jitter,
+		db:               db,
+		firer:            firer,
+		logger:           logger,
 	}
 }
 
 // Run begins the polling loop, querying due schedules and firing triggers.
+// A reaper loop runs alongside it to recover schedules whose lease expired, and a watcher
+// tails the schedule notification feed to wake the loop for schedules created meanwhile.
 // This method runs until the context is cancelled (graceful shutdown).
 func (e *Engine) Run(ctx context.Context) error {
 	e.logger.Info("scheduler engine started",
-		zap.Duration("tick_interval", e.tick))
+		zap.Duration("tick_interval", e.tick),
+		zap.Duration("lease_duration", e.leaseDuration),
+		zap.Int("workers", e.workers),
+		zap.Int("batch_size", e.batchSize),
+		zap.String("instance_id", e.instanceID))
 
-	ticker := time.NewTicker(e.tick)
-	defer ticker.Stop()
+	go e.runReaper(ctx)
+	go e.watchNotifications(ctx)
+
+	// Poll right away, then sleep until the next known due time
+	deadline := e.clock.Now()
+	timer := time.NewTimer(0)
+	defer timer.Stop()
 
 	for {
 		select {
-		case <-ticker.C:
+		case <-timer.C:
 			e.processSchedules(ctx)
+			delay := e.nextPollDelay(ctx)
+			deadline = e.clock.Now().Add(delay)
+			timer.Reset(delay)
+		case <-e.wake.signal:
+			fireAt := e.wake.take()
+			if fireAt.IsZero() || !fireAt.Before(deadline) {
+				continue
+			}
+			e.logger.Debug("waking up early for new schedule", zap.Time("fire_at", fireAt))
+			delay := max(fireAt.Sub(e.clock.Now()), 0)
+			deadline = e.clock.Now().Add(delay)
+			timer.Reset(delay)
 		case <-ctx.Done():
 			e.logger.Info("scheduler engine shutting down")
 			return ctx.Err()
@@ -51,45 +146,65 @@ func (e *Engine) Run(ctx context.Context) error {
 	}
 }
 
-// processSchedules queries and processes all due schedules in a single tick.
-func (e *Engine) processSchedules(ctx context.Context) {
-	// Query up to 100 due schedules (configurable limit)
-	schedules, err := e.db.GetDueSchedules(ctx, 100)
+// RunOnce performs a single scheduling pass at the clock's current time: it recovers expired
+// leases, then claims and fires every due schedule. Run does the same on its own timers;
+// RunOnce lets a caller (e.g. the simulation harness) drive the engine on a virtual timeline.
+func (e *Engine) RunOnce(ctx context.Context) {
+	e.reclaimExpiredLeases(ctx)
+	e.processSchedules(ctx)
+}
+
+// nextPollDelay returns how long to sleep until the earliest pending schedule is due,
+// bounded by [minPollDelay, tick]. Falls back to tick when the due time is unknown.
+func (e *Engine) nextPollDelay(ctx context.Context) time.Duration {
+	next, err := e.db.NextDueTime(ctx)
 	if err != nil {
-		e.logger.Error("failed to query due schedules", zap.Error(err))
-		return
+		e.logger.Warn("failed to query next due time", zap.Error(err))
+		return e.tick
 	}
-
-	if len(schedules) == 0 {
-		e.logger.Debug("no due schedules found")
-		return
+	if next == nil {
+		return e.tick
 	}
 
-	e.logger.Info("processing due schedules",
-		zap.Int("count", len(schedules)))
+	return min(max(next.Sub(e.clock.Now()), minPollDelay), e.tick)
+}
+
+// processSchedules claims and processes due schedules. Batches are claimed back to back
+// while they come back full, so a backlog drains without waiting for the next poll.
+// Each batch is drained before the next claim, so batches never overlap.
+func (e *Engine) processSchedules(ctx context.Context) {
+	for ctx.Err() == nil {
+		// Rows claimed by other instances are skipped
+		schedules, err := e.db.ClaimDueSchedules(ctx, e.instanceID, e.batchSize, e.leaseDuration)
+		if err != nil {
+			e.logger.Error("failed to claim due schedules", zap.Error(err))
+			return
+		}
+
+		if len(schedules) == 0 {
+			e.logger.Debug("no due schedules found")
+			return
+		}
 
-	// Process each schedule
-	successCount := 0
-	failureCount := 0
+		e.logger.Info("processing claimed schedules",
+			zap.Int("count", len(schedules)),
+			zap.Int("workers", e.workers),
+			zap.String("instance_id", e.instanceID))
 
-	for _, schedule := range schedules {
-		if err := e.processSchedule(ctx, schedule); err != nil {
-			e.logger.Error("failed to process schedule",
-				zap.String("schedule_id", schedule.Schedule.ID),
-				zap.String("trigger_id", schedule.Trigger.ID),
-				zap.Error(err))
-			failureCount++
-		} else {
-			successCount++
+		// Fire the batch on the worker pool
+		successCount, failureCount := e.dispatchBatch(ctx, schedules)
+
+		e.logger.Info("completed processing schedules",
+			zap.Int("success", successCount),
+			zap.Int("failure", failureCount))
+
+		if len(schedules) < e.batchSize {
+			return
 		}
 	}
-
-	e.logger.Info("completed processing schedules",
-		zap.Int("success", successCount),
-		zap.Int("failure", failureCount))
 }
 
-// processSchedule handles a single schedule: mark processing, fire trigger, update status, create next schedule.
+// processSchedule handles a single claimed schedule: fire trigger, update status, create next schedule.
 func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storage.ScheduleWithTrigger) error {
 	schedule := scheduleWithTrigger.Schedule
 	trigger := scheduleWithTrigger.Trigger
@@ -99,15 +214,57 @@ func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storag
 		zap.String("trigger_id", trigger.ID),
 		zap.String("trigger_name", trigger.Name),
 		zap.String("trigger_type", string(trigger.Type)),
-		zap.Time("fire_at", schedule.FireAt))
+		zap.Time("fire_at", schedule.FireAt),
+		zap.Bool("backfill", schedule.Backfill))
+
+	// Step 1: The schedule is already 'processing' and owned by this instance (see ClaimDueSchedules).
+	// Keep the lease alive while we work on it so the reaper does not hand it to another instance.
+	// The schedule may have waited in a worker queue, so confirm we still own it first.
+	if err := e.db.ExtendScheduleLease(ctx, schedule.ID, e.instanceID, e.leaseDuration); err != nil {
+		return fmt.Errorf("failed to confirm schedule lease: %w", err)
+	}
+	stopHeartbeat := e.startLeaseHeartbeat(ctx, schedule.ID)
+	defer stopHeartbeat()
+
+	// Step 2: Apply the misfire policy for recurring triggers that fell behind. Backfill schedules
+	// replay a past occurrence on purpose and stand outside the trigger's recurrence.
+	var plan recurrencePlan
+	if triggers.IsRecurring(trigger.Type) && !schedule.Backfill {
+		var err error
+		plan, err = planRecurringSchedule(&trigger, schedule, e.clock.Now().UTC())
+		if err != nil {
+			return err
+		}
 
-	// Step 1: Mark schedule as 'processing' to prevent duplicate execution
-	err := e.db.UpdateScheduleStatus(ctx, schedule.ID, models.ScheduleStatusProcessing)
-	if err != nil {
-		return fmt.Errorf("failed to mark schedule as processing: %w", err)
+		if plan.behind {
+			e.logger.Warn("scheduler is behind on recurring trigger",
+				zap.String("schedule_id", schedule.ID),
+				zap.String("trigger_id", trigger.ID),
+				zap.Time("fire_at", schedule.FireAt),
+				zap.String("misfire_policy", string(plan.options.MisfirePolicy)),
+				zap.Int("dropped_occurrences", plan.dropped),
+				zap.Time("next_scheduled_for", plan.next))
+		}
+
+		if plan.skip {
+			return e.skipSchedule(ctx, &trigger, schedule, plan, "occurrence missed while the scheduler was behind (misfire_policy skip)")
+		}
+
+		// Step 3: Skip or defer occurrences that fall inside a business calendar exclusion
+		if len(plan.options.Calendars) > 0 {
+			handled, err := e.applyCalendars(ctx, &trigger, schedule, plan)
+			if handled || err != nil {
+				return err
+			}
+		}
+
+		// Step 4: Skip the occurrence, or replace the previous one, while the previous event is running
+		if skipped, err := e.applyConcurrencyPolicy(ctx, &trigger, schedule, plan); skipped || err != nil {
+			return err
+		}
 	}
 
-	// Step 2: Extract payload from trigger config
+	// Step 5: Extract payload from trigger config
 	config, err := storage.ParseTriggerConfig(&trigger)
 	if err != nil {
 		return fmt.Errorf("failed to parse trigger config: %w", err)
@@ -115,23 +272,33 @@ func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storag
 
 	payload := storage.ExtractPayloadFromConfig(trigger.Type, config)
 
-	// Step 3: Fire trigger via EventService (creates event log + publishes to Kafka)
-	eventID, err := e.eventService.FireTrigger(ctx, &trigger, models.EventSourceScheduler, payload, false)
+	// Step 6: Fire trigger via EventService (records the event and completes the schedule in one
+	// transaction, then publishes to Kafka)
+	eventID, err := e.firer.FireScheduledTrigger(ctx, &trigger, &schedule, payload)
+	if errors.Is(err, storage.ErrScheduleLeaseLost) {
+		// Another instance reclaimed the schedule; nothing was recorded and it is no longer ours to retry
+		e.logger.Warn("lost schedule lease before firing",
+			zap.String("schedule_id", schedule.ID),
+			zap.String("trigger_id", trigger.ID))
+		return fmt.Errorf("failed to fire trigger: %w", err)
+	}
 	if err != nil {
-		// CRITICAL: On failure, implement retry logic with max attempts
-		const maxRetries = 5
+		// CRITICAL: On failure, retry with exponential backoff up to the trigger's max attempts
+		policy := triggers.RetryPolicyFromConfig(trigger.Config)
 		currentAttempts := schedule.AttemptCount + 1 // +1 because we're about to increment
 
 		e.logger.Error("failed to fire trigger",
 			zap.String("schedule_id", schedule.ID),
 			zap.String("trigger_id", trigger.ID),
 			zap.Int("current_attempts", currentAttempts),
-			zap.Int("max_retries", maxRetries),
+			zap.Int("max_attempts", policy.MaxAttempts),
 			zap.Error(err))
 
-		if currentAttempts < maxRetries {
-			// Revert to 'pending' for retry on next poll (increments attempt_count)
-			revertErr := e.db.RevertScheduleToPending(ctx, schedule.ID)
+		if currentAttempts < policy.MaxAttempts {
+			// Revert to 'pending' (increments attempt_count); it becomes claimable again after the backoff
+			backoff := policy.Backoff(currentAttempts, e.jitter())
+			nextAttemptAt := e.clock.Now().UTC().Add(backoff)
+			revertErr := e.db.RevertScheduleToPending(ctx, schedule.ID, e.instanceID, nextAttemptAt)
 			if revertErr != nil {
 				e.logger.Error("failed to revert schedule to pending",
 					zap.String("schedule_id", schedule.ID),
@@ -140,11 +307,13 @@ func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storag
 				e.logger.Info("schedule reverted to pending for retry",
 					zap.String("schedule_id", schedule.ID),
 					zap.Int("attempts", currentAttempts),
-					zap.Int("remaining_retries", maxRetries-currentAttempts))
+					zap.Int("remaining_retries", policy.MaxAttempts-currentAttempts),
+					zap.Duration("backoff", backoff),
+					zap.Time("next_attempt_at", nextAttemptAt))
 			}
 		} else {
-			// Max retries exceeded - mark as 'cancelled' to prevent further attempts
-			cancelErr := e.db.UpdateScheduleStatus(ctx, schedule.ID, models.ScheduleStatusCancelled)
+			// Max attempts exceeded - mark as 'cancelled' to prevent further attempts
+			cancelErr := e.db.UpdateScheduleStatus(ctx, schedule.ID, e.instanceID, models.ScheduleStatusCancelled)
 			if cancelErr != nil {
 				e.logger.Error("failed to cancel schedule after max retries",
 					zap.String("schedule_id", schedule.ID),
@@ -158,7 +327,7 @@ func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storag
 		}
 
 		// Return error to stop further processing (no next schedule creation)
-		return fmt.Errorf("failed to fire trigger (attempt %d/%d): %w", currentAttempts, maxRetries, err)
+		return fmt.Errorf("failed to fire trigger (attempt %d/%d): %w", currentAttempts, policy.MaxAttempts, err)
 	}
 
 	e.logger.Info("trigger fired successfully",
@@ -166,13 +335,21 @@ func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storag
 		zap.String("event_id", eventID),
 		zap.String("trigger_id", trigger.ID))
 
-	// Step 4: Mark schedule as 'completed' (only on success)
-	err = e.db.UpdateScheduleStatus(ctx, schedule.ID, models.ScheduleStatusCompleted)
+	// A backfill schedule is done once fired: it neither counts towards max_fires nor schedules a next run
+	if schedule.Backfill {
+		return nil
+	}
+
+	// Step 7: Count the fire. A failed count is not worth stalling the trigger over: it only
+	// matters for max_fires, which then allows one extra fire.
+	fireCount, err := e.db.IncrementTriggerFireCount(ctx, trigger.ID)
 	if err != nil {
-		return fmt.Errorf("failed to mark schedule as completed: %w", err)
+		e.logger.Error("failed to count trigger fire",
+			zap.String("trigger_id", trigger.ID),
+			zap.Error(err))
 	}
 
-	// Step 5: Handle trigger type-specific logic
+	// Step 8: Handle trigger type-specific logic
 	switch trigger.Type {
 	case models.TriggerTypeTimeScheduled:
 		// One-time trigger - deactivate after firing
@@ -186,18 +363,25 @@ func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storag
 		e.logger.Info("deactivated one-time trigger",
 			zap.String("trigger_id", trigger.ID))
 
-	case models.TriggerTypeCronScheduled:
-		// Recurring trigger - create next schedule if trigger is still active
-		if trigger.Status == models.TriggerStatusActive {
-			err = e.createNextSchedule(ctx, &trigger)
+	case models.TriggerTypeCronScheduled, models.TriggerTypeIntervalScheduled:
+		// Recurring trigger - create next schedule if trigger is still active and has fires left
+		if maxFires := plan.options.MaxFires; maxFires > 0 && fireCount >= maxFires {
+			if err = e.db.DeactivateTrigger(ctx, trigger.ID); err != nil {
+				return fmt.Errorf("failed to deactivate trigger after max_fires: %w", err)
+			}
+			e.logger.Info("deactivated recurring trigger after reaching max_fires",
+				zap.String("trigger_id", trigger.ID),
+				zap.Int("max_fires", maxFires))
+		} else if trigger.Status == models.TriggerStatusActive {
+			err = e.createNextSchedule(ctx, &trigger, plan)
 			if err != nil {
-				e.logger.Error("failed to create next schedule for CRON trigger",
+				e.logger.Error("failed to create next schedule for recurring trigger",
 					zap.String("trigger_id", trigger.ID),
 					zap.Error(err))
 				return fmt.Errorf("failed to create next schedule: %w", err)
 			}
 		} else {
-			e.logger.Info("skipping next schedule creation for inactive CRON trigger",
+			e.logger.Info("skipping next schedule creation for inactive recurring trigger",
 				zap.String("trigger_id", trigger.ID))
 		}
 	}
@@ -205,40 +389,98 @@ func (e *Engine) processSchedule(ctx context.Context, scheduleWithTrigger storag
 	return nil
 }
 
-// createNextSchedule calculates and creates the next schedule entry for a CRON trigger.
-func (e *Engine) createNextSchedule(ctx context.Context, trigger *models.Trigger) error {
-	// Parse CRON config from trigger
-	cronConfig, err := triggers.ParseCronConfig(trigger.Config)
-	if err != nil {
-		return fmt.Errorf("failed to parse cron config: %w", err)
+// skipSchedule marks a recurring schedule as skipped, records the reason in the event log and
+// schedules the next occurrence.
+func (e *Engine) skipSchedule(ctx context.Context, trigger *models.Trigger, schedule models.TriggerSchedule, plan recurrencePlan, reason string) error {
+	if err := e.db.UpdateScheduleStatus(ctx, schedule.ID, e.instanceID, models.ScheduleStatusSkipped); err != nil {
+		return fmt.Errorf("failed to mark schedule as skipped: %w", err)
 	}
 
-	// Calculate next fire time
-	nextFireTime, err := triggers.CalculateNextFireTime(cronConfig.Cron, cronConfig.Timezone, time.Now())
-	if err != nil {
-		return fmt.Errorf("failed to calculate next fire time: %w", err)
+	e.logger.Info("skipped occurrence",
+		zap.String("schedule_id", schedule.ID),
+		zap.String("trigger_id", trigger.ID),
+		zap.Time("fire_at", schedule.FireAt),
+		zap.String("reason", reason))
+
+	e.recordSkippedOccurrence(ctx, trigger, schedule.Occurrence(), reason)
+
+	if trigger.Status != models.TriggerStatusActive {
+		return nil
+	}
+	if err := e.createNextSchedule(ctx, trigger, plan); err != nil {
+		return fmt.Errorf("failed to create next schedule: %w", err)
 	}
+	return nil
+}
+
+// recordSkippedOccurrence writes a skipped event log entry for an occurrence, so the event history
+// shows why it did not fire. Failures are logged, not returned: the skip stands either way.
+func (e *Engine) recordSkippedOccurrence(ctx context.Context, trigger *models.Trigger, occurrence time.Time, reason string) {
+	now := e.clock.Now().UTC()
+	occurrence = occurrence.UTC()
+	eventLog := &models.EventLog{
+		ID:              uuid.New().String(),
+		TriggerID:       &trigger.ID,
+		TriggerType:     trigger.Type,
+		FiredAt:         now,
+		ScheduledFor:    &occurrence,
+		Source:          models.EventSourceScheduler,
+		ExecutionStatus: models.ExecutionStatusSkipped,
+		SkipReason:      &reason,
+		RetentionStatus: models.RetentionStatusActive,
+		CreatedAt:       now,
+	}
+	if err := e.db.CreateEventLog(ctx, eventLog); err != nil {
+		e.logger.Error("failed to record skipped occurrence in event log",
+			zap.String("trigger_id", trigger.ID),
+			zap.Time("scheduled_for", occurrence),
+			zap.Error(err))
+	}
+}
+
+// createNextSchedule creates the next schedule entry for a recurring trigger, as planned by its misfire policy.
+// The occurrence is recorded as scheduled_for; fire_at adds the trigger's jitter or spread offset.
+func (e *Engine) createNextSchedule(ctx context.Context, trigger *models.Trigger, plan recurrencePlan) error {
+	if plan.next.IsZero() {
+		// Finite recurrence (RRULE COUNT/UNTIL) used up or past end_at - nothing left to schedule
+		if err := e.db.DeactivateTrigger(ctx, trigger.ID); err != nil {
+			return fmt.Errorf("failed to deactivate exhausted trigger: %w", err)
+		}
+		e.logger.Info("deactivated recurring trigger with no further occurrences",
+			zap.String("trigger_id", trigger.ID))
+		return nil
+	}
+
+	next := plan.options.FireTimeFor(plan.next, trigger.ID, e.jitter)
 
 	// Create new schedule entry
 	nextSchedule := &models.TriggerSchedule{
 		ID:           uuid.New().String(),
 		TriggerID:    trigger.ID,
-		FireAt:       nextFireTime,
+		FireAt:       next.FireAt,
+		ScheduledFor: &next.ScheduledFor,
 		Status:       models.ScheduleStatusPending,
 		AttemptCount: 0,
 	}
 
-	err = e.db.CreateNextSchedule(ctx, nextSchedule)
+	err := e.db.CreateNextSchedule(ctx, nextSchedule)
 	if err != nil {
 		return fmt.Errorf("failed to insert next schedule: %w", err)
 	}
 
-	e.logger.Info("created next schedule for CRON trigger",
+	e.logger.Info("created next schedule for recurring trigger",
 		zap.String("trigger_id", trigger.ID),
 		zap.String("next_schedule_id", nextSchedule.ID),
-		zap.Time("next_fire_at", nextFireTime),
-		zap.String("cron_expr", cronConfig.Cron),
-		zap.String("timezone", cronConfig.Timezone))
+		zap.Time("next_scheduled_for", next.ScheduledFor),
+		zap.Time("next_fire_at", next.FireAt),
+		zap.String("trigger_type", string(trigger.Type)))
+
+	// Occurrences the trigger's DST policy drops never get a schedule; log them as skipped
+	for _, adjustment := range triggers.TriggerDSTAdjustments(trigger, plan.occurrence, plan.next) {
+		if adjustment.Skipped {
+			e.recordSkippedOccurrence(ctx, trigger, adjustment.At, adjustment.Note)
+		}
+	}
 
 	return nil
 }
@@ -0,0 +1,296 @@
+package scheduler_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/scheduler"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/storage/sqlite"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// countingFirer fires through the real event service and counts the fires of each schedule.
+type countingFirer struct {
+	service *events.Service
+
+	mu    sync.Mutex
+	fires map[string]int
+}
+
+func (f *countingFirer) FireScheduledTrigger(ctx context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule, payload map[string]interface{}) (string, error) {
+	f.mu.Lock()
+	f.fires[schedule.ID]++
+	f.mu.Unlock()
+
+	return f.service.FireScheduledTrigger(ctx, trigger, schedule, payload)
+}
+
+// TestEnginesFireEachScheduleOnce runs several engines side by side against one store, all
+// stepping at the same instants, and checks that every occurrence fires exactly once.
+func TestEnginesFireEachScheduleOnce(t *testing.T) {
+	backends := []struct {
+		name     string
+		newStore func(t *testing.T, clk clock.Clock) storage.Store
+	}{
+		{"memory", func(t *testing.T, clk clock.Clock) storage.Store {
+			return memory.NewStore(clk)
+		}},
+		{"sqlite", func(t *testing.T, clk clock.Clock) storage.Store {
+			db, err := sqlite.Open(context.Background(), ":memory:")
+			if err != nil {
+				t.Fatalf("open sqlite: %v", err)
+			}
+			t.Cleanup(func() { db.Close() })
+			return sqlite.NewClient(db, clk)
+		}},
+	}
+
+	const triggerCount, engineCount, minutes = 20, 4, 3
+
+	for _, backend := range backends {
+		t.Run(backend.name, func(t *testing.T) {
+			ctx := context.Background()
+			clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			store := backend.newStore(t, clk)
+
+			publisher := platformEvents.NewMemoryPublisher(triggerCount * minutes * 2)
+			service := events.NewService(store, publisher, clk, zap.NewNop())
+			firer := &countingFirer{service: service, fires: make(map[string]int)}
+
+			triggerIDs := make([]string, 0, triggerCount)
+			for i := 0; i < triggerCount; i++ {
+				triggerIDs = append(triggerIDs, createCronTrigger(t, store, clk, fmt.Sprintf("every-minute-%d", i), nil))
+			}
+
+			engines := make([]*scheduler.Engine, 0, engineCount)
+			for i := 0; i < engineCount; i++ {
+				engines = append(engines, scheduler.NewEngine(scheduler.Config{
+					Tick:       time.Second,
+					InstanceID: fmt.Sprintf("engine-%d", i),
+					Workers:    4,
+					BatchSize:  5,
+					Clock:      clk,
+				}, store, firer, zap.NewNop()))
+			}
+
+			for minute := 0; minute < minutes; minute++ {
+				clk.Advance(time.Minute)
+				var wg sync.WaitGroup
+				for _, engine := range engines {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						engine.RunOnce(ctx)
+					}()
+				}
+				wg.Wait()
+			}
+
+			for scheduleID, fires := range firer.fires {
+				if fires != 1 {
+					t.Errorf("schedule %s fired %d times, want 1", scheduleID, fires)
+				}
+			}
+			if len(firer.fires) != triggerCount*minutes {
+				t.Errorf("fired %d schedules, want %d", len(firer.fires), triggerCount*minutes)
+			}
+
+			for _, triggerID := range triggerIDs {
+				logs, total, err := store.ListEventLogs(ctx, models.ListEventsQuery{TriggerID: triggerID, Limit: 100})
+				if err != nil {
+					t.Fatalf("ListEventLogs: %v", err)
+				}
+				if total != minutes {
+					t.Errorf("trigger %s has %d events, want %d", triggerID, total, minutes)
+					continue
+				}
+				seen := make(map[time.Time]bool, minutes)
+				for _, eventLog := range logs {
+					if eventLog.ScheduledFor == nil || seen[eventLog.ScheduledFor.UTC()] {
+						t.Errorf("trigger %s: event %s has missing or duplicate scheduled_for %v", triggerID, eventLog.ID, eventLog.ScheduledFor)
+						continue
+					}
+					seen[eventLog.ScheduledFor.UTC()] = true
+				}
+			}
+		})
+	}
+}
+
+// createCronTrigger stores a trigger firing every minute, with its first schedule. options are
+// added to its config.
+func createCronTrigger(t *testing.T, store storage.Store, clk clock.Clock, name string, options map[string]interface{}) string {
+	t.Helper()
+
+	fields := map[string]interface{}{"cron": "* * * * *", "endpoint": "https://example.com/hook"}
+	for key, value := range options {
+		fields[key] = value
+	}
+	config, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("marshal config: %v", err)
+	}
+
+	trigger := &models.Trigger{
+		ID:     uuid.New().String(),
+		Name:   name,
+		Type:   models.TriggerTypeCronScheduled,
+		Status: models.TriggerStatusActive,
+	}
+	normalized, schedule, err := triggers.PrepareConfig(trigger.Type, trigger.ID, config, clk.Now(), func() float64 { return 0 })
+	if err != nil {
+		t.Fatalf("PrepareConfig: %v", err)
+	}
+	trigger.Config = normalized
+
+	if err := store.CreateTrigger(context.Background(), trigger, schedule); err != nil {
+		t.Fatalf("CreateTrigger: %v", err)
+	}
+	return trigger.ID
+}
+
+// TestEngineDeactivatesAfterMaxFires checks that a recurring trigger stops after max_fires fires:
+// the last fire deactivates it instead of scheduling the next occurrence.
+func TestEngineDeactivatesAfterMaxFires(t *testing.T) {
+	const maxFires = 3
+
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	service := events.NewService(store, platformEvents.NewMemoryPublisher(10), clk, zap.NewNop())
+	engine := scheduler.NewEngine(scheduler.Config{Tick: time.Second, InstanceID: "engine", Clock: clk}, store, service, zap.NewNop())
+
+	triggerID := createCronTrigger(t, store, clk, "three-times", map[string]interface{}{"max_fires": maxFires})
+
+	pending := func() int {
+		count := 0
+		for _, schedule := range store.Schedules(triggerID) {
+			if schedule.Status == models.ScheduleStatusPending {
+				count++
+			}
+		}
+		return count
+	}
+
+	for minute := 1; minute <= maxFires+2; minute++ {
+		clk.Advance(time.Minute)
+		engine.RunOnce(ctx)
+
+		trigger, _, err := store.GetTrigger(ctx, triggerID)
+		if err != nil {
+			t.Fatalf("GetTrigger: %v", err)
+		}
+		fires := min(minute, maxFires)
+		if trigger.FireCount != fires {
+			t.Errorf("minute %d: fire_count = %d, want %d", minute, trigger.FireCount, fires)
+		}
+
+		wantStatus, wantPending := models.TriggerStatusActive, 1
+		if minute >= maxFires {
+			wantStatus, wantPending = models.TriggerStatusInactive, 0
+		}
+		if trigger.Status != wantStatus {
+			t.Errorf("minute %d: trigger status = %s, want %s", minute, trigger.Status, wantStatus)
+		}
+		if got := pending(); got != wantPending {
+			t.Errorf("minute %d: %d pending schedules, want %d", minute, got, wantPending)
+		}
+	}
+
+	if _, total, err := store.ListEventLogs(ctx, models.ListEventsQuery{TriggerID: triggerID, Limit: 100}); err != nil || total != maxFires {
+		t.Errorf("trigger has %d events (err %v), want %d", total, err, maxFires)
+	}
+}
+
+// TestEngineFiresPreviewedOccurrences checks that the occurrence preview agrees with the engine on
+// a trigger with an active window, a spread and a calendar: the engine fires exactly the previewed
+// occurrences at their previewed fire times, and skips the ones previewed as skipped.
+func TestEngineFiresPreviewedOccurrences(t *testing.T) {
+	for _, policy := range []models.CalendarPolicy{models.CalendarPolicySkip, models.CalendarPolicyDefer} {
+		t.Run(string(policy), func(t *testing.T) {
+			ctx := context.Background()
+			clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+			store := memory.NewStore(clk)
+			service := triggers.NewService(store, clk, zap.NewNop())
+			firer := events.NewService(store, platformEvents.NewMemoryPublisher(100), clk, zap.NewNop())
+			engine := scheduler.NewEngine(scheduler.Config{Tick: time.Second, InstanceID: "engine", Clock: clk}, store, firer, zap.NewNop())
+
+			// 2025-01-02 in New York is 05:00Z to 05:00Z the next day
+			if _, err := service.CreateCalendar(ctx, models.CreateCalendarRequest{Name: "closed", Timezone: "America/New_York", Holidays: []string{"2025-01-02"}}); err != nil {
+				t.Fatalf("CreateCalendar: %v", err)
+			}
+			trigger, err := service.CreateTrigger(ctx, models.CreateTriggerRequest{
+				Name: "every-8h",
+				Type: models.TriggerTypeCronScheduled,
+				Config: json.RawMessage(`{"cron":"0 */8 * * *","endpoint":"https://example.com/hook","spread":"30m",` +
+					`"start_at":"2025-01-02T00:00:00Z","end_at":"2025-01-04T00:00:00Z","calendars":["closed"],"calendar_policy":"` + string(policy) + `"}`),
+			})
+			if err != nil {
+				t.Fatalf("CreateTrigger: %v", err)
+			}
+
+			preview, err := service.ListOccurrences(ctx, trigger.ID, models.ListOccurrencesQuery{Count: triggers.MaxOccurrenceCount})
+			if err != nil {
+				t.Fatalf("ListOccurrences: %v", err)
+			}
+			var wantFired, wantSkipped []string
+			for _, occurrence := range preview.Occurrences {
+				if occurrence.Status == models.OccurrenceStatusSkipped {
+					wantSkipped = append(wantSkipped, occurrence.ScheduledFor.Format(time.RFC3339))
+					continue
+				}
+				wantFired = append(wantFired, occurrence.ScheduledFor.Format(time.RFC3339)+" at "+occurrence.FireAt.Format(time.RFC3339))
+			}
+			if len(wantFired) == 0 {
+				t.Fatal("preview has no occurrence to fire")
+			}
+
+			for clk.Now().Before(time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC)) {
+				clk.Advance(time.Minute)
+				engine.RunOnce(ctx)
+			}
+
+			var fired, skipped []string
+			for _, schedule := range store.Schedules(trigger.ID) {
+				if schedule.Status == models.ScheduleStatusPending || schedule.Status == models.ScheduleStatusProcessing {
+					t.Errorf("schedule for %s is still %s after end_at", schedule.Occurrence(), schedule.Status)
+				}
+				switch schedule.Status {
+				case models.ScheduleStatusCompleted:
+					fired = append(fired, schedule.Occurrence().UTC().Format(time.RFC3339)+" at "+schedule.FireAt.UTC().Format(time.RFC3339))
+				case models.ScheduleStatusSkipped:
+					skipped = append(skipped, schedule.Occurrence().UTC().Format(time.RFC3339))
+				}
+			}
+			if strings.Join(fired, "\n") != strings.Join(wantFired, "\n") {
+				t.Errorf("engine fired\n%s\npreview listed\n%s", strings.Join(fired, "\n"), strings.Join(wantFired, "\n"))
+			}
+
+			// Deferring skips the first excluded schedule too, in favour of one at the exclusion's end
+			if policy == models.CalendarPolicySkip && strings.Join(skipped, ",") != strings.Join(wantSkipped, ",") {
+				t.Errorf("engine skipped %v, preview listed %v", skipped, wantSkipped)
+			}
+
+			stored, _, err := store.GetTrigger(ctx, trigger.ID)
+			if err != nil {
+				t.Fatalf("GetTrigger: %v", err)
+			}
+			if stored.Status != models.TriggerStatusInactive {
+				t.Errorf("trigger status after end_at = %s, want inactive", stored.Status)
+			}
+		})
+	}
+}
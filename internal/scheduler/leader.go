@@ -0,0 +1,241 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"go.uber.org/zap"
+)
+
+// DefaultLeaderLeaseDuration is how long a leader's lease lasts without renewal when
+// LeaderConfig.LeaseDuration is unset. A standby takes over at most this long (plus one
+// RenewInterval) after the leader stops renewing.
+const DefaultLeaderLeaseDuration = 15 * time.Second
+
+// releaseTimeout bounds how long a stopping leader waits to release its lease.
+const releaseTimeout = 5 * time.Second
+
+// LeaderConfig holds the tunables of a leader elector.
+type LeaderConfig struct {
+	// Name is the lease the instances compete for; defaults to storage.SchedulerLeaseName.
+	Name string
+	// InstanceID identifies this instance as the lease holder (the engine's InstanceID).
+	InstanceID string
+	// LeaseDuration is how long the lease survives without renewal.
+	LeaseDuration time.Duration
+	// RenewInterval is how often the leader renews its lease and a standby tries to acquire it;
+	// defaults to a third of LeaseDuration.
+	RenewInterval time.Duration
+	// Clock provides the current time; defaults to the system clock.
+	Clock clock.Clock
+}
+
+// LeaderTerm is one uninterrupted period of leadership. Its fencing token is greater than that of
+// every earlier term, whichever instance held it.
+type LeaderTerm struct {
+	Name         string
+	InstanceID   string
+	FencingToken int64
+}
+
+// LeaderElector elects one leader among the scheduler instances sharing a database, through a lease
+// in the store. Only the leader runs its work; the others stand by and take over once the leader's
+// lease expires or is released.
+type LeaderElector struct {
+	name          string
+	instanceID    string
+	leaseDuration time.Duration
+	renewInterval time.Duration
+	clock         clock.Clock
+	store         storage.LeaderStore
+	logger        *zap.Logger
+}
+
+// NewLeaderElector constructs an elector with the provided configuration and dependencies.
+func NewLeaderElector(cfg LeaderConfig, store storage.LeaderStore, logger *zap.Logger) *LeaderElector {
+	if cfg.Name == "" {
+		cfg.Name = storage.SchedulerLeaseName
+	}
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = DefaultLeaderLeaseDuration
+	}
+	if cfg.RenewInterval <= 0 || cfg.RenewInterval >= cfg.LeaseDuration {
+		cfg.RenewInterval = cfg.LeaseDuration / 3
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.System()
+	}
+
+	return &LeaderElector{
+		name:          cfg.Name,
+		instanceID:    cfg.InstanceID,
+		leaseDuration: cfg.LeaseDuration,
+		renewInterval: cfg.RenewInterval,
+		clock:         cfg.Clock,
+		store:         store,
+		logger:        logger,
+	}
+}
+
+// Run campaigns for leadership until the context is cancelled. Each time this instance becomes the
+// leader, lead runs with a context that is cancelled as soon as the lease is lost, or can no longer
+// be renewed in time to be sure it is still held; Run waits for lead to return before standing by
+// again. On shutdown the lease is released so a standby takes over right away.
+func (l *LeaderElector) Run(ctx context.Context, lead func(ctx context.Context, term LeaderTerm) error) error {
+	l.logger.Info("leader election started",
+		zap.String("lease", l.name),
+		zap.String("instance_id", l.instanceID),
+		zap.Duration("lease_duration", l.leaseDuration),
+		zap.Duration("renew_interval", l.renewInterval))
+
+	var standingBy string
+	for {
+		lease, acquired, err := l.store.AcquireLeadership(ctx, l.name, l.instanceID, l.leaseDuration)
+		switch {
+		case err != nil:
+			if ctx.Err() == nil {
+				l.logger.Error("failed to acquire leadership", zap.Error(err))
+			}
+		case acquired:
+			standingBy = ""
+			l.lead(ctx, lease, lead)
+		case lease.Holder != standingBy:
+			// Log once per leader, not on every attempt
+			standingBy = lease.Holder
+			l.logger.Info("standing by for the current leader",
+				zap.String("leader", lease.Holder),
+				zap.Int64("fencing_token", lease.FencingToken),
+				zap.Time("lease_expires_at", lease.ExpiresAt))
+		}
+
+		select {
+		case <-time.After(l.renewInterval):
+		case <-ctx.Done():
+			l.logger.Info("leader election stopped")
+			return ctx.Err()
+		}
+	}
+}
+
+// lead runs one term: lead runs under a term context while the lease is renewed every renewInterval.
+// The term ends when the lease is lost, when it may have expired unrenewed, when lead returns or
+// when ctx is cancelled.
+func (l *LeaderElector) lead(ctx context.Context, lease *models.SchedulerLease, lead func(ctx context.Context, term LeaderTerm) error) {
+	term := LeaderTerm{Name: l.name, InstanceID: l.instanceID, FencingToken: lease.FencingToken}
+	termLogger := l.logger.With(zap.Int64("fencing_token", term.FencingToken))
+	termLogger.Info("became scheduler leader", zap.String("instance_id", l.instanceID))
+
+	termCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- lead(termCtx, term)
+	}()
+
+	ended := func(err error, reason string) {
+		if err != nil && !errors.Is(err, context.Canceled) {
+			termLogger.Error("leader work stopped with error", zap.Error(err))
+		}
+		termLogger.Info("leadership ended", zap.String("reason", reason))
+	}
+	// stop ends the term and waits for lead, so a successor never overlaps with it
+	stop := func(reason string) {
+		cancel()
+		ended(<-done, reason)
+	}
+
+	ticker := time.NewTicker(l.renewInterval)
+	defer ticker.Stop()
+
+	renewedAt := lease.RenewedAt
+	for {
+		select {
+		case err := <-done:
+			// lead returned on its own: hand the lease over rather than sit on it
+			cancel()
+			ended(err, "leader work returned")
+			l.release(term)
+			return
+
+		case <-ctx.Done():
+			stop("shutting down")
+			l.release(term)
+			return
+
+		case <-ticker.C:
+			attemptedAt := l.clock.Now()
+			_, err := l.store.RenewLeadership(ctx, l.name, l.instanceID, term.FencingToken, l.leaseDuration)
+			switch {
+			case err == nil:
+				renewedAt = attemptedAt
+			case errors.Is(err, storage.ErrLeadershipLost):
+				stop("lease lost to another instance")
+				return
+			case ctx.Err() == nil:
+				termLogger.Warn("failed to renew leadership", zap.Error(err))
+				// Step down before the lease can expire under us: the next renewal would come too late
+				if !l.clock.Now().Add(l.renewInterval).Before(renewedAt.Add(l.leaseDuration)) {
+					stop("lease could not be renewed before expiring")
+					return
+				}
+			}
+		}
+	}
+}
+
+// release hands the lease over to the standbys. It runs on shutdown, so it does not use the
+// (cancelled) run context.
+func (l *LeaderElector) release(term LeaderTerm) {
+	ctx, cancel := context.WithTimeout(context.Background(), releaseTimeout)
+	defer cancel()
+
+	if err := l.store.ReleaseLeadership(ctx, term.Name, term.InstanceID, term.FencingToken); err != nil {
+		l.logger.Warn("failed to release leadership", zap.Error(err))
+	}
+}
+
+// Fence wraps the engine's store so that schedules are only claimed or reclaimed while term's lease
+// is still the current one. It guards against a leader that was deposed before noticing (e.g. after a
+// long pause): its claims fail with storage.ErrLeadershipLost instead of competing with the new leader.
+func (l *LeaderElector) Fence(db Store, term LeaderTerm) Store {
+	return &fencedStore{Store: db, leaders: l.store, term: term, clock: l.clock}
+}
+
+// fencedStore checks the fencing token of a leader term before each claim.
+type fencedStore struct {
+	Store
+	leaders storage.LeaderStore
+	term    LeaderTerm
+	clock   clock.Clock
+}
+
+func (f *fencedStore) ClaimDueSchedules(ctx context.Context, owner string, limit int, lease time.Duration) ([]storage.ScheduleWithTrigger, error) {
+	if err := f.checkTerm(ctx); err != nil {
+		return nil, err
+	}
+	return f.Store.ClaimDueSchedules(ctx, owner, limit, lease)
+}
+
+func (f *fencedStore) ReclaimExpiredSchedules(ctx context.Context, limit int) ([]storage.ScheduleWithTrigger, error) {
+	if err := f.checkTerm(ctx); err != nil {
+		return nil, err
+	}
+	return f.Store.ReclaimExpiredSchedules(ctx, limit)
+}
+
+// checkTerm returns storage.ErrLeadershipLost unless the term's lease is current and unexpired.
+func (f *fencedStore) checkTerm(ctx context.Context) error {
+	lease, err := f.leaders.GetLeader(ctx, f.term.Name)
+	if err != nil {
+		return fmt.Errorf("check leadership: %w", err)
+	}
+	if lease == nil || lease.Holder != f.term.InstanceID || lease.FencingToken != f.term.FencingToken ||
+		!lease.ExpiresAt.After(f.clock.Now()) {
+		return storage.ErrLeadershipLost
+	}
+	return nil
+}
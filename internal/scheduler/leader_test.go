@@ -0,0 +1,281 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/scheduler"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"go.uber.org/zap"
+)
+
+// The elector renews on a real ticker; leases expire on the manual clock.
+const (
+	testRenewInterval = 5 * time.Millisecond
+	testLeaseDuration = 30 * time.Second
+)
+
+// faultyLeaderStore fails leadership calls on demand and counts the renewals.
+type faultyLeaderStore struct {
+	storage.LeaderStore
+
+	mu         sync.Mutex
+	acquireErr error
+	renewErr   error
+	renewals   int
+}
+
+func (s *faultyLeaderStore) AcquireLeadership(ctx context.Context, name, holder string, ttl time.Duration) (*models.SchedulerLease, bool, error) {
+	s.mu.Lock()
+	err := s.acquireErr
+	s.mu.Unlock()
+	if err != nil {
+		return nil, false, err
+	}
+	return s.LeaderStore.AcquireLeadership(ctx, name, holder, ttl)
+}
+
+func (s *faultyLeaderStore) RenewLeadership(ctx context.Context, name, holder string, token int64, ttl time.Duration) (*models.SchedulerLease, error) {
+	s.mu.Lock()
+	s.renewals++
+	err := s.renewErr
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	return s.LeaderStore.RenewLeadership(ctx, name, holder, token, ttl)
+}
+
+func (s *faultyLeaderStore) set(acquireErr, renewErr error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.acquireErr, s.renewErr = acquireErr, renewErr
+}
+
+func (s *faultyLeaderStore) renewalCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.renewals
+}
+
+// term is a leader term started by LeaderElector.Run, with the context its work runs under.
+type term struct {
+	scheduler.LeaderTerm
+	ctx context.Context
+}
+
+// runElector runs an elector for instanceID until the test ends. Each term it wins is sent on the
+// returned channel; its work runs until the term context is cancelled.
+func runElector(t *testing.T, leaders storage.LeaderStore, clk clock.Clock, instanceID string) <-chan term {
+	t.Helper()
+
+	elector := scheduler.NewLeaderElector(scheduler.LeaderConfig{
+		InstanceID:    instanceID,
+		LeaseDuration: testLeaseDuration,
+		RenewInterval: testRenewInterval,
+		Clock:         clk,
+	}, leaders, zap.NewNop())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	terms := make(chan term, 10)
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		_ = elector.Run(ctx, func(ctx context.Context, leaderTerm scheduler.LeaderTerm) error {
+			terms <- term{LeaderTerm: leaderTerm, ctx: ctx}
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-stopped
+	})
+	return terms
+}
+
+// nextTerm waits for the next term won by an elector.
+func nextTerm(t *testing.T, terms <-chan term) term {
+	t.Helper()
+	select {
+	case started := <-terms:
+		return started
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a leader term")
+		return term{}
+	}
+}
+
+// waitUntil polls cond until it holds.
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting until %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestLeaderElectorRun(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	leaders := &faultyLeaderStore{LeaderStore: store}
+
+	terms := runElector(t, leaders, clk, "a")
+	first := nextTerm(t, terms)
+	if first.InstanceID != "a" || first.Name != storage.SchedulerLeaseName || first.FencingToken != 1 {
+		t.Fatalf("first term = %+v, want instance a on the scheduler lease with fencing token 1", first.LeaderTerm)
+	}
+
+	leader := func() *models.SchedulerLease {
+		lease, err := store.GetLeader(ctx, storage.SchedulerLeaseName)
+		if err != nil {
+			t.Fatalf("GetLeader: %v", err)
+		}
+		return lease
+	}
+
+	// Renewing moves the expiry along with the clock
+	clk.Advance(10 * time.Second)
+	waitUntil(t, "the lease is renewed", func() bool { return leader().RenewedAt.Equal(clk.Now()) })
+	if lease := leader(); lease.Holder != "a" || !lease.ExpiresAt.Equal(clk.Now().Add(testLeaseDuration)) {
+		t.Errorf("lease after renewing = %+v, want held by a until %s", lease, clk.Now().Add(testLeaseDuration))
+	}
+	if _, acquired, err := store.AcquireLeadership(ctx, storage.SchedulerLeaseName, "b", testLeaseDuration); err != nil || acquired {
+		t.Errorf("AcquireLeadership by a standby = %t, %v, want false while the leader renews", acquired, err)
+	}
+
+	// Failed renewals are tolerated while the lease cannot have expired yet
+	leaders.set(nil, errors.New("connection refused"))
+	renewals := leaders.renewalCount()
+	clk.Advance(10 * time.Second)
+	waitUntil(t, "renewals fail", func() bool { return leaders.renewalCount() >= renewals+3 })
+	if err := first.ctx.Err(); err != nil {
+		t.Fatalf("term ended after failed renewals with 20s of its lease left: %v", err)
+	}
+
+	// Once the next renewal would come too late, the term ends before the lease expires
+	clk.Advance(testLeaseDuration - 10*time.Second)
+	waitUntil(t, "the term ends", func() bool { return first.ctx.Err() != nil })
+
+	// The instance campaigns again and starts a new term once the store works
+	leaders.set(nil, nil)
+	clk.Advance(time.Second)
+	second := nextTerm(t, terms)
+	if second.FencingToken <= first.FencingToken {
+		t.Errorf("second term fencing token = %d, want more than %d", second.FencingToken, first.FencingToken)
+	}
+
+	// Another instance takes over the expired lease: the term ends when the renewal finds out
+	leaders.set(errors.New("connection refused"), nil)
+	clk.Advance(testLeaseDuration)
+	takeover, acquired, err := store.AcquireLeadership(ctx, storage.SchedulerLeaseName, "b", testLeaseDuration)
+	if err != nil || !acquired {
+		t.Fatalf("AcquireLeadership of the expired lease = %t, %v, want acquired", acquired, err)
+	}
+	waitUntil(t, "the term ends", func() bool { return second.ctx.Err() != nil })
+
+	// The deposed instance stands by instead of starting another term
+	leaders.set(nil, nil)
+	time.Sleep(10 * testRenewInterval)
+	select {
+	case started := <-terms:
+		t.Fatalf("term %+v started while b holds the lease", started.LeaderTerm)
+	default:
+	}
+	if lease := leader(); lease.Holder != "b" || lease.FencingToken != takeover.FencingToken {
+		t.Errorf("lease = %+v, want still held by b", lease)
+	}
+}
+
+func TestLeaderElectorRunReleasesOnShutdown(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+
+	elector := scheduler.NewLeaderElector(scheduler.LeaderConfig{
+		InstanceID:    "a",
+		LeaseDuration: testLeaseDuration,
+		RenewInterval: testRenewInterval,
+		Clock:         clk,
+	}, store, zap.NewNop())
+
+	runCtx, cancel := context.WithCancel(ctx)
+	started := make(chan struct{})
+	result := make(chan error, 1)
+	go func() {
+		result <- elector.Run(runCtx, func(ctx context.Context, _ scheduler.LeaderTerm) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	}()
+	<-started
+	cancel()
+	if err := <-result; !errors.Is(err, context.Canceled) {
+		t.Errorf("Run = %v, want context.Canceled", err)
+	}
+
+	// A standby takes over right away, without waiting for the lease to expire
+	if _, acquired, err := store.AcquireLeadership(ctx, storage.SchedulerLeaseName, "b", testLeaseDuration); err != nil || !acquired {
+		t.Errorf("AcquireLeadership after shutdown = %t, %v, want acquired", acquired, err)
+	}
+}
+
+func TestLeaderElectorFence(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	createCronTrigger(t, store, clk, "fenced", nil)
+	elector := scheduler.NewLeaderElector(scheduler.LeaderConfig{InstanceID: "a", LeaseDuration: testLeaseDuration, Clock: clk}, store, zap.NewNop())
+
+	acquire := func(holder string) scheduler.LeaderTerm {
+		lease, acquired, err := store.AcquireLeadership(ctx, storage.SchedulerLeaseName, holder, testLeaseDuration)
+		if err != nil || !acquired {
+			t.Fatalf("AcquireLeadership by %s = %t, %v, want acquired", holder, acquired, err)
+		}
+		return scheduler.LeaderTerm{Name: storage.SchedulerLeaseName, InstanceID: holder, FencingToken: lease.FencingToken}
+	}
+	checkWrites := func(name string, fenced scheduler.Store, wantErr error) {
+		t.Helper()
+		if _, err := fenced.ClaimDueSchedules(ctx, "owner", 10, time.Minute); !errors.Is(err, wantErr) {
+			t.Errorf("%s: ClaimDueSchedules err = %v, want %v", name, err, wantErr)
+		}
+		if _, err := fenced.ReclaimExpiredSchedules(ctx, 10); !errors.Is(err, wantErr) {
+			t.Errorf("%s: ReclaimExpiredSchedules err = %v, want %v", name, err, wantErr)
+		}
+	}
+
+	clk.Set(time.Date(2025, 1, 1, 0, 0, 45, 0, time.UTC))
+	stale := elector.Fence(store, acquire("a"))
+	clk.Set(time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC))
+	claimed, err := stale.ClaimDueSchedules(ctx, "a", 10, time.Minute)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("ClaimDueSchedules by the current term = %d schedules, %v, want the due one", len(claimed), err)
+	}
+
+	// An expired term is fenced off even before anyone else takes over
+	clk.Advance(testLeaseDuration)
+	checkWrites("expired term", stale, storage.ErrLeadershipLost)
+
+	// A newer term by another instance
+	current := elector.Fence(store, acquire("b"))
+	checkWrites("stale term after a takeover", stale, storage.ErrLeadershipLost)
+	checkWrites("current term", current, nil)
+
+	// A newer term by the same instance still fences off its old one
+	clk.Advance(testLeaseDuration)
+	renewed := elector.Fence(store, acquire("a"))
+	checkWrites("stale term of the same instance", stale, storage.ErrLeadershipLost)
+	checkWrites("deposed term", current, storage.ErrLeadershipLost)
+	checkWrites("newest term", renewed, nil)
+}
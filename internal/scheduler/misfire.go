@@ -0,0 +1,96 @@
+package scheduler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+)
+
+// maxMisfireScan bounds how many missed occurrences are enumerated for a single schedule.
+// Beyond it the oldest occurrences are dropped as if they exceeded the catch-up limit.
+const maxMisfireScan = 100000
+
+// recurrencePlan is what the engine does with a claimed schedule of a recurring (CRON or
+// interval) trigger, given how far behind it is.
+type recurrencePlan struct {
+	recurrence triggers.Recurrence
+	options    *triggers.RecurrenceOptions
+	occurrence time.Time // occurrence of the claimed schedule
+	behind     bool      // the occurrence after this schedule is already due
+	skip       bool      // do not fire this schedule (misfire policy "skip")
+	next       time.Time // occurrence of the schedule to create after this one (before its offset); zero when exhausted
+	dropped    int       // missed occurrences that will never fire
+}
+
+// planRecurringSchedule applies the trigger's misfire policy to a claimed schedule.
+//
+// The scheduler is considered behind when the occurrence following the schedule's occurrence
+// is already due, i.e. at least one further occurrence was missed (scheduler downtime,
+// paused processing, long retries):
+//   - fire_once: fire the overdue schedule once, continue from the first future occurrence
+//   - fire_all_missed: fire it and chain through the missed occurrences, firing at most
+//     MisfireCatchupLimit occurrences in total (the overdue one plus the most recent missed ones)
+//   - skip: do not fire the overdue schedule, continue from the first future occurrence
+func planRecurringSchedule(trigger *models.Trigger, schedule models.TriggerSchedule, now time.Time) (recurrencePlan, error) {
+	recurrence, options, err := triggers.ParseRecurrence(trigger)
+	if err != nil {
+		return recurrencePlan{}, fmt.Errorf("failed to parse recurrence: %w", err)
+	}
+
+	// Occurrences are due once their offset fire time passes. A spread offset is fixed per trigger,
+	// so shift now back by it; jitter is random per occurrence, so only its lower bound (0) is known.
+	now = now.Add(-options.Offset(trigger.ID, noJitter))
+
+	following := recurrence.Next(schedule.Occurrence())
+	plan := recurrencePlan{
+		recurrence: recurrence,
+		options:    options,
+		occurrence: schedule.Occurrence(),
+		behind:     !following.IsZero() && !following.After(now),
+		next:       recurrence.Next(now),
+	}
+	if !plan.behind {
+		plan.next = following
+		return plan, nil
+	}
+
+	switch options.MisfirePolicy {
+	case models.MisfirePolicySkip:
+		plan.skip = true
+	case models.MisfirePolicyFireAllMissed:
+		plan.next, plan.dropped = firstReplayedOccurrence(recurrence, following, now, options.MisfireCatchupLimit-1)
+	}
+
+	return plan, nil
+}
+
+// noJitter is a random source that always returns the smallest jitter.
+func noJitter() float64 { return 0 }
+
+// firstReplayedOccurrence walks the missed occurrences starting at first (all <= now) and returns
+// the oldest one within the newest `limit` occurrences, plus how many older ones are dropped.
+// With no room left to replay (limit 0), it returns the first occurrence after now.
+func firstReplayedOccurrence(recurrence triggers.Recurrence, first, now time.Time, limit int) (time.Time, int) {
+	window := make([]time.Time, 0, limit)
+	total := 0
+
+	t := first
+	for ; !t.IsZero() && !t.After(now) && total < maxMisfireScan; t = recurrence.Next(t) {
+		if len(window) == limit {
+			if limit == 0 {
+				total++
+				continue
+			}
+			window = window[1:]
+		}
+		window = append(window, t)
+		total++
+	}
+
+	if len(window) == 0 {
+		return recurrence.Next(now), total
+	}
+	return window[0], total - len(window)
+}
@@ -0,0 +1,89 @@
+package scheduler
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
+
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"go.uber.org/zap"
+)
+
+// DefaultWorkers is the number of schedules fired in parallel when Config.Workers is unset.
+const DefaultWorkers = 8
+
+// Stats is a point-in-time view of the engine's worker pool, intended for monitoring.
+type Stats struct {
+	Workers  int   `json:"workers"`
+	Queued   int64 `json:"queued"`    // claimed schedules waiting for a worker
+	InFlight int64 `json:"in_flight"` // schedules currently being fired
+}
+
+// poolCounters tracks the work handed to the worker pool.
+type poolCounters struct {
+	queued   atomic.Int64
+	inFlight atomic.Int64
+}
+
+// Stats returns the current worker pool counters.
+func (e *Engine) Stats() Stats {
+	return Stats{
+		Workers:  e.workers,
+		Queued:   e.counters.queued.Load(),
+		InFlight: e.counters.inFlight.Load(),
+	}
+}
+
+// dispatchBatch fires a claimed batch on the worker pool and blocks until every schedule is done.
+// Schedules are partitioned by trigger ID, so all occurrences of one trigger go to the same worker
+// and fire in fire_at order, while different triggers proceed in parallel.
+func (e *Engine) dispatchBatch(ctx context.Context, schedules []storage.ScheduleWithTrigger) (successCount, failureCount int) {
+	queues := make([][]storage.ScheduleWithTrigger, e.workers)
+	for _, schedule := range schedules {
+		idx := workerIndex(schedule.Trigger.ID, e.workers)
+		queues[idx] = append(queues[idx], schedule)
+	}
+
+	var succeeded, failed atomic.Int64
+	var wg sync.WaitGroup
+
+	e.counters.queued.Add(int64(len(schedules)))
+	for _, queue := range queues {
+		if len(queue) == 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(queue []storage.ScheduleWithTrigger) {
+			defer wg.Done()
+
+			for _, schedule := range queue {
+				e.counters.queued.Add(-1)
+				e.counters.inFlight.Add(1)
+				err := e.processSchedule(ctx, schedule)
+				e.counters.inFlight.Add(-1)
+
+				if err != nil {
+					e.logger.Error("failed to process schedule",
+						zap.String("schedule_id", schedule.Schedule.ID),
+						zap.String("trigger_id", schedule.Trigger.ID),
+						zap.Error(err))
+					failed.Add(1)
+				} else {
+					succeeded.Add(1)
+				}
+			}
+		}(queue)
+	}
+
+	wg.Wait()
+	return int(succeeded.Load()), int(failed.Load())
+}
+
+// workerIndex maps a trigger ID onto a worker so that a trigger is always served by the same worker.
+func workerIndex(triggerID string, workers int) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(triggerID))
+	return int(h.Sum32() % uint32(workers))
+}
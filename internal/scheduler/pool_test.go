@@ -0,0 +1,159 @@
+package scheduler_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/scheduler"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
+	"go.uber.org/zap"
+)
+
+// gatedFirer holds every fire until it is released, recording the occurrences each trigger fired in
+// order and whether two fires of one trigger ever ran at once.
+type gatedFirer struct {
+	service *events.Service
+	release chan struct{}
+
+	mu          sync.Mutex
+	holding     int
+	started     int
+	active      map[string]int
+	overlapping map[string]bool
+	fired       map[string][]time.Time
+}
+
+func (f *gatedFirer) FireScheduledTrigger(ctx context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule, payload map[string]interface{}) (string, error) {
+	f.mu.Lock()
+	f.holding++
+	f.started++
+	f.active[trigger.ID]++
+	if f.active[trigger.ID] > 1 {
+		f.overlapping[trigger.ID] = true
+	}
+	f.fired[trigger.ID] = append(f.fired[trigger.ID], *schedule.ScheduledFor)
+	f.mu.Unlock()
+
+	<-f.release
+
+	f.mu.Lock()
+	f.holding--
+	f.active[trigger.ID]--
+	f.mu.Unlock()
+
+	return f.service.FireScheduledTrigger(ctx, trigger, schedule, payload)
+}
+
+// held returns how many fires are waiting for release and how many started in total.
+func (f *gatedFirer) held() (holding, started int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.holding, f.started
+}
+
+// TestEnginePoolKeepsPerTriggerOrder fires a batch of interleaved schedules of several triggers on
+// a pool with fewer workers than triggers, and checks that each trigger's schedules fire one at a
+// time and in order, and that the pool counters account for every claimed schedule.
+func TestEnginePoolKeepsPerTriggerOrder(t *testing.T) {
+	const (
+		workers   = 4
+		triggerN  = 7
+		backfills = 3
+		total     = triggerN * (1 + backfills) // each trigger's first schedule plus its backfills
+	)
+
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	firer := &gatedFirer{
+		service:     events.NewService(store, platformEvents.NewMemoryPublisher(total), clk, zap.NewNop()),
+		release:     make(chan struct{}),
+		active:      map[string]int{},
+		overlapping: map[string]bool{},
+		fired:       map[string][]time.Time{},
+	}
+	engine := scheduler.NewEngine(scheduler.Config{Tick: time.Second, InstanceID: "engine", Workers: workers, Clock: clk}, store, firer, zap.NewNop())
+	service := triggers.NewService(store, clk, zap.NewNop())
+
+	var triggerIDs []string
+	for i := range triggerN {
+		triggerIDs = append(triggerIDs, createCronTrigger(t, store, clk, fmt.Sprintf("trigger-%d", i), nil))
+	}
+	// The first schedules are due at 00:01; the backfills of all triggers fire at the same
+	// seconds, so the claimed batch interleaves the triggers
+	clk.Set(time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC))
+	from := time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC)
+	for _, triggerID := range triggerIDs {
+		if _, err := service.Backfill(ctx, triggerID, models.BackfillRequest{From: from, To: from.Add(backfills * time.Minute)}); err != nil {
+			t.Fatalf("Backfill: %v", err)
+		}
+	}
+	clk.Advance(backfills * time.Second)
+
+	if stats := engine.Stats(); stats != (scheduler.Stats{Workers: workers}) {
+		t.Fatalf("Stats before firing = %+v, want an idle pool of %d workers", stats, workers)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		engine.RunOnce(ctx)
+	}()
+
+	// Once every busy worker waits in the firer, the counters hold the whole batch
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		holding, started := firer.held()
+		stats := engine.Stats()
+		if holding > 0 && stats.InFlight == int64(holding) && stats.Queued == int64(total-started) {
+			if holding > workers {
+				t.Fatalf("%d schedules fire at once on %d workers", holding, workers)
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for the workers: stats %+v, %d fires held, %d started", stats, holding, started)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for range total {
+		firer.release <- struct{}{}
+	}
+	<-done
+
+	if stats := engine.Stats(); stats != (scheduler.Stats{Workers: workers}) {
+		t.Errorf("Stats after firing = %+v, want an idle pool of %d workers", stats, workers)
+	}
+
+	wantFired := []time.Time{
+		time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 10, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 11, 0, 0, time.UTC),
+		time.Date(2025, 1, 1, 0, 12, 0, 0, time.UTC),
+	}
+	for _, triggerID := range triggerIDs {
+		if firer.overlapping[triggerID] {
+			t.Errorf("trigger %s fired two schedules at once", triggerID)
+		}
+		fired := firer.fired[triggerID]
+		if len(fired) != len(wantFired) {
+			t.Errorf("trigger %s fired %v, want %v", triggerID, fired, wantFired)
+			continue
+		}
+		for i := range wantFired {
+			if !fired[i].Equal(wantFired[i]) {
+				t.Errorf("trigger %s fired %v, want %v in order", triggerID, fired, wantFired)
+				break
+			}
+		}
+	}
+}
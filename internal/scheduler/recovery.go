@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"go.uber.org/zap"
+)
+
+// startLeaseHeartbeat renews the lease on a claimed schedule until the returned stop function is called.
+func (e *Engine) startLeaseHeartbeat(ctx context.Context, scheduleID string) func() {
+	heartbeatCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(e.leaseDuration / 3)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				err := e.db.ExtendScheduleLease(heartbeatCtx, scheduleID, e.instanceID, e.leaseDuration)
+				if errors.Is(err, storage.ErrScheduleLeaseLost) {
+					e.logger.Warn("lost lease on schedule while processing it",
+						zap.String("schedule_id", scheduleID),
+						zap.String("instance_id", e.instanceID))
+					return
+				}
+				if err != nil && heartbeatCtx.Err() == nil {
+					e.logger.Error("failed to extend schedule lease",
+						zap.String("schedule_id", scheduleID),
+						zap.Error(err))
+				}
+			case <-heartbeatCtx.Done():
+				return
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// runReaper periodically returns schedules with expired leases to 'pending'.
+// Expired leases mean the owning instance crashed (or stalled) mid-processing.
+func (e *Engine) runReaper(ctx context.Context) {
+	interval := e.leaseDuration / 2
+	if interval < time.Second {
+		interval = time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			e.reclaimExpiredLeases(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reclaimExpiredLeases recovers expired schedules and logs each recovery. Nothing is written to the
+// event log: the schedule fires again as usual, and its event records the outcome of that fire.
+func (e *Engine) reclaimExpiredLeases(ctx context.Context) {
+	recovered, err := e.db.ReclaimExpiredSchedules(ctx, 100)
+	if err != nil {
+		e.logger.Error("failed to reclaim expired schedule leases", zap.Error(err))
+		return
+	}
+
+	for _, s := range recovered {
+		claimedBy := "unknown"
+		if s.Schedule.ClaimedBy != nil {
+			claimedBy = *s.Schedule.ClaimedBy
+		}
+
+		fields := []zap.Field{
+			zap.String("schedule_id", s.Schedule.ID),
+			zap.String("trigger_id", s.Trigger.ID),
+			zap.String("claimed_by", claimedBy),
+			zap.Time("fire_at", s.Schedule.FireAt),
+		}
+		if s.Schedule.ScheduledFor != nil {
+			fields = append(fields, zap.Time("scheduled_for", *s.Schedule.ScheduledFor))
+		}
+		e.logger.Warn("recovered schedule with expired lease: its owner stopped before processing finished, returned to pending", fields...)
+	}
+}
@@ -0,0 +1,69 @@
+package scheduler_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/events"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/scheduler"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	platformEvents "github.com/dhima/event-trigger-platform/platform/events"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// TestEngineRecoversExpiredLeases leaves a schedule claimed by an instance that never finishes it,
+// and checks that once the lease expires the engine logs the recovery and fires the schedule, with
+// only that fire in the event log.
+func TestEngineRecoversExpiredLeases(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	store := memory.NewStore(clk)
+	service := events.NewService(store, platformEvents.NewMemoryPublisher(10), clk, zap.NewNop())
+	core, logs := observer.New(zapcore.WarnLevel)
+	engine := scheduler.NewEngine(scheduler.Config{Tick: time.Second, InstanceID: "engine", LeaseDuration: 30 * time.Second, Clock: clk}, store, service, zap.New(core))
+
+	triggerID := createCronTrigger(t, store, clk, "recovered", nil)
+	occurrence := time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC)
+	clk.Set(occurrence)
+	if claimed, err := store.ClaimDueSchedules(ctx, "crashed", 10, 30*time.Second); err != nil || len(claimed) != 1 {
+		t.Fatalf("ClaimDueSchedules = %d schedules, %v, want the due one", len(claimed), err)
+	}
+
+	// The lease is still held: nothing is recovered or fired
+	engine.RunOnce(ctx)
+	if n := logs.Len(); n != 0 {
+		t.Fatalf("logged %d warnings before the lease expired, want none", n)
+	}
+
+	clk.Advance(31 * time.Second)
+	engine.RunOnce(ctx)
+
+	entries := logs.FilterMessageSnippet("recovered schedule with expired lease").All()
+	if len(entries) != 1 {
+		t.Fatalf("logged %d recoveries, want 1", len(entries))
+	}
+	fields := entries[0].ContextMap()
+	if fields["trigger_id"] != triggerID || fields["claimed_by"] != "crashed" {
+		t.Errorf("recovery fields = %v, want trigger %s claimed by crashed", fields, triggerID)
+	}
+	if scheduledFor, ok := fields["scheduled_for"].(time.Time); !ok || !scheduledFor.Equal(occurrence) {
+		t.Errorf("recovery scheduled_for = %v, want %s", fields["scheduled_for"], occurrence)
+	}
+
+	eventLogs, total, err := store.ListEventLogs(ctx, models.ListEventsQuery{TriggerID: triggerID, Limit: 100})
+	if err != nil {
+		t.Fatalf("ListEventLogs: %v", err)
+	}
+	if total != 1 {
+		t.Fatalf("trigger has %d events, want only the fire of the recovered schedule", total)
+	}
+	eventLog := eventLogs[0]
+	if eventLog.ExecutionStatus != models.ExecutionStatusSuccess || eventLog.ScheduledFor == nil || !eventLog.ScheduledFor.Equal(occurrence) {
+		t.Errorf("event = %s for %v, want success for %s", eventLog.ExecutionStatus, eventLog.ScheduledFor, occurrence)
+	}
+}
@@ -0,0 +1,251 @@
+// Package simulation runs the scheduler engine against an in-memory store on a virtual timeline,
+// so misfires, retries and DST transitions can be reproduced deterministically.
+//
+// Typical use:
+//
+//	h := simulation.New(time.Date(2025, 3, 8, 0, 0, 0, 0, time.UTC), nil)
+//	id, _ := h.CreateTrigger(ctx, "nightly", models.TriggerTypeCronScheduled, map[string]interface{}{
+//		"cron": "30 2 * * *", "timezone": "America/New_York", "endpoint": "https://example.com",
+//	})
+//	h.RunUntil(ctx, time.Date(2025, 3, 11, 0, 0, 0, 0, time.UTC))
+//	err := h.ExpectFired(
+//		simulation.Fired(id, time.Date(2025, 3, 8, 7, 30, 0, 0, time.UTC)),
+//		...
+//	)
+//
+// The engine runs with a single worker, a seeded jitter source and is stepped explicitly
+// (Engine.RunOnce), so the sequence of fired events is fully determined by the triggers and
+// the timeline.
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/scheduler"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// instanceID is the owner the simulated engine claims schedules under.
+const instanceID = "simulation"
+
+// maxPassesPerInstant stops RunUntil if schedules stay due without being fired.
+const maxPassesPerInstant = 10000
+
+// ErrInjectedFailure is returned by the simulated firer for failures requested with FailNext.
+var ErrInjectedFailure = errors.New("injected fire failure")
+
+// FiredEvent is one successful trigger firing observed by the harness.
+type FiredEvent struct {
+	TriggerID    string
+	ScheduledFor time.Time // occurrence of the schedule that fired (before any jitter/spread offset)
+	FiredAt      time.Time // virtual time at which it fired
+}
+
+// Fired builds the expected event for a trigger that fires exactly on time.
+func Fired(triggerID string, at time.Time) FiredEvent {
+	return FiredEvent{TriggerID: triggerID, ScheduledFor: at.UTC(), FiredAt: at.UTC()}
+}
+
+// FiredLate builds the expected event for an occurrence scheduled for scheduledFor that fired at firedAt.
+func FiredLate(triggerID string, scheduledFor, firedAt time.Time) FiredEvent {
+	return FiredEvent{TriggerID: triggerID, ScheduledFor: scheduledFor.UTC(), FiredAt: firedAt.UTC()}
+}
+
+func (e FiredEvent) String() string {
+	return fmt.Sprintf("%s scheduled_for=%s fired_at=%s",
+		e.TriggerID, e.ScheduledFor.Format(time.RFC3339), e.FiredAt.Format(time.RFC3339))
+}
+
+// Harness wires an engine, an in-memory store and a recording firer to a manual clock.
+type Harness struct {
+	Clock  *clock.Manual
+	Store  *memory.Store
+	Engine *scheduler.Engine
+	firer  *recordingFirer
+	jitter func() float64
+}
+
+// New creates a harness whose virtual clock starts at start. A nil logger discards engine logs.
+func New(start time.Time, logger *zap.Logger) *Harness {
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+
+	clk := clock.NewManual(start.UTC())
+	store := memory.NewStore(clk)
+	firer := &recordingFirer{clock: clk, store: store, failures: make(map[string]int)}
+	jitter := rand.New(rand.NewSource(1)).Float64
+	engine := scheduler.NewEngine(scheduler.Config{
+		Tick:       5 * time.Second,
+		InstanceID: instanceID,
+		Workers:    1,
+		Clock:      clk,
+		Jitter:     jitter,
+	}, store, firer, logger)
+
+	return &Harness{
+		Clock:  clk,
+		Store:  store,
+		Engine: engine,
+		firer:  firer,
+		jitter: jitter,
+	}
+}
+
+// CreateTrigger validates config like the API does and stores the trigger with its first
+// schedule, computed from the current virtual time. Returns the trigger ID.
+func (h *Harness) CreateTrigger(ctx context.Context, name string, triggerType models.TriggerType, config interface{}) (string, error) {
+	raw, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("marshal trigger config: %w", err)
+	}
+
+	trigger := models.Trigger{
+		ID:     uuid.New().String(),
+		Name:   name,
+		Type:   triggerType,
+		Status: models.TriggerStatusActive,
+	}
+
+	normalized, schedule, err := triggers.PrepareConfig(triggerType, trigger.ID, raw, h.Clock.Now(), h.jitter)
+	if err != nil {
+		return "", err
+	}
+	trigger.Config = normalized
+
+	if err := h.Store.CreateTrigger(ctx, &trigger, schedule); err != nil {
+		return "", err
+	}
+	return trigger.ID, nil
+}
+
+// FailNext makes the next n fire attempts of a trigger fail, to exercise retries.
+func (h *Harness) FailNext(triggerID string, n int) {
+	h.firer.mu.Lock()
+	defer h.firer.mu.Unlock()
+	h.firer.failures[triggerID] += n
+}
+
+// Step runs one scheduling pass at the current virtual time.
+func (h *Harness) Step(ctx context.Context) {
+	h.Engine.RunOnce(ctx)
+}
+
+// Advance moves the clock forward by d without running the engine in between (as if the
+// scheduler was down), then runs one scheduling pass.
+func (h *Harness) Advance(ctx context.Context, d time.Duration) {
+	h.Clock.Advance(d)
+	h.Step(ctx)
+}
+
+// RunUntil runs the engine up to and including until, jumping the clock from one due time to
+// the next, so every schedule fires exactly when it becomes due. Schedules that become due
+// during a pass (e.g. missed occurrences being replayed) are picked up at the same instant.
+func (h *Harness) RunUntil(ctx context.Context, until time.Time) error {
+	passes := 0
+	for {
+		h.Step(ctx)
+
+		next, err := h.Store.NextDueTime(ctx)
+		if err != nil {
+			return err
+		}
+
+		now := h.Clock.Now()
+		if next == nil || next.After(until) {
+			if until.After(now) {
+				h.Clock.Set(until)
+				h.Step(ctx)
+			}
+			return nil
+		}
+
+		if !next.After(now) {
+			if passes++; passes >= maxPassesPerInstant {
+				return fmt.Errorf("no progress after %d passes at %s", passes, now.Format(time.RFC3339))
+			}
+			continue
+		}
+		passes = 0
+		h.Clock.Set(*next)
+	}
+}
+
+// Fired returns the successful firings so far, in order.
+func (h *Harness) Fired() []FiredEvent {
+	h.firer.mu.Lock()
+	defer h.firer.mu.Unlock()
+	return append([]FiredEvent(nil), h.firer.fired...)
+}
+
+// ExpectFired compares the firings so far with expected and describes the first difference.
+func (h *Harness) ExpectFired(expected ...FiredEvent) error {
+	actual := h.Fired()
+
+	for i := 0; i < len(expected) || i < len(actual); i++ {
+		switch {
+		case i >= len(actual):
+			return fmt.Errorf("event %d: expected %s, got nothing\n%s", i, expected[i], describe(actual))
+		case i >= len(expected):
+			return fmt.Errorf("event %d: unexpected %s\n%s", i, actual[i], describe(actual))
+		case !sameEvent(expected[i], actual[i]):
+			return fmt.Errorf("event %d: expected %s, got %s\n%s", i, expected[i], actual[i], describe(actual))
+		}
+	}
+	return nil
+}
+
+func sameEvent(a, b FiredEvent) bool {
+	return a.TriggerID == b.TriggerID && a.ScheduledFor.Equal(b.ScheduledFor) && a.FiredAt.Equal(b.FiredAt)
+}
+
+func describe(events []FiredEvent) string {
+	var b strings.Builder
+	b.WriteString("fired events:")
+	for i, e := range events {
+		fmt.Fprintf(&b, "\n  %d: %s", i, e)
+	}
+	return b.String()
+}
+
+// recordingFirer stands in for events.Service: it records firings instead of publishing them, and
+// completes the fired schedule as the service does.
+type recordingFirer struct {
+	mu       sync.Mutex
+	clock    clock.Clock
+	store    *memory.Store
+	failures map[string]int
+	fired    []FiredEvent
+}
+
+func (f *recordingFirer) FireScheduledTrigger(ctx context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule, _ map[string]interface{}) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.failures[trigger.ID] > 0 {
+		f.failures[trigger.ID]--
+		return "", ErrInjectedFailure
+	}
+	if err := f.store.UpdateScheduleStatus(ctx, schedule.ID, *schedule.ClaimedBy, models.ScheduleStatusCompleted); err != nil {
+		return "", err
+	}
+
+	f.fired = append(f.fired, FiredEvent{
+		TriggerID:    trigger.ID,
+		ScheduledFor: schedule.Occurrence().UTC(),
+		FiredAt:      f.clock.Now().UTC(),
+	})
+	return uuid.New().String(), nil
+}
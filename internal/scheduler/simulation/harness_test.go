@@ -0,0 +1,271 @@
+package simulation_test
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/scheduler/simulation"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"github.com/google/uuid"
+)
+
+// scenario sets up triggers (and any downtime) on a fresh harness and returns the firings
+// expected once the harness has run until the scenario's end.
+type scenario struct {
+	name  string
+	start time.Time
+	until time.Time
+	setup func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error)
+}
+
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestHarness(t *testing.T) {
+	scenarios := []scenario{
+		{
+			name:  "misfire skip drops the overdue occurrence",
+			start: utc(2025, 1, 1, 0, 0),
+			until: utc(2025, 1, 1, 5, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "hourly", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "0 * * * *", "endpoint": "https://example.com", "misfire_policy": "skip",
+				})
+				if err != nil {
+					return nil, err
+				}
+				h.Advance(ctx, 3*time.Hour+30*time.Minute)
+				return []simulation.FiredEvent{
+					simulation.Fired(id, utc(2025, 1, 1, 4, 0)),
+					simulation.Fired(id, utc(2025, 1, 1, 5, 0)),
+				}, nil
+			},
+		},
+		{
+			name:  "misfire fire_once fires the overdue occurrence late",
+			start: utc(2025, 1, 1, 0, 0),
+			until: utc(2025, 1, 1, 5, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "hourly", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "0 * * * *", "endpoint": "https://example.com", "misfire_policy": "fire_once",
+				})
+				if err != nil {
+					return nil, err
+				}
+				h.Advance(ctx, 3*time.Hour+30*time.Minute)
+				return []simulation.FiredEvent{
+					simulation.FiredLate(id, utc(2025, 1, 1, 1, 0), utc(2025, 1, 1, 3, 30)),
+					simulation.Fired(id, utc(2025, 1, 1, 4, 0)),
+					simulation.Fired(id, utc(2025, 1, 1, 5, 0)),
+				}, nil
+			},
+		},
+		{
+			name:  "misfire fire_all_missed replays up to the catch-up limit",
+			start: utc(2025, 1, 1, 0, 0),
+			until: utc(2025, 1, 1, 5, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "hourly", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "0 * * * *", "endpoint": "https://example.com",
+					"misfire_policy": "fire_all_missed", "misfire_catchup_limit": 2,
+				})
+				if err != nil {
+					return nil, err
+				}
+				// 01:00 is overdue and 02:00 and 03:00 were missed: the limit keeps 01:00 and 03:00
+				h.Advance(ctx, 3*time.Hour+30*time.Minute)
+				return []simulation.FiredEvent{
+					simulation.FiredLate(id, utc(2025, 1, 1, 1, 0), utc(2025, 1, 1, 3, 30)),
+					simulation.FiredLate(id, utc(2025, 1, 1, 3, 0), utc(2025, 1, 1, 3, 30)),
+					simulation.Fired(id, utc(2025, 1, 1, 4, 0)),
+					simulation.Fired(id, utc(2025, 1, 1, 5, 0)),
+				}, nil
+			},
+		},
+		{
+			name:  "failed fires are retried with backoff",
+			start: utc(2025, 1, 1, 0, 0),
+			until: utc(2025, 1, 1, 1, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "once", models.TriggerTypeTimeScheduled, map[string]interface{}{
+					"run_at": "2025-01-01T00:10:00Z", "endpoint": "https://example.com",
+					"retry_policy": map[string]interface{}{"max_attempts": 5, "base_delay": "1m", "max_delay": "10m"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				h.FailNext(id, 2)
+
+				// The harness seeds its jitter source with 1; only the two backoffs draw from it
+				policy := triggers.RetryPolicy{MaxAttempts: 5, BaseDelay: time.Minute, MaxDelay: 10 * time.Minute}
+				jitter := rand.New(rand.NewSource(1)).Float64
+				firedAt := utc(2025, 1, 1, 0, 10).Add(policy.Backoff(1, jitter())).Add(policy.Backoff(2, jitter()))
+				return []simulation.FiredEvent{
+					simulation.FiredLate(id, utc(2025, 1, 1, 0, 10), firedAt),
+				}, nil
+			},
+		},
+		{
+			name:  "a schedule is cancelled once its attempts run out",
+			start: utc(2025, 1, 1, 0, 0),
+			until: utc(2025, 1, 1, 1, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "once", models.TriggerTypeTimeScheduled, map[string]interface{}{
+					"run_at": "2025-01-01T00:10:00Z", "endpoint": "https://example.com",
+					"retry_policy": map[string]interface{}{"max_attempts": 2, "base_delay": "1m"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				h.FailNext(id, 2)
+				return nil, nil
+			},
+		},
+		{
+			name:  "spring forward skips the nonexistent time by default",
+			start: utc(2025, 3, 8, 0, 0),
+			until: utc(2025, 3, 11, 0, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "nightly", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "30 2 * * *", "timezone": "America/New_York", "endpoint": "https://example.com",
+				})
+				if err != nil {
+					return nil, err
+				}
+				return []simulation.FiredEvent{
+					simulation.Fired(id, utc(2025, 3, 8, 7, 30)),  // 02:30 EST
+					simulation.Fired(id, utc(2025, 3, 10, 6, 30)), // 02:30 EDT
+				}, nil
+			},
+		},
+		{
+			name:  "spring forward shift_forward runs the nonexistent time an hour later",
+			start: utc(2025, 3, 8, 0, 0),
+			until: utc(2025, 3, 11, 0, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "nightly", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "30 2 * * *", "timezone": "America/New_York", "endpoint": "https://example.com",
+					"dst_policy": map[string]interface{}{"spring_forward": "shift_forward"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return []simulation.FiredEvent{
+					simulation.Fired(id, utc(2025, 3, 8, 7, 30)),
+					simulation.Fired(id, utc(2025, 3, 9, 7, 30)), // 03:30 EDT
+					simulation.Fired(id, utc(2025, 3, 10, 6, 30)),
+				}, nil
+			},
+		},
+		{
+			name:  "fall back runs the repeated time twice by default",
+			start: utc(2025, 11, 1, 0, 0),
+			until: utc(2025, 11, 4, 0, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "nightly", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "30 1 * * *", "timezone": "America/New_York", "endpoint": "https://example.com",
+				})
+				if err != nil {
+					return nil, err
+				}
+				return []simulation.FiredEvent{
+					simulation.Fired(id, utc(2025, 11, 1, 5, 30)),
+					simulation.Fired(id, utc(2025, 11, 2, 5, 30)), // 01:30 EDT
+					simulation.Fired(id, utc(2025, 11, 2, 6, 30)), // 01:30 EST
+					simulation.Fired(id, utc(2025, 11, 3, 6, 30)),
+				}, nil
+			},
+		},
+		{
+			name:  "fall back run_once runs the repeated time once",
+			start: utc(2025, 11, 1, 0, 0),
+			until: utc(2025, 11, 4, 0, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				id, err := h.CreateTrigger(ctx, "nightly", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "30 1 * * *", "timezone": "America/New_York", "endpoint": "https://example.com",
+					"dst_policy": map[string]interface{}{"fall_back": "run_once"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				return []simulation.FiredEvent{
+					simulation.Fired(id, utc(2025, 11, 1, 5, 30)),
+					simulation.Fired(id, utc(2025, 11, 2, 5, 30)),
+					simulation.Fired(id, utc(2025, 11, 3, 6, 30)),
+				}, nil
+			},
+		},
+		{
+			name:  "calendar skip drops occurrences on holidays",
+			start: utc(2025, 1, 1, 0, 0),
+			until: utc(2025, 1, 4, 0, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				err := h.Store.CreateCalendar(ctx, &models.Calendar{
+					ID: uuid.New().String(), Name: "holidays", Timezone: "UTC", Holidays: []string{"2025-01-02"},
+				})
+				if err != nil {
+					return nil, err
+				}
+				id, err := h.CreateTrigger(ctx, "daily", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "0 9 * * *", "endpoint": "https://example.com",
+					"calendars": []string{"holidays"}, "calendar_policy": "skip",
+				})
+				if err != nil {
+					return nil, err
+				}
+				return []simulation.FiredEvent{
+					simulation.Fired(id, utc(2025, 1, 1, 9, 0)),
+					simulation.Fired(id, utc(2025, 1, 3, 9, 0)),
+				}, nil
+			},
+		},
+		{
+			name:  "calendar defer fires when the blackout window ends",
+			start: utc(2025, 1, 1, 0, 0),
+			until: utc(2025, 1, 4, 0, 0),
+			setup: func(ctx context.Context, h *simulation.Harness) ([]simulation.FiredEvent, error) {
+				err := h.Store.CreateCalendar(ctx, &models.Calendar{
+					ID: uuid.New().String(), Name: "maintenance", Timezone: "UTC",
+					Windows: []models.ExclusionWindow{{Name: "upgrade", Start: "2025-01-02T00:00:00Z", End: "2025-01-02T12:00:00Z"}},
+				})
+				if err != nil {
+					return nil, err
+				}
+				id, err := h.CreateTrigger(ctx, "daily", models.TriggerTypeCronScheduled, map[string]interface{}{
+					"cron": "0 9 * * *", "endpoint": "https://example.com",
+					"calendars": []string{"maintenance"}, "calendar_policy": "defer",
+				})
+				if err != nil {
+					return nil, err
+				}
+				return []simulation.FiredEvent{
+					simulation.Fired(id, utc(2025, 1, 1, 9, 0)),
+					simulation.FiredLate(id, utc(2025, 1, 2, 9, 0), utc(2025, 1, 2, 12, 0)),
+					simulation.Fired(id, utc(2025, 1, 3, 9, 0)),
+				}, nil
+			},
+		},
+	}
+
+	for _, sc := range scenarios {
+		t.Run(sc.name, func(t *testing.T) {
+			ctx := context.Background()
+			h := simulation.New(sc.start, nil)
+
+			expected, err := sc.setup(ctx, h)
+			if err != nil {
+				t.Fatalf("setup: %v", err)
+			}
+			if err := h.RunUntil(ctx, sc.until); err != nil {
+				t.Fatalf("RunUntil: %v", err)
+			}
+			if err := h.ExpectFired(expected...); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
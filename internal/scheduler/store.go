@@ -0,0 +1,29 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// Store is the persistence the engine needs to claim, fire and complete schedules.
+// Any storage.Store satisfies it (storage.MySQLClient in production, memory.Store in simulations).
+type Store interface {
+	storage.ScheduleStore
+	DeactivateTrigger(ctx context.Context, triggerID string) error
+	IncrementTriggerFireCount(ctx context.Context, triggerID string) (int, error)
+	CreateEventLog(ctx context.Context, eventLog *models.EventLog) error
+	GetRunningEventLog(ctx context.Context, triggerID string) (*models.EventLog, error)
+	ReplaceRunningEventLogs(ctx context.Context, triggerID string, reason string) (int, error)
+	ExpireRunningEventLogs(ctx context.Context, triggerID string, firedBefore time.Time, reason string) (int, error)
+	GetCalendarByName(ctx context.Context, name string) (*models.Calendar, error)
+}
+
+// TriggerFirer fires a trigger for a claimed schedule and returns the event ID. The schedule is
+// completed in the same transaction that records the event; on error it is left as it was.
+// events.Service implements it in production.
+type TriggerFirer interface {
+	FireScheduledTrigger(ctx context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule, payload map[string]interface{}) (string, error)
+}
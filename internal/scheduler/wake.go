@@ -0,0 +1,97 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultWakePollInterval is how often the notification feed is checked when Config.WakePollInterval is unset.
+const DefaultWakePollInterval = 500 * time.Millisecond
+
+// notificationBatchSize bounds how many notifications are read per poll of the feed.
+const notificationBatchSize = 500
+
+// wakeup carries the earliest requested wake-up time from Wake callers to the Run loop.
+type wakeup struct {
+	mu     sync.Mutex
+	at     time.Time
+	signal chan struct{}
+}
+
+func newWakeup() *wakeup {
+	return &wakeup{signal: make(chan struct{}, 1)}
+}
+
+// request records at (keeping the earliest pending request) and signals the Run loop.
+func (w *wakeup) request(at time.Time) {
+	w.mu.Lock()
+	if w.at.IsZero() || at.Before(w.at) {
+		w.at = at
+	}
+	w.mu.Unlock()
+
+	select {
+	case w.signal <- struct{}{}:
+	default:
+	}
+}
+
+// take returns and clears the earliest pending request.
+func (w *wakeup) take() time.Time {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	at := w.at
+	w.at = time.Time{}
+	return at
+}
+
+// Wake tells the engine that a schedule is due at fireAt. If the engine is sleeping past
+// fireAt it wakes up in time for it; otherwise the call is a no-op. Safe for concurrent use.
+func (e *Engine) Wake(fireAt time.Time) {
+	e.wake.request(fireAt)
+}
+
+// watchNotifications tails the schedule_notifications feed written by the API and wakes
+// the engine for every new schedule, until the context is cancelled.
+func (e *Engine) watchNotifications(ctx context.Context) {
+	lastID, err := e.db.LatestScheduleNotificationID(ctx)
+	if err != nil {
+		e.logger.Error("failed to read schedule notification feed, early wake-ups disabled", zap.Error(err))
+		return
+	}
+
+	ticker := time.NewTicker(e.wakePollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			notifications, err := e.db.ListScheduleNotificationsSince(ctx, lastID, notificationBatchSize)
+			if err != nil {
+				e.logger.Warn("failed to poll schedule notifications", zap.Error(err))
+				continue
+			}
+			if len(notifications) == 0 {
+				continue
+			}
+
+			earliest := notifications[0].FireAt
+			for _, n := range notifications {
+				if n.FireAt.Before(earliest) {
+					earliest = n.FireAt
+				}
+			}
+			lastID = notifications[len(notifications)-1].ID
+
+			e.logger.Debug("new schedules announced",
+				zap.Int("count", len(notifications)),
+				zap.Time("earliest_fire_at", earliest))
+			e.Wake(earliest)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/go-sql-driver/mysql"
+)
+
+var (
+	// ErrCalendarNotFound is returned when a calendar is not found.
+	ErrCalendarNotFound = errors.New("calendar not found")
+	// ErrCalendarNameTaken is returned when a calendar name is already in use.
+	ErrCalendarNameTaken = errors.New("calendar name already exists")
+)
+
+// mysqlDuplicateEntry is the MySQL error number for unique key violations.
+const mysqlDuplicateEntry = 1062
+
+// calendarColumns is the projection scanned by scanCalendar.
+const calendarColumns = `id, name, timezone, holidays, windows, created_at, updated_at`
+
+// CreateCalendar inserts a calendar.
+func (c *MySQLClient) CreateCalendar(ctx context.Context, calendar *models.Calendar) error {
+	holidays, windows, err := marshalCalendarLists(calendar)
+	if err != nil {
+		return err
+	}
+
+	now := c.now()
+	_, err = c.db.ExecContext(ctx,
+		`INSERT INTO calendars (`+calendarColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		calendar.ID, calendar.Name, calendar.Timezone, holidays, windows, now, now,
+	)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return ErrCalendarNameTaken
+	}
+	if err != nil {
+		return fmt.Errorf("insert calendar: %w", err)
+	}
+
+	return nil
+}
+
+// GetCalendar fetches a calendar by ID.
+func (c *MySQLClient) GetCalendar(ctx context.Context, calendarID string) (*models.Calendar, error) {
+	row := c.db.QueryRowContext(ctx, `SELECT `+calendarColumns+` FROM calendars WHERE id = ?`, calendarID)
+	return scanCalendar(row)
+}
+
+// GetCalendarByName fetches a calendar by its unique name.
+func (c *MySQLClient) GetCalendarByName(ctx context.Context, name string) (*models.Calendar, error) {
+	row := c.db.QueryRowContext(ctx, `SELECT `+calendarColumns+` FROM calendars WHERE name = ?`, name)
+	return scanCalendar(row)
+}
+
+// ListCalendars returns every calendar ordered by name.
+func (c *MySQLClient) ListCalendars(ctx context.Context) ([]models.Calendar, error) {
+	rows, err := c.db.QueryContext(ctx, `SELECT `+calendarColumns+` FROM calendars ORDER BY name ASC`)
+	if err != nil {
+		return nil, fmt.Errorf("query calendars: %w", err)
+	}
+	defer rows.Close()
+
+	calendars := make([]models.Calendar, 0)
+	for rows.Next() {
+		calendar, err := scanCalendar(rows)
+		if err != nil {
+			return nil, err
+		}
+		calendars = append(calendars, *calendar)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate calendars: %w", err)
+	}
+
+	return calendars, nil
+}
+
+// UpdateCalendar replaces the timezone, holidays and windows of a calendar.
+func (c *MySQLClient) UpdateCalendar(ctx context.Context, calendar *models.Calendar) error {
+	holidays, windows, err := marshalCalendarLists(calendar)
+	if err != nil {
+		return err
+	}
+
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE calendars SET timezone = ?, holidays = ?, windows = ?, updated_at = ? WHERE id = ?`,
+		calendar.Timezone, holidays, windows, c.now(), calendar.ID,
+	)
+	if err != nil {
+		return fmt.Errorf("update calendar: %w", err)
+	}
+
+	return calendarRowsAffected(result)
+}
+
+// DeleteCalendar removes a calendar. Triggers still referencing it ignore the missing calendar.
+func (c *MySQLClient) DeleteCalendar(ctx context.Context, calendarID string) error {
+	result, err := c.db.ExecContext(ctx, `DELETE FROM calendars WHERE id = ?`, calendarID)
+	if err != nil {
+		return fmt.Errorf("delete calendar: %w", err)
+	}
+
+	return calendarRowsAffected(result)
+}
+
+// calendarRowsAffected maps an UPDATE/DELETE that matched no row to ErrCalendarNotFound.
+func calendarRowsAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrCalendarNotFound
+	}
+	return nil
+}
+
+// marshalCalendarLists encodes the holidays and windows of a calendar for their JSON columns.
+func marshalCalendarLists(calendar *models.Calendar) (string, string, error) {
+	holidays := calendar.Holidays
+	if holidays == nil {
+		holidays = []string{}
+	}
+	windows := calendar.Windows
+	if windows == nil {
+		windows = []models.ExclusionWindow{}
+	}
+
+	holidaysJSON, err := json.Marshal(holidays)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal calendar holidays: %w", err)
+	}
+	windowsJSON, err := json.Marshal(windows)
+	if err != nil {
+		return "", "", fmt.Errorf("marshal calendar windows: %w", err)
+	}
+
+	return string(holidaysJSON), string(windowsJSON), nil
+}
+
+// scanner is satisfied by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanCalendar reads a row selected with calendarColumns.
+func scanCalendar(row scanner) (*models.Calendar, error) {
+	var calendar models.Calendar
+	var holidays, windows string
+	if err := row.Scan(&calendar.ID, &calendar.Name, &calendar.Timezone, &holidays, &windows, &calendar.CreatedAt, &calendar.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCalendarNotFound
+		}
+		return nil, fmt.Errorf("scan calendar: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(holidays), &calendar.Holidays); err != nil {
+		return nil, fmt.Errorf("decode calendar holidays: %w", err)
+	}
+	if err := json.Unmarshal([]byte(windows), &calendar.Windows); err != nil {
+		return nil, fmt.Errorf("decode calendar windows: %w", err)
+	}
+
+	return &calendar, nil
+}
@@ -4,19 +4,47 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/go-sql-driver/mysql"
 )
 
+// ErrEventLogNotRunning is returned when acknowledging an event that is not running.
+var ErrEventLogNotRunning = errors.New("event is not running")
+
+// ErrEventLogNotFound is returned when recording the delivery of an unknown event.
+var ErrEventLogNotFound = errors.New("event not found")
+
+// ErrEventLogExists is returned when inserting an event log whose ID is already taken, e.g. by a
+// concurrent firing that reserved the same idempotent event ID.
+var ErrEventLogExists = errors.New("event already exists")
+
+// execer is satisfied by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// eventLogColumns is the projection scanned by scanEventLog.
+const eventLogColumns = `id, trigger_id, trigger_type, fired_at, scheduled_for, payload, source,
+	execution_status, error_message, skip_reason, dst_note, acknowledged_at, published_at,
+	delivery_status, delivery_attempts, delivery_status_code, delivery_error, delivery_completed_at, retention_status, is_test_run, created_at`
+
 // CreateEventLog inserts a new event log entry into the database.
 func (c *MySQLClient) CreateEventLog(ctx context.Context, eventLog *models.EventLog) error {
+	return insertEventLog(ctx, c.db, eventLog)
+}
+
+// insertEventLog inserts an event log entry, on its own or inside a transaction.
+func insertEventLog(ctx context.Context, db execer, eventLog *models.EventLog) error {
 	query := `
 		INSERT INTO event_logs (
-			id, trigger_id, trigger_type, fired_at, payload, source,
-			execution_status, error_message, retention_status, is_test_run, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			id, trigger_id, trigger_type, fired_at, scheduled_for, payload, source,
+			execution_status, error_message, skip_reason, dst_note, retention_status, is_test_run, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	// Convert payload to JSON bytes
@@ -26,20 +54,27 @@ func (c *MySQLClient) CreateEventLog(ctx context.Context, eventLog *models.Event
 		payloadBytes = eventLog.Payload
 	}
 
-	_, err = c.db.ExecContext(ctx, query,
+	_, err = db.ExecContext(ctx, query,
 		eventLog.ID,
 		eventLog.TriggerID,
 		eventLog.TriggerType,
 		eventLog.FiredAt,
+		eventLog.ScheduledFor,
 		payloadBytes,
 		eventLog.Source,
 		eventLog.ExecutionStatus,
 		eventLog.ErrorMessage,
+		eventLog.SkipReason,
+		eventLog.DSTNote,
 		eventLog.RetentionStatus,
 		eventLog.IsTestRun,
 		eventLog.CreatedAt,
 	)
 
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		return fmt.Errorf("failed to create event log %s: %w", eventLog.ID, ErrEventLogExists)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to create event log: %w", err)
 	}
@@ -67,33 +102,12 @@ func (c *MySQLClient) UpdateEventLogStatus(ctx context.Context, eventID string,
 // GetEventLog retrieves a single event log by ID.
 func (c *MySQLClient) GetEventLog(ctx context.Context, eventID string) (*models.EventLog, error) {
 	query := `
-		SELECT id, trigger_id, trigger_type, fired_at, payload, source,
-		       execution_status, error_message, retention_status, is_test_run, created_at
+		SELECT ` + eventLogColumns + `
 		FROM event_logs
 		WHERE id = ?
 	`
 
-	row := c.db.QueryRowContext(ctx, query, eventID)
-
-	var eventLog models.EventLog
-	var triggerID sql.NullString
-	var errorMessage sql.NullString
-	var payload sql.NullString
-
-	err := row.Scan(
-		&eventLog.ID,
-		&triggerID,
-		&eventLog.TriggerType,
-		&eventLog.FiredAt,
-		&payload,
-		&eventLog.Source,
-		&eventLog.ExecutionStatus,
-		&errorMessage,
-		&eventLog.RetentionStatus,
-		&eventLog.IsTestRun,
-		&eventLog.CreatedAt,
-	)
-
+	eventLog, err := scanEventLog(c.db.QueryRowContext(ctx, query, eventID))
 	if err == sql.ErrNoRows {
 		return nil, nil // Event log not found
 	}
@@ -101,18 +115,7 @@ func (c *MySQLClient) GetEventLog(ctx context.Context, eventID string) (*models.
 		return nil, fmt.Errorf("failed to get event log: %w", err)
 	}
 
-	// Handle nullable fields
-	if triggerID.Valid {
-		eventLog.TriggerID = &triggerID.String
-	}
-	if errorMessage.Valid {
-		eventLog.ErrorMessage = &errorMessage.String
-	}
-	if payload.Valid {
-		eventLog.Payload = json.RawMessage(payload.String)
-	}
-
-	return &eventLog, nil
+	return eventLog, nil
 }
 
 // ListEventLogs retrieves event logs with filtering and pagination.
@@ -174,13 +177,12 @@ func (c *MySQLClient) ListEventLogs(ctx context.Context, query models.ListEvents
 
 	// Get paginated results
 	listQuery := fmt.Sprintf(`
-		SELECT id, trigger_id, trigger_type, fired_at, payload, source,
-		       execution_status, error_message, retention_status, is_test_run, created_at
+		SELECT %s
 		FROM event_logs
 		%s
 		ORDER BY fired_at DESC
 		LIMIT ? OFFSET ?
-	`, whereClause)
+	`, eventLogColumns, whereClause)
 
 	args = append(args, limit, offset)
 
@@ -192,45 +194,216 @@ func (c *MySQLClient) ListEventLogs(ctx context.Context, query models.ListEvents
 
 	eventLogs := []models.EventLog{}
 	for rows.Next() {
-		var eventLog models.EventLog
-		var triggerID sql.NullString
-		var errorMessage sql.NullString
-		var payload sql.NullString
-
-		err := rows.Scan(
-			&eventLog.ID,
-			&triggerID,
-			&eventLog.TriggerType,
-			&eventLog.FiredAt,
-			&payload,
-			&eventLog.Source,
-			&eventLog.ExecutionStatus,
-			&errorMessage,
-			&eventLog.RetentionStatus,
-			&eventLog.IsTestRun,
-			&eventLog.CreatedAt,
-		)
+		eventLog, err := scanEventLog(rows)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan event log: %w", err)
 		}
+		eventLogs = append(eventLogs, *eventLog)
+	}
 
-		// Handle nullable fields
-		if triggerID.Valid {
-			eventLog.TriggerID = &triggerID.String
-		}
-		if errorMessage.Valid {
-			eventLog.ErrorMessage = &errorMessage.String
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating event logs: %w", err)
+	}
+
+	return eventLogs, totalCount, nil
+}
+
+// GetRunningEventLog returns the trigger's most recently fired running event (nil if none).
+func (c *MySQLClient) GetRunningEventLog(ctx context.Context, triggerID string) (*models.EventLog, error) {
+	query := `
+		SELECT ` + eventLogColumns + `
+		FROM event_logs
+		WHERE trigger_id = ? AND execution_status = 'running'
+		ORDER BY fired_at DESC
+		LIMIT 1
+	`
+
+	eventLog, err := scanEventLog(c.db.QueryRowContext(ctx, query, triggerID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running event log: %w", err)
+	}
+
+	return eventLog, nil
+}
+
+// ReplaceRunningEventLogs marks the trigger's running events as replaced, with reason as their error message.
+func (c *MySQLClient) ReplaceRunningEventLogs(ctx context.Context, triggerID string, reason string) (int, error) {
+	result, err := c.db.ExecContext(ctx, `
+		UPDATE event_logs
+		SET execution_status = 'replaced', error_message = ?
+		WHERE trigger_id = ? AND execution_status = 'running'
+	`, reason, triggerID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace running event logs: %w", err)
+	}
+
+	replaced, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check replaced event logs: %w", err)
+	}
+
+	return int(replaced), nil
+}
+
+// ExpireRunningEventLogs marks the trigger's running events fired at or before firedBefore as failed,
+// with reason as their error message.
+func (c *MySQLClient) ExpireRunningEventLogs(ctx context.Context, triggerID string, firedBefore time.Time, reason string) (int, error) {
+	result, err := c.db.ExecContext(ctx, `
+		UPDATE event_logs
+		SET execution_status = 'failure', error_message = ?
+		WHERE trigger_id = ? AND execution_status = 'running' AND fired_at <= ?
+	`, reason, triggerID, firedBefore.UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire running event logs: %w", err)
+	}
+
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check expired event logs: %w", err)
+	}
+
+	return int(expired), nil
+}
+
+// AcknowledgeEventLog records the outcome of a running event. The status check is part of the
+// update, so an event replaced meanwhile is not acknowledged.
+func (c *MySQLClient) AcknowledgeEventLog(ctx context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error {
+	result, err := c.db.ExecContext(ctx, `
+		UPDATE event_logs
+		SET execution_status = ?, error_message = ?, acknowledged_at = ?
+		WHERE id = ? AND execution_status = 'running'
+	`, status, errorMessage, c.now(), eventID)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge event log: %w", err)
+	}
+
+	acknowledged, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check acknowledged event log: %w", err)
+	}
+	if acknowledged == 0 {
+		return ErrEventLogNotRunning
+	}
+
+	return nil
+}
+
+// RecordEventDelivery stores the outcome of the dispatcher's HTTP call for an event.
+func (c *MySQLClient) RecordEventDelivery(ctx context.Context, eventID string, delivery *models.EventDelivery) error {
+	delivery.CompletedAt = c.now()
+
+	result, err := c.db.ExecContext(ctx, `
+		UPDATE event_logs
+		SET delivery_status = ?, delivery_attempts = ?, delivery_status_code = ?, delivery_error = ?, delivery_completed_at = ?
+		WHERE id = ?
+	`, delivery.Status, delivery.Attempts, delivery.StatusCode, delivery.Error, delivery.CompletedAt, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to record event delivery: %w", err)
+	}
+
+	recorded, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check recorded event delivery: %w", err)
+	}
+	if recorded == 0 {
+		// No row changed: the event does not exist, or the same outcome was recorded within the second
+		var exists bool
+		if err := c.db.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM event_logs WHERE id = ?)`, eventID).Scan(&exists); err != nil {
+			return fmt.Errorf("failed to check event log: %w", err)
 		}
-		if payload.Valid {
-			eventLog.Payload = json.RawMessage(payload.String)
+		if !exists {
+			return ErrEventLogNotFound
 		}
+	}
+
+	return nil
+}
 
-		eventLogs = append(eventLogs, eventLog)
+// scanEventLog reads a row selected with eventLogColumns.
+func scanEventLog(row scanner) (*models.EventLog, error) {
+	var eventLog models.EventLog
+	var triggerID sql.NullString
+	var errorMessage sql.NullString
+	var skipReason sql.NullString
+	var dstNote sql.NullString
+	var payload sql.NullString
+	var scheduledFor sql.NullTime
+	var acknowledgedAt sql.NullTime
+	var publishedAt sql.NullTime
+	var deliveryStatus sql.NullString
+	var deliveryAttempts int
+	var deliveryStatusCode sql.NullInt64
+	var deliveryError sql.NullString
+	var deliveryCompletedAt sql.NullTime
+
+	if err := row.Scan(
+		&eventLog.ID,
+		&triggerID,
+		&eventLog.TriggerType,
+		&eventLog.FiredAt,
+		&scheduledFor,
+		&payload,
+		&eventLog.Source,
+		&eventLog.ExecutionStatus,
+		&errorMessage,
+		&skipReason,
+		&dstNote,
+		&acknowledgedAt,
+		&publishedAt,
+		&deliveryStatus,
+		&deliveryAttempts,
+		&deliveryStatusCode,
+		&deliveryError,
+		&deliveryCompletedAt,
+		&eventLog.RetentionStatus,
+		&eventLog.IsTestRun,
+		&eventLog.CreatedAt,
+	); err != nil {
+		return nil, err
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, 0, fmt.Errorf("error iterating event logs: %w", err)
+	// Handle nullable fields
+	if triggerID.Valid {
+		eventLog.TriggerID = &triggerID.String
+	}
+	if errorMessage.Valid {
+		eventLog.ErrorMessage = &errorMessage.String
+	}
+	if skipReason.Valid {
+		eventLog.SkipReason = &skipReason.String
+	}
+	if dstNote.Valid {
+		eventLog.DSTNote = &dstNote.String
+	}
+	if payload.Valid {
+		eventLog.Payload = json.RawMessage(payload.String)
+	}
+	if scheduledFor.Valid {
+		eventLog.ScheduledFor = &scheduledFor.Time
+	}
+	if acknowledgedAt.Valid {
+		eventLog.AcknowledgedAt = &acknowledgedAt.Time
+	}
+	if publishedAt.Valid {
+		eventLog.PublishedAt = &publishedAt.Time
+	}
+	if deliveryStatus.Valid {
+		eventLog.Delivery = &models.EventDelivery{
+			Status:      models.DeliveryStatus(deliveryStatus.String),
+			Attempts:    deliveryAttempts,
+			CompletedAt: deliveryCompletedAt.Time,
+		}
+		if deliveryStatusCode.Valid {
+			statusCode := int(deliveryStatusCode.Int64)
+			eventLog.Delivery.StatusCode = &statusCode
+		}
+		if deliveryError.Valid {
+			eventLog.Delivery.Error = &deliveryError.String
+		}
 	}
 
-	return eventLogs, totalCount, nil
+	return &eventLog, nil
 }
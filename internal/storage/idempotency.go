@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// ReserveIdempotencyKey binds jobID to eventID unless an earlier attempt bound it already.
+func (c *MySQLClient) ReserveIdempotencyKey(ctx context.Context, jobID, eventID string) (string, error) {
+	_, err := c.db.ExecContext(ctx,
+		`INSERT INTO idempotency_keys (job_id, event_id, created_at) VALUES (?, ?, ?)`,
+		jobID,
+		eventID,
+		c.now(),
+	)
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+		// Reserved by an earlier attempt: reuse its event ID
+		existing, getErr := c.GetIdempotencyKey(ctx, jobID)
+		if getErr != nil {
+			return "", getErr
+		}
+		if existing == "" {
+			return "", fmt.Errorf("idempotency key %s vanished after a duplicate insert", jobID)
+		}
+		return existing, nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("insert idempotency key: %w", err)
+	}
+
+	return eventID, nil
+}
+
+// RebindIdempotencyKey binds jobID to eventID when it is still bound to staleEventID by a reservation
+// made before reservedBefore, and returns the event ID it is bound to afterwards.
+func (c *MySQLClient) RebindIdempotencyKey(ctx context.Context, jobID, staleEventID, eventID string, reservedBefore time.Time) (string, error) {
+	if _, err := c.db.ExecContext(ctx,
+		`UPDATE idempotency_keys SET event_id = ?, created_at = ?
+		 WHERE job_id = ? AND event_id = ? AND created_at < ?`,
+		eventID,
+		c.now(),
+		jobID,
+		staleEventID,
+		reservedBefore.UTC(),
+	); err != nil {
+		return "", fmt.Errorf("rebind idempotency key: %w", err)
+	}
+
+	return c.GetIdempotencyKey(ctx, jobID)
+}
+
+// GetIdempotencyKey returns the event ID bound to jobID, or "" when none is.
+func (c *MySQLClient) GetIdempotencyKey(ctx context.Context, jobID string) (string, error) {
+	var eventID string
+	err := c.db.QueryRowContext(ctx, `SELECT event_id FROM idempotency_keys WHERE job_id = ?`, jobID).Scan(&eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("query idempotency key: %w", err)
+	}
+
+	return eventID, nil
+}
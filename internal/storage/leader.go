@@ -0,0 +1,164 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/go-sql-driver/mysql"
+)
+
+// SchedulerLeaseName names the lease the scheduler instances elect their leader with.
+const SchedulerLeaseName = "scheduler"
+
+// ErrLeadershipLost is returned when renewing a lease that expired or was taken over by another holder.
+var ErrLeadershipLost = errors.New("leadership lost")
+
+// AcquireLeadership makes holder the leader of name when the lease is free, expired or already its
+// own. The lease row is locked for the check, so concurrent candidates cannot both win.
+func (c *MySQLClient) AcquireLeadership(ctx context.Context, name, holder string, ttl time.Duration) (*models.SchedulerLease, bool, error) {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	now := c.now()
+	var lease *models.SchedulerLease
+	lease, err = scanSchedulerLease(tx.QueryRowContext(ctx, schedulerLeaseQuery+` FOR UPDATE`, name))
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		lease = &models.SchedulerLease{Name: name, Holder: holder, FencingToken: 1, AcquiredAt: now, RenewedAt: now, ExpiresAt: now.Add(ttl)}
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO scheduler_leases (name, holder, fencing_token, acquired_at, renewed_at, expires_at)
+			 VALUES (?, ?, ?, ?, ?, ?)`,
+			lease.Name, lease.Holder, lease.FencingToken, lease.AcquiredAt, lease.RenewedAt, lease.ExpiresAt,
+		)
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry {
+			// Another candidate created the lease first
+			_ = tx.Rollback()
+			current, getErr := c.GetLeader(ctx, name)
+			if getErr != nil {
+				return nil, false, getErr
+			}
+			return current, current != nil && current.Holder == holder && current.ExpiresAt.After(now), nil
+		}
+		if err != nil {
+			return nil, false, fmt.Errorf("insert scheduler lease: %w", err)
+		}
+
+	case err != nil:
+		return nil, false, fmt.Errorf("lock scheduler lease: %w", err)
+
+	case lease.Holder != holder && lease.ExpiresAt.After(now):
+		err = tx.Rollback()
+		return lease, false, nil
+
+	default:
+		lease.Holder = holder
+		lease.FencingToken++
+		lease.AcquiredAt, lease.RenewedAt, lease.ExpiresAt = now, now, now.Add(ttl)
+		if _, err = tx.ExecContext(ctx,
+			`UPDATE scheduler_leases
+			 SET holder = ?, fencing_token = ?, acquired_at = ?, renewed_at = ?, expires_at = ?
+			 WHERE name = ?`,
+			lease.Holder, lease.FencingToken, lease.AcquiredAt, lease.RenewedAt, lease.ExpiresAt, name,
+		); err != nil {
+			return nil, false, fmt.Errorf("update scheduler lease: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, false, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return lease, true, nil
+}
+
+// RenewLeadership extends the lease held by holder under token, in a single conditional update.
+func (c *MySQLClient) RenewLeadership(ctx context.Context, name, holder string, token int64, ttl time.Duration) (*models.SchedulerLease, error) {
+	now := c.now()
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE scheduler_leases
+		 SET renewed_at = ?, expires_at = ?
+		 WHERE name = ? AND holder = ? AND fencing_token = ? AND expires_at > ?`,
+		now,
+		now.Add(ttl),
+		name,
+		holder,
+		token,
+		now,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("renew scheduler lease: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return nil, fmt.Errorf("get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return nil, ErrLeadershipLost
+	}
+
+	return c.GetLeader(ctx, name)
+}
+
+// ReleaseLeadership expires the lease held by holder under token.
+func (c *MySQLClient) ReleaseLeadership(ctx context.Context, name, holder string, token int64) error {
+	now := c.now()
+	if _, err := c.db.ExecContext(ctx,
+		`UPDATE scheduler_leases
+		 SET expires_at = ?
+		 WHERE name = ? AND holder = ? AND fencing_token = ? AND expires_at > ?`,
+		now,
+		name,
+		holder,
+		token,
+		now,
+	); err != nil {
+		return fmt.Errorf("release scheduler lease: %w", err)
+	}
+	return nil
+}
+
+// GetLeader returns the lease of name, or nil when it was never acquired.
+func (c *MySQLClient) GetLeader(ctx context.Context, name string) (*models.SchedulerLease, error) {
+	lease, err := scanSchedulerLease(c.db.QueryRowContext(ctx, schedulerLeaseQuery, name))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get scheduler lease: %w", err)
+	}
+	return lease, nil
+}
+
+const schedulerLeaseQuery = `
+	SELECT name, holder, fencing_token, acquired_at, renewed_at, expires_at
+	FROM scheduler_leases
+	WHERE name = ?`
+
+func scanSchedulerLease(row scanner) (*models.SchedulerLease, error) {
+	var lease models.SchedulerLease
+	if err := row.Scan(
+		&lease.Name,
+		&lease.Holder,
+		&lease.FencingToken,
+		&lease.AcquiredAt,
+		&lease.RenewedAt,
+		&lease.ExpiresAt,
+	); err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
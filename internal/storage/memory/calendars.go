@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// CreateCalendar inserts a calendar; names are unique like the MySQL unique key.
+func (s *Store) CreateCalendar(_ context.Context, calendar *models.Calendar) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.calendars[calendar.ID]; exists {
+		return fmt.Errorf("insert calendar: duplicate id %s", calendar.ID)
+	}
+	if s.calendarByName(calendar.Name) != nil {
+		return storage.ErrCalendarNameTaken
+	}
+
+	now := s.now()
+	stored := copyCalendar(calendar)
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	s.calendars[calendar.ID] = &stored
+	return nil
+}
+
+// GetCalendar fetches a calendar by ID.
+func (s *Store) GetCalendar(_ context.Context, calendarID string) (*models.Calendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calendar, ok := s.calendars[calendarID]
+	if !ok {
+		return nil, storage.ErrCalendarNotFound
+	}
+
+	copied := copyCalendar(calendar)
+	return &copied, nil
+}
+
+// GetCalendarByName fetches a calendar by its unique name.
+func (s *Store) GetCalendarByName(_ context.Context, name string) (*models.Calendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calendar := s.calendarByName(name)
+	if calendar == nil {
+		return nil, storage.ErrCalendarNotFound
+	}
+
+	copied := copyCalendar(calendar)
+	return &copied, nil
+}
+
+// ListCalendars returns every calendar ordered by name.
+func (s *Store) ListCalendars(_ context.Context) ([]models.Calendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	calendars := make([]models.Calendar, 0, len(s.calendars))
+	for _, calendar := range s.calendars {
+		calendars = append(calendars, copyCalendar(calendar))
+	}
+	sort.Slice(calendars, func(i, j int) bool {
+		return calendars[i].Name < calendars[j].Name
+	})
+
+	return calendars, nil
+}
+
+// UpdateCalendar replaces the timezone, holidays and windows of a calendar.
+func (s *Store) UpdateCalendar(_ context.Context, calendar *models.Calendar) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stored, ok := s.calendars[calendar.ID]
+	if !ok {
+		return storage.ErrCalendarNotFound
+	}
+
+	updated := copyCalendar(calendar)
+	stored.Timezone = updated.Timezone
+	stored.Holidays = updated.Holidays
+	stored.Windows = updated.Windows
+	stored.UpdatedAt = s.now()
+	return nil
+}
+
+// DeleteCalendar removes a calendar.
+func (s *Store) DeleteCalendar(_ context.Context, calendarID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.calendars[calendarID]; !ok {
+		return storage.ErrCalendarNotFound
+	}
+
+	delete(s.calendars, calendarID)
+	return nil
+}
+
+// calendarByName returns the stored calendar with the given name, or nil. Callers hold s.mu.
+func (s *Store) calendarByName(name string) *models.Calendar {
+	for _, calendar := range s.calendars {
+		if calendar.Name == name {
+			return calendar
+		}
+	}
+	return nil
+}
+
+// copyCalendar deep-copies a calendar. Missing lists come back empty, as from the JSON columns.
+func copyCalendar(calendar *models.Calendar) models.Calendar {
+	copied := *calendar
+	copied.Holidays = append([]string{}, calendar.Holidays...)
+	copied.Windows = append([]models.ExclusionWindow{}, calendar.Windows...)
+	return copied
+}
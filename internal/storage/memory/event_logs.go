@@ -0,0 +1,297 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// CreateEventLog inserts a new event log entry.
+func (s *Store) CreateEventLog(_ context.Context, eventLog *models.EventLog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.insertEventLog(eventLog)
+}
+
+// insertEventLog stores a copy of eventLog. Callers hold s.mu.
+func (s *Store) insertEventLog(eventLog *models.EventLog) error {
+	for _, existing := range s.eventLogs {
+		if existing.ID == eventLog.ID {
+			return fmt.Errorf("failed to create event log %s: %w", eventLog.ID, storage.ErrEventLogExists)
+		}
+	}
+
+	stored := copyEventLog(eventLog)
+	s.eventLogs = append(s.eventLogs, &stored)
+	return nil
+}
+
+// UpdateEventLogStatus updates the execution status and error message of an event log.
+func (s *Store) UpdateEventLogStatus(_ context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ID == eventID {
+			eventLog.ExecutionStatus = status
+			eventLog.ErrorMessage = nil
+			if errorMessage != nil {
+				eventLog.ErrorMessage = stringPtr(*errorMessage)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// GetEventLog retrieves a single event log by ID (nil if not found).
+func (s *Store) GetEventLog(_ context.Context, eventID string) (*models.EventLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.applyRetention()
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ID == eventID {
+			copied := copyEventLog(eventLog)
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+// ListEventLogs retrieves event logs with filtering and pagination.
+// Returns the list of event logs and the total count for pagination.
+func (s *Store) ListEventLogs(_ context.Context, query models.ListEventsQuery) ([]models.EventLog, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.applyRetention()
+
+	retentionStatus := query.RetentionStatus
+	if retentionStatus == "" {
+		retentionStatus = "active"
+	}
+
+	matched := []*models.EventLog{}
+	for i := len(s.eventLogs) - 1; i >= 0; i-- {
+		eventLog := s.eventLogs[i]
+		if string(eventLog.RetentionStatus) != retentionStatus {
+			continue
+		}
+		if query.TriggerID != "" && (eventLog.TriggerID == nil || *eventLog.TriggerID != query.TriggerID) {
+			continue
+		}
+		if query.ExecutionStatus != "" && string(eventLog.ExecutionStatus) != query.ExecutionStatus {
+			continue
+		}
+		if query.Source != "" && string(eventLog.Source) != query.Source {
+			continue
+		}
+		matched = append(matched, eventLog)
+	}
+
+	// ORDER BY fired_at DESC
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].FiredAt.After(matched[j].FiredAt)
+	})
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	start, end := offsetPage(len(matched), page, limit)
+	eventLogs := make([]models.EventLog, 0, end-start)
+	for _, eventLog := range matched[start:end] {
+		eventLogs = append(eventLogs, copyEventLog(eventLog))
+	}
+
+	return eventLogs, int64(len(matched)), nil
+}
+
+// GetRunningEventLog returns the trigger's most recently fired running event (nil if none).
+func (s *Store) GetRunningEventLog(_ context.Context, triggerID string) (*models.EventLog, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.applyRetention()
+	var running *models.EventLog
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ExecutionStatus != models.ExecutionStatusRunning || eventLog.TriggerID == nil || *eventLog.TriggerID != triggerID {
+			continue
+		}
+		// Later insertions win fired_at ties
+		if running == nil || !eventLog.FiredAt.Before(running.FiredAt) {
+			running = eventLog
+		}
+	}
+	if running == nil {
+		return nil, nil
+	}
+
+	copied := copyEventLog(running)
+	return &copied, nil
+}
+
+// ReplaceRunningEventLogs marks the trigger's running events as replaced, with reason as their error message.
+func (s *Store) ReplaceRunningEventLogs(_ context.Context, triggerID string, reason string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	replaced := 0
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ExecutionStatus == models.ExecutionStatusRunning && eventLog.TriggerID != nil && *eventLog.TriggerID == triggerID {
+			eventLog.ExecutionStatus = models.ExecutionStatusReplaced
+			eventLog.ErrorMessage = stringPtr(reason)
+			replaced++
+		}
+	}
+	return replaced, nil
+}
+
+// ExpireRunningEventLogs marks the trigger's running events fired at or before firedBefore as failed,
+// with reason as their error message.
+func (s *Store) ExpireRunningEventLogs(_ context.Context, triggerID string, firedBefore time.Time, reason string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	expired := 0
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ExecutionStatus == models.ExecutionStatusRunning && eventLog.TriggerID != nil && *eventLog.TriggerID == triggerID &&
+			!eventLog.FiredAt.After(firedBefore) {
+			eventLog.ExecutionStatus = models.ExecutionStatusFailure
+			eventLog.ErrorMessage = stringPtr(reason)
+			expired++
+		}
+	}
+	return expired, nil
+}
+
+// AcknowledgeEventLog records the outcome of a running event.
+func (s *Store) AcknowledgeEventLog(_ context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ID != eventID {
+			continue
+		}
+		if eventLog.ExecutionStatus != models.ExecutionStatusRunning {
+			break
+		}
+		eventLog.ExecutionStatus = status
+		eventLog.ErrorMessage = nil
+		if errorMessage != nil {
+			eventLog.ErrorMessage = stringPtr(*errorMessage)
+		}
+		eventLog.AcknowledgedAt = timePtr(s.now())
+		return nil
+	}
+	return storage.ErrEventLogNotRunning
+}
+
+// RecordEventDelivery stores the outcome of the dispatcher's HTTP call for an event.
+func (s *Store) RecordEventDelivery(_ context.Context, eventID string, delivery *models.EventDelivery) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ID != eventID {
+			continue
+		}
+		delivery.CompletedAt = s.now()
+		eventLog.Delivery = copyEventDelivery(delivery)
+		return nil
+	}
+	return storage.ErrEventLogNotFound
+}
+
+// applyRetention runs the retention lifecycle the MySQL events perform in the background:
+// active logs are archived after storage.EventLogArchiveAfter, all logs are deleted after
+// storage.EventLogDeleteAfter and sent outbox messages after storage.OutboxMessageDeleteAfter.
+// Callers hold s.mu.
+func (s *Store) applyRetention() {
+	now := s.now()
+	archiveBefore := now.Add(-storage.EventLogArchiveAfter)
+	deleteBefore := now.Add(-storage.EventLogDeleteAfter)
+
+	kept := s.eventLogs[:0]
+	for _, eventLog := range s.eventLogs {
+		if eventLog.FiredAt.Before(deleteBefore) {
+			continue
+		}
+		if eventLog.RetentionStatus == models.RetentionStatusActive && eventLog.FiredAt.Before(archiveBefore) {
+			eventLog.RetentionStatus = models.RetentionStatusArchived
+		}
+		kept = append(kept, eventLog)
+	}
+	s.eventLogs = kept
+
+	sentBefore := now.Add(-storage.OutboxMessageDeleteAfter)
+	keptMessages := s.outbox[:0]
+	for _, message := range s.outbox {
+		if message.SentAt != nil && message.SentAt.Before(sentBefore) {
+			continue
+		}
+		keptMessages = append(keptMessages, message)
+	}
+	s.outbox = keptMessages
+}
+
+func copyEventLog(eventLog *models.EventLog) models.EventLog {
+	copied := *eventLog
+	copied.Payload = append(json.RawMessage(nil), eventLog.Payload...)
+	if eventLog.Payload == nil {
+		copied.Payload = nil
+	}
+	if eventLog.TriggerID != nil {
+		copied.TriggerID = stringPtr(*eventLog.TriggerID)
+	}
+	if eventLog.ScheduledFor != nil {
+		copied.ScheduledFor = timePtr(*eventLog.ScheduledFor)
+	}
+	if eventLog.ErrorMessage != nil {
+		copied.ErrorMessage = stringPtr(*eventLog.ErrorMessage)
+	}
+	if eventLog.SkipReason != nil {
+		copied.SkipReason = stringPtr(*eventLog.SkipReason)
+	}
+	if eventLog.DSTNote != nil {
+		copied.DSTNote = stringPtr(*eventLog.DSTNote)
+	}
+	if eventLog.AcknowledgedAt != nil {
+		copied.AcknowledgedAt = timePtr(*eventLog.AcknowledgedAt)
+	}
+	if eventLog.PublishedAt != nil {
+		copied.PublishedAt = timePtr(*eventLog.PublishedAt)
+	}
+	if eventLog.Delivery != nil {
+		copied.Delivery = copyEventDelivery(eventLog.Delivery)
+	}
+	return copied
+}
+
+func copyEventDelivery(delivery *models.EventDelivery) *models.EventDelivery {
+	copied := *delivery
+	if delivery.StatusCode != nil {
+		statusCode := *delivery.StatusCode
+		copied.StatusCode = &statusCode
+	}
+	if delivery.Error != nil {
+		copied.Error = stringPtr(*delivery.Error)
+	}
+	return &copied
+}
@@ -0,0 +1,60 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// ReserveIdempotencyKey binds jobID to eventID unless an earlier attempt bound it already.
+func (s *Store) ReserveIdempotencyKey(_ context.Context, jobID, eventID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneIdempotencyKeys()
+	if key, ok := s.idempotencyKeys[jobID]; ok {
+		return key.eventID, nil
+	}
+
+	s.idempotencyKeys[jobID] = idempotencyKey{eventID: eventID, createdAt: s.now()}
+	return eventID, nil
+}
+
+// GetIdempotencyKey returns the event ID bound to jobID, or "" when none is.
+func (s *Store) GetIdempotencyKey(_ context.Context, jobID string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneIdempotencyKeys()
+	return s.idempotencyKeys[jobID].eventID, nil
+}
+
+// RebindIdempotencyKey binds jobID to eventID when it is still bound to staleEventID by a reservation
+// made before reservedBefore, and returns the event ID it is bound to afterwards.
+func (s *Store) RebindIdempotencyKey(_ context.Context, jobID, staleEventID, eventID string, reservedBefore time.Time) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneIdempotencyKeys()
+	key, ok := s.idempotencyKeys[jobID]
+	if !ok {
+		return "", nil
+	}
+	if key.eventID == staleEventID && key.createdAt.Before(reservedBefore) {
+		key = idempotencyKey{eventID: eventID, createdAt: s.now()}
+		s.idempotencyKeys[jobID] = key
+	}
+	return key.eventID, nil
+}
+
+// pruneIdempotencyKeys deletes the keys MySQL's retention event would have deleted by now.
+// Callers must hold s.mu.
+func (s *Store) pruneIdempotencyKeys() {
+	deleteBefore := s.now().Add(-storage.IdempotencyKeyDeleteAfter)
+	for jobID, key := range s.idempotencyKeys {
+		if key.createdAt.Before(deleteBefore) {
+			delete(s.idempotencyKeys, jobID)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// AcquireLeadership makes holder the leader of name when the lease is free, expired or already its own.
+func (s *Store) AcquireLeadership(_ context.Context, name, holder string, ttl time.Duration) (*models.SchedulerLease, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	lease, ok := s.leases[name]
+	if ok && lease.Holder != holder && lease.ExpiresAt.After(now) {
+		copied := *lease
+		return &copied, false, nil
+	}
+	if !ok {
+		lease = &models.SchedulerLease{Name: name}
+		s.leases[name] = lease
+	}
+
+	lease.Holder = holder
+	lease.FencingToken++
+	lease.AcquiredAt, lease.RenewedAt, lease.ExpiresAt = now, now, now.Add(ttl)
+
+	copied := *lease
+	return &copied, true, nil
+}
+
+// RenewLeadership extends the lease held by holder under token.
+func (s *Store) RenewLeadership(_ context.Context, name, holder string, token int64, ttl time.Duration) (*models.SchedulerLease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	lease, ok := s.leases[name]
+	if !ok || lease.Holder != holder || lease.FencingToken != token || !lease.ExpiresAt.After(now) {
+		return nil, storage.ErrLeadershipLost
+	}
+
+	lease.RenewedAt, lease.ExpiresAt = now, now.Add(ttl)
+
+	copied := *lease
+	return &copied, nil
+}
+
+// ReleaseLeadership expires the lease held by holder under token.
+func (s *Store) ReleaseLeadership(_ context.Context, name, holder string, token int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	if lease, ok := s.leases[name]; ok && lease.Holder == holder && lease.FencingToken == token && lease.ExpiresAt.After(now) {
+		lease.ExpiresAt = now
+	}
+	return nil
+}
+
+// GetLeader returns the lease of name, or nil when it was never acquired.
+func (s *Store) GetLeader(_ context.Context, name string) (*models.SchedulerLease, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease, ok := s.leases[name]
+	if !ok {
+		return nil, nil
+	}
+	copied := *lease
+	return &copied, nil
+}
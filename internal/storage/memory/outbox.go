@@ -0,0 +1,151 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// RecordEvent inserts an event log and its outbox message, completing the schedule that fired it
+// (if any), all or nothing.
+func (s *Store) RecordEvent(_ context.Context, eventLog *models.EventLog, message *models.OutboxMessage, completion *storage.ScheduleCompletion) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var schedule *models.TriggerSchedule
+	if completion != nil {
+		if schedule = s.ownedSchedule(completion.ScheduleID, completion.Owner); schedule == nil {
+			return fmt.Errorf("schedule %s: %w", completion.ScheduleID, storage.ErrScheduleLeaseLost)
+		}
+	}
+	for _, existing := range s.eventLogs {
+		if existing.ID == eventLog.ID {
+			return fmt.Errorf("failed to create event log %s: %w", eventLog.ID, storage.ErrEventLogExists)
+		}
+	}
+	for _, existing := range s.outbox {
+		if existing.EventID == message.EventID {
+			return fmt.Errorf("insert outbox message: duplicate event id %s", message.EventID)
+		}
+	}
+
+	if err := s.insertEventLog(eventLog); err != nil {
+		return err
+	}
+
+	if schedule != nil {
+		schedule.Status = models.ScheduleStatusCompleted
+		schedule.LeaseExpiresAt = nil
+		schedule.UpdatedAt = s.now()
+	}
+
+	s.outboxSeq++
+	message.ID = s.outboxSeq
+	stored := copyOutboxMessage(message)
+	s.outbox = append(s.outbox, &stored)
+	return nil
+}
+
+// ClaimOutboxMessages leases due pending messages to owner, oldest first. Each claim counts as a
+// delivery attempt.
+func (s *Store) ClaimOutboxMessages(_ context.Context, owner string, limit int, lease time.Duration) ([]models.OutboxMessage, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	messages := []models.OutboxMessage{}
+	for _, message := range s.outbox {
+		if len(messages) >= limit {
+			break
+		}
+		if message.Status != models.OutboxStatusPending || message.NextAttemptAt.After(now) {
+			continue
+		}
+		if message.LeaseExpiresAt != nil && message.LeaseExpiresAt.After(now) {
+			continue
+		}
+
+		message.ClaimedBy = stringPtr(owner)
+		message.LeaseExpiresAt = timePtr(now.Add(lease))
+		message.AttemptCount++
+		messages = append(messages, copyOutboxMessage(message))
+	}
+	return messages, nil
+}
+
+// MarkOutboxMessageSent marks a message claimed by owner as sent and stamps its event log's published_at.
+func (s *Store) MarkOutboxMessageSent(_ context.Context, messageID int64, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message := s.ownedOutboxMessage(messageID, owner)
+	if message == nil {
+		return storage.ErrOutboxLeaseLost
+	}
+
+	now := s.now()
+	message.Status = models.OutboxStatusSent
+	message.SentAt = timePtr(now)
+	message.LeaseExpiresAt = nil
+	message.LastError = nil
+
+	// The event log may be gone already (retention), which is fine
+	for _, eventLog := range s.eventLogs {
+		if eventLog.ID == message.EventID {
+			eventLog.PublishedAt = timePtr(now)
+		}
+	}
+	return nil
+}
+
+// RetryOutboxMessage releases a message claimed by owner until nextAttemptAt, recording the error.
+func (s *Store) RetryOutboxMessage(_ context.Context, messageID int64, owner string, nextAttemptAt time.Time, lastError string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	message := s.ownedOutboxMessage(messageID, owner)
+	if message == nil {
+		return storage.ErrOutboxLeaseLost
+	}
+
+	message.ClaimedBy = nil
+	message.LeaseExpiresAt = nil
+	message.NextAttemptAt = nextAttemptAt.UTC()
+	message.LastError = stringPtr(lastError)
+	return nil
+}
+
+// ownedOutboxMessage returns the pending message claimed by owner, or nil. Callers hold s.mu.
+func (s *Store) ownedOutboxMessage(messageID int64, owner string) *models.OutboxMessage {
+	for _, message := range s.outbox {
+		if message.ID == messageID {
+			if message.Status != models.OutboxStatusPending || message.ClaimedBy == nil || *message.ClaimedBy != owner {
+				return nil
+			}
+			return message
+		}
+	}
+	return nil
+}
+
+func copyOutboxMessage(message *models.OutboxMessage) models.OutboxMessage {
+	copied := *message
+	copied.Payload = append(json.RawMessage(nil), message.Payload...)
+	if message.ClaimedBy != nil {
+		copied.ClaimedBy = stringPtr(*message.ClaimedBy)
+	}
+	if message.LeaseExpiresAt != nil {
+		copied.LeaseExpiresAt = timePtr(*message.LeaseExpiresAt)
+	}
+	if message.LastError != nil {
+		copied.LastError = stringPtr(*message.LastError)
+	}
+	if message.SentAt != nil {
+		copied.SentAt = timePtr(*message.SentAt)
+	}
+	return copied
+}
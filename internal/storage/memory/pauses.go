@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// PauseTrigger deactivates an active trigger, cancels its pending schedules (backfill ones excepted)
+// and records the pause.
+func (s *Store) PauseTrigger(_ context.Context, triggerID string, event *models.TriggerPauseEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.setTriggerStatus(triggerID, models.TriggerStatusActive, models.TriggerStatusInactive); err != nil {
+		return err
+	}
+	s.cancelPendingSchedules(triggerID)
+	s.insertPauseEvent(triggerID, event)
+	return nil
+}
+
+// ResumeTrigger reactivates an inactive trigger, replaces its pending schedules (backfill ones
+// excepted) with schedules and records the resume.
+func (s *Store) ResumeTrigger(_ context.Context, triggerID string, schedules []models.TriggerSchedule, event *models.TriggerPauseEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.setTriggerStatus(triggerID, models.TriggerStatusInactive, models.TriggerStatusActive); err != nil {
+		return err
+	}
+	s.cancelPendingSchedules(triggerID)
+	for i := range schedules {
+		schedule := schedules[i]
+		s.insertSchedule(&schedule, triggerID)
+		s.notify(schedule.FireAt)
+	}
+	s.insertPauseEvent(triggerID, event)
+	return nil
+}
+
+// ListTriggerPauseEvents returns a trigger's pause history, newest first.
+func (s *Store) ListTriggerPauseEvents(_ context.Context, triggerID string) ([]models.TriggerPauseEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events := []models.TriggerPauseEvent{}
+	for i := len(s.pauseEvents) - 1; i >= 0; i-- {
+		if s.pauseEvents[i].TriggerID == triggerID {
+			events = append(events, copyPauseEvent(s.pauseEvents[i]))
+		}
+	}
+	return events, nil
+}
+
+// setTriggerStatus moves a trigger from one status to another. Callers hold s.mu.
+func (s *Store) setTriggerStatus(triggerID string, from, to models.TriggerStatus) error {
+	trigger, ok := s.triggers[triggerID]
+	if !ok {
+		return storage.ErrTriggerNotFound
+	}
+	if trigger.Status != from {
+		return storage.ErrTriggerStatusConflict
+	}
+
+	trigger.Status = to
+	trigger.UpdatedAt = s.now()
+	return nil
+}
+
+// cancelPendingSchedules cancels a trigger's pending/processing schedules, backfill ones excepted.
+// Callers hold s.mu.
+func (s *Store) cancelPendingSchedules(triggerID string) {
+	now := s.now()
+	for _, schedule := range s.schedules {
+		if schedule.TriggerID != triggerID || schedule.Backfill {
+			continue
+		}
+		if schedule.Status == models.ScheduleStatusPending || schedule.Status == models.ScheduleStatusProcessing {
+			schedule.Status = models.ScheduleStatusCancelled
+			schedule.UpdatedAt = now
+		}
+	}
+}
+
+// insertPauseEvent stores a copy of event, stamping its trigger and creation time. Callers hold s.mu.
+func (s *Store) insertPauseEvent(triggerID string, event *models.TriggerPauseEvent) {
+	event.TriggerID = triggerID
+	event.CreatedAt = s.now()
+	stored := copyPauseEvent(event)
+	s.pauseEvents = append(s.pauseEvents, &stored)
+}
+
+func copyPauseEvent(event *models.TriggerPauseEvent) models.TriggerPauseEvent {
+	copied := *event
+	if event.NextFireAt != nil {
+		copied.NextFireAt = timePtr(event.NextFireAt.UTC())
+	}
+	if event.Reason != nil {
+		copied.Reason = stringPtr(*event.Reason)
+	}
+	return copied
+}
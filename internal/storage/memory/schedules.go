@@ -0,0 +1,366 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// Schedules returns copies of a trigger's schedules in creation order, whatever their status.
+func (s *Store) Schedules(triggerID string) []models.TriggerSchedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedules := []models.TriggerSchedule{}
+	for _, schedule := range s.schedules {
+		if schedule.TriggerID == triggerID {
+			schedules = append(schedules, copySchedule(schedule))
+		}
+	}
+	return schedules
+}
+
+// UpsertTriggerSchedule replaces all pending schedules for a trigger and inserts the provided one.
+// Backfill schedules are left alone: they replay past occurrences, whatever the trigger's schedule.
+func (s *Store) UpsertTriggerSchedule(_ context.Context, triggerID string, schedule *models.TriggerSchedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	for _, existing := range s.schedules {
+		if existing.TriggerID != triggerID || existing.Backfill {
+			continue
+		}
+		if existing.Status == models.ScheduleStatusPending || existing.Status == models.ScheduleStatusProcessing {
+			existing.Status = models.ScheduleStatusCancelled
+			existing.UpdatedAt = now
+		}
+	}
+
+	if schedule == nil {
+		return nil
+	}
+	if _, ok := s.triggers[triggerID]; !ok {
+		return fmt.Errorf("insert schedule: %w", storage.ErrTriggerNotFound)
+	}
+
+	s.insertSchedule(schedule, triggerID)
+	s.notify(schedule.FireAt)
+	return nil
+}
+
+// CreateNextSchedule inserts a new schedule entry for recurring (CRON) triggers.
+func (s *Store) CreateNextSchedule(_ context.Context, schedule *models.TriggerSchedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.triggers[schedule.TriggerID]; !ok {
+		return fmt.Errorf("failed to create next schedule: %w", storage.ErrTriggerNotFound)
+	}
+	s.insertSchedule(schedule, schedule.TriggerID)
+	return nil
+}
+
+// CreateBackfillSchedules inserts the backfill schedules of one trigger and announces the earliest.
+func (s *Store) CreateBackfillSchedules(_ context.Context, schedules []models.TriggerSchedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	earliest := schedules[0].FireAt
+	for i := range schedules {
+		if _, ok := s.triggers[schedules[i].TriggerID]; !ok {
+			return fmt.Errorf("insert backfill schedule: %w", storage.ErrTriggerNotFound)
+		}
+		if schedules[i].FireAt.Before(earliest) {
+			earliest = schedules[i].FireAt
+		}
+	}
+
+	for i := range schedules {
+		schedule := schedules[i]
+		schedule.Backfill = true
+		s.insertSchedule(&schedule, schedule.TriggerID)
+	}
+	s.notify(earliest)
+	return nil
+}
+
+// ClaimDueSchedules atomically claims pending schedules of active triggers that are due to fire
+// (and not backing off), oldest fire_at first, stamping them with owner and a lease.
+func (s *Store) ClaimDueSchedules(_ context.Context, owner string, limit int, lease time.Duration) ([]storage.ScheduleWithTrigger, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	due := []*models.TriggerSchedule{}
+	for _, schedule := range s.schedules {
+		if schedule.Status != models.ScheduleStatusPending || schedule.FireAt.After(now) {
+			continue
+		}
+		if schedule.NextAttemptAt != nil && schedule.NextAttemptAt.After(now) {
+			continue
+		}
+		if !s.triggerActive(schedule.TriggerID) {
+			continue
+		}
+		due = append(due, schedule)
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].FireAt.Before(due[j].FireAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	claimed := make([]storage.ScheduleWithTrigger, 0, len(due))
+	for _, schedule := range due {
+		schedule.Status = models.ScheduleStatusProcessing
+		schedule.ClaimedBy = stringPtr(owner)
+		schedule.ClaimedAt = timePtr(now)
+		schedule.LeaseExpiresAt = timePtr(now.Add(lease))
+		schedule.UpdatedAt = now
+
+		claimed = append(claimed, storage.ScheduleWithTrigger{
+			Schedule: copySchedule(schedule),
+			Trigger:  copyTrigger(s.triggers[schedule.TriggerID]),
+		})
+	}
+
+	return claimed, nil
+}
+
+// NextDueTime returns when the earliest claimable schedule becomes due (fire_at, or next_attempt_at
+// for schedules backing off), or nil when there is no pending schedule for an active trigger.
+func (s *Store) NextDueTime(_ context.Context) (*time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var next *time.Time
+	for _, schedule := range s.schedules {
+		if schedule.Status != models.ScheduleStatusPending || !s.triggerActive(schedule.TriggerID) {
+			continue
+		}
+
+		due := schedule.FireAt
+		if schedule.NextAttemptAt != nil && schedule.NextAttemptAt.After(due) {
+			due = *schedule.NextAttemptAt
+		}
+		if next == nil || due.Before(*next) {
+			next = timePtr(due)
+		}
+	}
+
+	return next, nil
+}
+
+// ExtendScheduleLease pushes the lease of a schedule the owner is still processing.
+// Returns ErrScheduleLeaseLost if the schedule is no longer claimed by owner.
+func (s *Store) ExtendScheduleLease(_ context.Context, scheduleID, owner string, lease time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule := s.ownedSchedule(scheduleID, owner)
+	if schedule == nil {
+		return storage.ErrScheduleLeaseLost
+	}
+
+	now := s.now()
+	schedule.LeaseExpiresAt = timePtr(now.Add(lease))
+	schedule.UpdatedAt = now
+	return nil
+}
+
+// ReclaimExpiredSchedules returns 'processing' schedules whose lease has expired to 'pending'.
+// The returned rows carry the claim that expired.
+func (s *Store) ReclaimExpiredSchedules(_ context.Context, limit int) ([]storage.ScheduleWithTrigger, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	expired := []*models.TriggerSchedule{}
+	for _, schedule := range s.schedules {
+		if schedule.Status == models.ScheduleStatusProcessing &&
+			schedule.LeaseExpiresAt != nil && schedule.LeaseExpiresAt.Before(now) {
+			expired = append(expired, schedule)
+		}
+	}
+
+	sort.SliceStable(expired, func(i, j int) bool {
+		return expired[i].LeaseExpiresAt.Before(*expired[j].LeaseExpiresAt)
+	})
+	if len(expired) > limit {
+		expired = expired[:limit]
+	}
+
+	reclaimed := make([]storage.ScheduleWithTrigger, 0, len(expired))
+	for _, schedule := range expired {
+		reclaimed = append(reclaimed, storage.ScheduleWithTrigger{
+			Schedule: copySchedule(schedule),
+			Trigger:  copyTrigger(s.triggers[schedule.TriggerID]),
+		})
+
+		schedule.Status = models.ScheduleStatusPending
+		schedule.ClaimedBy = nil
+		schedule.ClaimedAt = nil
+		schedule.LeaseExpiresAt = nil
+		schedule.UpdatedAt = now
+	}
+
+	return reclaimed, nil
+}
+
+// UpdateScheduleStatus moves a schedule claimed by owner to a terminal status (without incrementing attempts).
+// Returns ErrScheduleLeaseLost if the row is no longer owned by owner.
+func (s *Store) UpdateScheduleStatus(_ context.Context, scheduleID, owner string, status models.ScheduleStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule := s.ownedSchedule(scheduleID, owner)
+	if schedule == nil {
+		return fmt.Errorf("schedule %s: %w", scheduleID, storage.ErrScheduleLeaseLost)
+	}
+
+	schedule.Status = status
+	schedule.LeaseExpiresAt = nil
+	schedule.UpdatedAt = s.now()
+	return nil
+}
+
+// RevertScheduleToPending releases a schedule claimed by owner for a retry at nextAttemptAt,
+// incrementing attempt_count. Returns ErrScheduleLeaseLost if the row is no longer owned by owner.
+func (s *Store) RevertScheduleToPending(_ context.Context, scheduleID, owner string, nextAttemptAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule := s.ownedSchedule(scheduleID, owner)
+	if schedule == nil {
+		return fmt.Errorf("schedule %s: %w", scheduleID, storage.ErrScheduleLeaseLost)
+	}
+
+	now := s.now()
+	schedule.Status = models.ScheduleStatusPending
+	schedule.ClaimedBy = nil
+	schedule.ClaimedAt = nil
+	schedule.LeaseExpiresAt = nil
+	schedule.AttemptCount++
+	schedule.LastAttemptAt = timePtr(now)
+	schedule.NextAttemptAt = timePtr(nextAttemptAt.UTC())
+	schedule.UpdatedAt = now
+	return nil
+}
+
+// IncrementScheduleAttempt increments the attempt count and updates last_attempt_at.
+func (s *Store) IncrementScheduleAttempt(_ context.Context, scheduleID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	schedule := s.findSchedule(scheduleID)
+	if schedule == nil {
+		return fmt.Errorf("schedule not found: %s", scheduleID)
+	}
+
+	now := s.now()
+	schedule.AttemptCount++
+	schedule.LastAttemptAt = timePtr(now)
+	schedule.UpdatedAt = now
+	return nil
+}
+
+// LatestScheduleNotificationID returns the newest notification ID (0 when there is none).
+func (s *Store) LatestScheduleNotificationID(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return int64(len(s.notifications)), nil
+}
+
+// ListScheduleNotificationsSince returns notifications newer than afterID, oldest first.
+func (s *Store) ListScheduleNotificationsSince(_ context.Context, afterID int64, limit int) ([]storage.ScheduleNotification, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	afterID = max(afterID, 0)
+	if afterID >= int64(len(s.notifications)) {
+		return []storage.ScheduleNotification{}, nil
+	}
+
+	end := min(afterID+int64(limit), int64(len(s.notifications)))
+	return append([]storage.ScheduleNotification{}, s.notifications[afterID:end]...), nil
+}
+
+// insertSchedule stores a copy of schedule for triggerID. Callers hold s.mu.
+func (s *Store) insertSchedule(schedule *models.TriggerSchedule, triggerID string) {
+	now := s.now()
+	stored := copySchedule(schedule)
+	stored.TriggerID = triggerID
+	stored.FireAt = schedule.FireAt.UTC()
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	s.schedules = append(s.schedules, &stored)
+}
+
+// notify appends to the schedule notification feed (IDs start at 1). Callers hold s.mu.
+func (s *Store) notify(fireAt time.Time) {
+	s.notifications = append(s.notifications, storage.ScheduleNotification{
+		ID:     int64(len(s.notifications) + 1),
+		FireAt: fireAt.UTC(),
+	})
+}
+
+// triggerActive reports whether the trigger exists and is active. Callers hold s.mu.
+func (s *Store) triggerActive(triggerID string) bool {
+	trigger, ok := s.triggers[triggerID]
+	return ok && trigger.Status == models.TriggerStatusActive
+}
+
+// findSchedule returns the stored schedule with the given ID, or nil. Callers hold s.mu.
+func (s *Store) findSchedule(scheduleID string) *models.TriggerSchedule {
+	for _, schedule := range s.schedules {
+		if schedule.ID == scheduleID {
+			return schedule
+		}
+	}
+	return nil
+}
+
+// ownedSchedule returns the schedule if it is 'processing' and claimed by owner. Callers hold s.mu.
+func (s *Store) ownedSchedule(scheduleID, owner string) *models.TriggerSchedule {
+	schedule := s.findSchedule(scheduleID)
+	if schedule == nil || schedule.Status != models.ScheduleStatusProcessing ||
+		schedule.ClaimedBy == nil || *schedule.ClaimedBy != owner {
+		return nil
+	}
+	return schedule
+}
+
+func copySchedule(schedule *models.TriggerSchedule) models.TriggerSchedule {
+	copied := *schedule
+	if schedule.ScheduledFor != nil {
+		copied.ScheduledFor = timePtr(schedule.ScheduledFor.UTC())
+	}
+	if schedule.LastAttemptAt != nil {
+		copied.LastAttemptAt = timePtr(*schedule.LastAttemptAt)
+	}
+	if schedule.NextAttemptAt != nil {
+		copied.NextAttemptAt = timePtr(*schedule.NextAttemptAt)
+	}
+	if schedule.ClaimedBy != nil {
+		copied.ClaimedBy = stringPtr(*schedule.ClaimedBy)
+	}
+	if schedule.ClaimedAt != nil {
+		copied.ClaimedAt = timePtr(*schedule.ClaimedAt)
+	}
+	if schedule.LeaseExpiresAt != nil {
+		copied.LeaseExpiresAt = timePtr(*schedule.LeaseExpiresAt)
+	}
+	return copied
+}
@@ -0,0 +1,77 @@
+// Package memory is an in-process implementation of storage.Store with the same semantics as
+// the MySQL client: schedule claiming, leases and retries, pagination, retention filters and
+// cascading deletes. Time comes from the injected clock. It backs simulations and tests, and
+// lets the services run without a MySQL server.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// Store keeps triggers, schedules, event logs, the event outbox, idempotency keys, schedule
+// notifications, calendars, pause history and scheduler leases in memory. Safe for concurrent use;
+// values are copied in and out, so callers never share state with it.
+type Store struct {
+	mu              sync.Mutex
+	clock           clock.Clock
+	triggers        map[string]*models.Trigger
+	triggerOrder    []string                  // insertion order, breaks created_at ties (newest first)
+	schedules       []*models.TriggerSchedule // insertion order, breaks fire_at ties like the primary key scan in MySQL
+	eventLogs       []*models.EventLog
+	outbox          []*models.OutboxMessage   // id order
+	outboxSeq       int64                     // last outbox message id, like AUTO_INCREMENT
+	idempotencyKeys map[string]idempotencyKey // by job id
+	notifications   []storage.ScheduleNotification
+	calendars       map[string]*models.Calendar
+	pauseEvents     []*models.TriggerPauseEvent // insertion order
+	leases          map[string]*models.SchedulerLease
+}
+
+var _ storage.Store = (*Store)(nil)
+
+// NewStore creates an empty store reading time from clk.
+func NewStore(clk clock.Clock) *Store {
+	return &Store{
+		clock:           clk,
+		triggers:        make(map[string]*models.Trigger),
+		idempotencyKeys: make(map[string]idempotencyKey),
+		calendars:       make(map[string]*models.Calendar),
+		leases:          make(map[string]*models.SchedulerLease),
+	}
+}
+
+// idempotencyKey is the event a job is bound to (see storage.IdempotencyStore).
+type idempotencyKey struct {
+	eventID   string
+	createdAt time.Time
+}
+
+// now returns the current time in UTC.
+func (s *Store) now() time.Time {
+	return s.clock.Now().UTC()
+}
+
+// offsetPage applies the MySQL client's LIMIT/OFFSET to n items and returns the [start, end) range.
+func offsetPage(n, page, limit int) (int, int) {
+	start := (page - 1) * limit
+	if start < 0 {
+		start = 0
+	}
+	if start > n {
+		start = n
+	}
+	return start, min(start+limit, n)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
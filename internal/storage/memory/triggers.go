@@ -0,0 +1,219 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// CreateTrigger inserts a trigger (and optional first schedule) atomically.
+func (s *Store) CreateTrigger(_ context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.triggers[trigger.ID]; exists {
+		return fmt.Errorf("insert trigger: duplicate id %s", trigger.ID)
+	}
+
+	now := s.now()
+	stored := *trigger
+	stored.Config = append(json.RawMessage(nil), trigger.Config...)
+	stored.FireCount = 0
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	s.triggers[trigger.ID] = &stored
+	s.triggerOrder = append(s.triggerOrder, trigger.ID)
+
+	if schedule != nil {
+		s.insertSchedule(schedule, trigger.ID)
+		s.notify(schedule.FireAt)
+	}
+
+	return nil
+}
+
+// GetTrigger fetches a trigger and its next scheduled run if any.
+func (s *Store) GetTrigger(_ context.Context, triggerID string) (*models.Trigger, *time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trigger, ok := s.triggers[triggerID]
+	if !ok {
+		return nil, nil, storage.ErrTriggerNotFound
+	}
+
+	copied := copyTrigger(trigger)
+	return &copied, s.nextRun(triggerID), nil
+}
+
+// ListTriggers returns triggers matching the filters with pagination information.
+func (s *Store) ListTriggers(_ context.Context, query models.ListTriggersQuery) ([]models.Trigger, []*time.Time, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	matched := make([]*models.Trigger, 0, len(s.triggerOrder))
+	for i := len(s.triggerOrder) - 1; i >= 0; i-- {
+		trigger := s.triggers[s.triggerOrder[i]]
+		if query.Type != "" && string(trigger.Type) != query.Type {
+			continue
+		}
+		if query.Status != "" && string(trigger.Status) != query.Status {
+			continue
+		}
+		matched = append(matched, trigger)
+	}
+
+	// ORDER BY created_at DESC; newer insertions first on ties
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].CreatedAt.After(matched[j].CreatedAt)
+	})
+
+	start, end := offsetPage(len(matched), query.Page, query.Limit)
+	triggers := make([]models.Trigger, 0, end-start)
+	nextRuns := make([]*time.Time, 0, end-start)
+	for _, trigger := range matched[start:end] {
+		triggers = append(triggers, copyTrigger(trigger))
+		nextRuns = append(nextRuns, s.nextRun(trigger.ID))
+	}
+
+	return triggers, nextRuns, int64(len(matched)), nil
+}
+
+// UpdateTrigger updates the mutable fields of a trigger.
+func (s *Store) UpdateTrigger(_ context.Context, triggerID string, updates map[string]interface{}) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trigger, ok := s.triggers[triggerID]
+	if !ok {
+		return storage.ErrTriggerNotFound
+	}
+
+	updated := *trigger
+	for column, value := range updates {
+		switch column {
+		case "name":
+			updated.Name = fmt.Sprint(value)
+		case "status":
+			updated.Status = models.TriggerStatus(fmt.Sprint(value))
+		case "config":
+			switch config := value.(type) {
+			case string:
+				updated.Config = json.RawMessage(config)
+			case []byte:
+				updated.Config = append(json.RawMessage(nil), config...)
+			default:
+				return fmt.Errorf("update trigger: unsupported config value %T", value)
+			}
+		default:
+			return fmt.Errorf("update trigger: unknown column %q", column)
+		}
+	}
+
+	updated.UpdatedAt = s.now()
+	*trigger = updated
+	return nil
+}
+
+// DeleteTrigger removes a trigger, its schedules and pause history (ON DELETE CASCADE); its event logs
+// lose the trigger reference (ON DELETE SET NULL).
+func (s *Store) DeleteTrigger(_ context.Context, triggerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.triggers[triggerID]; !ok {
+		return storage.ErrTriggerNotFound
+	}
+
+	delete(s.triggers, triggerID)
+	for i, id := range s.triggerOrder {
+		if id == triggerID {
+			s.triggerOrder = append(s.triggerOrder[:i], s.triggerOrder[i+1:]...)
+			break
+		}
+	}
+
+	remaining := s.schedules[:0]
+	for _, schedule := range s.schedules {
+		if schedule.TriggerID != triggerID {
+			remaining = append(remaining, schedule)
+		}
+	}
+	s.schedules = remaining
+
+	pauseEvents := s.pauseEvents[:0]
+	for _, event := range s.pauseEvents {
+		if event.TriggerID != triggerID {
+			pauseEvents = append(pauseEvents, event)
+		}
+	}
+	s.pauseEvents = pauseEvents
+
+	for _, eventLog := range s.eventLogs {
+		if eventLog.TriggerID != nil && *eventLog.TriggerID == triggerID {
+			eventLog.TriggerID = nil
+		}
+	}
+
+	return nil
+}
+
+// DeactivateTrigger marks a trigger as inactive.
+func (s *Store) DeactivateTrigger(_ context.Context, triggerID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trigger, ok := s.triggers[triggerID]
+	if !ok {
+		return fmt.Errorf("trigger not found: %s", triggerID)
+	}
+	trigger.Status = models.TriggerStatusInactive
+	trigger.UpdatedAt = s.now()
+	return nil
+}
+
+// IncrementTriggerFireCount adds one to a trigger's fire count and returns the new count.
+func (s *Store) IncrementTriggerFireCount(_ context.Context, triggerID string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	trigger, ok := s.triggers[triggerID]
+	if !ok {
+		return 0, storage.ErrTriggerNotFound
+	}
+	trigger.FireCount++
+	return trigger.FireCount, nil
+}
+
+// nextRun returns the earliest pending/processing fire_at of a trigger's own (not backfill) schedules.
+// Callers hold s.mu.
+func (s *Store) nextRun(triggerID string) *time.Time {
+	var next *time.Time
+	for _, schedule := range s.schedules {
+		if schedule.TriggerID != triggerID || schedule.Backfill {
+			continue
+		}
+		if schedule.Status != models.ScheduleStatusPending && schedule.Status != models.ScheduleStatusProcessing {
+			continue
+		}
+		if next == nil || schedule.FireAt.Before(*next) {
+			next = timePtr(schedule.FireAt)
+		}
+	}
+	return next
+}
+
+func copyTrigger(trigger *models.Trigger) models.Trigger {
+	copied := *trigger
+	copied.Config = append(json.RawMessage(nil), trigger.Config...)
+	return copied
+}
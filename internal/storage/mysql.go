@@ -1,13 +1,26 @@
 package storage
 
-import "database/sql"
+import (
+	"database/sql"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+)
 
 // MySQLClient wraps direct SQL access for triggers and event logs.
+// Timestamps used in queries come from the injected clock rather than NOW(),
+// so the scheduling logic follows the application's notion of time.
 type MySQLClient struct {
-	db *sql.DB
+	db    *sql.DB
+	clock clock.Clock
+}
+
+// NewMySQLClient wires a sql.DB and clock; pass configured instances from main.
+func NewMySQLClient(db *sql.DB, clk clock.Clock) *MySQLClient {
+	return &MySQLClient{db: db, clock: clk}
 }
 
-// NewMySQLClient wires a sql.DB; pass a configured instance from main.
-func NewMySQLClient(db *sql.DB) *MySQLClient {
-	return &MySQLClient{db: db}
+// now returns the current time in UTC (DATETIME columns are stored in UTC).
+func (c *MySQLClient) now() time.Time {
+	return c.clock.Now().UTC()
 }
@@ -0,0 +1,78 @@
+//go:build integration
+
+package storage_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/storagetest"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// mysqlTables are emptied before each conformance subtest.
+var mysqlTables = []string{
+	"event_outbox",
+	"idempotency_keys",
+	"event_logs",
+	"schedule_notifications",
+	"trigger_pause_events",
+	"trigger_schedules",
+	"triggers",
+	"calendars",
+	"scheduler_leases",
+}
+
+// TestMySQLStore runs the conformance suite against the database in MYSQL_TEST_DSN, which must
+// have db/migrations applied (e.g. the Compose MySQL) and parseTime=true&loc=UTC set. Its tables
+// are truncated, so never point it at a database you care about:
+//
+//	MYSQL_TEST_DSN='appuser:apppassword@tcp(localhost:3306)/event_trigger?parseTime=true&loc=UTC' \
+//		go test -tags=integration ./internal/storage/...
+func TestMySQLStore(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN not set")
+	}
+
+	storagetest.Run(t, func(t *testing.T, clk *clock.Manual) storage.Store {
+		return storage.NewMySQLClient(openMySQL(t, dsn), clk)
+	})
+}
+
+// openMySQL connects to dsn and empties the platform's tables.
+func openMySQL(t *testing.T, dsn string) *sql.DB {
+	t.Helper()
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("open mysql: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// One connection, so the foreign key checks stay off for every truncation
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		t.Fatalf("connect to mysql: %v", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+		t.Fatalf("disable foreign key checks: %v", err)
+	}
+	for _, table := range mysqlTables {
+		if _, err := conn.ExecContext(ctx, "TRUNCATE TABLE "+table); err != nil {
+			t.Fatalf("truncate %s: %v", table, err)
+		}
+	}
+	if _, err := conn.ExecContext(ctx, "SET FOREIGN_KEY_CHECKS = 1"); err != nil {
+		t.Fatalf("enable foreign key checks: %v", err)
+	}
+
+	return db
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// ScheduleNotification announces a schedule written by the API, so sleeping schedulers
+// can wake up early when it is due before their next poll.
+type ScheduleNotification struct {
+	ID     int64
+	FireAt time.Time
+}
+
+// insertScheduleNotification records a new schedule inside the transaction that created it,
+// so schedulers never see a notification for a schedule that was rolled back.
+func insertScheduleNotification(ctx context.Context, tx *sql.Tx, fireAt time.Time) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schedule_notifications (fire_at) VALUES (?)`,
+		fireAt,
+	); err != nil {
+		return fmt.Errorf("insert schedule notification: %w", err)
+	}
+	return nil
+}
+
+// LatestScheduleNotificationID returns the newest notification ID (0 when there is none).
+// Schedulers start watching from here: anything older is covered by their first poll.
+func (c *MySQLClient) LatestScheduleNotificationID(ctx context.Context) (int64, error) {
+	var id sql.NullInt64
+	if err := c.db.QueryRowContext(ctx, `SELECT MAX(id) FROM schedule_notifications`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to query latest schedule notification: %w", err)
+	}
+	return id.Int64, nil
+}
+
+// ListScheduleNotificationsSince returns notifications newer than afterID, oldest first.
+func (c *MySQLClient) ListScheduleNotificationsSince(ctx context.Context, afterID int64, limit int) ([]ScheduleNotification, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT id, fire_at
+		FROM schedule_notifications
+		WHERE id > ?
+		ORDER BY id ASC
+		LIMIT ?
+	`, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schedule notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []ScheduleNotification{}
+	for rows.Next() {
+		var n ScheduleNotification
+		if err := rows.Scan(&n.ID, &n.FireAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schedule notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schedule notifications: %w", err)
+	}
+
+	return notifications, nil
+}
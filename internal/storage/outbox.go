@@ -0,0 +1,301 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+// ErrOutboxLeaseLost is returned when a deliverer acts on an outbox message it no longer holds
+// (its lease expired and a relay claimed the message).
+var ErrOutboxLeaseLost = errors.New("outbox message lease lost")
+
+// ScheduleCompletion identifies the claimed schedule an event fires for, to be completed together
+// with the event (see OutboxStore.RecordEvent).
+type ScheduleCompletion struct {
+	ScheduleID string
+	Owner      string // Scheduler instance holding the schedule's claim
+}
+
+// outboxColumns is the projection scanned by scanOutboxMessage.
+const outboxColumns = `id, event_id, message_key, payload, status, attempt_count, next_attempt_at,
+	claimed_by, lease_expires_at, last_error, created_at, sent_at`
+
+// RecordEvent inserts an event log and its outbox message, completing the schedule that fired it
+// (if any), in one transaction.
+func (c *MySQLClient) RecordEvent(ctx context.Context, eventLog *models.EventLog, message *models.OutboxMessage, completion *ScheduleCompletion) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if completion != nil {
+		var result sql.Result
+		result, err = tx.ExecContext(ctx,
+			`UPDATE trigger_schedules
+			 SET status = 'completed', lease_expires_at = NULL, updated_at = ?
+			 WHERE id = ? AND claimed_by = ? AND status = 'processing'`,
+			c.now(),
+			completion.ScheduleID,
+			completion.Owner,
+		)
+		if err != nil {
+			return fmt.Errorf("complete schedule: %w", err)
+		}
+		var rows int64
+		if rows, err = result.RowsAffected(); err != nil {
+			return fmt.Errorf("get rows affected: %w", err)
+		}
+		if rows == 0 {
+			err = fmt.Errorf("schedule %s: %w", completion.ScheduleID, ErrScheduleLeaseLost)
+			return err
+		}
+	}
+
+	if err = insertEventLog(ctx, tx, eventLog); err != nil {
+		return err
+	}
+
+	var result sql.Result
+	result, err = tx.ExecContext(ctx,
+		`INSERT INTO event_outbox (event_id, message_key, payload, status, attempt_count, next_attempt_at, claimed_by, lease_expires_at, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		message.EventID,
+		message.MessageKey,
+		[]byte(message.Payload),
+		message.Status,
+		message.AttemptCount,
+		message.NextAttemptAt,
+		message.ClaimedBy,
+		message.LeaseExpiresAt,
+		message.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("insert outbox message: %w", err)
+	}
+	if message.ID, err = result.LastInsertId(); err != nil {
+		return fmt.Errorf("get outbox message id: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimOutboxMessages leases due pending messages to owner. The rows are locked with
+// SELECT ... FOR UPDATE SKIP LOCKED, so concurrent relays never claim the same message.
+// Each claim counts as a delivery attempt.
+func (c *MySQLClient) ClaimOutboxMessages(ctx context.Context, owner string, limit int, lease time.Duration) ([]models.OutboxMessage, error) {
+	now := c.now()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var rows *sql.Rows
+	rows, err = tx.QueryContext(ctx, `
+		SELECT `+outboxColumns+`
+		FROM event_outbox
+		WHERE status = 'pending'
+		  AND next_attempt_at <= ?
+		  AND (lease_expires_at IS NULL OR lease_expires_at <= ?)
+		ORDER BY id ASC
+		LIMIT ?
+		FOR UPDATE SKIP LOCKED
+	`, now, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query due outbox messages: %w", err)
+	}
+
+	var messages []models.OutboxMessage
+	messages, err = scanOutboxMessages(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(messages) > 0 {
+		placeholders := make([]string, 0, len(messages))
+		args := make([]interface{}, 0, len(messages)+2)
+		args = append(args, owner, now.Add(lease))
+		for _, message := range messages {
+			placeholders = append(placeholders, "?")
+			args = append(args, message.ID)
+		}
+
+		if _, err = tx.ExecContext(ctx, fmt.Sprintf(`
+			UPDATE event_outbox
+			SET claimed_by = ?,
+			    lease_expires_at = ?,
+			    attempt_count = attempt_count + 1
+			WHERE id IN (%s)
+		`, strings.Join(placeholders, ", ")), args...); err != nil {
+			return nil, fmt.Errorf("claim outbox messages: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	leaseExpiresAt := now.Add(lease)
+	for i := range messages {
+		claimedBy := owner
+		messages[i].ClaimedBy = &claimedBy
+		messages[i].LeaseExpiresAt = &leaseExpiresAt
+		messages[i].AttemptCount++
+	}
+
+	return messages, nil
+}
+
+// MarkOutboxMessageSent marks a message claimed by owner as sent and stamps its event log's
+// published_at, in one transaction.
+func (c *MySQLClient) MarkOutboxMessageSent(ctx context.Context, messageID int64, owner string) error {
+	now := c.now()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var result sql.Result
+	result, err = tx.ExecContext(ctx,
+		`UPDATE event_outbox
+		 SET status = 'sent', sent_at = ?, lease_expires_at = NULL, last_error = NULL
+		 WHERE id = ? AND claimed_by = ? AND status = 'pending'`,
+		now,
+		messageID,
+		owner,
+	)
+	if err != nil {
+		return fmt.Errorf("mark outbox message sent: %w", err)
+	}
+	var rows int64
+	if rows, err = result.RowsAffected(); err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rows == 0 {
+		err = ErrOutboxLeaseLost
+		return err
+	}
+
+	// The event log may be gone already (retention), which is fine
+	if _, err = tx.ExecContext(ctx,
+		`UPDATE event_logs
+		 SET published_at = ?
+		 WHERE id = (SELECT event_id FROM event_outbox WHERE id = ?)`,
+		now,
+		messageID,
+	); err != nil {
+		return fmt.Errorf("stamp event log published_at: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// RetryOutboxMessage releases a message claimed by owner until nextAttemptAt, recording the error.
+func (c *MySQLClient) RetryOutboxMessage(ctx context.Context, messageID int64, owner string, nextAttemptAt time.Time, lastError string) error {
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE event_outbox
+		 SET claimed_by = NULL, lease_expires_at = NULL, next_attempt_at = ?, last_error = ?
+		 WHERE id = ? AND claimed_by = ? AND status = 'pending'`,
+		nextAttemptAt,
+		lastError,
+		messageID,
+		owner,
+	)
+	if err != nil {
+		return fmt.Errorf("release outbox message: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("get rows affected: %w", err)
+	}
+	if rows == 0 {
+		return ErrOutboxLeaseLost
+	}
+
+	return nil
+}
+
+func scanOutboxMessages(rows *sql.Rows) ([]models.OutboxMessage, error) {
+	defer rows.Close()
+
+	messages := []models.OutboxMessage{}
+	for rows.Next() {
+		var message models.OutboxMessage
+		var payload []byte
+		var claimedBy, lastError sql.NullString
+		var leaseExpiresAt, sentAt sql.NullTime
+
+		if err := rows.Scan(
+			&message.ID,
+			&message.EventID,
+			&message.MessageKey,
+			&payload,
+			&message.Status,
+			&message.AttemptCount,
+			&message.NextAttemptAt,
+			&claimedBy,
+			&leaseExpiresAt,
+			&lastError,
+			&message.CreatedAt,
+			&sentAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+
+		message.Payload = payload
+		if claimedBy.Valid {
+			message.ClaimedBy = &claimedBy.String
+		}
+		if leaseExpiresAt.Valid {
+			message.LeaseExpiresAt = &leaseExpiresAt.Time
+		}
+		if lastError.Valid {
+			message.LastError = &lastError.String
+		}
+		if sentAt.Valid {
+			message.SentAt = &sentAt.Time
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate outbox messages: %w", err)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,223 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+// ErrTriggerStatusConflict is returned when pausing a trigger that is not active, or resuming one
+// that is not inactive (including when another request changed its status first).
+var ErrTriggerStatusConflict = errors.New("trigger status conflict")
+
+// PauseTrigger deactivates an active trigger, cancels its pending schedules (backfill ones excepted)
+// and records the pause, in one transaction.
+func (c *MySQLClient) PauseTrigger(ctx context.Context, triggerID string, event *models.TriggerPauseEvent) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = c.setTriggerStatus(ctx, tx, triggerID, models.TriggerStatusActive, models.TriggerStatusInactive); err != nil {
+		return err
+	}
+	if err = c.cancelPendingSchedules(ctx, tx, triggerID); err != nil {
+		return err
+	}
+
+	if err = c.insertPauseEvent(ctx, tx, triggerID, event); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeTrigger reactivates an inactive trigger, replaces its pending schedules (backfill ones
+// excepted) with schedules and records the resume, in one transaction.
+func (c *MySQLClient) ResumeTrigger(ctx context.Context, triggerID string, schedules []models.TriggerSchedule, event *models.TriggerPauseEvent) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = c.setTriggerStatus(ctx, tx, triggerID, models.TriggerStatusInactive, models.TriggerStatusActive); err != nil {
+		return err
+	}
+	// A config update while paused may have left a schedule computed back then
+	if err = c.cancelPendingSchedules(ctx, tx, triggerID); err != nil {
+		return err
+	}
+
+	var earliest time.Time
+	for i, schedule := range schedules {
+		if _, err = tx.ExecContext(ctx,
+			`INSERT INTO trigger_schedules (id, trigger_id, fire_at, scheduled_for, backfill, status, attempt_count)
+			 VALUES (?, ?, ?, ?, ?, ?, 0)`,
+			schedule.ID,
+			triggerID,
+			schedule.FireAt,
+			schedule.ScheduledFor,
+			schedule.Backfill,
+			schedule.Status,
+		); err != nil {
+			return fmt.Errorf("insert schedule: %w", err)
+		}
+		if i == 0 || schedule.FireAt.Before(earliest) {
+			earliest = schedule.FireAt
+		}
+	}
+	if len(schedules) > 0 {
+		if err = insertScheduleNotification(ctx, tx, earliest); err != nil {
+			return err
+		}
+	}
+
+	if err = c.insertPauseEvent(ctx, tx, triggerID, event); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListTriggerPauseEvents returns a trigger's pause history, newest first.
+func (c *MySQLClient) ListTriggerPauseEvents(ctx context.Context, triggerID string) ([]models.TriggerPauseEvent, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT id, trigger_id, action, resume_policy, missed_occurrences, next_fire_at, reason, created_at
+		FROM trigger_pause_events
+		WHERE trigger_id = ?
+		ORDER BY created_at DESC
+	`, triggerID)
+	if err != nil {
+		return nil, fmt.Errorf("query pause events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []models.TriggerPauseEvent{}
+	for rows.Next() {
+		var event models.TriggerPauseEvent
+		var resumePolicy, reason sql.NullString
+		var nextFireAt sql.NullTime
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.TriggerID,
+			&event.Action,
+			&resumePolicy,
+			&event.MissedOccurrences,
+			&nextFireAt,
+			&reason,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan pause event: %w", err)
+		}
+
+		event.ResumePolicy = models.ResumePolicy(resumePolicy.String)
+		if nextFireAt.Valid {
+			event.NextFireAt = &nextFireAt.Time
+		}
+		if reason.Valid {
+			event.Reason = &reason.String
+		}
+
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate pause events: %w", err)
+	}
+
+	return events, nil
+}
+
+// setTriggerStatus moves a trigger from one status to another. Returns ErrTriggerNotFound for unknown
+// IDs and ErrTriggerStatusConflict when the trigger is not in the from status.
+func (c *MySQLClient) setTriggerStatus(ctx context.Context, tx *sql.Tx, triggerID string, from, to models.TriggerStatus) error {
+	var status models.TriggerStatus
+	err := tx.QueryRowContext(ctx, `SELECT status FROM triggers WHERE id = ? FOR UPDATE`, triggerID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTriggerNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("lock trigger: %w", err)
+	}
+	if status != from {
+		return ErrTriggerStatusConflict
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE triggers SET status = ?, updated_at = ? WHERE id = ?`,
+		to,
+		c.now(),
+		triggerID,
+	); err != nil {
+		return fmt.Errorf("update trigger status: %w", err)
+	}
+
+	return nil
+}
+
+// cancelPendingSchedules cancels a trigger's pending/processing schedules, backfill ones excepted.
+func (c *MySQLClient) cancelPendingSchedules(ctx context.Context, tx *sql.Tx, triggerID string) error {
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE trigger_schedules
+		 SET status = 'cancelled', updated_at = ?
+		 WHERE trigger_id = ? AND status IN ('pending', 'processing') AND backfill = FALSE`,
+		c.now(),
+		triggerID,
+	); err != nil {
+		return fmt.Errorf("cancel pending schedules: %w", err)
+	}
+	return nil
+}
+
+// insertPauseEvent records an entry of a trigger's pause history, stamping its trigger and creation time.
+func (c *MySQLClient) insertPauseEvent(ctx context.Context, tx *sql.Tx, triggerID string, event *models.TriggerPauseEvent) error {
+	event.TriggerID = triggerID
+	event.CreatedAt = c.now()
+
+	var resumePolicy interface{}
+	if event.ResumePolicy != "" {
+		resumePolicy = event.ResumePolicy
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO trigger_pause_events (id, trigger_id, action, resume_policy, missed_occurrences, next_fire_at, reason, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		event.ID,
+		triggerID,
+		event.Action,
+		resumePolicy,
+		event.MissedOccurrences,
+		event.NextFireAt,
+		event.Reason,
+		event.CreatedAt,
+	); err != nil {
+		return fmt.Errorf("insert pause event: %w", err)
+	}
+
+	return nil
+}
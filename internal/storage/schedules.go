@@ -4,11 +4,18 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/dhima/event-trigger-platform/internal/models"
 )
 
+// ErrScheduleLeaseLost is returned when a scheduler instance tries to act on a schedule
+// it no longer owns (its lease expired and the row was reclaimed or re-claimed).
+var ErrScheduleLeaseLost = errors.New("schedule lease lost")
+
 // ScheduleWithTrigger combines a trigger schedule with its parent trigger context.
 // Used by the scheduler to have all necessary information for firing triggers.
 type ScheduleWithTrigger struct {
@@ -16,42 +23,137 @@ type ScheduleWithTrigger struct {
 	Trigger  models.Trigger
 }
 
-// GetDueSchedules retrieves pending schedules that are due to fire, along with their trigger context.
-// Uses JOIN query to fetch both schedule and trigger data in a single query.
-// Results are ordered by fire_at ASC to process oldest schedules first.
-func (c *MySQLClient) GetDueSchedules(ctx context.Context, limit int) ([]ScheduleWithTrigger, error) {
+// ClaimDueSchedules atomically claims pending schedules that are due to fire, along with their trigger context.
+// The rows are locked with SELECT ... FOR UPDATE SKIP LOCKED and flipped to 'processing' (stamped with the
+// claiming owner and a lease of the given duration) inside a single transaction, so concurrent scheduler
+// instances never claim the same row. Schedules backing off after a failed attempt are skipped until their
+// next_attempt_at. Results are ordered by fire_at ASC to process oldest schedules first.
+func (c *MySQLClient) ClaimDueSchedules(ctx context.Context, owner string, limit int, lease time.Duration) ([]ScheduleWithTrigger, error) {
+	now := c.now()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
 	query := `
 		SELECT
-			ts.id, ts.trigger_id, ts.fire_at, ts.status, ts.attempt_count, ts.last_attempt_at, ts.created_at, ts.updated_at,
-			t.id, t.name, t.type, t.status, t.config, t.created_at, t.updated_at
+			ts.id, ts.trigger_id, ts.fire_at, ts.scheduled_for, ts.backfill, ts.status, ts.attempt_count, ts.last_attempt_at,
+			ts.next_attempt_at, ts.created_at, ts.updated_at,
+			t.id, t.name, t.type, t.status, t.config, t.fire_count, t.created_at, t.updated_at
 		FROM trigger_schedules ts
 		INNER JOIN triggers t ON ts.trigger_id = t.id
-		WHERE ts.fire_at <= NOW()
+		WHERE ts.fire_at <= ?
+		  AND (ts.next_attempt_at IS NULL OR ts.next_attempt_at <= ?)
 		  AND ts.status = 'pending'
 		  AND t.status = 'active'
 		ORDER BY ts.fire_at ASC
 		LIMIT ?
+		FOR UPDATE OF ts SKIP LOCKED
+	`
+
+	schedules, err := scanSchedulesWithTrigger(tx.QueryContext(ctx, query, now, now, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedules) == 0 {
+		if err = tx.Commit(); err != nil {
+			return nil, fmt.Errorf("commit transaction: %w", err)
+		}
+		return schedules, nil
+	}
+
+	placeholders := make([]string, 0, len(schedules))
+	args := make([]interface{}, 0, len(schedules)+4)
+	args = append(args, owner, now, now.Add(lease), now)
+	for _, s := range schedules {
+		placeholders = append(placeholders, "?")
+		args = append(args, s.Schedule.ID)
+	}
+
+	claimQuery := fmt.Sprintf(`
+		UPDATE trigger_schedules
+		SET status = 'processing',
+		    claimed_by = ?,
+		    claimed_at = ?,
+		    lease_expires_at = ?,
+		    updated_at = ?
+		WHERE id IN (%s)
+		  AND status = 'pending'
+	`, strings.Join(placeholders, ", "))
+
+	if _, err = tx.ExecContext(ctx, claimQuery, args...); err != nil {
+		return nil, fmt.Errorf("claim due schedules: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	leaseExpiresAt := now.Add(lease)
+	for i := range schedules {
+		claimedBy, claimedAt := owner, now
+		schedules[i].Schedule.Status = models.ScheduleStatusProcessing
+		schedules[i].Schedule.ClaimedBy = &claimedBy
+		schedules[i].Schedule.ClaimedAt = &claimedAt
+		schedules[i].Schedule.LeaseExpiresAt = &leaseExpiresAt
+	}
+
+	return schedules, nil
+}
+
+// NextDueTime returns when the earliest claimable schedule becomes due (fire_at, or next_attempt_at for
+// schedules backing off), or nil when there is no pending schedule for an active trigger.
+func (c *MySQLClient) NextDueTime(ctx context.Context) (*time.Time, error) {
+	query := `
+		SELECT MIN(GREATEST(ts.fire_at, COALESCE(ts.next_attempt_at, ts.fire_at)))
+		FROM trigger_schedules ts
+		INNER JOIN triggers t ON ts.trigger_id = t.id
+		WHERE ts.status = 'pending'
+		  AND t.status = 'active'
 	`
 
-	rows, err := c.db.QueryContext(ctx, query, limit)
+	var next sql.NullTime
+	if err := c.db.QueryRowContext(ctx, query).Scan(&next); err != nil {
+		return nil, fmt.Errorf("failed to query next due time: %w", err)
+	}
+
+	if !next.Valid {
+		return nil, nil
+	}
+	return &next.Time, nil
+}
+
+// scanSchedulesWithTrigger scans the schedule + trigger JOIN projection used by the claim query.
+func scanSchedulesWithTrigger(rows *sql.Rows, err error) ([]ScheduleWithTrigger, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to query due schedules: %w", err)
 	}
 	defer rows.Close()
 
-	var schedules []ScheduleWithTrigger
+	schedules := []ScheduleWithTrigger{}
 	for rows.Next() {
 		var s ScheduleWithTrigger
-		var lastAttemptAt sql.NullTime
+		var scheduledFor, lastAttemptAt, nextAttemptAt sql.NullTime
 
 		err := rows.Scan(
 			// Schedule fields
 			&s.Schedule.ID,
 			&s.Schedule.TriggerID,
 			&s.Schedule.FireAt,
+			&scheduledFor,
+			&s.Schedule.Backfill,
 			&s.Schedule.Status,
 			&s.Schedule.AttemptCount,
 			&lastAttemptAt,
+			&nextAttemptAt,
 			&s.Schedule.CreatedAt,
 			&s.Schedule.UpdatedAt,
 			// Trigger fields
@@ -60,6 +162,7 @@ func (c *MySQLClient) GetDueSchedules(ctx context.Context, limit int) ([]Schedul
 			&s.Trigger.Type,
 			&s.Trigger.Status,
 			&s.Trigger.Config,
+			&s.Trigger.FireCount,
 			&s.Trigger.CreatedAt,
 			&s.Trigger.UpdatedAt,
 		)
@@ -68,9 +171,15 @@ func (c *MySQLClient) GetDueSchedules(ctx context.Context, limit int) ([]Schedul
 		}
 
 		// Handle nullable fields
+		if scheduledFor.Valid {
+			s.Schedule.ScheduledFor = &scheduledFor.Time
+		}
 		if lastAttemptAt.Valid {
 			s.Schedule.LastAttemptAt = &lastAttemptAt.Time
 		}
+		if nextAttemptAt.Valid {
+			s.Schedule.NextAttemptAt = &nextAttemptAt.Time
+		}
 
 		schedules = append(schedules, s)
 	}
@@ -82,18 +191,163 @@ func (c *MySQLClient) GetDueSchedules(ctx context.Context, limit int) ([]Schedul
 	return schedules, nil
 }
 
-// UpdateScheduleStatus updates the status of a schedule (without incrementing attempts).
-// This method uses row-level locking (implicitly via UPDATE) to prevent duplicate processing.
-// Used for: pending → processing, processing → completed
-func (c *MySQLClient) UpdateScheduleStatus(ctx context.Context, scheduleID string, status models.ScheduleStatus) error {
+// ExtendScheduleLease pushes the lease of a schedule the owner is still processing.
+// Returns ErrScheduleLeaseLost if the schedule is no longer claimed by owner.
+func (c *MySQLClient) ExtendScheduleLease(ctx context.Context, scheduleID, owner string, lease time.Duration) error {
+	query := `
+		UPDATE trigger_schedules
+		SET lease_expires_at = ?,
+		    updated_at = ?
+		WHERE id = ?
+		  AND claimed_by = ?
+		  AND status = 'processing'
+	`
+
+	now := c.now()
+	result, err := c.db.ExecContext(ctx, query, now.Add(lease), now, scheduleID, owner)
+	if err != nil {
+		return fmt.Errorf("failed to extend schedule lease: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return ErrScheduleLeaseLost
+	}
+
+	return nil
+}
+
+// ReclaimExpiredSchedules returns 'processing' schedules whose lease has expired to 'pending'.
+// The returned rows carry the claim that expired (ClaimedBy/ClaimedAt/LeaseExpiresAt) so the
+// caller can record the recovery.
+func (c *MySQLClient) ReclaimExpiredSchedules(ctx context.Context, limit int) ([]ScheduleWithTrigger, error) {
+	now := c.now()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			ts.id, ts.trigger_id, ts.fire_at, ts.scheduled_for, ts.status, ts.attempt_count, ts.last_attempt_at,
+			ts.claimed_by, ts.claimed_at, ts.lease_expires_at, ts.created_at, ts.updated_at,
+			t.id, t.name, t.type, t.status, t.config, t.fire_count, t.created_at, t.updated_at
+		FROM trigger_schedules ts
+		INNER JOIN triggers t ON ts.trigger_id = t.id
+		WHERE ts.status = 'processing'
+		  AND ts.lease_expires_at < ?
+		ORDER BY ts.lease_expires_at ASC
+		LIMIT ?
+		FOR UPDATE OF ts SKIP LOCKED
+	`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired schedule leases: %w", err)
+	}
+
+	expired := []ScheduleWithTrigger{}
+	for rows.Next() {
+		var s ScheduleWithTrigger
+		var scheduledFor, lastAttemptAt, claimedAt, leaseExpiresAt sql.NullTime
+		var claimedBy sql.NullString
+
+		if err = rows.Scan(
+			&s.Schedule.ID,
+			&s.Schedule.TriggerID,
+			&s.Schedule.FireAt,
+			&scheduledFor,
+			&s.Schedule.Status,
+			&s.Schedule.AttemptCount,
+			&lastAttemptAt,
+			&claimedBy,
+			&claimedAt,
+			&leaseExpiresAt,
+			&s.Schedule.CreatedAt,
+			&s.Schedule.UpdatedAt,
+			&s.Trigger.ID,
+			&s.Trigger.Name,
+			&s.Trigger.Type,
+			&s.Trigger.Status,
+			&s.Trigger.Config,
+			&s.Trigger.FireCount,
+			&s.Trigger.CreatedAt,
+			&s.Trigger.UpdatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan expired schedule lease: %w", err)
+		}
+
+		if scheduledFor.Valid {
+			s.Schedule.ScheduledFor = &scheduledFor.Time
+		}
+		if lastAttemptAt.Valid {
+			s.Schedule.LastAttemptAt = &lastAttemptAt.Time
+		}
+		if claimedBy.Valid {
+			s.Schedule.ClaimedBy = &claimedBy.String
+		}
+		if claimedAt.Valid {
+			s.Schedule.ClaimedAt = &claimedAt.Time
+		}
+		if leaseExpiresAt.Valid {
+			s.Schedule.LeaseExpiresAt = &leaseExpiresAt.Time
+		}
+
+		expired = append(expired, s)
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating expired schedule leases: %w", err)
+	}
+	rows.Close()
+
+	for _, s := range expired {
+		if _, err = tx.ExecContext(ctx, `
+			UPDATE trigger_schedules
+			SET status = 'pending',
+			    claimed_by = NULL,
+			    claimed_at = NULL,
+			    lease_expires_at = NULL,
+			    updated_at = ?
+			WHERE id = ?
+		`, now, s.Schedule.ID); err != nil {
+			return nil, fmt.Errorf("failed to reclaim schedule %s: %w", s.Schedule.ID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return expired, nil
+}
+
+// UpdateScheduleStatus moves a schedule claimed by owner to a terminal status (without incrementing attempts).
+// Claiming (pending → processing) goes through ClaimDueSchedules; this is used for the
+// terminal transitions: processing → completed, processing → cancelled.
+// Returns ErrScheduleLeaseLost if the lease expired and the row is no longer owned by owner.
+func (c *MySQLClient) UpdateScheduleStatus(ctx context.Context, scheduleID, owner string, status models.ScheduleStatus) error {
 	query := `
 		UPDATE trigger_schedules
 		SET status = ?,
-		    updated_at = NOW()
+		    lease_expires_at = NULL,
+		    updated_at = ?
 		WHERE id = ?
+		  AND claimed_by = ?
+		  AND status = 'processing'
 	`
 
-	result, err := c.db.ExecContext(ctx, query, status, scheduleID)
+	result, err := c.db.ExecContext(ctx, query, status, c.now(), scheduleID, owner)
 	if err != nil {
 		return fmt.Errorf("failed to update schedule status: %w", err)
 	}
@@ -104,7 +358,7 @@ func (c *MySQLClient) UpdateScheduleStatus(ctx context.Context, scheduleID strin
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("schedule not found: %s", scheduleID)
+		return fmt.Errorf("schedule %s: %w", scheduleID, ErrScheduleLeaseLost)
 	}
 
 	return nil
@@ -116,12 +370,13 @@ func (c *MySQLClient) IncrementScheduleAttempt(ctx context.Context, scheduleID s
 	query := `
 		UPDATE trigger_schedules
 		SET attempt_count = attempt_count + 1,
-		    last_attempt_at = NOW(),
-		    updated_at = NOW()
+		    last_attempt_at = ?,
+		    updated_at = ?
 		WHERE id = ?
 	`
 
-	result, err := c.db.ExecContext(ctx, query, scheduleID)
+	now := c.now()
+	result, err := c.db.ExecContext(ctx, query, now, now, scheduleID)
 	if err != nil {
 		return fmt.Errorf("failed to increment schedule attempt: %w", err)
 	}
@@ -138,20 +393,28 @@ func (c *MySQLClient) IncrementScheduleAttempt(ctx context.Context, scheduleID s
 	return nil
 }
 
-// RevertScheduleToPending reverts a schedule from 'processing' to 'pending' for retry.
-// Increments attempt_count and updates last_attempt_at.
+// RevertScheduleToPending reverts a schedule claimed by owner from 'processing' to 'pending' for retry.
+// Increments attempt_count, updates last_attempt_at, releases the claim and holds the row back
+// until nextAttemptAt (retry backoff).
 // Used when trigger firing fails but hasn't exceeded max retries.
-func (c *MySQLClient) RevertScheduleToPending(ctx context.Context, scheduleID string) error {
+func (c *MySQLClient) RevertScheduleToPending(ctx context.Context, scheduleID, owner string, nextAttemptAt time.Time) error {
 	query := `
 		UPDATE trigger_schedules
 		SET status = 'pending',
+		    claimed_by = NULL,
+		    claimed_at = NULL,
+		    lease_expires_at = NULL,
 		    attempt_count = attempt_count + 1,
-		    last_attempt_at = NOW(),
-		    updated_at = NOW()
+		    last_attempt_at = ?,
+		    next_attempt_at = ?,
+		    updated_at = ?
 		WHERE id = ?
+		  AND claimed_by = ?
+		  AND status = 'processing'
 	`
 
-	result, err := c.db.ExecContext(ctx, query, scheduleID)
+	now := c.now()
+	result, err := c.db.ExecContext(ctx, query, now, nextAttemptAt.UTC(), now, scheduleID, owner)
 	if err != nil {
 		return fmt.Errorf("failed to revert schedule to pending: %w", err)
 	}
@@ -162,7 +425,7 @@ func (c *MySQLClient) RevertScheduleToPending(ctx context.Context, scheduleID st
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("schedule not found: %s", scheduleID)
+		return fmt.Errorf("schedule %s: %w", scheduleID, ErrScheduleLeaseLost)
 	}
 
 	return nil
@@ -172,14 +435,15 @@ func (c *MySQLClient) RevertScheduleToPending(ctx context.Context, scheduleID st
 // This is called after successfully firing a CRON trigger to schedule the next occurrence.
 func (c *MySQLClient) CreateNextSchedule(ctx context.Context, schedule *models.TriggerSchedule) error {
 	query := `
-		INSERT INTO trigger_schedules (id, trigger_id, fire_at, status, attempt_count)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO trigger_schedules (id, trigger_id, fire_at, scheduled_for, status, attempt_count)
+		VALUES (?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := c.db.ExecContext(ctx, query,
 		schedule.ID,
 		schedule.TriggerID,
 		schedule.FireAt,
+		schedule.ScheduledFor,
 		schedule.Status,
 		schedule.AttemptCount,
 	)
@@ -191,16 +455,63 @@ func (c *MySQLClient) CreateNextSchedule(ctx context.Context, schedule *models.T
 	return nil
 }
 
+// CreateBackfillSchedules inserts the backfill schedules of one trigger in a single transaction and
+// announces the earliest on the schedule notification feed.
+func (c *MySQLClient) CreateBackfillSchedules(ctx context.Context, schedules []models.TriggerSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	earliest := schedules[0].FireAt
+	for _, schedule := range schedules {
+		if _, err = tx.ExecContext(ctx,
+			`INSERT INTO trigger_schedules (id, trigger_id, fire_at, scheduled_for, backfill, status, attempt_count)
+			 VALUES (?, ?, ?, ?, TRUE, ?, 0)`,
+			schedule.ID,
+			schedule.TriggerID,
+			schedule.FireAt,
+			schedule.ScheduledFor,
+			schedule.Status,
+		); err != nil {
+			return fmt.Errorf("insert backfill schedule: %w", err)
+		}
+		if schedule.FireAt.Before(earliest) {
+			earliest = schedule.FireAt
+		}
+	}
+
+	if err = insertScheduleNotification(ctx, tx, earliest); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 // DeactivateTrigger marks a trigger as inactive.
 // This is used for one-time (time_scheduled) triggers after they fire.
 func (c *MySQLClient) DeactivateTrigger(ctx context.Context, triggerID string) error {
 	query := `
 		UPDATE triggers
-		SET status = 'inactive', updated_at = NOW()
+		SET status = 'inactive', updated_at = ?
 		WHERE id = ?
 	`
 
-	result, err := c.db.ExecContext(ctx, query, triggerID)
+	result, err := c.db.ExecContext(ctx, query, c.now(), triggerID)
 	if err != nil {
 		return fmt.Errorf("failed to deactivate trigger: %w", err)
 	}
@@ -217,6 +528,32 @@ func (c *MySQLClient) DeactivateTrigger(ctx context.Context, triggerID string) e
 	return nil
 }
 
+// IncrementTriggerFireCount adds one to a trigger's fire count and returns the new count.
+// LAST_INSERT_ID(expr) hands the incremented value back without a second query.
+func (c *MySQLClient) IncrementTriggerFireCount(ctx context.Context, triggerID string) (int, error) {
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE triggers SET fire_count = LAST_INSERT_ID(fire_count + 1) WHERE id = ?`,
+		triggerID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment trigger fire count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return 0, ErrTriggerNotFound
+	}
+
+	count, err := result.LastInsertId()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read trigger fire count: %w", err)
+	}
+	return int(count), nil
+}
+
 // ParseTriggerConfig parses a trigger's JSON config into a typed struct.
 // This is a helper function to extract endpoint, payload, etc. from trigger config.
 func ParseTriggerConfig(trigger *models.Trigger) (map[string]interface{}, error) {
@@ -236,7 +573,7 @@ func ExtractPayloadFromConfig(triggerType models.TriggerType, config map[string]
 		return config
 	}
 
-	// For time_scheduled and cron_scheduled, payload is in the config
+	// For scheduled trigger types, payload is in the config
 	if payload, ok := config["payload"].(map[string]interface{}); ok {
 		return payload
 	}
@@ -0,0 +1,379 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/mattn/go-sqlite3"
+)
+
+// eventLogColumns is the projection scanned by scanEventLog.
+const eventLogColumns = `id, trigger_id, trigger_type, fired_at, scheduled_for, payload, source,
+	execution_status, error_message, skip_reason, dst_note, acknowledged_at, published_at,
+	delivery_status, delivery_attempts, delivery_status_code, delivery_error, delivery_completed_at, retention_status, is_test_run, created_at`
+
+// CreateEventLog inserts a new event log entry into the database.
+func (c *Client) CreateEventLog(ctx context.Context, eventLog *models.EventLog) error {
+	return insertEventLog(ctx, c.db, eventLog)
+}
+
+// insertEventLog inserts an event log entry, on its own or inside a transaction.
+func insertEventLog(ctx context.Context, db execer, eventLog *models.EventLog) error {
+	var payload interface{}
+	if eventLog.Payload != nil {
+		payload = string(eventLog.Payload)
+	}
+
+	var scheduledFor, acknowledgedAt, publishedAt interface{}
+	if eventLog.ScheduledFor != nil {
+		scheduledFor = eventLog.ScheduledFor.UTC()
+	}
+	if eventLog.AcknowledgedAt != nil {
+		acknowledgedAt = eventLog.AcknowledgedAt.UTC()
+	}
+	if eventLog.PublishedAt != nil {
+		publishedAt = eventLog.PublishedAt.UTC()
+	}
+	var deliveryStatus, deliveryStatusCode, deliveryError, deliveryCompletedAt interface{}
+	var deliveryAttempts int
+	if delivery := eventLog.Delivery; delivery != nil {
+		deliveryStatus, deliveryAttempts, deliveryError = delivery.Status, delivery.Attempts, delivery.Error
+		deliveryCompletedAt = delivery.CompletedAt.UTC()
+		if delivery.StatusCode != nil {
+			deliveryStatusCode = *delivery.StatusCode
+		}
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO event_logs (`+eventLogColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		eventLog.ID,
+		eventLog.TriggerID,
+		eventLog.TriggerType,
+		eventLog.FiredAt.UTC(),
+		scheduledFor,
+		payload,
+		eventLog.Source,
+		eventLog.ExecutionStatus,
+		eventLog.ErrorMessage,
+		eventLog.SkipReason,
+		eventLog.DSTNote,
+		acknowledgedAt,
+		publishedAt,
+		deliveryStatus,
+		deliveryAttempts,
+		deliveryStatusCode,
+		deliveryError,
+		deliveryCompletedAt,
+		eventLog.RetentionStatus,
+		eventLog.IsTestRun,
+		eventLog.CreatedAt.UTC(),
+	)
+	var sqliteErr sqlite3.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+		return fmt.Errorf("failed to create event log %s: %w", eventLog.ID, storage.ErrEventLogExists)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create event log: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateEventLogStatus updates the execution status and error message of an event log.
+// This is used when Kafka publishing fails after the event log is created.
+func (c *Client) UpdateEventLogStatus(ctx context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error {
+	_, err := c.db.ExecContext(ctx,
+		`UPDATE event_logs SET execution_status = ?, error_message = ? WHERE id = ?`,
+		status, errorMessage, eventID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update event log status: %w", err)
+	}
+
+	return nil
+}
+
+// GetEventLog retrieves a single event log by ID (nil if not found).
+func (c *Client) GetEventLog(ctx context.Context, eventID string) (*models.EventLog, error) {
+	row := c.db.QueryRowContext(ctx, `SELECT `+eventLogColumns+` FROM event_logs WHERE id = ?`, eventID)
+
+	eventLog, err := scanEventLog(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil // Event log not found
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event log: %w", err)
+	}
+
+	return eventLog, nil
+}
+
+// ListEventLogs retrieves event logs with filtering and pagination.
+// Returns the list of event logs and the total count for pagination.
+func (c *Client) ListEventLogs(ctx context.Context, query models.ListEventsQuery) ([]models.EventLog, int64, error) {
+	// Default to 'active' retention status if not specified
+	retentionStatus := query.RetentionStatus
+	if retentionStatus == "" {
+		retentionStatus = "active"
+	}
+	whereClauses := []string{"retention_status = ?"}
+	args := []interface{}{retentionStatus}
+
+	if query.TriggerID != "" {
+		whereClauses = append(whereClauses, "trigger_id = ?")
+		args = append(args, query.TriggerID)
+	}
+
+	if query.ExecutionStatus != "" {
+		whereClauses = append(whereClauses, "execution_status = ?")
+		args = append(args, query.ExecutionStatus)
+	}
+
+	if query.Source != "" {
+		whereClauses = append(whereClauses, "source = ?")
+		args = append(args, query.Source)
+	}
+
+	whereClause := "WHERE " + strings.Join(whereClauses, " AND ")
+
+	var totalCount int64
+	if err := c.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM event_logs "+whereClause, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to count event logs: %w", err)
+	}
+
+	page := query.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := query.Limit
+	if limit < 1 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	offset := (page - 1) * limit
+
+	// rowid breaks fired_at ties: newer insertions first
+	listQuery := fmt.Sprintf(`
+		SELECT %s
+		FROM event_logs
+		%s
+		ORDER BY fired_at DESC, rowid DESC
+		LIMIT ? OFFSET ?
+	`, eventLogColumns, whereClause)
+
+	args = append(args, limit, offset)
+
+	rows, err := c.db.QueryContext(ctx, listQuery, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list event logs: %w", err)
+	}
+	defer rows.Close()
+
+	eventLogs := []models.EventLog{}
+	for rows.Next() {
+		eventLog, err := scanEventLog(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan event log: %w", err)
+		}
+		eventLogs = append(eventLogs, *eventLog)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating event logs: %w", err)
+	}
+
+	return eventLogs, totalCount, nil
+}
+
+// GetRunningEventLog returns the trigger's most recently fired running event (nil if none).
+func (c *Client) GetRunningEventLog(ctx context.Context, triggerID string) (*models.EventLog, error) {
+	row := c.db.QueryRowContext(ctx, `
+		SELECT `+eventLogColumns+`
+		FROM event_logs
+		WHERE trigger_id = ? AND execution_status = 'running'
+		ORDER BY fired_at DESC, rowid DESC
+		LIMIT 1
+	`, triggerID)
+
+	eventLog, err := scanEventLog(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get running event log: %w", err)
+	}
+
+	return eventLog, nil
+}
+
+// ReplaceRunningEventLogs marks the trigger's running events as replaced, with reason as their error message.
+func (c *Client) ReplaceRunningEventLogs(ctx context.Context, triggerID string, reason string) (int, error) {
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE event_logs SET execution_status = 'replaced', error_message = ? WHERE trigger_id = ? AND execution_status = 'running'`,
+		reason, triggerID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to replace running event logs: %w", err)
+	}
+
+	replaced, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check replaced event logs: %w", err)
+	}
+
+	return int(replaced), nil
+}
+
+// ExpireRunningEventLogs marks the trigger's running events fired at or before firedBefore as failed,
+// with reason as their error message.
+func (c *Client) ExpireRunningEventLogs(ctx context.Context, triggerID string, firedBefore time.Time, reason string) (int, error) {
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE event_logs SET execution_status = 'failure', error_message = ? WHERE trigger_id = ? AND execution_status = 'running' AND fired_at <= ?`,
+		reason, triggerID, firedBefore.UTC(),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to expire running event logs: %w", err)
+	}
+
+	expired, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to check expired event logs: %w", err)
+	}
+
+	return int(expired), nil
+}
+
+// AcknowledgeEventLog records the outcome of a running event. The status check is part of the
+// update, so an event replaced meanwhile is not acknowledged.
+func (c *Client) AcknowledgeEventLog(ctx context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error {
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE event_logs SET execution_status = ?, error_message = ?, acknowledged_at = ? WHERE id = ? AND execution_status = 'running'`,
+		status, errorMessage, c.now(), eventID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to acknowledge event log: %w", err)
+	}
+
+	acknowledged, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check acknowledged event log: %w", err)
+	}
+	if acknowledged == 0 {
+		return storage.ErrEventLogNotRunning
+	}
+
+	return nil
+}
+
+// RecordEventDelivery stores the outcome of the dispatcher's HTTP call for an event.
+func (c *Client) RecordEventDelivery(ctx context.Context, eventID string, delivery *models.EventDelivery) error {
+	delivery.CompletedAt = c.now()
+
+	result, err := c.db.ExecContext(ctx, `
+		UPDATE event_logs
+		SET delivery_status = ?, delivery_attempts = ?, delivery_status_code = ?, delivery_error = ?, delivery_completed_at = ?
+		WHERE id = ?
+	`, delivery.Status, delivery.Attempts, delivery.StatusCode, delivery.Error, delivery.CompletedAt.UTC(), eventID)
+	if err != nil {
+		return fmt.Errorf("failed to record event delivery: %w", err)
+	}
+
+	recorded, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check recorded event delivery: %w", err)
+	}
+	if recorded == 0 {
+		return storage.ErrEventLogNotFound
+	}
+
+	return nil
+}
+
+// scanner is satisfied by *sql.Row and *sql.Rows.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEventLog(row scanner) (*models.EventLog, error) {
+	var eventLog models.EventLog
+	var triggerID, errorMessage, skipReason, dstNote, payload sql.NullString
+	var scheduledFor, acknowledgedAt, publishedAt, deliveryCompletedAt nullTime
+	var deliveryStatus, deliveryError sql.NullString
+	var deliveryStatusCode sql.NullInt64
+	var deliveryAttempts int
+
+	if err := row.Scan(
+		&eventLog.ID,
+		&triggerID,
+		&eventLog.TriggerType,
+		&eventLog.FiredAt,
+		&scheduledFor,
+		&payload,
+		&eventLog.Source,
+		&eventLog.ExecutionStatus,
+		&errorMessage,
+		&skipReason,
+		&dstNote,
+		&acknowledgedAt,
+		&publishedAt,
+		&deliveryStatus,
+		&deliveryAttempts,
+		&deliveryStatusCode,
+		&deliveryError,
+		&deliveryCompletedAt,
+		&eventLog.RetentionStatus,
+		&eventLog.IsTestRun,
+		&eventLog.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	// Handle nullable fields
+	if triggerID.Valid {
+		eventLog.TriggerID = &triggerID.String
+	}
+	if errorMessage.Valid {
+		eventLog.ErrorMessage = &errorMessage.String
+	}
+	if skipReason.Valid {
+		eventLog.SkipReason = &skipReason.String
+	}
+	if dstNote.Valid {
+		eventLog.DSTNote = &dstNote.String
+	}
+	if payload.Valid {
+		eventLog.Payload = json.RawMessage(payload.String)
+	}
+	eventLog.ScheduledFor = scheduledFor.Ptr()
+	eventLog.AcknowledgedAt = acknowledgedAt.Ptr()
+	eventLog.PublishedAt = publishedAt.Ptr()
+	if deliveryStatus.Valid {
+		eventLog.Delivery = &models.EventDelivery{
+			Status:   models.DeliveryStatus(deliveryStatus.String),
+			Attempts: deliveryAttempts,
+		}
+		if completedAt := deliveryCompletedAt.Ptr(); completedAt != nil {
+			eventLog.Delivery.CompletedAt = *completedAt
+		}
+		if deliveryStatusCode.Valid {
+			statusCode := int(deliveryStatusCode.Int64)
+			eventLog.Delivery.StatusCode = &statusCode
+		}
+		if deliveryError.Valid {
+			eventLog.Delivery.Error = &deliveryError.String
+		}
+	}
+
+	return &eventLog, nil
+}
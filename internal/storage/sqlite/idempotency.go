@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ReserveIdempotencyKey binds jobID to eventID unless an earlier attempt bound it already.
+// Keys are only updated once expired (RebindIdempotencyKey), so the insert and the read back need
+// no transaction.
+func (c *Client) ReserveIdempotencyKey(ctx context.Context, jobID, eventID string) (string, error) {
+	if _, err := c.db.ExecContext(ctx,
+		`INSERT INTO idempotency_keys (job_id, event_id, created_at) VALUES (?, ?, ?)
+		 ON CONFLICT (job_id) DO NOTHING`,
+		jobID,
+		eventID,
+		c.now(),
+	); err != nil {
+		return "", fmt.Errorf("insert idempotency key: %w", err)
+	}
+
+	reserved, err := c.GetIdempotencyKey(ctx, jobID)
+	if err != nil {
+		return "", err
+	}
+	if reserved == "" {
+		return "", fmt.Errorf("idempotency key %s vanished after insert", jobID)
+	}
+
+	return reserved, nil
+}
+
+// RebindIdempotencyKey binds jobID to eventID when it is still bound to staleEventID by a reservation
+// made before reservedBefore, and returns the event ID it is bound to afterwards.
+func (c *Client) RebindIdempotencyKey(ctx context.Context, jobID, staleEventID, eventID string, reservedBefore time.Time) (string, error) {
+	if _, err := c.db.ExecContext(ctx,
+		`UPDATE idempotency_keys SET event_id = ?, created_at = ?
+		 WHERE job_id = ? AND event_id = ? AND created_at < ?`,
+		eventID,
+		c.now(),
+		jobID,
+		staleEventID,
+		reservedBefore.UTC(),
+	); err != nil {
+		return "", fmt.Errorf("rebind idempotency key: %w", err)
+	}
+
+	return c.GetIdempotencyKey(ctx, jobID)
+}
+
+// GetIdempotencyKey returns the event ID bound to jobID, or "" when none is.
+func (c *Client) GetIdempotencyKey(ctx context.Context, jobID string) (string, error) {
+	var eventID string
+	err := c.db.QueryRowContext(ctx, `SELECT event_id FROM idempotency_keys WHERE job_id = ?`, jobID).Scan(&eventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("query idempotency key: %w", err)
+	}
+
+	return eventID, nil
+}
@@ -0,0 +1,71 @@
+-- SQLite schema equivalent to db/migrations 001-009 (MySQL). Enumerated columns are plain
+-- TEXT validated by the services; DATETIME columns hold UTC timestamps written by the client.
+
+CREATE TABLE IF NOT EXISTS triggers (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    type TEXT NOT NULL,                     -- webhook, time_scheduled, cron_scheduled
+    status TEXT NOT NULL DEFAULT 'active',  -- active, inactive
+    config TEXT NOT NULL,                   -- JSON
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_triggers_status ON triggers (status);
+CREATE INDEX IF NOT EXISTS idx_triggers_type ON triggers (type);
+CREATE INDEX IF NOT EXISTS idx_triggers_created_at ON triggers (created_at);
+
+CREATE TABLE IF NOT EXISTS trigger_schedules (
+    id TEXT PRIMARY KEY,
+    trigger_id TEXT NOT NULL REFERENCES triggers (id) ON DELETE CASCADE,
+    fire_at DATETIME NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending', -- pending, processing, completed, cancelled, skipped
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    last_attempt_at DATETIME NULL,
+    next_attempt_at DATETIME NULL,
+    claimed_by TEXT NULL,
+    claimed_at DATETIME NULL,
+    lease_expires_at DATETIME NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_trigger_schedules_status_fire_at ON trigger_schedules (status, fire_at);
+CREATE INDEX IF NOT EXISTS idx_trigger_schedules_status_lease_expires_at ON trigger_schedules (status, lease_expires_at);
+CREATE INDEX IF NOT EXISTS idx_trigger_schedules_trigger_id ON trigger_schedules (trigger_id);
+
+CREATE TABLE IF NOT EXISTS event_logs (
+    id TEXT PRIMARY KEY,
+    trigger_id TEXT NULL REFERENCES triggers (id) ON DELETE SET NULL, -- NULL for manual/test runs
+    trigger_type TEXT NOT NULL,
+    fired_at DATETIME NOT NULL,
+    scheduled_for DATETIME NULL,
+    payload TEXT NULL,                                -- JSON
+    source TEXT NOT NULL,                             -- webhook, scheduler, manual-test
+    execution_status TEXT NOT NULL DEFAULT 'success', -- success, failure
+    error_message TEXT NULL,
+    retention_status TEXT NOT NULL DEFAULT 'active',  -- active, archived, deleted
+    is_test_run BOOLEAN NOT NULL DEFAULT 0,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_logs_fired_at ON event_logs (fired_at);
+CREATE INDEX IF NOT EXISTS idx_event_logs_trigger_id ON event_logs (trigger_id);
+CREATE INDEX IF NOT EXISTS idx_event_logs_retention_status ON event_logs (retention_status);
+CREATE INDEX IF NOT EXISTS idx_event_logs_execution_status ON event_logs (execution_status);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    job_id TEXT PRIMARY KEY,
+    event_id TEXT NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_created_at ON idempotency_keys (created_at);
+
+CREATE TABLE IF NOT EXISTS schedule_notifications (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    fire_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_schedule_notifications_created_at ON schedule_notifications (created_at);
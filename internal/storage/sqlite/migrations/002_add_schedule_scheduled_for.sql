@@ -0,0 +1,2 @@
+-- CRON occurrence a schedule stands for when fire_at is offset by jitter/spread (NULL means fire_at).
+ALTER TABLE trigger_schedules ADD COLUMN scheduled_for DATETIME;
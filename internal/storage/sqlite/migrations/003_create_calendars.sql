@@ -0,0 +1,14 @@
+-- Business calendars referenced by recurring triggers (db/migrations/012). holidays and
+-- windows are JSON arrays.
+CREATE TABLE IF NOT EXISTS calendars (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    holidays TEXT NOT NULL,
+    windows TEXT NOT NULL,
+    created_at DATETIME NOT NULL,
+    updated_at DATETIME NOT NULL
+);
+
+-- Reason an occurrence was logged with execution_status 'skipped'.
+ALTER TABLE event_logs ADD COLUMN skip_reason TEXT;
@@ -0,0 +1,2 @@
+-- Number of occurrences the scheduler fired for a trigger, enforced against the config's max_fires.
+ALTER TABLE triggers ADD COLUMN fire_count INTEGER NOT NULL DEFAULT 0;
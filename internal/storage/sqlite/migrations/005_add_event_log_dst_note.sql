@@ -0,0 +1,2 @@
+-- How a daylight saving transition affected a CRON occurrence (db/migrations/014).
+ALTER TABLE event_logs ADD COLUMN dst_note TEXT;
@@ -0,0 +1,2 @@
+-- Backfill schedules replay past occurrences of a trigger beside its regular schedule.
+ALTER TABLE trigger_schedules ADD COLUMN backfill INTEGER NOT NULL DEFAULT 0;
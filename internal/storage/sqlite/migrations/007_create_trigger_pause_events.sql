@@ -0,0 +1,13 @@
+-- Pause/resume history of triggers (db/migrations/016).
+CREATE TABLE IF NOT EXISTS trigger_pause_events (
+    id TEXT PRIMARY KEY,
+    trigger_id TEXT NOT NULL REFERENCES triggers (id) ON DELETE CASCADE,
+    action TEXT NOT NULL,        -- pause, resume
+    resume_policy TEXT NULL,     -- fire_once, skip (resume only)
+    missed_occurrences INTEGER NOT NULL DEFAULT 0,
+    next_fire_at DATETIME NULL,
+    reason TEXT NULL,
+    created_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_trigger_pause_events_trigger_id ON trigger_pause_events (trigger_id);
@@ -0,0 +1,4 @@
+-- Running events (concurrency_policy forbid/replace) record when their consumer acknowledged them.
+ALTER TABLE event_logs ADD COLUMN acknowledged_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_event_logs_trigger_id_execution_status ON event_logs (trigger_id, execution_status);
@@ -0,0 +1,9 @@
+-- Leader lease of the scheduler's leader-election mode (db/migrations/018).
+CREATE TABLE IF NOT EXISTS scheduler_leases (
+    name TEXT PRIMARY KEY,
+    holder TEXT NOT NULL,
+    fencing_token INTEGER NOT NULL,
+    acquired_at DATETIME NOT NULL,
+    renewed_at DATETIME NOT NULL,
+    expires_at DATETIME NOT NULL
+);
@@ -0,0 +1,19 @@
+-- Transactional outbox of trigger events (db/migrations/019).
+CREATE TABLE IF NOT EXISTS event_outbox (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    event_id TEXT NOT NULL UNIQUE,
+    message_key TEXT NOT NULL,
+    payload TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',  -- pending, sent
+    attempt_count INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at DATETIME NOT NULL,
+    claimed_by TEXT NULL,
+    lease_expires_at DATETIME NULL,
+    last_error TEXT NULL,
+    created_at DATETIME NOT NULL,
+    sent_at DATETIME NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_outbox_status_next_attempt_at ON event_outbox (status, next_attempt_at);
+
+ALTER TABLE event_logs ADD COLUMN published_at DATETIME;
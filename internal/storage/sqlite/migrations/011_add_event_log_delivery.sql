@@ -0,0 +1,6 @@
+-- Outcome of the dispatcher's HTTP call for an event (NULL delivery_status: not dispatched yet).
+ALTER TABLE event_logs ADD COLUMN delivery_status TEXT CHECK (delivery_status IN ('delivered', 'failed'));
+ALTER TABLE event_logs ADD COLUMN delivery_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE event_logs ADD COLUMN delivery_status_code INTEGER;
+ALTER TABLE event_logs ADD COLUMN delivery_error TEXT;
+ALTER TABLE event_logs ADD COLUMN delivery_completed_at DATETIME;
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// insertScheduleNotification records a new schedule inside the transaction that created it,
+// so schedulers never see a notification for a schedule that was rolled back.
+func (c *Client) insertScheduleNotification(ctx context.Context, tx *sql.Tx, fireAt time.Time) error {
+	if _, err := tx.ExecContext(ctx,
+		`INSERT INTO schedule_notifications (fire_at, created_at) VALUES (?, ?)`,
+		fireAt.UTC(), c.now(),
+	); err != nil {
+		return fmt.Errorf("insert schedule notification: %w", err)
+	}
+	return nil
+}
+
+// LatestScheduleNotificationID returns the newest notification ID (0 when there is none).
+// Schedulers start watching from here: anything older is covered by their first poll.
+func (c *Client) LatestScheduleNotificationID(ctx context.Context) (int64, error) {
+	var id sql.NullInt64
+	if err := c.db.QueryRowContext(ctx, `SELECT MAX(id) FROM schedule_notifications`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to query latest schedule notification: %w", err)
+	}
+	return id.Int64, nil
+}
+
+// ListScheduleNotificationsSince returns notifications newer than afterID, oldest first.
+func (c *Client) ListScheduleNotificationsSince(ctx context.Context, afterID int64, limit int) ([]storage.ScheduleNotification, error) {
+	rows, err := c.db.QueryContext(ctx, `
+		SELECT id, fire_at
+		FROM schedule_notifications
+		WHERE id > ?
+		ORDER BY id ASC
+		LIMIT ?
+	`, afterID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query schedule notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := []storage.ScheduleNotification{}
+	for rows.Next() {
+		var n storage.ScheduleNotification
+		if err := rows.Scan(&n.ID, &n.FireAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schedule notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating schedule notifications: %w", err)
+	}
+
+	return notifications, nil
+}
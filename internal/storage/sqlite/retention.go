@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"go.uber.org/zap"
+)
+
+// DefaultRetentionInterval matches the most frequent MySQL retention event (archive_old_events).
+const DefaultRetentionInterval = 5 * time.Minute
+
+// RetentionResult counts the rows changed by one retention pass.
+type RetentionResult struct {
+	ArchivedEventLogs            int64
+	DeletedEventLogs             int64
+	DeletedIdempotencyKeys       int64
+	DeletedScheduleNotifications int64
+	DeletedOutboxMessages        int64
+}
+
+// ApplyRetention performs one pass of the lifecycle the MySQL EVENT scheduler runs in the
+// background (db/migrations/004, 009 and 019): archive active event logs after
+// storage.EventLogArchiveAfter, delete event logs after storage.EventLogDeleteAfter, and prune
+// idempotency keys, schedule notifications and sent outbox messages.
+func (c *Client) ApplyRetention(ctx context.Context) (RetentionResult, error) {
+	now := c.now()
+	var result RetentionResult
+
+	steps := []struct {
+		name     string
+		query    string
+		cutoff   time.Time
+		affected *int64
+	}{
+		{
+			name:     "archive event logs",
+			query:    `UPDATE event_logs SET retention_status = 'archived' WHERE retention_status = 'active' AND fired_at < ?`,
+			cutoff:   now.Add(-storage.EventLogArchiveAfter),
+			affected: &result.ArchivedEventLogs,
+		},
+		{
+			name:     "delete event logs",
+			query:    `DELETE FROM event_logs WHERE fired_at < ?`,
+			cutoff:   now.Add(-storage.EventLogDeleteAfter),
+			affected: &result.DeletedEventLogs,
+		},
+		{
+			name:     "delete idempotency keys",
+			query:    `DELETE FROM idempotency_keys WHERE created_at < ?`,
+			cutoff:   now.Add(-storage.IdempotencyKeyDeleteAfter),
+			affected: &result.DeletedIdempotencyKeys,
+		},
+		{
+			name:     "delete schedule notifications",
+			query:    `DELETE FROM schedule_notifications WHERE created_at < ?`,
+			cutoff:   now.Add(-storage.ScheduleNotificationDeleteAfter),
+			affected: &result.DeletedScheduleNotifications,
+		},
+		{
+			name:     "delete sent outbox messages",
+			query:    `DELETE FROM event_outbox WHERE status = 'sent' AND sent_at < ?`,
+			cutoff:   now.Add(-storage.OutboxMessageDeleteAfter),
+			affected: &result.DeletedOutboxMessages,
+		},
+	}
+
+	for _, step := range steps {
+		res, err := c.db.ExecContext(ctx, step.query, step.cutoff)
+		if err != nil {
+			return result, fmt.Errorf("%s: %w", step.name, err)
+		}
+		if *step.affected, err = res.RowsAffected(); err != nil {
+			return result, fmt.Errorf("%s: rows affected: %w", step.name, err)
+		}
+	}
+
+	return result, nil
+}
+
+// RunRetention applies retention every interval until ctx is cancelled. It replaces the MySQL
+// retention events for SQLite deployments; run it in one process per database.
+func (c *Client) RunRetention(ctx context.Context, interval time.Duration, logger *zap.Logger) {
+	if interval <= 0 {
+		interval = DefaultRetentionInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := c.ApplyRetention(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			logger.Error("sqlite retention pass failed", zap.Error(err))
+		case result != (RetentionResult{}):
+			logger.Info("sqlite retention pass completed",
+				zap.Int64("archived_event_logs", result.ArchivedEventLogs),
+				zap.Int64("deleted_event_logs", result.DeletedEventLogs),
+				zap.Int64("deleted_idempotency_keys", result.DeletedIdempotencyKeys),
+				zap.Int64("deleted_schedule_notifications", result.DeletedScheduleNotifications),
+				zap.Int64("deleted_outbox_messages", result.DeletedOutboxMessages))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
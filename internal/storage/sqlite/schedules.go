@@ -0,0 +1,515 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// UpsertTriggerSchedule replaces all pending schedules for a trigger and inserts the provided one.
+// Backfill schedules are left alone: they replay past occurrences, whatever the trigger's schedule.
+func (c *Client) UpsertTriggerSchedule(ctx context.Context, triggerID string, schedule *models.TriggerSchedule) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if _, err = tx.ExecContext(
+		ctx,
+		`UPDATE trigger_schedules
+		 SET status = 'cancelled', updated_at = ?
+		 WHERE trigger_id = ? AND status IN ('pending', 'processing') AND backfill = 0`,
+		c.now(),
+		triggerID,
+	); err != nil {
+		return fmt.Errorf("cancel existing schedules: %w", err)
+	}
+
+	if schedule != nil {
+		if err = c.insertSchedule(ctx, tx, triggerID, schedule); err != nil {
+			return fmt.Errorf("insert schedule: %w", err)
+		}
+
+		if err = c.insertScheduleNotification(ctx, tx, schedule.FireAt); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CreateNextSchedule inserts a new schedule entry for recurring (CRON) triggers.
+// This is called after successfully firing a CRON trigger to schedule the next occurrence.
+func (c *Client) CreateNextSchedule(ctx context.Context, schedule *models.TriggerSchedule) error {
+	if err := c.insertSchedule(ctx, c.db, schedule.TriggerID, schedule); err != nil {
+		return fmt.Errorf("failed to create next schedule: %w", err)
+	}
+	return nil
+}
+
+// CreateBackfillSchedules inserts the backfill schedules of one trigger in a single transaction and
+// announces the earliest on the schedule notification feed.
+func (c *Client) CreateBackfillSchedules(ctx context.Context, schedules []models.TriggerSchedule) error {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	earliest := schedules[0].FireAt
+	for i := range schedules {
+		schedule := schedules[i]
+		schedule.Backfill = true
+		if err = c.insertSchedule(ctx, tx, schedule.TriggerID, &schedule); err != nil {
+			return fmt.Errorf("insert backfill schedule: %w", err)
+		}
+		if schedule.FireAt.Before(earliest) {
+			earliest = schedule.FireAt
+		}
+	}
+
+	if err = c.insertScheduleNotification(ctx, tx, earliest); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// execer is satisfied by *sql.DB and *sql.Tx.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (c *Client) insertSchedule(ctx context.Context, db execer, triggerID string, schedule *models.TriggerSchedule) error {
+	var scheduledFor interface{}
+	if schedule.ScheduledFor != nil {
+		scheduledFor = schedule.ScheduledFor.UTC()
+	}
+
+	now := c.now()
+	_, err := db.ExecContext(ctx,
+		`INSERT INTO trigger_schedules (id, trigger_id, fire_at, scheduled_for, backfill, status, attempt_count, created_at, updated_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		schedule.ID,
+		triggerID,
+		schedule.FireAt.UTC(),
+		scheduledFor,
+		schedule.Backfill,
+		schedule.Status,
+		schedule.AttemptCount,
+		now,
+		now,
+	)
+	return err
+}
+
+// ClaimDueSchedules atomically claims pending schedules that are due to fire, along with their trigger context.
+// The select and the flip to 'processing' (stamped with the claiming owner and a lease of the given duration)
+// run in one IMMEDIATE transaction, which holds SQLite's write lock, so concurrent scheduler processes never
+// claim the same row. Schedules backing off after a failed attempt are skipped until their next_attempt_at.
+// Results are ordered by fire_at ASC to process oldest schedules first.
+func (c *Client) ClaimDueSchedules(ctx context.Context, owner string, limit int, lease time.Duration) ([]storage.ScheduleWithTrigger, error) {
+	now := c.now()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query := `
+		SELECT
+			ts.id, ts.trigger_id, ts.fire_at, ts.scheduled_for, ts.backfill, ts.status, ts.attempt_count, ts.last_attempt_at,
+			ts.next_attempt_at, ts.created_at, ts.updated_at,
+			t.id, t.name, t.type, t.status, t.config, t.fire_count, t.created_at, t.updated_at
+		FROM trigger_schedules ts
+		INNER JOIN triggers t ON ts.trigger_id = t.id
+		WHERE ts.fire_at <= ?
+		  AND (ts.next_attempt_at IS NULL OR ts.next_attempt_at <= ?)
+		  AND ts.status = 'pending'
+		  AND t.status = 'active'
+		ORDER BY ts.fire_at ASC, ts.rowid ASC
+		LIMIT ?
+	`
+
+	schedules, err := scanSchedulesWithTrigger(tx.QueryContext(ctx, query, now, now, limit))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(schedules) > 0 {
+		placeholders := make([]string, 0, len(schedules))
+		args := make([]interface{}, 0, len(schedules)+4)
+		args = append(args, owner, now, now.Add(lease), now)
+		for _, s := range schedules {
+			placeholders = append(placeholders, "?")
+			args = append(args, s.Schedule.ID)
+		}
+
+		claimQuery := fmt.Sprintf(`
+			UPDATE trigger_schedules
+			SET status = 'processing',
+			    claimed_by = ?,
+			    claimed_at = ?,
+			    lease_expires_at = ?,
+			    updated_at = ?
+			WHERE id IN (%s)
+			  AND status = 'pending'
+		`, strings.Join(placeholders, ", "))
+
+		if _, err = tx.ExecContext(ctx, claimQuery, args...); err != nil {
+			return nil, fmt.Errorf("claim due schedules: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	leaseExpiresAt := now.Add(lease)
+	for i := range schedules {
+		claimedBy, claimedAt := owner, now
+		schedules[i].Schedule.Status = models.ScheduleStatusProcessing
+		schedules[i].Schedule.ClaimedBy = &claimedBy
+		schedules[i].Schedule.ClaimedAt = &claimedAt
+		schedules[i].Schedule.LeaseExpiresAt = &leaseExpiresAt
+	}
+
+	return schedules, nil
+}
+
+// NextDueTime returns when the earliest claimable schedule becomes due (fire_at, or next_attempt_at for
+// schedules backing off), or nil when there is no pending schedule for an active trigger.
+func (c *Client) NextDueTime(ctx context.Context) (*time.Time, error) {
+	query := `
+		SELECT MIN(MAX(ts.fire_at, COALESCE(ts.next_attempt_at, ts.fire_at)))
+		FROM trigger_schedules ts
+		INNER JOIN triggers t ON ts.trigger_id = t.id
+		WHERE ts.status = 'pending'
+		  AND t.status = 'active'
+	`
+
+	var next nullTime
+	if err := c.db.QueryRowContext(ctx, query).Scan(&next); err != nil {
+		return nil, fmt.Errorf("failed to query next due time: %w", err)
+	}
+
+	return next.Ptr(), nil
+}
+
+// scanSchedulesWithTrigger scans the schedule + trigger JOIN projection used by the claim query.
+func scanSchedulesWithTrigger(rows *sql.Rows, err error) ([]storage.ScheduleWithTrigger, error) {
+	if err != nil {
+		return nil, fmt.Errorf("failed to query due schedules: %w", err)
+	}
+	defer rows.Close()
+
+	schedules := []storage.ScheduleWithTrigger{}
+	for rows.Next() {
+		var s storage.ScheduleWithTrigger
+		var scheduledFor, lastAttemptAt, nextAttemptAt nullTime
+		var config string
+
+		err := rows.Scan(
+			// Schedule fields
+			&s.Schedule.ID,
+			&s.Schedule.TriggerID,
+			&s.Schedule.FireAt,
+			&scheduledFor,
+			&s.Schedule.Backfill,
+			&s.Schedule.Status,
+			&s.Schedule.AttemptCount,
+			&lastAttemptAt,
+			&nextAttemptAt,
+			&s.Schedule.CreatedAt,
+			&s.Schedule.UpdatedAt,
+			// Trigger fields
+			&s.Trigger.ID,
+			&s.Trigger.Name,
+			&s.Trigger.Type,
+			&s.Trigger.Status,
+			&config,
+			&s.Trigger.FireCount,
+			&s.Trigger.CreatedAt,
+			&s.Trigger.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan schedule with trigger: %w", err)
+		}
+
+		s.Schedule.ScheduledFor = scheduledFor.Ptr()
+		s.Schedule.LastAttemptAt = lastAttemptAt.Ptr()
+		s.Schedule.NextAttemptAt = nextAttemptAt.Ptr()
+		s.Trigger.Config = jsonRawMessage(config)
+
+		schedules = append(schedules, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating due schedules: %w", err)
+	}
+
+	return schedules, nil
+}
+
+// ExtendScheduleLease pushes the lease of a schedule the owner is still processing.
+// Returns ErrScheduleLeaseLost if the schedule is no longer claimed by owner.
+func (c *Client) ExtendScheduleLease(ctx context.Context, scheduleID, owner string, lease time.Duration) error {
+	query := `
+		UPDATE trigger_schedules
+		SET lease_expires_at = ?,
+		    updated_at = ?
+		WHERE id = ?
+		  AND claimed_by = ?
+		  AND status = 'processing'
+	`
+
+	now := c.now()
+	result, err := c.db.ExecContext(ctx, query, now.Add(lease), now, scheduleID, owner)
+	if err != nil {
+		return fmt.Errorf("failed to extend schedule lease: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrScheduleLeaseLost
+	}
+
+	return nil
+}
+
+// ReclaimExpiredSchedules returns 'processing' schedules whose lease has expired to 'pending'.
+// The returned rows carry the claim that expired (ClaimedBy/ClaimedAt/LeaseExpiresAt) so the
+// caller can record the recovery.
+func (c *Client) ReclaimExpiredSchedules(ctx context.Context, limit int) ([]storage.ScheduleWithTrigger, error) {
+	now := c.now()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			ts.id, ts.trigger_id, ts.fire_at, ts.scheduled_for, ts.status, ts.attempt_count, ts.last_attempt_at,
+			ts.claimed_by, ts.claimed_at, ts.lease_expires_at, ts.created_at, ts.updated_at,
+			t.id, t.name, t.type, t.status, t.config, t.fire_count, t.created_at, t.updated_at
+		FROM trigger_schedules ts
+		INNER JOIN triggers t ON ts.trigger_id = t.id
+		WHERE ts.status = 'processing'
+		  AND ts.lease_expires_at < ?
+		ORDER BY ts.lease_expires_at ASC
+		LIMIT ?
+	`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query expired schedule leases: %w", err)
+	}
+
+	expired := []storage.ScheduleWithTrigger{}
+	for rows.Next() {
+		var s storage.ScheduleWithTrigger
+		var scheduledFor, lastAttemptAt, claimedAt, leaseExpiresAt nullTime
+		var claimedBy sql.NullString
+		var config string
+
+		if err = rows.Scan(
+			&s.Schedule.ID,
+			&s.Schedule.TriggerID,
+			&s.Schedule.FireAt,
+			&scheduledFor,
+			&s.Schedule.Status,
+			&s.Schedule.AttemptCount,
+			&lastAttemptAt,
+			&claimedBy,
+			&claimedAt,
+			&leaseExpiresAt,
+			&s.Schedule.CreatedAt,
+			&s.Schedule.UpdatedAt,
+			&s.Trigger.ID,
+			&s.Trigger.Name,
+			&s.Trigger.Type,
+			&s.Trigger.Status,
+			&config,
+			&s.Trigger.FireCount,
+			&s.Trigger.CreatedAt,
+			&s.Trigger.UpdatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan expired schedule lease: %w", err)
+		}
+
+		s.Schedule.ScheduledFor = scheduledFor.Ptr()
+		s.Schedule.LastAttemptAt = lastAttemptAt.Ptr()
+		if claimedBy.Valid {
+			s.Schedule.ClaimedBy = &claimedBy.String
+		}
+		s.Schedule.ClaimedAt = claimedAt.Ptr()
+		s.Schedule.LeaseExpiresAt = leaseExpiresAt.Ptr()
+		s.Trigger.Config = jsonRawMessage(config)
+
+		expired = append(expired, s)
+	}
+	if err = rows.Err(); err != nil {
+		rows.Close()
+		return nil, fmt.Errorf("error iterating expired schedule leases: %w", err)
+	}
+	rows.Close()
+
+	for _, s := range expired {
+		if _, err = tx.ExecContext(ctx, `
+			UPDATE trigger_schedules
+			SET status = 'pending',
+			    claimed_by = NULL,
+			    claimed_at = NULL,
+			    lease_expires_at = NULL,
+			    updated_at = ?
+			WHERE id = ?
+		`, now, s.Schedule.ID); err != nil {
+			return nil, fmt.Errorf("failed to reclaim schedule %s: %w", s.Schedule.ID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return expired, nil
+}
+
+// UpdateScheduleStatus moves a schedule claimed by owner to a terminal status (without incrementing attempts).
+// Returns ErrScheduleLeaseLost if the lease expired and the row is no longer owned by owner.
+func (c *Client) UpdateScheduleStatus(ctx context.Context, scheduleID, owner string, status models.ScheduleStatus) error {
+	query := `
+		UPDATE trigger_schedules
+		SET status = ?,
+		    lease_expires_at = NULL,
+		    updated_at = ?
+		WHERE id = ?
+		  AND claimed_by = ?
+		  AND status = 'processing'
+	`
+
+	result, err := c.db.ExecContext(ctx, query, status, c.now(), scheduleID, owner)
+	if err != nil {
+		return fmt.Errorf("failed to update schedule status: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("schedule %s: %w", scheduleID, storage.ErrScheduleLeaseLost)
+	}
+
+	return nil
+}
+
+// IncrementScheduleAttempt increments the attempt count and updates last_attempt_at.
+func (c *Client) IncrementScheduleAttempt(ctx context.Context, scheduleID string) error {
+	query := `
+		UPDATE trigger_schedules
+		SET attempt_count = attempt_count + 1,
+		    last_attempt_at = ?,
+		    updated_at = ?
+		WHERE id = ?
+	`
+
+	now := c.now()
+	result, err := c.db.ExecContext(ctx, query, now, now, scheduleID)
+	if err != nil {
+		return fmt.Errorf("failed to increment schedule attempt: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("schedule not found: %s", scheduleID)
+	}
+
+	return nil
+}
+
+// RevertScheduleToPending reverts a schedule claimed by owner from 'processing' to 'pending' for retry.
+// Increments attempt_count, updates last_attempt_at, releases the claim and holds the row back
+// until nextAttemptAt (retry backoff).
+func (c *Client) RevertScheduleToPending(ctx context.Context, scheduleID, owner string, nextAttemptAt time.Time) error {
+	query := `
+		UPDATE trigger_schedules
+		SET status = 'pending',
+		    claimed_by = NULL,
+		    claimed_at = NULL,
+		    lease_expires_at = NULL,
+		    attempt_count = attempt_count + 1,
+		    last_attempt_at = ?,
+		    next_attempt_at = ?,
+		    updated_at = ?
+		WHERE id = ?
+		  AND claimed_by = ?
+		  AND status = 'processing'
+	`
+
+	now := c.now()
+	result, err := c.db.ExecContext(ctx, query, now, nextAttemptAt.UTC(), now, scheduleID, owner)
+	if err != nil {
+		return fmt.Errorf("failed to revert schedule to pending: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("schedule %s: %w", scheduleID, storage.ErrScheduleLeaseLost)
+	}
+
+	return nil
+}
@@ -0,0 +1,212 @@
+// Package sqlite is an embedded implementation of storage.Store for single-node deployments.
+// It has the same semantics as the MySQL client, ships its own schema migrations and replaces
+// the MySQL EVENT scheduler (retention, cleanup) with an in-process loop, see RunRetention.
+//
+// SQLite serializes writers: transactions start with BEGIN IMMEDIATE and each process uses a
+// single connection, so schedule claims are atomic across the API and scheduler processes
+// sharing the database file without SELECT ... FOR UPDATE SKIP LOCKED.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	_ "github.com/mattn/go-sqlite3" // registers the "sqlite3" driver
+)
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// busyTimeout is how long a connection waits for another process's write transaction.
+const busyTimeout = 5 * time.Second
+
+// Client implements storage.Store on a SQLite database.
+// Timestamps used in queries come from the injected clock.
+type Client struct {
+	db    *sql.DB
+	clock clock.Clock
+}
+
+var _ storage.Store = (*Client)(nil)
+
+// NewClient wires a database opened with Open and a clock; pass configured instances from main.
+func NewClient(db *sql.DB, clk clock.Clock) *Client {
+	return &Client{db: db, clock: clk}
+}
+
+// Open opens (creating if needed) the database file at path and applies pending migrations.
+// ":memory:" opens a private in-memory database. path may carry extra driver options
+// (e.g. "data/platform.db?_synchronous=NORMAL").
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	if path == "" {
+		return nil, fmt.Errorf("sqlite database path is required")
+	}
+
+	params := url.Values{}
+	params.Set("_busy_timeout", strconv.FormatInt(busyTimeout.Milliseconds(), 10))
+	params.Set("_foreign_keys", "1")
+	params.Set("_journal_mode", "WAL")
+	params.Set("_txlock", "immediate")
+
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	db, err := sql.Open("sqlite3", "file:"+path+separator+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("open sqlite database: %w", err)
+	}
+
+	// One connection per process: SQLite has a single writer anyway, and an in-memory
+	// database only lives as long as its connection.
+	db.SetMaxOpenConns(1)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+
+	if err := Migrate(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// Migrate applies the embedded migrations that are not yet recorded in schema_migrations, in order.
+func Migrate(ctx context.Context, db *sql.DB) error {
+	if _, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INTEGER PRIMARY KEY,
+			applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`); err != nil {
+		return fmt.Errorf("create schema_migrations: %w", err)
+	}
+
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return fmt.Errorf("list migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		base := strings.TrimPrefix(name, "migrations/")
+		version, err := strconv.Atoi(strings.SplitN(base, "_", 2)[0])
+		if err != nil {
+			return fmt.Errorf("migration %s: invalid version prefix", base)
+		}
+
+		script, err := migrations.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("read migration %s: %w", base, err)
+		}
+
+		if err := applyMigration(ctx, db, version, string(script)); err != nil {
+			return fmt.Errorf("apply migration %s: %w", base, err)
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs script and records version in one transaction, unless version is already applied.
+func applyMigration(ctx context.Context, db *sql.DB, version int, script string) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	var applied int
+	if err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations WHERE version = ?`, version).Scan(&applied); err != nil {
+		return fmt.Errorf("check schema_migrations: %w", err)
+	}
+	if applied > 0 {
+		return tx.Commit()
+	}
+
+	if _, err = tx.ExecContext(ctx, script); err != nil {
+		return err
+	}
+	if _, err = tx.ExecContext(ctx, `INSERT INTO schema_migrations (version) VALUES (?)`, version); err != nil {
+		return fmt.Errorf("record migration: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	return nil
+}
+
+// now returns the current time in UTC (DATETIME columns are stored in UTC).
+func (c *Client) now() time.Time {
+	return c.clock.Now().UTC()
+}
+
+// timestampLayouts are the formats DATETIME values are read back in: the driver writes the first.
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02T15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+}
+
+// nullTime scans DATETIME values. The driver only converts columns declared DATETIME; computed
+// values (subqueries, MIN/MAX) come back as text, so both are accepted.
+type nullTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Scan implements sql.Scanner.
+func (t *nullTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		t.Time, t.Valid = time.Time{}, false
+		return nil
+	case time.Time:
+		t.Time, t.Valid = v.UTC(), true
+		return nil
+	case []byte:
+		return t.parse(string(v))
+	case string:
+		return t.parse(v)
+	default:
+		return fmt.Errorf("unsupported timestamp value %T", value)
+	}
+}
+
+func (t *nullTime) parse(value string) error {
+	value = strings.TrimSuffix(value, "Z")
+	for _, layout := range timestampLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, time.UTC); err == nil {
+			t.Time, t.Valid = parsed.UTC(), true
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid timestamp %q", value)
+}
+
+// Ptr returns the scanned time, or nil for NULL.
+func (t nullTime) Ptr() *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	value := t.Time
+	return &value
+}
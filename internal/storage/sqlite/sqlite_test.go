@@ -0,0 +1,22 @@
+package sqlite_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/dhima/event-trigger-platform/internal/storage/sqlite"
+	"github.com/dhima/event-trigger-platform/internal/storage/storagetest"
+)
+
+func TestSQLiteStore(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T, clk *clock.Manual) storage.Store {
+		db, err := sqlite.Open(context.Background(), ":memory:")
+		if err != nil {
+			t.Fatalf("open sqlite: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return sqlite.NewClient(db, clk)
+	})
+}
@@ -0,0 +1,292 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// triggerColumns are the columns UpdateTrigger accepts.
+var triggerColumns = map[string]bool{"name": true, "status": true, "config": true}
+
+// CreateTrigger inserts a trigger (and optional first schedule) atomically.
+func (c *Client) CreateTrigger(ctx context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	now := c.now()
+	if _, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO triggers (id, name, type, status, config, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		trigger.ID,
+		trigger.Name,
+		trigger.Type,
+		trigger.Status,
+		string(trigger.Config),
+		now,
+		now,
+	); err != nil {
+		return fmt.Errorf("insert trigger: %w", err)
+	}
+
+	if schedule != nil {
+		if err = c.insertSchedule(ctx, tx, trigger.ID, schedule); err != nil {
+			return fmt.Errorf("insert trigger schedule: %w", err)
+		}
+
+		if err = c.insertScheduleNotification(ctx, tx, schedule.FireAt); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetTrigger fetches a trigger and its next scheduled run if any.
+func (c *Client) GetTrigger(ctx context.Context, triggerID string) (*models.Trigger, *time.Time, error) {
+	row := c.db.QueryRowContext(
+		ctx,
+		`SELECT id, name, type, status, config, fire_count, created_at, updated_at
+		 FROM triggers WHERE id = ?`,
+		triggerID,
+	)
+
+	var t models.Trigger
+	var config string
+	if err := row.Scan(&t.ID, &t.Name, &t.Type, &t.Status, &config, &t.FireCount, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, storage.ErrTriggerNotFound
+		}
+		return nil, nil, fmt.Errorf("scan trigger: %w", err)
+	}
+
+	t.Config = jsonRawMessage(config)
+
+	next, err := c.getNextSchedule(ctx, triggerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &t, next, nil
+}
+
+// ListTriggers returns triggers matching the filters with pagination information.
+func (c *Client) ListTriggers(ctx context.Context, query models.ListTriggersQuery) ([]models.Trigger, []*time.Time, int64, error) {
+	criteria := make([]string, 0, 2)
+	args := make([]interface{}, 0, 4)
+
+	if query.Type != "" {
+		criteria = append(criteria, "type = ?")
+		args = append(args, query.Type)
+	}
+	if query.Status != "" {
+		criteria = append(criteria, "status = ?")
+		args = append(args, query.Status)
+	}
+
+	where := ""
+	if len(criteria) > 0 {
+		where = "WHERE " + strings.Join(criteria, " AND ")
+	}
+
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM triggers %s", where)
+
+	var total int64
+	if err := c.db.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
+		return nil, nil, 0, fmt.Errorf("count triggers: %w", err)
+	}
+
+	offset := (query.Page - 1) * query.Limit
+	argsWithPagination := append(append([]interface{}{}, args...), query.Limit, offset)
+
+	// rowid breaks created_at ties: newer insertions first
+	dataQuery := fmt.Sprintf(`
+		SELECT id, name, type, status, config, fire_count, created_at, updated_at,
+			(
+				SELECT fire_at FROM trigger_schedules
+				WHERE trigger_id = triggers.id
+				  AND status IN ('pending', 'processing')
+				  AND backfill = 0
+				ORDER BY fire_at ASC
+				LIMIT 1
+			) AS next_fire_at
+		FROM triggers
+		%s
+		ORDER BY created_at DESC, rowid DESC
+		LIMIT ? OFFSET ?`, where)
+
+	rows, err := c.db.QueryContext(ctx, dataQuery, argsWithPagination...)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("query triggers: %w", err)
+	}
+	defer rows.Close()
+
+	triggers := make([]models.Trigger, 0)
+	nextRuns := make([]*time.Time, 0)
+
+	for rows.Next() {
+		var trigger models.Trigger
+		var config string
+		var nextFire nullTime
+		if err := rows.Scan(&trigger.ID, &trigger.Name, &trigger.Type, &trigger.Status, &config, &trigger.FireCount, &trigger.CreatedAt, &trigger.UpdatedAt, &nextFire); err != nil {
+			return nil, nil, 0, fmt.Errorf("scan trigger row: %w", err)
+		}
+		trigger.Config = jsonRawMessage(config)
+
+		triggers = append(triggers, trigger)
+		nextRuns = append(nextRuns, nextFire.Ptr())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, nil, 0, fmt.Errorf("iterate triggers: %w", err)
+	}
+
+	return triggers, nextRuns, total, nil
+}
+
+// UpdateTrigger updates the mutable fields of a trigger.
+func (c *Client) UpdateTrigger(ctx context.Context, triggerID string, updates map[string]interface{}) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	setParts := make([]string, 0, len(updates)+1)
+	args := make([]interface{}, 0, len(updates)+2)
+
+	for column, value := range updates {
+		if !triggerColumns[column] {
+			return fmt.Errorf("update trigger: unknown column %q", column)
+		}
+		if config, ok := value.([]byte); ok {
+			value = string(config)
+		}
+		setParts = append(setParts, fmt.Sprintf("%s = ?", column))
+		args = append(args, value)
+	}
+
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, c.now(), triggerID)
+
+	query := fmt.Sprintf("UPDATE triggers SET %s WHERE id = ?", strings.Join(setParts, ", "))
+	res, err := c.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("update trigger: %w", err)
+	}
+
+	rowsAffected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return storage.ErrTriggerNotFound
+	}
+
+	return nil
+}
+
+// DeleteTrigger removes a trigger and its schedules.
+func (c *Client) DeleteTrigger(ctx context.Context, triggerID string) error {
+	res, err := c.db.ExecContext(ctx, "DELETE FROM triggers WHERE id = ?", triggerID)
+	if err != nil {
+		return fmt.Errorf("delete trigger: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return storage.ErrTriggerNotFound
+	}
+
+	return nil
+}
+
+// DeactivateTrigger marks a trigger as inactive.
+// This is used for one-time (time_scheduled) triggers after they fire.
+func (c *Client) DeactivateTrigger(ctx context.Context, triggerID string) error {
+	result, err := c.db.ExecContext(ctx,
+		`UPDATE triggers SET status = 'inactive', updated_at = ? WHERE id = ?`,
+		c.now(), triggerID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate trigger: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("trigger not found: %s", triggerID)
+	}
+
+	return nil
+}
+
+// IncrementTriggerFireCount adds one to a trigger's fire count and returns the new count.
+func (c *Client) IncrementTriggerFireCount(ctx context.Context, triggerID string) (int, error) {
+	var count int
+	err := c.db.QueryRowContext(ctx,
+		`UPDATE triggers SET fire_count = fire_count + 1 WHERE id = ? RETURNING fire_count`,
+		triggerID,
+	).Scan(&count)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, storage.ErrTriggerNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment trigger fire count: %w", err)
+	}
+	return count, nil
+}
+
+func (c *Client) getNextSchedule(ctx context.Context, triggerID string) (*time.Time, error) {
+	row := c.db.QueryRowContext(
+		ctx,
+		`SELECT fire_at
+		 FROM trigger_schedules
+		 WHERE trigger_id = ? AND status IN ('pending', 'processing') AND backfill = 0
+		 ORDER BY fire_at ASC
+		 LIMIT 1`,
+		triggerID,
+	)
+
+	var next nullTime
+	if err := row.Scan(&next); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("scan next schedule: %w", err)
+	}
+
+	return next.Ptr(), nil
+}
+
+func jsonRawMessage(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+	return json.RawMessage(value)
+}
@@ -0,0 +1,1718 @@
+// Package storagetest is the conformance suite for storage.Store implementations. Every backend
+// must pass it, so services behave the same whichever store they are wired to:
+//
+//	func TestMemoryStore(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T, clk *clock.Manual) storage.Store {
+//			return memory.NewStore(clk)
+//		})
+//	}
+//
+// newStore must return an empty store that reads time from clk. For MySQL that means a
+// client built with NewMySQLClient(db, clk) over freshly truncated tables.
+package storagetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/google/uuid"
+)
+
+// lease is the claim duration used throughout the suite.
+const lease = 30 * time.Second
+
+// Factory creates an empty store that reads time from clk.
+type Factory func(t *testing.T, clk *clock.Manual) storage.Store
+
+// Run executes the conformance suite against stores created by newStore.
+func Run(t *testing.T, newStore Factory) {
+	tests := []struct {
+		name string
+		run  func(t *testing.T, s *suite)
+	}{
+		{"TriggerCRUD", testTriggerCRUD},
+		{"TriggerNotFound", testTriggerNotFound},
+		{"ListTriggers", testListTriggers},
+		{"DeleteTriggerCascades", testDeleteTriggerCascades},
+		{"UpsertTriggerSchedule", testUpsertTriggerSchedule},
+		{"ClaimDueSchedules", testClaimDueSchedules},
+		{"ConcurrentClaims", testConcurrentClaims},
+		{"ClaimSkipsInactiveTriggers", testClaimSkipsInactiveTriggers},
+		{"TriggerFireCount", testTriggerFireCount},
+		{"ScheduleStatusTransitions", testScheduleStatusTransitions},
+		{"RevertScheduleToPending", testRevertScheduleToPending},
+		{"LeaseExpiry", testLeaseExpiry},
+		{"NextDueTime", testNextDueTime},
+		{"CreateNextSchedule", testCreateNextSchedule},
+		{"BackfillSchedules", testBackfillSchedules},
+		{"PauseResumeTrigger", testPauseResumeTrigger},
+		{"ScheduleNotifications", testScheduleNotifications},
+		{"EventLogs", testEventLogs},
+		{"ListEventLogs", testListEventLogs},
+		{"SkippedEventLogs", testSkippedEventLogs},
+		{"RunningEventLogs", testRunningEventLogs},
+		{"ExpireRunningEventLogs", testExpireRunningEventLogs},
+		{"EventDelivery", testEventDelivery},
+		{"EventOutbox", testEventOutbox},
+		{"IdempotencyKeys", testIdempotencyKeys},
+		{"SchedulerLeadership", testSchedulerLeadership},
+		{"CalendarCRUD", testCalendarCRUD},
+		{"ListCalendars", testListCalendars},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Whole seconds: MySQL DATETIME columns do not keep fractions.
+			clk := clock.NewManual(time.Now().UTC().Truncate(time.Second))
+			tt.run(t, &suite{t: t, ctx: context.Background(), clock: clk, store: newStore(t, clk)})
+		})
+	}
+}
+
+// suite carries one subtest's store and clock, with helpers that fail the test on unexpected errors.
+type suite struct {
+	t     *testing.T
+	ctx   context.Context
+	clock *clock.Manual
+	store storage.Store
+}
+
+func (s *suite) createTrigger(name string, triggerType models.TriggerType, fireAt *time.Time) (*models.Trigger, *models.TriggerSchedule) {
+	s.t.Helper()
+
+	trigger := &models.Trigger{
+		ID:     uuid.New().String(),
+		Name:   name,
+		Type:   triggerType,
+		Status: models.TriggerStatusActive,
+		Config: json.RawMessage(`{"endpoint":"https://example.com/hook"}`),
+	}
+
+	var schedule *models.TriggerSchedule
+	if fireAt != nil {
+		schedule = newSchedule(trigger.ID, *fireAt)
+	}
+
+	if err := s.store.CreateTrigger(s.ctx, trigger, schedule); err != nil {
+		s.t.Fatalf("CreateTrigger: %v", err)
+	}
+	return trigger, schedule
+}
+
+func (s *suite) claim(owner string, limit int) []storage.ScheduleWithTrigger {
+	s.t.Helper()
+
+	claimed, err := s.store.ClaimDueSchedules(s.ctx, owner, limit, lease)
+	if err != nil {
+		s.t.Fatalf("ClaimDueSchedules: %v", err)
+	}
+	return claimed
+}
+
+func (s *suite) nextDue() *time.Time {
+	s.t.Helper()
+
+	next, err := s.store.NextDueTime(s.ctx)
+	if err != nil {
+		s.t.Fatalf("NextDueTime: %v", err)
+	}
+	return next
+}
+
+func (s *suite) at(offset time.Duration) time.Time {
+	return s.clock.Now().Add(offset)
+}
+
+func newSchedule(triggerID string, fireAt time.Time) *models.TriggerSchedule {
+	return &models.TriggerSchedule{
+		ID:        uuid.New().String(),
+		TriggerID: triggerID,
+		FireAt:    fireAt.UTC(),
+		Status:    models.ScheduleStatusPending,
+	}
+}
+
+func testTriggerCRUD(t *testing.T, s *suite) {
+	fireAt := s.at(time.Hour)
+	created, _ := s.createTrigger("nightly", models.TriggerTypeTimeScheduled, &fireAt)
+
+	trigger, nextRun, err := s.store.GetTrigger(s.ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger: %v", err)
+	}
+	if trigger.Name != "nightly" || trigger.Type != models.TriggerTypeTimeScheduled || trigger.Status != models.TriggerStatusActive {
+		t.Errorf("GetTrigger = %+v, want the created trigger", trigger)
+	}
+	assertJSONEqual(t, trigger.Config, created.Config)
+	assertTime(t, "next run", nextRun, &fireAt)
+
+	config := json.RawMessage(`{"endpoint":"https://example.com/other"}`)
+	err = s.store.UpdateTrigger(s.ctx, created.ID, map[string]interface{}{
+		"name":   "renamed",
+		"status": string(models.TriggerStatusInactive),
+		"config": string(config),
+	})
+	if err != nil {
+		t.Fatalf("UpdateTrigger: %v", err)
+	}
+
+	trigger, _, err = s.store.GetTrigger(s.ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger after update: %v", err)
+	}
+	if trigger.Name != "renamed" || trigger.Status != models.TriggerStatusInactive {
+		t.Errorf("after update got name=%q status=%q", trigger.Name, trigger.Status)
+	}
+	assertJSONEqual(t, trigger.Config, config)
+
+	if err := s.store.UpdateTrigger(s.ctx, created.ID, map[string]interface{}{"status": string(models.TriggerStatusActive)}); err != nil {
+		t.Fatalf("UpdateTrigger: %v", err)
+	}
+	if err := s.store.DeactivateTrigger(s.ctx, created.ID); err != nil {
+		t.Fatalf("DeactivateTrigger: %v", err)
+	}
+	trigger, _, _ = s.store.GetTrigger(s.ctx, created.ID)
+	if trigger.Status != models.TriggerStatusInactive {
+		t.Errorf("status after DeactivateTrigger = %q, want inactive", trigger.Status)
+	}
+
+	if err := s.store.DeleteTrigger(s.ctx, created.ID); err != nil {
+		t.Fatalf("DeleteTrigger: %v", err)
+	}
+	if _, _, err := s.store.GetTrigger(s.ctx, created.ID); !errors.Is(err, storage.ErrTriggerNotFound) {
+		t.Errorf("GetTrigger after delete: err = %v, want ErrTriggerNotFound", err)
+	}
+}
+
+func testTriggerNotFound(t *testing.T, s *suite) {
+	missing := uuid.New().String()
+
+	if _, _, err := s.store.GetTrigger(s.ctx, missing); !errors.Is(err, storage.ErrTriggerNotFound) {
+		t.Errorf("GetTrigger: err = %v, want ErrTriggerNotFound", err)
+	}
+	if err := s.store.UpdateTrigger(s.ctx, missing, map[string]interface{}{"name": "x"}); !errors.Is(err, storage.ErrTriggerNotFound) {
+		t.Errorf("UpdateTrigger: err = %v, want ErrTriggerNotFound", err)
+	}
+	if err := s.store.DeleteTrigger(s.ctx, missing); !errors.Is(err, storage.ErrTriggerNotFound) {
+		t.Errorf("DeleteTrigger: err = %v, want ErrTriggerNotFound", err)
+	}
+	if err := s.store.DeactivateTrigger(s.ctx, missing); err == nil {
+		t.Error("DeactivateTrigger: expected an error for an unknown trigger")
+	}
+}
+
+func testListTriggers(t *testing.T, s *suite) {
+	ids := map[models.TriggerType][]string{}
+	for i := 0; i < 5; i++ {
+		triggerType := models.TriggerTypeWebhook
+		if i%2 == 1 {
+			triggerType = models.TriggerTypeCronScheduled
+		}
+		trigger, _ := s.createTrigger(fmt.Sprintf("trigger-%d", i), triggerType, nil)
+		ids[triggerType] = append(ids[triggerType], trigger.ID)
+	}
+	if err := s.store.DeactivateTrigger(s.ctx, ids[models.TriggerTypeWebhook][0]); err != nil {
+		t.Fatalf("DeactivateTrigger: %v", err)
+	}
+
+	all, nextRuns, total, err := s.store.ListTriggers(s.ctx, models.ListTriggersQuery{Page: 1, Limit: 100})
+	if err != nil {
+		t.Fatalf("ListTriggers: %v", err)
+	}
+	if total != 5 || len(all) != 5 || len(nextRuns) != 5 {
+		t.Fatalf("ListTriggers = %d triggers, %d next runs, total %d; want 5, 5, 5", len(all), len(nextRuns), total)
+	}
+	for i := 1; i < len(all); i++ {
+		if all[i].CreatedAt.After(all[i-1].CreatedAt) {
+			t.Errorf("triggers not ordered by created_at DESC at index %d", i)
+		}
+	}
+
+	// Pages partition the result set (created_at ties make the order within a second unspecified).
+	seen := map[string]bool{}
+	for page := 1; page <= 3; page++ {
+		triggers, _, total, err := s.store.ListTriggers(s.ctx, models.ListTriggersQuery{Page: page, Limit: 2})
+		if err != nil {
+			t.Fatalf("ListTriggers page %d: %v", page, err)
+		}
+		if total != 5 {
+			t.Errorf("page %d total = %d, want 5", page, total)
+		}
+		if want := min(2, 5-(page-1)*2); len(triggers) != want {
+			t.Errorf("page %d has %d triggers, want %d", page, len(triggers), want)
+		}
+		for _, trigger := range triggers {
+			if seen[trigger.ID] {
+				t.Errorf("trigger %s returned on more than one page", trigger.ID)
+			}
+			seen[trigger.ID] = true
+		}
+	}
+	if len(seen) != 5 {
+		t.Errorf("pages returned %d distinct triggers, want 5", len(seen))
+	}
+
+	webhooks, _, total, err := s.store.ListTriggers(s.ctx, models.ListTriggersQuery{
+		Type: string(models.TriggerTypeWebhook), Page: 1, Limit: 100,
+	})
+	if err != nil {
+		t.Fatalf("ListTriggers by type: %v", err)
+	}
+	assertIDs(t, "type=webhook", triggerIDs(webhooks), ids[models.TriggerTypeWebhook])
+	if total != 3 {
+		t.Errorf("type=webhook total = %d, want 3", total)
+	}
+
+	active, _, total, err := s.store.ListTriggers(s.ctx, models.ListTriggersQuery{
+		Type: string(models.TriggerTypeWebhook), Status: string(models.TriggerStatusActive), Page: 1, Limit: 100,
+	})
+	if err != nil {
+		t.Fatalf("ListTriggers by type and status: %v", err)
+	}
+	assertIDs(t, "type=webhook status=active", triggerIDs(active), ids[models.TriggerTypeWebhook][1:])
+	if total != 2 {
+		t.Errorf("type=webhook status=active total = %d, want 2", total)
+	}
+}
+
+func testDeleteTriggerCascades(t *testing.T, s *suite) {
+	fireAt := s.at(-time.Minute)
+	trigger, _ := s.createTrigger("doomed", models.TriggerTypeTimeScheduled, &fireAt)
+
+	eventLog := newEventLog(&trigger.ID, s.at(0))
+	if err := s.store.CreateEventLog(s.ctx, eventLog); err != nil {
+		t.Fatalf("CreateEventLog: %v", err)
+	}
+
+	if err := s.store.DeleteTrigger(s.ctx, trigger.ID); err != nil {
+		t.Fatalf("DeleteTrigger: %v", err)
+	}
+
+	if claimed := s.claim("owner-a", 10); len(claimed) != 0 {
+		t.Errorf("claimed %d schedules of a deleted trigger", len(claimed))
+	}
+	if next := s.nextDue(); next != nil {
+		t.Errorf("NextDueTime = %v after deleting the only trigger, want nil", next)
+	}
+
+	stored, err := s.store.GetEventLog(s.ctx, eventLog.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored == nil {
+		t.Fatal("event log was deleted with its trigger")
+	}
+	if stored.TriggerID != nil {
+		t.Errorf("event log trigger_id = %s after trigger delete, want NULL", *stored.TriggerID)
+	}
+}
+
+func testUpsertTriggerSchedule(t *testing.T, s *suite) {
+	first := s.at(-time.Minute)
+	trigger, _ := s.createTrigger("moving", models.TriggerTypeTimeScheduled, &first)
+
+	replacement := s.at(-30 * time.Second)
+	schedule := newSchedule(trigger.ID, replacement)
+	if err := s.store.UpsertTriggerSchedule(s.ctx, trigger.ID, schedule); err != nil {
+		t.Fatalf("UpsertTriggerSchedule: %v", err)
+	}
+
+	_, nextRun, err := s.store.GetTrigger(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger: %v", err)
+	}
+	assertTime(t, "next run after upsert", nextRun, &replacement)
+
+	claimed := s.claim("owner-a", 10)
+	if len(claimed) != 1 || claimed[0].Schedule.ID != schedule.ID {
+		t.Fatalf("claimed %v, want only the replacement schedule %s", scheduleIDs(claimed), schedule.ID)
+	}
+
+	// A nil schedule cancels the claimed one too.
+	if err := s.store.UpsertTriggerSchedule(s.ctx, trigger.ID, nil); err != nil {
+		t.Fatalf("UpsertTriggerSchedule(nil): %v", err)
+	}
+	_, nextRun, _ = s.store.GetTrigger(s.ctx, trigger.ID)
+	if nextRun != nil {
+		t.Errorf("next run = %v after cancelling schedules, want nil", nextRun)
+	}
+	err = s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-a", models.ScheduleStatusCompleted)
+	if !errors.Is(err, storage.ErrScheduleLeaseLost) {
+		t.Errorf("completing a cancelled schedule: err = %v, want ErrScheduleLeaseLost", err)
+	}
+}
+
+func testClaimDueSchedules(t *testing.T, s *suite) {
+	late := s.at(-time.Minute)
+	early := s.at(-time.Hour)
+	future := s.at(time.Hour)
+	lateTrigger, lateSchedule := s.createTrigger("late", models.TriggerTypeTimeScheduled, &late)
+	_, earlySchedule := s.createTrigger("early", models.TriggerTypeTimeScheduled, &early)
+	s.createTrigger("future", models.TriggerTypeTimeScheduled, &future)
+
+	claimed := s.claim("owner-a", 1)
+	if len(claimed) != 1 || claimed[0].Schedule.ID != earlySchedule.ID {
+		t.Fatalf("claimed %v with limit 1, want the oldest due schedule %s", scheduleIDs(claimed), earlySchedule.ID)
+	}
+
+	claimed = s.claim("owner-b", 10)
+	if len(claimed) != 1 || claimed[0].Schedule.ID != lateSchedule.ID {
+		t.Fatalf("second claim got %v, want only %s", scheduleIDs(claimed), lateSchedule.ID)
+	}
+
+	got := claimed[0]
+	if got.Trigger.ID != lateTrigger.ID || got.Trigger.Name != "late" {
+		t.Errorf("claimed schedule carries trigger %+v, want %s", got.Trigger, lateTrigger.ID)
+	}
+	if got.Schedule.Status != models.ScheduleStatusProcessing {
+		t.Errorf("claimed status = %q, want processing", got.Schedule.Status)
+	}
+	if got.Schedule.ClaimedBy == nil || *got.Schedule.ClaimedBy != "owner-b" {
+		t.Errorf("claimed_by = %v, want owner-b", got.Schedule.ClaimedBy)
+	}
+	leaseExpiresAt := s.at(lease)
+	assertTime(t, "lease_expires_at", got.Schedule.LeaseExpiresAt, &leaseExpiresAt)
+	assertTime(t, "fire_at", &got.Schedule.FireAt, &late)
+
+	if claimed := s.claim("owner-c", 10); len(claimed) != 0 {
+		t.Errorf("claimed %v again while leased", scheduleIDs(claimed))
+	}
+}
+
+// testConcurrentClaims races several claimers over the same due schedules: each schedule must be
+// claimed by exactly one of them, and all of them must be claimed.
+func testConcurrentClaims(t *testing.T, s *suite) {
+	const schedules, claimers, limit = 60, 8, 3
+
+	due := s.at(-time.Minute)
+	want := make(map[string]bool, schedules)
+	for i := 0; i < schedules; i++ {
+		_, schedule := s.createTrigger(fmt.Sprintf("concurrent-%d", i), models.TriggerTypeTimeScheduled, &due)
+		want[schedule.ID] = true
+	}
+
+	var mu sync.Mutex
+	claimedBy := make(map[string][]string, schedules)
+	errs := make(chan error, claimers)
+	var wg sync.WaitGroup
+	for i := 0; i < claimers; i++ {
+		owner := fmt.Sprintf("claimer-%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				claimed, err := s.store.ClaimDueSchedules(s.ctx, owner, limit, lease)
+				if err != nil {
+					errs <- fmt.Errorf("%s: ClaimDueSchedules: %w", owner, err)
+					return
+				}
+				if len(claimed) == 0 {
+					return
+				}
+				mu.Lock()
+				for _, c := range claimed {
+					claimedBy[c.Schedule.ID] = append(claimedBy[c.Schedule.ID], owner)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	for scheduleID, owners := range claimedBy {
+		if !want[scheduleID] {
+			t.Errorf("claimed unknown schedule %s", scheduleID)
+		}
+		if len(owners) != 1 {
+			t.Errorf("schedule %s claimed %d times, by %v", scheduleID, len(owners), owners)
+		}
+	}
+	if len(claimedBy) != schedules {
+		t.Errorf("claimed %d distinct schedules, want %d", len(claimedBy), schedules)
+	}
+}
+
+func testClaimSkipsInactiveTriggers(t *testing.T, s *suite) {
+	due := s.at(-time.Minute)
+	trigger, _ := s.createTrigger("paused", models.TriggerTypeTimeScheduled, &due)
+	if err := s.store.DeactivateTrigger(s.ctx, trigger.ID); err != nil {
+		t.Fatalf("DeactivateTrigger: %v", err)
+	}
+
+	if claimed := s.claim("owner-a", 10); len(claimed) != 0 {
+		t.Errorf("claimed %v of an inactive trigger", scheduleIDs(claimed))
+	}
+	if next := s.nextDue(); next != nil {
+		t.Errorf("NextDueTime = %v with only inactive triggers, want nil", next)
+	}
+}
+
+func testTriggerFireCount(t *testing.T, s *suite) {
+	due := s.at(-time.Minute)
+	trigger, _ := s.createTrigger("counted", models.TriggerTypeCronScheduled, &due)
+	if trigger.FireCount != 0 {
+		t.Errorf("FireCount of a new trigger = %d, want 0", trigger.FireCount)
+	}
+
+	for want := 1; want <= 3; want++ {
+		count, err := s.store.IncrementTriggerFireCount(s.ctx, trigger.ID)
+		if err != nil {
+			t.Fatalf("IncrementTriggerFireCount: %v", err)
+		}
+		if count != want {
+			t.Errorf("IncrementTriggerFireCount = %d, want %d", count, want)
+		}
+	}
+
+	stored, _, _ := s.store.GetTrigger(s.ctx, trigger.ID)
+	if stored.FireCount != 3 {
+		t.Errorf("GetTrigger FireCount = %d, want 3", stored.FireCount)
+	}
+	if claimed := s.claim("owner-a", 10); len(claimed) != 1 || claimed[0].Trigger.FireCount != 3 {
+		t.Errorf("claimed trigger FireCount = %v, want 3", claimed)
+	}
+
+	if _, err := s.store.IncrementTriggerFireCount(s.ctx, uuid.New().String()); !errors.Is(err, storage.ErrTriggerNotFound) {
+		t.Errorf("IncrementTriggerFireCount of an unknown trigger: err = %v, want ErrTriggerNotFound", err)
+	}
+}
+
+func testScheduleStatusTransitions(t *testing.T, s *suite) {
+	for _, status := range []models.ScheduleStatus{
+		models.ScheduleStatusCompleted,
+		models.ScheduleStatusCancelled,
+		models.ScheduleStatusSkipped,
+	} {
+		due := s.at(-time.Minute)
+		trigger, schedule := s.createTrigger(string(status), models.TriggerTypeTimeScheduled, &due)
+		if claimed := s.claim("owner-a", 10); len(claimed) != 1 {
+			t.Fatalf("claimed %d schedules, want 1", len(claimed))
+		}
+
+		err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-b", status)
+		if !errors.Is(err, storage.ErrScheduleLeaseLost) {
+			t.Errorf("%s by another owner: err = %v, want ErrScheduleLeaseLost", status, err)
+		}
+		if err := s.store.ExtendScheduleLease(s.ctx, schedule.ID, "owner-a", lease); err != nil {
+			t.Errorf("ExtendScheduleLease by the owner: %v", err)
+		}
+		if err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-a", status); err != nil {
+			t.Fatalf("%s by the owner: %v", status, err)
+		}
+
+		_, nextRun, _ := s.store.GetTrigger(s.ctx, trigger.ID)
+		if nextRun != nil {
+			t.Errorf("next run = %v after %s, want nil", nextRun, status)
+		}
+		if err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-a", status); !errors.Is(err, storage.ErrScheduleLeaseLost) {
+			t.Errorf("repeating %s: err = %v, want ErrScheduleLeaseLost", status, err)
+		}
+		if err := s.store.ExtendScheduleLease(s.ctx, schedule.ID, "owner-a", lease); !errors.Is(err, storage.ErrScheduleLeaseLost) {
+			t.Errorf("extending a %s schedule: err = %v, want ErrScheduleLeaseLost", status, err)
+		}
+		if claimed := s.claim("owner-a", 10); len(claimed) != 0 {
+			t.Errorf("claimed %v after %s", scheduleIDs(claimed), status)
+		}
+	}
+}
+
+func testRevertScheduleToPending(t *testing.T, s *suite) {
+	due := s.at(-time.Minute)
+	_, schedule := s.createTrigger("flaky", models.TriggerTypeTimeScheduled, &due)
+	s.claim("owner-a", 10)
+
+	revertedAt := s.at(0)
+	retryAt := s.at(10 * time.Second)
+	if err := s.store.RevertScheduleToPending(s.ctx, schedule.ID, "owner-b", retryAt); !errors.Is(err, storage.ErrScheduleLeaseLost) {
+		t.Errorf("revert by another owner: err = %v, want ErrScheduleLeaseLost", err)
+	}
+	if err := s.store.RevertScheduleToPending(s.ctx, schedule.ID, "owner-a", retryAt); err != nil {
+		t.Fatalf("RevertScheduleToPending: %v", err)
+	}
+
+	if claimed := s.claim("owner-a", 10); len(claimed) != 0 {
+		t.Errorf("claimed %v before next_attempt_at", scheduleIDs(claimed))
+	}
+	assertTime(t, "NextDueTime while backing off", s.nextDue(), &retryAt)
+
+	s.clock.Set(retryAt)
+	claimed := s.claim("owner-a", 10)
+	if len(claimed) != 1 {
+		t.Fatalf("claimed %d schedules at next_attempt_at, want 1", len(claimed))
+	}
+	if claimed[0].Schedule.AttemptCount != 1 {
+		t.Errorf("attempt_count = %d after one revert, want 1", claimed[0].Schedule.AttemptCount)
+	}
+	assertTime(t, "next_attempt_at", claimed[0].Schedule.NextAttemptAt, &retryAt)
+	assertTime(t, "last_attempt_at", claimed[0].Schedule.LastAttemptAt, &revertedAt)
+
+	if err := s.store.IncrementScheduleAttempt(s.ctx, schedule.ID); err != nil {
+		t.Fatalf("IncrementScheduleAttempt: %v", err)
+	}
+	if err := s.store.RevertScheduleToPending(s.ctx, schedule.ID, "owner-a", s.at(0)); err != nil {
+		t.Fatalf("RevertScheduleToPending: %v", err)
+	}
+	claimed = s.claim("owner-a", 10)
+	if len(claimed) != 1 || claimed[0].Schedule.AttemptCount != 3 {
+		t.Errorf("attempt_count after increment and revert = %v, want 3", claimed)
+	}
+}
+
+func testLeaseExpiry(t *testing.T, s *suite) {
+	due := s.at(-time.Minute)
+	_, schedule := s.createTrigger("stuck", models.TriggerTypeTimeScheduled, &due)
+	s.claim("owner-a", 10)
+
+	s.clock.Advance(lease / 2)
+	if err := s.store.ExtendScheduleLease(s.ctx, schedule.ID, "owner-a", lease); err != nil {
+		t.Fatalf("ExtendScheduleLease: %v", err)
+	}
+
+	s.clock.Advance(lease - time.Second)
+	reclaimed, err := s.store.ReclaimExpiredSchedules(s.ctx, 10)
+	if err != nil {
+		t.Fatalf("ReclaimExpiredSchedules: %v", err)
+	}
+	if len(reclaimed) != 0 {
+		t.Errorf("reclaimed %v before the extended lease expired", scheduleIDs(reclaimed))
+	}
+
+	s.clock.Advance(2 * time.Second)
+	reclaimed, err = s.store.ReclaimExpiredSchedules(s.ctx, 10)
+	if err != nil {
+		t.Fatalf("ReclaimExpiredSchedules: %v", err)
+	}
+	if len(reclaimed) != 1 || reclaimed[0].Schedule.ID != schedule.ID {
+		t.Fatalf("reclaimed %v, want %s", scheduleIDs(reclaimed), schedule.ID)
+	}
+	if owner := reclaimed[0].Schedule.ClaimedBy; owner == nil || *owner != "owner-a" {
+		t.Errorf("reclaimed row claimed_by = %v, want the expired claim owner-a", owner)
+	}
+
+	if err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-a", models.ScheduleStatusCompleted); !errors.Is(err, storage.ErrScheduleLeaseLost) {
+		t.Errorf("completing after reclaim: err = %v, want ErrScheduleLeaseLost", err)
+	}
+
+	claimed := s.claim("owner-b", 10)
+	if len(claimed) != 1 || claimed[0].Schedule.ID != schedule.ID {
+		t.Fatalf("claimed %v after reclaim, want %s", scheduleIDs(claimed), schedule.ID)
+	}
+	if err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-b", models.ScheduleStatusCompleted); err != nil {
+		t.Errorf("completing by the new owner: %v", err)
+	}
+}
+
+func testNextDueTime(t *testing.T, s *suite) {
+	if next := s.nextDue(); next != nil {
+		t.Errorf("NextDueTime on an empty store = %v, want nil", next)
+	}
+
+	later := s.at(2 * time.Hour)
+	sooner := s.at(time.Hour)
+	s.createTrigger("later", models.TriggerTypeTimeScheduled, &later)
+	s.createTrigger("sooner", models.TriggerTypeTimeScheduled, &sooner)
+	assertTime(t, "NextDueTime", s.nextDue(), &sooner)
+
+	overdue := s.at(-time.Hour)
+	_, schedule := s.createTrigger("overdue", models.TriggerTypeTimeScheduled, &overdue)
+	assertTime(t, "NextDueTime with an overdue schedule", s.nextDue(), &overdue)
+
+	s.claim("owner-a", 1)
+	assertTime(t, "NextDueTime ignores claimed schedules", s.nextDue(), &sooner)
+
+	if err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-a", models.ScheduleStatusCompleted); err != nil {
+		t.Fatalf("UpdateScheduleStatus: %v", err)
+	}
+	assertTime(t, "NextDueTime after completion", s.nextDue(), &sooner)
+}
+
+func testCreateNextSchedule(t *testing.T, s *suite) {
+	due := s.at(-time.Minute)
+	trigger, schedule := s.createTrigger("recurring", models.TriggerTypeCronScheduled, &due)
+	s.claim("owner-a", 10)
+
+	// The occurrence fires 30s late (jitter/spread); both times are kept
+	occurrence := s.at(time.Hour)
+	next := newSchedule(trigger.ID, occurrence.Add(30*time.Second))
+	next.ScheduledFor = &occurrence
+	if err := s.store.CreateNextSchedule(s.ctx, next); err != nil {
+		t.Fatalf("CreateNextSchedule: %v", err)
+	}
+	if err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "owner-a", models.ScheduleStatusCompleted); err != nil {
+		t.Fatalf("UpdateScheduleStatus: %v", err)
+	}
+
+	_, nextRun, err := s.store.GetTrigger(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger: %v", err)
+	}
+	assertTime(t, "next run", nextRun, &next.FireAt)
+
+	s.clock.Set(next.FireAt)
+	claimed := s.claim("owner-a", 10)
+	if len(claimed) != 1 || claimed[0].Schedule.ID != next.ID {
+		t.Fatalf("claimed %v, want the next occurrence %s", scheduleIDs(claimed), next.ID)
+	}
+	assertTime(t, "fire_at", &claimed[0].Schedule.FireAt, &next.FireAt)
+	assertTime(t, "scheduled_for", claimed[0].Schedule.ScheduledFor, &occurrence)
+}
+
+func testBackfillSchedules(t *testing.T, s *suite) {
+	regular := s.at(time.Hour)
+	trigger, _ := s.createTrigger("backfilled", models.TriggerTypeCronScheduled, &regular)
+
+	// Two past occurrences replayed a minute apart, the first one due now
+	var backfill []models.TriggerSchedule
+	for i, occurrence := range []time.Time{s.at(-48 * time.Hour), s.at(-24 * time.Hour)} {
+		schedule := newSchedule(trigger.ID, s.at(time.Duration(i)*time.Minute))
+		schedule.ScheduledFor = &occurrence
+		backfill = append(backfill, *schedule)
+	}
+	if err := s.store.CreateBackfillSchedules(s.ctx, backfill); err != nil {
+		t.Fatalf("CreateBackfillSchedules: %v", err)
+	}
+	assertTime(t, "next due", s.nextDue(), &backfill[0].FireAt)
+
+	// The trigger's next run is its own schedule, not a replay
+	_, nextRun, err := s.store.GetTrigger(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger: %v", err)
+	}
+	assertTime(t, "next run", nextRun, &regular)
+
+	// Rescheduling the trigger cancels its own schedule and keeps the backfill
+	if err := s.store.UpsertTriggerSchedule(s.ctx, trigger.ID, nil); err != nil {
+		t.Fatalf("UpsertTriggerSchedule(nil): %v", err)
+	}
+	s.clock.Set(backfill[1].FireAt)
+	claimed := s.claim("owner-a", 10)
+	assertIDs(t, "claimed", scheduleIDs(claimed), []string{backfill[0].ID, backfill[1].ID})
+	for i, c := range claimed {
+		if !c.Schedule.Backfill {
+			t.Errorf("claimed[%d].Backfill = false, want true", i)
+		}
+		assertTime(t, "scheduled_for", c.Schedule.ScheduledFor, backfill[i].ScheduledFor)
+	}
+}
+
+func testPauseResumeTrigger(t *testing.T, s *suite) {
+	regular := s.at(time.Hour)
+	trigger, _ := s.createTrigger("paused", models.TriggerTypeCronScheduled, &regular)
+	occurrence := s.at(-time.Hour)
+	backfill := newSchedule(trigger.ID, s.at(2*time.Hour))
+	backfill.ScheduledFor = &occurrence
+	if err := s.store.CreateBackfillSchedules(s.ctx, []models.TriggerSchedule{*backfill}); err != nil {
+		t.Fatalf("CreateBackfillSchedules: %v", err)
+	}
+
+	reason := "maintenance"
+	pause := &models.TriggerPauseEvent{ID: uuid.New().String(), Action: models.PauseActionPause, Reason: &reason}
+	if err := s.store.PauseTrigger(s.ctx, trigger.ID, pause); err != nil {
+		t.Fatalf("PauseTrigger: %v", err)
+	}
+	if pause.TriggerID != trigger.ID || !pause.CreatedAt.Equal(s.clock.Now()) {
+		t.Errorf("pause event stamped (%s, %v), want (%s, %v)", pause.TriggerID, pause.CreatedAt, trigger.ID, s.clock.Now())
+	}
+
+	paused, nextRun, err := s.store.GetTrigger(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger: %v", err)
+	}
+	if paused.Status != models.TriggerStatusInactive {
+		t.Errorf("status after pause = %s, want inactive", paused.Status)
+	}
+	if nextRun != nil {
+		t.Errorf("next run after pause = %v, want nil", nextRun)
+	}
+
+	if err := s.store.PauseTrigger(s.ctx, trigger.ID, &models.TriggerPauseEvent{ID: uuid.New().String(), Action: models.PauseActionPause}); !errors.Is(err, storage.ErrTriggerStatusConflict) {
+		t.Errorf("pausing a paused trigger: err = %v, want ErrTriggerStatusConflict", err)
+	}
+	if err := s.store.PauseTrigger(s.ctx, uuid.New().String(), &models.TriggerPauseEvent{ID: uuid.New().String(), Action: models.PauseActionPause}); !errors.Is(err, storage.ErrTriggerNotFound) {
+		t.Errorf("pausing a missing trigger: err = %v, want ErrTriggerNotFound", err)
+	}
+
+	s.clock.Advance(time.Minute)
+	resumed := newSchedule(trigger.ID, s.at(30*time.Minute))
+	missed := s.at(-30 * time.Minute)
+	replay := newSchedule(trigger.ID, s.at(20*time.Minute))
+	replay.ScheduledFor = &missed
+	replay.Backfill = true
+	resume := &models.TriggerPauseEvent{
+		ID:                uuid.New().String(),
+		Action:            models.PauseActionResume,
+		ResumePolicy:      models.ResumePolicyFireOnce,
+		MissedOccurrences: 2,
+		NextFireAt:        &resumed.FireAt,
+	}
+	if err := s.store.ResumeTrigger(s.ctx, trigger.ID, []models.TriggerSchedule{*resumed, *replay}, resume); err != nil {
+		t.Fatalf("ResumeTrigger: %v", err)
+	}
+	if err := s.store.ResumeTrigger(s.ctx, trigger.ID, nil, &models.TriggerPauseEvent{ID: uuid.New().String(), Action: models.PauseActionResume}); !errors.Is(err, storage.ErrTriggerStatusConflict) {
+		t.Errorf("resuming an active trigger: err = %v, want ErrTriggerStatusConflict", err)
+	}
+
+	active, nextRun, err := s.store.GetTrigger(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetTrigger: %v", err)
+	}
+	if active.Status != models.TriggerStatusActive {
+		t.Errorf("status after resume = %s, want active", active.Status)
+	}
+	assertTime(t, "next run after resume", nextRun, &resumed.FireAt)
+
+	history, err := s.store.ListTriggerPauseEvents(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("ListTriggerPauseEvents: %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("got %d pause events, want 2", len(history))
+	}
+	got, want := history[0], *resume
+	if got.ID != want.ID || got.Action != want.Action || got.ResumePolicy != want.ResumePolicy || got.MissedOccurrences != want.MissedOccurrences || got.Reason != nil {
+		t.Errorf("latest pause event = %+v, want %+v", got, want)
+	}
+	assertTime(t, "resume next_fire_at", got.NextFireAt, want.NextFireAt)
+	assertTime(t, "resume created_at", &got.CreatedAt, &want.CreatedAt)
+	if got := history[1]; got.ID != pause.ID || got.ResumePolicy != "" || got.NextFireAt != nil || got.Reason == nil || *got.Reason != reason {
+		t.Errorf("oldest pause event = %+v, want the pause with reason %q", got, reason)
+	}
+
+	// The schedule pending before the pause was cancelled, the backfill was kept
+	s.clock.Set(backfill.FireAt)
+	claimed := s.claim("owner-a", 10)
+	assertIDs(t, "claimed after resume", scheduleIDs(claimed), []string{replay.ID, resumed.ID, backfill.ID})
+	if !claimed[0].Schedule.Backfill || claimed[1].Schedule.Backfill {
+		t.Errorf("claimed backfill flags = %v, %v; want the replay alone flagged", claimed[0].Schedule.Backfill, claimed[1].Schedule.Backfill)
+	}
+	assertTime(t, "replay scheduled_for", claimed[0].Schedule.ScheduledFor, &missed)
+
+	// History goes with the trigger
+	if err := s.store.DeleteTrigger(s.ctx, trigger.ID); err != nil {
+		t.Fatalf("DeleteTrigger: %v", err)
+	}
+	history, err = s.store.ListTriggerPauseEvents(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("ListTriggerPauseEvents: %v", err)
+	}
+	if len(history) != 0 {
+		t.Errorf("got %d pause events of a deleted trigger, want 0", len(history))
+	}
+}
+
+func testScheduleNotifications(t *testing.T, s *suite) {
+	latest, err := s.store.LatestScheduleNotificationID(s.ctx)
+	if err != nil {
+		t.Fatalf("LatestScheduleNotificationID: %v", err)
+	}
+
+	first := s.at(time.Hour)
+	second := s.at(time.Minute)
+	trigger, _ := s.createTrigger("first", models.TriggerTypeTimeScheduled, &first)
+	s.createTrigger("webhook", models.TriggerTypeWebhook, nil)
+	if err := s.store.UpsertTriggerSchedule(s.ctx, trigger.ID, newSchedule(trigger.ID, second)); err != nil {
+		t.Fatalf("UpsertTriggerSchedule: %v", err)
+	}
+
+	notifications, err := s.store.ListScheduleNotificationsSince(s.ctx, latest, 10)
+	if err != nil {
+		t.Fatalf("ListScheduleNotificationsSince: %v", err)
+	}
+	if len(notifications) != 2 {
+		t.Fatalf("got %d notifications, want 2 (one per stored schedule)", len(notifications))
+	}
+	assertTime(t, "first notification", &notifications[0].FireAt, &first)
+	assertTime(t, "second notification", &notifications[1].FireAt, &second)
+	if notifications[1].ID <= notifications[0].ID {
+		t.Errorf("notification IDs %d, %d are not increasing", notifications[0].ID, notifications[1].ID)
+	}
+
+	limited, err := s.store.ListScheduleNotificationsSince(s.ctx, latest, 1)
+	if err != nil {
+		t.Fatalf("ListScheduleNotificationsSince: %v", err)
+	}
+	if len(limited) != 1 || limited[0].ID != notifications[0].ID {
+		t.Errorf("limit 1 returned %v, want the oldest notification", limited)
+	}
+
+	newest, err := s.store.LatestScheduleNotificationID(s.ctx)
+	if err != nil {
+		t.Fatalf("LatestScheduleNotificationID: %v", err)
+	}
+	if newest != notifications[1].ID {
+		t.Errorf("LatestScheduleNotificationID = %d, want %d", newest, notifications[1].ID)
+	}
+	rest, err := s.store.ListScheduleNotificationsSince(s.ctx, newest, 10)
+	if err != nil {
+		t.Fatalf("ListScheduleNotificationsSince: %v", err)
+	}
+	if len(rest) != 0 {
+		t.Errorf("got %d notifications after the newest, want 0", len(rest))
+	}
+}
+
+func testEventLogs(t *testing.T, s *suite) {
+	trigger, _ := s.createTrigger("logged", models.TriggerTypeTimeScheduled, nil)
+
+	eventLog := newEventLog(&trigger.ID, s.at(0))
+	scheduledFor := s.at(-time.Minute)
+	eventLog.ScheduledFor = &scheduledFor
+	if err := s.store.CreateEventLog(s.ctx, eventLog); err != nil {
+		t.Fatalf("CreateEventLog: %v", err)
+	}
+	if err := s.store.CreateEventLog(s.ctx, eventLog); err == nil {
+		t.Error("CreateEventLog accepted a duplicate ID")
+	}
+
+	stored, err := s.store.GetEventLog(s.ctx, eventLog.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored == nil {
+		t.Fatal("GetEventLog returned nil for a stored event")
+	}
+	if stored.TriggerID == nil || *stored.TriggerID != trigger.ID || stored.Source != models.EventSourceScheduler ||
+		stored.ExecutionStatus != models.ExecutionStatusSuccess || stored.RetentionStatus != models.RetentionStatusActive {
+		t.Errorf("GetEventLog = %+v, want the created event", stored)
+	}
+	assertTime(t, "fired_at", &stored.FiredAt, &eventLog.FiredAt)
+	assertTime(t, "scheduled_for", stored.ScheduledFor, &scheduledFor)
+	assertJSONEqual(t, stored.Payload, eventLog.Payload)
+
+	message := "kafka unavailable"
+	if err := s.store.UpdateEventLogStatus(s.ctx, eventLog.ID, models.ExecutionStatusFailure, &message); err != nil {
+		t.Fatalf("UpdateEventLogStatus: %v", err)
+	}
+	stored, err = s.store.GetEventLog(s.ctx, eventLog.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored.ExecutionStatus != models.ExecutionStatusFailure || stored.ErrorMessage == nil || *stored.ErrorMessage != message {
+		t.Errorf("after UpdateEventLogStatus got status=%q error=%v", stored.ExecutionStatus, stored.ErrorMessage)
+	}
+
+	missing, err := s.store.GetEventLog(s.ctx, uuid.New().String())
+	if err != nil {
+		t.Fatalf("GetEventLog for an unknown ID: %v", err)
+	}
+	if missing != nil {
+		t.Errorf("GetEventLog for an unknown ID = %+v, want nil", missing)
+	}
+}
+
+func testRunningEventLogs(t *testing.T, s *suite) {
+	trigger, _ := s.createTrigger("running", models.TriggerTypeCronScheduled, nil)
+	other, _ := s.createTrigger("other", models.TriggerTypeCronScheduled, nil)
+
+	running, err := s.store.GetRunningEventLog(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetRunningEventLog: %v", err)
+	}
+	if running != nil {
+		t.Errorf("GetRunningEventLog without events = %+v, want nil", running)
+	}
+
+	var events []*models.EventLog
+	for i, triggerID := range []*string{&trigger.ID, &trigger.ID, &other.ID} {
+		eventLog := newEventLog(triggerID, s.at(time.Duration(i-3)*time.Minute))
+		eventLog.ExecutionStatus = models.ExecutionStatusRunning
+		if err := s.store.CreateEventLog(s.ctx, eventLog); err != nil {
+			t.Fatalf("CreateEventLog: %v", err)
+		}
+		events = append(events, eventLog)
+	}
+	done := newEventLog(&trigger.ID, s.at(0))
+	if err := s.store.CreateEventLog(s.ctx, done); err != nil {
+		t.Fatalf("CreateEventLog: %v", err)
+	}
+
+	// The latest running event, not the latest event
+	running, err = s.store.GetRunningEventLog(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetRunningEventLog: %v", err)
+	}
+	if running == nil || running.ID != events[1].ID {
+		t.Fatalf("GetRunningEventLog = %+v, want %s", running, events[1].ID)
+	}
+	if running.AcknowledgedAt != nil {
+		t.Errorf("running event acknowledged_at = %v, want nil", running.AcknowledgedAt)
+	}
+
+	s.clock.Advance(time.Second)
+	message := "worker crashed"
+	if err := s.store.AcknowledgeEventLog(s.ctx, events[1].ID, models.ExecutionStatusFailure, &message); err != nil {
+		t.Fatalf("AcknowledgeEventLog: %v", err)
+	}
+	acknowledged, err := s.store.GetEventLog(s.ctx, events[1].ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if acknowledged.ExecutionStatus != models.ExecutionStatusFailure || acknowledged.ErrorMessage == nil || *acknowledged.ErrorMessage != message {
+		t.Errorf("after AcknowledgeEventLog got status=%q error=%v", acknowledged.ExecutionStatus, acknowledged.ErrorMessage)
+	}
+	now := s.clock.Now()
+	assertTime(t, "acknowledged_at", acknowledged.AcknowledgedAt, &now)
+
+	for _, eventID := range []string{events[1].ID, done.ID, uuid.New().String()} {
+		if err := s.store.AcknowledgeEventLog(s.ctx, eventID, models.ExecutionStatusSuccess, nil); !errors.Is(err, storage.ErrEventLogNotRunning) {
+			t.Errorf("AcknowledgeEventLog(%s): err = %v, want ErrEventLogNotRunning", eventID, err)
+		}
+	}
+
+	reason := "replaced by a newer occurrence"
+	replaced, err := s.store.ReplaceRunningEventLogs(s.ctx, trigger.ID, reason)
+	if err != nil {
+		t.Fatalf("ReplaceRunningEventLogs: %v", err)
+	}
+	if replaced != 1 {
+		t.Errorf("ReplaceRunningEventLogs replaced %d events, want 1", replaced)
+	}
+	stored, err := s.store.GetEventLog(s.ctx, events[0].ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored.ExecutionStatus != models.ExecutionStatusReplaced || stored.ErrorMessage == nil || *stored.ErrorMessage != reason {
+		t.Errorf("after ReplaceRunningEventLogs got status=%q error=%v", stored.ExecutionStatus, stored.ErrorMessage)
+	}
+	if err := s.store.AcknowledgeEventLog(s.ctx, events[0].ID, models.ExecutionStatusSuccess, nil); !errors.Is(err, storage.ErrEventLogNotRunning) {
+		t.Errorf("acknowledging a replaced event: err = %v, want ErrEventLogNotRunning", err)
+	}
+
+	running, err = s.store.GetRunningEventLog(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetRunningEventLog: %v", err)
+	}
+	if running != nil {
+		t.Errorf("GetRunningEventLog after replacing = %+v, want nil", running)
+	}
+	// Other triggers' events are untouched
+	running, err = s.store.GetRunningEventLog(s.ctx, other.ID)
+	if err != nil {
+		t.Fatalf("GetRunningEventLog: %v", err)
+	}
+	if running == nil || running.ID != events[2].ID {
+		t.Errorf("GetRunningEventLog(other) = %+v, want %s", running, events[2].ID)
+	}
+}
+
+func testExpireRunningEventLogs(t *testing.T, s *suite) {
+	trigger, _ := s.createTrigger("expiring", models.TriggerTypeCronScheduled, nil)
+	other, _ := s.createTrigger("other", models.TriggerTypeCronScheduled, nil)
+
+	// Running events fired 3, 2 and 1 minutes ago, an old running event of another trigger and an old finished event
+	var events []*models.EventLog
+	for _, running := range []struct {
+		triggerID *string
+		firedAt   time.Duration
+	}{{&trigger.ID, -3 * time.Minute}, {&trigger.ID, -2 * time.Minute}, {&trigger.ID, -time.Minute}, {&other.ID, -3 * time.Minute}} {
+		eventLog := newEventLog(running.triggerID, s.at(running.firedAt))
+		eventLog.ExecutionStatus = models.ExecutionStatusRunning
+		if err := s.store.CreateEventLog(s.ctx, eventLog); err != nil {
+			t.Fatalf("CreateEventLog: %v", err)
+		}
+		events = append(events, eventLog)
+	}
+	done := newEventLog(&trigger.ID, s.at(-3*time.Minute))
+	if err := s.store.CreateEventLog(s.ctx, done); err != nil {
+		t.Fatalf("CreateEventLog: %v", err)
+	}
+
+	// The cutoff is inclusive
+	reason := "not acknowledged within running_timeout 2m0s"
+	expired, err := s.store.ExpireRunningEventLogs(s.ctx, trigger.ID, s.at(-2*time.Minute), reason)
+	if err != nil {
+		t.Fatalf("ExpireRunningEventLogs: %v", err)
+	}
+	if expired != 2 {
+		t.Errorf("ExpireRunningEventLogs expired %d events, want 2", expired)
+	}
+
+	want := []struct {
+		eventLog *models.EventLog
+		status   models.ExecutionStatus
+	}{
+		{events[0], models.ExecutionStatusFailure},
+		{events[1], models.ExecutionStatusFailure},
+		{events[2], models.ExecutionStatusRunning},
+		{events[3], models.ExecutionStatusRunning},
+		{done, models.ExecutionStatusSuccess},
+	}
+	for i, w := range want {
+		stored, err := s.store.GetEventLog(s.ctx, w.eventLog.ID)
+		if err != nil {
+			t.Fatalf("GetEventLog: %v", err)
+		}
+		if stored.ExecutionStatus != w.status {
+			t.Errorf("event %d status = %q, want %q", i, stored.ExecutionStatus, w.status)
+		}
+		if w.status == models.ExecutionStatusFailure && (stored.ErrorMessage == nil || *stored.ErrorMessage != reason) {
+			t.Errorf("event %d error_message = %v, want %q", i, stored.ErrorMessage, reason)
+		}
+	}
+
+	if err := s.store.AcknowledgeEventLog(s.ctx, events[0].ID, models.ExecutionStatusSuccess, nil); !errors.Is(err, storage.ErrEventLogNotRunning) {
+		t.Errorf("acknowledging an expired event: err = %v, want ErrEventLogNotRunning", err)
+	}
+	running, err := s.store.GetRunningEventLog(s.ctx, trigger.ID)
+	if err != nil {
+		t.Fatalf("GetRunningEventLog: %v", err)
+	}
+	if running == nil || running.ID != events[2].ID {
+		t.Errorf("GetRunningEventLog after expiring = %+v, want %s", running, events[2].ID)
+	}
+}
+
+func testEventDelivery(t *testing.T, s *suite) {
+	trigger, _ := s.createTrigger("delivered", models.TriggerTypeWebhook, nil)
+	eventLog := newEventLog(&trigger.ID, s.at(0))
+	if err := s.store.CreateEventLog(s.ctx, eventLog); err != nil {
+		t.Fatalf("CreateEventLog: %v", err)
+	}
+
+	stored, err := s.store.GetEventLog(s.ctx, eventLog.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored.Delivery != nil {
+		t.Errorf("undispatched event delivery = %+v, want nil", stored.Delivery)
+	}
+
+	// A failed attempt without a response, then the successful retry replacing it
+	s.clock.Advance(time.Second)
+	message := "connection refused"
+	if err := s.store.RecordEventDelivery(s.ctx, eventLog.ID, &models.EventDelivery{
+		Status:   models.DeliveryStatusFailed,
+		Attempts: 3,
+		Error:    &message,
+	}); err != nil {
+		t.Fatalf("RecordEventDelivery: %v", err)
+	}
+	stored, err = s.store.GetEventLog(s.ctx, eventLog.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored.Delivery == nil || stored.Delivery.Status != models.DeliveryStatusFailed || stored.Delivery.Attempts != 3 ||
+		stored.Delivery.StatusCode != nil || stored.Delivery.Error == nil || *stored.Delivery.Error != message {
+		t.Errorf("failed delivery = %+v", stored.Delivery)
+	}
+
+	s.clock.Advance(time.Second)
+	statusCode := 204
+	delivery := &models.EventDelivery{Status: models.DeliveryStatusDelivered, Attempts: 1, StatusCode: &statusCode}
+	if err := s.store.RecordEventDelivery(s.ctx, eventLog.ID, delivery); err != nil {
+		t.Fatalf("RecordEventDelivery: %v", err)
+	}
+	now := s.clock.Now()
+	if !delivery.CompletedAt.Equal(now) {
+		t.Errorf("RecordEventDelivery set completed_at = %v, want %v", delivery.CompletedAt, now)
+	}
+	stored, err = s.store.GetEventLog(s.ctx, eventLog.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored.Delivery == nil || stored.Delivery.Status != models.DeliveryStatusDelivered || stored.Delivery.Attempts != 1 ||
+		stored.Delivery.StatusCode == nil || *stored.Delivery.StatusCode != statusCode || stored.Delivery.Error != nil {
+		t.Fatalf("delivered delivery = %+v", stored.Delivery)
+	}
+	assertTime(t, "delivery completed_at", &stored.Delivery.CompletedAt, &now)
+
+	// Recording the same outcome again within the second is not mistaken for an unknown event
+	if err := s.store.RecordEventDelivery(s.ctx, eventLog.ID, delivery); err != nil {
+		t.Errorf("RecordEventDelivery (unchanged): %v", err)
+	}
+	if err := s.store.RecordEventDelivery(s.ctx, uuid.New().String(), delivery); !errors.Is(err, storage.ErrEventLogNotFound) {
+		t.Errorf("RecordEventDelivery(unknown): err = %v, want ErrEventLogNotFound", err)
+	}
+}
+
+func testListEventLogs(t *testing.T, s *suite) {
+	trigger, _ := s.createTrigger("listed", models.TriggerTypeWebhook, nil)
+
+	var activeIDs []string
+	for i := 0; i < 5; i++ {
+		eventLog := newEventLog(&trigger.ID, s.at(time.Duration(-i)*time.Minute))
+		if i == 3 {
+			eventLog.ExecutionStatus = models.ExecutionStatusFailure
+		}
+		if err := s.store.CreateEventLog(s.ctx, eventLog); err != nil {
+			t.Fatalf("CreateEventLog: %v", err)
+		}
+		activeIDs = append(activeIDs, eventLog.ID)
+	}
+
+	manual := newEventLog(nil, s.at(-10*time.Minute))
+	manual.Source = models.EventSourceManualTest
+	manual.IsTestRun = true
+	archived := newEventLog(&trigger.ID, s.at(-3*time.Hour))
+	archived.RetentionStatus = models.RetentionStatusArchived
+	for _, eventLog := range []*models.EventLog{manual, archived} {
+		if err := s.store.CreateEventLog(s.ctx, eventLog); err != nil {
+			t.Fatalf("CreateEventLog: %v", err)
+		}
+	}
+
+	// Default retention filter is "active"; newest fired_at first.
+	logs, total, err := s.store.ListEventLogs(s.ctx, models.ListEventsQuery{})
+	if err != nil {
+		t.Fatalf("ListEventLogs: %v", err)
+	}
+	if total != 6 || len(logs) != 6 {
+		t.Fatalf("ListEventLogs = %d logs, total %d; want 6, 6", len(logs), total)
+	}
+	if got, want := eventIDs(logs), append(append([]string{}, activeIDs...), manual.ID); !slices.Equal(got, want) {
+		t.Errorf("ListEventLogs order = %v, want %v", got, want)
+	}
+
+	page, total, err := s.store.ListEventLogs(s.ctx, models.ListEventsQuery{Page: 2, Limit: 2})
+	if err != nil {
+		t.Fatalf("ListEventLogs page 2: %v", err)
+	}
+	if total != 6 || !slices.Equal(eventIDs(page), activeIDs[2:4]) {
+		t.Errorf("page 2 = %v (total %d), want %v (total 6)", eventIDs(page), total, activeIDs[2:4])
+	}
+
+	cases := []struct {
+		name  string
+		query models.ListEventsQuery
+		want  []string
+	}{
+		{"archived", models.ListEventsQuery{RetentionStatus: string(models.RetentionStatusArchived)}, []string{archived.ID}},
+		{"trigger", models.ListEventsQuery{TriggerID: trigger.ID}, activeIDs},
+		{"failures", models.ListEventsQuery{ExecutionStatus: string(models.ExecutionStatusFailure)}, activeIDs[3:4]},
+		{"manual tests", models.ListEventsQuery{Source: string(models.EventSourceManualTest)}, []string{manual.ID}},
+	}
+	for _, tc := range cases {
+		logs, total, err := s.store.ListEventLogs(s.ctx, tc.query)
+		if err != nil {
+			t.Fatalf("ListEventLogs %s: %v", tc.name, err)
+		}
+		if !slices.Equal(eventIDs(logs), tc.want) || total != int64(len(tc.want)) {
+			t.Errorf("ListEventLogs %s = %v (total %d), want %v", tc.name, eventIDs(logs), total, tc.want)
+		}
+	}
+}
+
+func testSkippedEventLogs(t *testing.T, s *suite) {
+	trigger, _ := s.createTrigger("skipped", models.TriggerTypeCronScheduled, nil)
+
+	eventLog := newEventLog(&trigger.ID, s.at(0))
+	reason := "holiday 2025-12-25 in calendar us-holidays"
+	eventLog.Payload = nil
+	eventLog.ExecutionStatus = models.ExecutionStatusSkipped
+	eventLog.SkipReason = &reason
+	fired := newEventLog(&trigger.ID, s.at(-time.Minute))
+	note := "01:30 occurs twice on 2025-11-02 in America/New_York (DST ends); run once, at 01:30 EDT"
+	fired.DSTNote = &note
+	for _, log := range []*models.EventLog{eventLog, fired} {
+		if err := s.store.CreateEventLog(s.ctx, log); err != nil {
+			t.Fatalf("CreateEventLog: %v", err)
+		}
+	}
+
+	stored, err := s.store.GetEventLog(s.ctx, eventLog.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored.ExecutionStatus != models.ExecutionStatusSkipped || stored.SkipReason == nil || *stored.SkipReason != reason {
+		t.Errorf("GetEventLog got status=%q skip_reason=%v, want skipped with %q", stored.ExecutionStatus, stored.SkipReason, reason)
+	}
+	if stored.Payload != nil {
+		t.Errorf("payload = %s, want none", stored.Payload)
+	}
+	if stored.DSTNote != nil {
+		t.Errorf("dst_note = %q, want none", *stored.DSTNote)
+	}
+
+	storedFired, err := s.store.GetEventLog(s.ctx, fired.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if storedFired.DSTNote == nil || *storedFired.DSTNote != note {
+		t.Errorf("GetEventLog dst_note = %v, want %q", storedFired.DSTNote, note)
+	}
+
+	logs, total, err := s.store.ListEventLogs(s.ctx, models.ListEventsQuery{ExecutionStatus: string(models.ExecutionStatusSkipped)})
+	if err != nil {
+		t.Fatalf("ListEventLogs: %v", err)
+	}
+	if total != 1 || !slices.Equal(eventIDs(logs), []string{eventLog.ID}) {
+		t.Errorf("ListEventLogs skipped = %v (total %d), want [%s]", eventIDs(logs), total, eventLog.ID)
+	}
+	if logs[0].SkipReason == nil || *logs[0].SkipReason != reason {
+		t.Errorf("listed skip_reason = %v, want %q", logs[0].SkipReason, reason)
+	}
+}
+
+func testSchedulerLeadership(t *testing.T, s *suite) {
+	const name, ttl = storage.SchedulerLeaseName, 15 * time.Second
+
+	leader, err := s.store.GetLeader(s.ctx, name)
+	if err != nil {
+		t.Fatalf("GetLeader: %v", err)
+	}
+	if leader != nil {
+		t.Fatalf("GetLeader before any election = %+v, want nil", leader)
+	}
+
+	first, acquired, err := s.store.AcquireLeadership(s.ctx, name, "a", ttl)
+	if err != nil {
+		t.Fatalf("AcquireLeadership: %v", err)
+	}
+	if !acquired || first.Holder != "a" {
+		t.Fatalf("AcquireLeadership(a) = %+v, %v; want a to lead", first, acquired)
+	}
+	expiresAt := s.at(ttl)
+	assertTime(t, "expires_at", &first.ExpiresAt, &expiresAt)
+
+	// A live lease keeps other candidates out
+	current, acquired, err := s.store.AcquireLeadership(s.ctx, name, "b", ttl)
+	if err != nil {
+		t.Fatalf("AcquireLeadership: %v", err)
+	}
+	if acquired || current.Holder != "a" || current.FencingToken != first.FencingToken {
+		t.Fatalf("AcquireLeadership(b) = %+v, %v; want a to keep leading", current, acquired)
+	}
+
+	s.clock.Advance(10 * time.Second)
+	renewed, err := s.store.RenewLeadership(s.ctx, name, "a", first.FencingToken, ttl)
+	if err != nil {
+		t.Fatalf("RenewLeadership: %v", err)
+	}
+	expiresAt = s.at(ttl)
+	assertTime(t, "renewed expires_at", &renewed.ExpiresAt, &expiresAt)
+	assertTime(t, "acquired_at", &renewed.AcquiredAt, &first.AcquiredAt)
+	if _, err := s.store.RenewLeadership(s.ctx, name, "a", first.FencingToken+1, ttl); !errors.Is(err, storage.ErrLeadershipLost) {
+		t.Errorf("RenewLeadership with a wrong token: err = %v, want ErrLeadershipLost", err)
+	}
+
+	// Once the lease runs out, a standby takes over with a greater fencing token
+	s.clock.Advance(ttl)
+	second, acquired, err := s.store.AcquireLeadership(s.ctx, name, "b", ttl)
+	if err != nil {
+		t.Fatalf("AcquireLeadership: %v", err)
+	}
+	if !acquired || second.Holder != "b" || second.FencingToken <= first.FencingToken {
+		t.Fatalf("AcquireLeadership(b) after expiry = %+v, %v; want b to lead with a token above %d", second, acquired, first.FencingToken)
+	}
+	if _, err := s.store.RenewLeadership(s.ctx, name, "a", first.FencingToken, ttl); !errors.Is(err, storage.ErrLeadershipLost) {
+		t.Errorf("RenewLeadership by the deposed leader: err = %v, want ErrLeadershipLost", err)
+	}
+
+	// The deposed leader cannot release the new leader's lease; the leader can
+	if err := s.store.ReleaseLeadership(s.ctx, name, "a", first.FencingToken); err != nil {
+		t.Fatalf("ReleaseLeadership: %v", err)
+	}
+	leader, err = s.store.GetLeader(s.ctx, name)
+	if err != nil {
+		t.Fatalf("GetLeader: %v", err)
+	}
+	if leader == nil || leader.Holder != "b" || !leader.ExpiresAt.After(s.clock.Now()) {
+		t.Fatalf("GetLeader after a stale release = %+v, want b's live lease", leader)
+	}
+
+	if err := s.store.ReleaseLeadership(s.ctx, name, "b", second.FencingToken); err != nil {
+		t.Fatalf("ReleaseLeadership: %v", err)
+	}
+	third, acquired, err := s.store.AcquireLeadership(s.ctx, name, "a", ttl)
+	if err != nil {
+		t.Fatalf("AcquireLeadership: %v", err)
+	}
+	if !acquired || third.FencingToken <= second.FencingToken {
+		t.Fatalf("AcquireLeadership(a) after release = %+v, %v; want a to lead with a token above %d", third, acquired, second.FencingToken)
+	}
+}
+
+func testEventOutbox(t *testing.T, s *suite) {
+	fireAt := s.at(-time.Minute)
+	trigger, schedule := s.createTrigger("outbox", models.TriggerTypeTimeScheduled, &fireAt)
+	if claimed := s.claim("a", 10); len(claimed) != 1 {
+		t.Fatalf("claimed %d schedules, want 1", len(claimed))
+	}
+
+	// The firer claims its own message for the delivery right after commit
+	fired := newEventLog(&trigger.ID, s.at(0))
+	firedMessage := newOutboxMessage(fired, s.at(0))
+	firer, firerLeaseExpiresAt := "firer", s.at(lease)
+	firedMessage.ClaimedBy = &firer
+	firedMessage.LeaseExpiresAt = &firerLeaseExpiresAt
+	firedMessage.AttemptCount = 1
+
+	// Completing a schedule claimed by someone else writes nothing
+	err := s.store.RecordEvent(s.ctx, fired, firedMessage, &storage.ScheduleCompletion{ScheduleID: schedule.ID, Owner: "b"})
+	if !errors.Is(err, storage.ErrScheduleLeaseLost) {
+		t.Fatalf("RecordEvent for another owner's schedule: err = %v, want ErrScheduleLeaseLost", err)
+	}
+	if stored, err := s.store.GetEventLog(s.ctx, fired.ID); err != nil || stored != nil {
+		t.Fatalf("event log after a failed RecordEvent = %+v, %v; want none", stored, err)
+	}
+
+	if err := s.store.RecordEvent(s.ctx, fired, firedMessage, &storage.ScheduleCompletion{ScheduleID: schedule.ID, Owner: "a"}); err != nil {
+		t.Fatalf("RecordEvent: %v", err)
+	}
+	if firedMessage.ID == 0 {
+		t.Error("RecordEvent did not set the outbox message ID")
+	}
+	if err := s.store.UpdateScheduleStatus(s.ctx, schedule.ID, "a", models.ScheduleStatusCompleted); !errors.Is(err, storage.ErrScheduleLeaseLost) {
+		t.Errorf("schedule still processing after RecordEvent: UpdateScheduleStatus err = %v", err)
+	}
+
+	// A message recorded without a claim is up for the relay right away
+	webhook := newEventLog(&trigger.ID, s.at(0))
+	webhookMessage := newOutboxMessage(webhook, s.at(0))
+	if err := s.store.RecordEvent(s.ctx, webhook, webhookMessage, nil); err != nil {
+		t.Fatalf("RecordEvent: %v", err)
+	}
+
+	// An event ID that is taken (a concurrent firing reserved the same one) writes nothing
+	duplicate := newEventLog(&trigger.ID, s.at(0))
+	duplicate.ID = webhook.ID
+	if err := s.store.RecordEvent(s.ctx, duplicate, newOutboxMessage(duplicate, s.at(0)), nil); !errors.Is(err, storage.ErrEventLogExists) {
+		t.Fatalf("RecordEvent with a taken event ID: err = %v, want ErrEventLogExists", err)
+	}
+	if err := s.store.CreateEventLog(s.ctx, duplicate); !errors.Is(err, storage.ErrEventLogExists) {
+		t.Errorf("CreateEventLog with a taken event ID: err = %v, want ErrEventLogExists", err)
+	}
+
+	claimed, err := s.store.ClaimOutboxMessages(s.ctx, "relay", 10, lease)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != webhookMessage.ID || claimed[0].AttemptCount != 1 {
+		t.Fatalf("ClaimOutboxMessages = %+v, want the unclaimed message on its first attempt", claimed)
+	}
+	if claimed[0].EventID != webhook.ID || claimed[0].MessageKey != trigger.ID {
+		t.Errorf("claimed message event_id=%s key=%s, want %s and %s", claimed[0].EventID, claimed[0].MessageKey, webhook.ID, trigger.ID)
+	}
+	assertJSONEqual(t, claimed[0].Payload, webhookMessage.Payload)
+
+	// A failed delivery is retried after its backoff, by any relay
+	if err := s.store.RetryOutboxMessage(s.ctx, webhookMessage.ID, "firer", s.at(10*time.Second), "broker down"); !errors.Is(err, storage.ErrOutboxLeaseLost) {
+		t.Errorf("RetryOutboxMessage by a non-owner: err = %v, want ErrOutboxLeaseLost", err)
+	}
+	if err := s.store.RetryOutboxMessage(s.ctx, webhookMessage.ID, "relay", s.at(10*time.Second), "broker down"); err != nil {
+		t.Fatalf("RetryOutboxMessage: %v", err)
+	}
+	if claimed, _ := s.store.ClaimOutboxMessages(s.ctx, "relay", 10, lease); len(claimed) != 0 {
+		t.Fatalf("ClaimOutboxMessages during backoff = %+v, want none", claimed)
+	}
+
+	s.clock.Advance(10 * time.Second)
+	claimed, err = s.store.ClaimOutboxMessages(s.ctx, "relay-2", 10, lease)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != webhookMessage.ID || claimed[0].AttemptCount != 2 {
+		t.Fatalf("ClaimOutboxMessages after backoff = %+v, want the message on its second attempt", claimed)
+	}
+	if claimed[0].LastError == nil || *claimed[0].LastError != "broker down" {
+		t.Errorf("last_error = %v, want broker down", claimed[0].LastError)
+	}
+
+	if err := s.store.MarkOutboxMessageSent(s.ctx, webhookMessage.ID, "relay-2"); err != nil {
+		t.Fatalf("MarkOutboxMessageSent: %v", err)
+	}
+	if err := s.store.MarkOutboxMessageSent(s.ctx, webhookMessage.ID, "relay-2"); !errors.Is(err, storage.ErrOutboxLeaseLost) {
+		t.Errorf("MarkOutboxMessageSent twice: err = %v, want ErrOutboxLeaseLost", err)
+	}
+	stored, err := s.store.GetEventLog(s.ctx, webhook.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	now := s.clock.Now()
+	assertTime(t, "published_at", stored.PublishedAt, &now)
+
+	// The firer crashed before delivering: its message is relayed once its lease expires
+	stored, err = s.store.GetEventLog(s.ctx, fired.ID)
+	if err != nil {
+		t.Fatalf("GetEventLog: %v", err)
+	}
+	if stored.PublishedAt != nil {
+		t.Errorf("undelivered event published_at = %v, want nil", stored.PublishedAt)
+	}
+	s.clock.Advance(lease)
+	claimed, err = s.store.ClaimOutboxMessages(s.ctx, "relay", 10, lease)
+	if err != nil {
+		t.Fatalf("ClaimOutboxMessages: %v", err)
+	}
+	if len(claimed) != 1 || claimed[0].ID != firedMessage.ID || claimed[0].AttemptCount != 2 {
+		t.Fatalf("ClaimOutboxMessages after the firer's lease expired = %+v, want its message on its second attempt", claimed)
+	}
+	if err := s.store.MarkOutboxMessageSent(s.ctx, firedMessage.ID, "firer"); !errors.Is(err, storage.ErrOutboxLeaseLost) {
+		t.Errorf("MarkOutboxMessageSent by the expired firer: err = %v, want ErrOutboxLeaseLost", err)
+	}
+}
+
+func testIdempotencyKeys(t *testing.T, s *suite) {
+	jobID := uuid.New().String()
+
+	eventID, err := s.store.GetIdempotencyKey(s.ctx, jobID)
+	if err != nil {
+		t.Fatalf("GetIdempotencyKey: %v", err)
+	}
+	if eventID != "" {
+		t.Fatalf("GetIdempotencyKey before any reservation = %q, want none", eventID)
+	}
+
+	first := uuid.New().String()
+	reserved, err := s.store.ReserveIdempotencyKey(s.ctx, jobID, first)
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey: %v", err)
+	}
+	if reserved != first {
+		t.Fatalf("ReserveIdempotencyKey = %q, want the new event ID %q", reserved, first)
+	}
+
+	// A retry of the job gets the original event ID back, whatever ID it proposes
+	s.clock.Advance(time.Minute)
+	reserved, err = s.store.ReserveIdempotencyKey(s.ctx, jobID, uuid.New().String())
+	if err != nil {
+		t.Fatalf("ReserveIdempotencyKey (retry): %v", err)
+	}
+	if reserved != first {
+		t.Errorf("ReserveIdempotencyKey (retry) = %q, want the original %q", reserved, first)
+	}
+	if eventID, err = s.store.GetIdempotencyKey(s.ctx, jobID); err != nil || eventID != first {
+		t.Errorf("GetIdempotencyKey = %q, %v; want %q", eventID, err, first)
+	}
+
+	// Keys are per job
+	other := uuid.New().String()
+	if reserved, err = s.store.ReserveIdempotencyKey(s.ctx, uuid.New().String(), other); err != nil || reserved != other {
+		t.Errorf("ReserveIdempotencyKey (other job) = %q, %v; want %q", reserved, err, other)
+	}
+
+	// A key is only rebound once reserved before the cutoff, and only from the event it is bound to
+	rebound := uuid.New().String()
+	if reserved, err = s.store.RebindIdempotencyKey(s.ctx, jobID, first, rebound, s.clock.Now().Add(-time.Hour)); err != nil || reserved != first {
+		t.Errorf("RebindIdempotencyKey of a recent key = %q, %v; want the original %q", reserved, err, first)
+	}
+	s.clock.Advance(2 * time.Hour)
+	cutoff := s.clock.Now().Add(-time.Hour)
+	if reserved, err = s.store.RebindIdempotencyKey(s.ctx, jobID, uuid.New().String(), rebound, cutoff); err != nil || reserved != first {
+		t.Errorf("RebindIdempotencyKey from another event = %q, %v; want the original %q", reserved, err, first)
+	}
+	if reserved, err = s.store.RebindIdempotencyKey(s.ctx, jobID, first, rebound, cutoff); err != nil || reserved != rebound {
+		t.Errorf("RebindIdempotencyKey of an expired key = %q, %v; want the new %q", reserved, err, rebound)
+	}
+	// A concurrent rebind that lost the race gets the winner's event ID, and the rebound key is new again
+	if reserved, err = s.store.RebindIdempotencyKey(s.ctx, jobID, first, uuid.New().String(), cutoff); err != nil || reserved != rebound {
+		t.Errorf("RebindIdempotencyKey after another rebind = %q, %v; want %q", reserved, err, rebound)
+	}
+	if reserved, err = s.store.ReserveIdempotencyKey(s.ctx, jobID, uuid.New().String()); err != nil || reserved != rebound {
+		t.Errorf("ReserveIdempotencyKey after the rebind = %q, %v; want %q", reserved, err, rebound)
+	}
+	if reserved, err = s.store.RebindIdempotencyKey(s.ctx, uuid.New().String(), first, rebound, cutoff); err != nil || reserved != "" {
+		t.Errorf("RebindIdempotencyKey of an unknown job = %q, %v; want none", reserved, err)
+	}
+}
+
+func testCalendarCRUD(t *testing.T, s *suite) {
+	calendar := newCalendar("us-holidays")
+	calendar.Holidays = []string{"2025-12-25", "2026-01-01"}
+	calendar.Windows = []models.ExclusionWindow{
+		{Name: "Year-end freeze", Start: "2025-12-22T00:00:00Z", End: "2026-01-02T00:00:00Z"},
+		{RRule: "DTSTART:20250104T000000Z\nRRULE:FREQ=WEEKLY;BYDAY=SA", Duration: "48h0m0s"},
+	}
+	if err := s.store.CreateCalendar(s.ctx, calendar); err != nil {
+		t.Fatalf("CreateCalendar: %v", err)
+	}
+
+	duplicate := newCalendar("us-holidays")
+	if err := s.store.CreateCalendar(s.ctx, duplicate); !errors.Is(err, storage.ErrCalendarNameTaken) {
+		t.Errorf("CreateCalendar with a taken name: err = %v, want ErrCalendarNameTaken", err)
+	}
+
+	stored, err := s.store.GetCalendar(s.ctx, calendar.ID)
+	if err != nil {
+		t.Fatalf("GetCalendar: %v", err)
+	}
+	if stored.Name != calendar.Name || stored.Timezone != calendar.Timezone ||
+		!slices.Equal(stored.Holidays, calendar.Holidays) || !slices.Equal(stored.Windows, calendar.Windows) {
+		t.Errorf("GetCalendar = %+v, want the created calendar", stored)
+	}
+	now := s.at(0)
+	assertTime(t, "created_at", &stored.CreatedAt, &now)
+
+	byName, err := s.store.GetCalendarByName(s.ctx, "us-holidays")
+	if err != nil {
+		t.Fatalf("GetCalendarByName: %v", err)
+	}
+	if byName.ID != calendar.ID {
+		t.Errorf("GetCalendarByName = %s, want %s", byName.ID, calendar.ID)
+	}
+
+	s.clock.Advance(time.Minute)
+	calendar.Timezone = "America/New_York"
+	calendar.Holidays = nil
+	calendar.Windows = calendar.Windows[:1]
+	if err := s.store.UpdateCalendar(s.ctx, calendar); err != nil {
+		t.Fatalf("UpdateCalendar: %v", err)
+	}
+
+	stored, err = s.store.GetCalendar(s.ctx, calendar.ID)
+	if err != nil {
+		t.Fatalf("GetCalendar after update: %v", err)
+	}
+	if stored.Timezone != "America/New_York" || len(stored.Holidays) != 0 || len(stored.Windows) != 1 {
+		t.Errorf("after UpdateCalendar got %+v", stored)
+	}
+	updatedAt := s.at(0)
+	assertTime(t, "updated_at", &stored.UpdatedAt, &updatedAt)
+
+	if err := s.store.DeleteCalendar(s.ctx, calendar.ID); err != nil {
+		t.Fatalf("DeleteCalendar: %v", err)
+	}
+
+	unknown := newCalendar("unknown")
+	checks := map[string]error{}
+	_, checks["GetCalendar"] = s.store.GetCalendar(s.ctx, calendar.ID)
+	_, checks["GetCalendarByName"] = s.store.GetCalendarByName(s.ctx, "us-holidays")
+	checks["UpdateCalendar"] = s.store.UpdateCalendar(s.ctx, unknown)
+	checks["DeleteCalendar"] = s.store.DeleteCalendar(s.ctx, calendar.ID)
+	for operation, err := range checks {
+		if !errors.Is(err, storage.ErrCalendarNotFound) {
+			t.Errorf("%s for a missing calendar: err = %v, want ErrCalendarNotFound", operation, err)
+		}
+	}
+}
+
+func testListCalendars(t *testing.T, s *suite) {
+	calendars, err := s.store.ListCalendars(s.ctx)
+	if err != nil {
+		t.Fatalf("ListCalendars: %v", err)
+	}
+	if len(calendars) != 0 {
+		t.Errorf("ListCalendars on an empty store = %d calendars, want 0", len(calendars))
+	}
+
+	for _, name := range []string{"release-freeze", "eu-holidays", "us-holidays"} {
+		if err := s.store.CreateCalendar(s.ctx, newCalendar(name)); err != nil {
+			t.Fatalf("CreateCalendar: %v", err)
+		}
+	}
+
+	calendars, err = s.store.ListCalendars(s.ctx)
+	if err != nil {
+		t.Fatalf("ListCalendars: %v", err)
+	}
+	var names []string
+	for _, calendar := range calendars {
+		names = append(names, calendar.Name)
+		if calendar.Holidays == nil || calendar.Windows == nil {
+			t.Errorf("calendar %s has nil lists, want empty ones", calendar.Name)
+		}
+	}
+	if want := []string{"eu-holidays", "release-freeze", "us-holidays"}; !slices.Equal(names, want) {
+		t.Errorf("ListCalendars order = %v, want %v", names, want)
+	}
+}
+
+func newCalendar(name string) *models.Calendar {
+	return &models.Calendar{
+		ID:       uuid.New().String(),
+		Name:     name,
+		Timezone: "UTC",
+	}
+}
+
+func newEventLog(triggerID *string, firedAt time.Time) *models.EventLog {
+	return &models.EventLog{
+		ID:              uuid.New().String(),
+		TriggerID:       triggerID,
+		TriggerType:     models.TriggerTypeTimeScheduled,
+		FiredAt:         firedAt.UTC(),
+		Payload:         json.RawMessage(`{"message":"hello"}`),
+		Source:          models.EventSourceScheduler,
+		ExecutionStatus: models.ExecutionStatusSuccess,
+		RetentionStatus: models.RetentionStatusActive,
+		CreatedAt:       firedAt.UTC(),
+	}
+}
+
+func newOutboxMessage(eventLog *models.EventLog, now time.Time) *models.OutboxMessage {
+	return &models.OutboxMessage{
+		EventID:       eventLog.ID,
+		MessageKey:    *eventLog.TriggerID,
+		Payload:       json.RawMessage(`{"event_id":"` + eventLog.ID + `"}`),
+		Status:        models.OutboxStatusPending,
+		AttemptCount:  0,
+		NextAttemptAt: now.UTC(),
+		CreatedAt:     now.UTC(),
+	}
+}
+
+func assertTime(t *testing.T, what string, got, want *time.Time) {
+	t.Helper()
+
+	switch {
+	case got == nil && want == nil:
+	case got == nil || want == nil:
+		t.Errorf("%s = %v, want %v", what, got, want)
+	case !got.Equal(*want):
+		t.Errorf("%s = %s, want %s", what, got.UTC().Format(time.RFC3339), want.UTC().Format(time.RFC3339))
+	}
+}
+
+func assertJSONEqual(t *testing.T, got, want json.RawMessage) {
+	t.Helper()
+
+	var gotValue, wantValue interface{}
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Errorf("invalid JSON %q: %v", got, err)
+		return
+	}
+	if err := json.Unmarshal(want, &wantValue); err != nil {
+		t.Errorf("invalid JSON %q: %v", want, err)
+		return
+	}
+	gotJSON, _ := json.Marshal(gotValue)
+	wantJSON, _ := json.Marshal(wantValue)
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("JSON = %s, want %s", gotJSON, wantJSON)
+	}
+}
+
+func assertIDs(t *testing.T, what string, got, want []string) {
+	t.Helper()
+
+	if !slices.Equal(slices.Sorted(slices.Values(got)), slices.Sorted(slices.Values(want))) {
+		t.Errorf("%s = %v, want %v", what, got, want)
+	}
+}
+
+func triggerIDs(triggers []models.Trigger) []string {
+	ids := make([]string, 0, len(triggers))
+	for _, trigger := range triggers {
+		ids = append(ids, trigger.ID)
+	}
+	return ids
+}
+
+func scheduleIDs(schedules []storage.ScheduleWithTrigger) []string {
+	ids := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		ids = append(ids, schedule.Schedule.ID)
+	}
+	return ids
+}
+
+func eventIDs(eventLogs []models.EventLog) []string {
+	ids := make([]string, 0, len(eventLogs))
+	for _, eventLog := range eventLogs {
+		ids = append(ids, eventLog.ID)
+	}
+	return ids
+}
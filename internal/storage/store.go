@@ -0,0 +1,182 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+// Retention windows (see db/migrations/004_setup_retention_events.sql,
+// 009_create_schedule_notifications_table.sql and 019_create_event_outbox_table.sql).
+const (
+	// EventLogArchiveAfter is the age at which active event logs become archived.
+	EventLogArchiveAfter = 2 * time.Hour
+	// EventLogDeleteAfter is the age at which event logs are deleted.
+	EventLogDeleteAfter = 48 * time.Hour
+	// IdempotencyKeyDeleteAfter is the age at which idempotency keys are deleted.
+	IdempotencyKeyDeleteAfter = 7 * 24 * time.Hour
+	// ScheduleNotificationDeleteAfter is the age at which schedule notifications are deleted.
+	ScheduleNotificationDeleteAfter = time.Hour
+	// OutboxMessageDeleteAfter is the age (since delivery) at which sent outbox messages are deleted.
+	OutboxMessageDeleteAfter = 24 * time.Hour
+)
+
+// TriggerStore persists triggers. Creating a trigger also stores its first schedule, if any.
+type TriggerStore interface {
+	CreateTrigger(ctx context.Context, trigger *models.Trigger, schedule *models.TriggerSchedule) error
+	// GetTrigger returns ErrTriggerNotFound for unknown IDs, along with the trigger's next pending run
+	// (backfill schedules are not runs of the trigger's own schedule).
+	GetTrigger(ctx context.Context, triggerID string) (*models.Trigger, *time.Time, error)
+	// ListTriggers returns one page (newest first), the next run of each trigger, and the total count.
+	ListTriggers(ctx context.Context, query models.ListTriggersQuery) ([]models.Trigger, []*time.Time, int64, error)
+	// UpdateTrigger applies column updates ("name", "status", "config").
+	UpdateTrigger(ctx context.Context, triggerID string, updates map[string]interface{}) error
+	// DeleteTrigger removes the trigger and its schedules; event logs keep their history without the trigger ID.
+	DeleteTrigger(ctx context.Context, triggerID string) error
+	DeactivateTrigger(ctx context.Context, triggerID string) error
+	// IncrementTriggerFireCount records a scheduled fire of the trigger and returns its new fire count.
+	IncrementTriggerFireCount(ctx context.Context, triggerID string) (int, error)
+}
+
+// ScheduleStore persists trigger schedules and implements the scheduler's claim/lease protocol:
+// pending → processing (ClaimDueSchedules) → completed/cancelled/skipped (UpdateScheduleStatus)
+// or back to pending (RevertScheduleToPending, ReclaimExpiredSchedules).
+type ScheduleStore interface {
+	// UpsertTriggerSchedule cancels the trigger's pending/processing schedules (backfill ones excepted)
+	// and inserts schedule (if not nil).
+	UpsertTriggerSchedule(ctx context.Context, triggerID string, schedule *models.TriggerSchedule) error
+	CreateNextSchedule(ctx context.Context, schedule *models.TriggerSchedule) error
+	// CreateBackfillSchedules inserts the backfill schedules of one trigger in a single transaction.
+	CreateBackfillSchedules(ctx context.Context, schedules []models.TriggerSchedule) error
+	ClaimDueSchedules(ctx context.Context, owner string, limit int, lease time.Duration) ([]ScheduleWithTrigger, error)
+	NextDueTime(ctx context.Context) (*time.Time, error)
+	ExtendScheduleLease(ctx context.Context, scheduleID, owner string, lease time.Duration) error
+	ReclaimExpiredSchedules(ctx context.Context, limit int) ([]ScheduleWithTrigger, error)
+	UpdateScheduleStatus(ctx context.Context, scheduleID, owner string, status models.ScheduleStatus) error
+	RevertScheduleToPending(ctx context.Context, scheduleID, owner string, nextAttemptAt time.Time) error
+	IncrementScheduleAttempt(ctx context.Context, scheduleID string) error
+	LatestScheduleNotificationID(ctx context.Context) (int64, error)
+	ListScheduleNotificationsSince(ctx context.Context, afterID int64, limit int) ([]ScheduleNotification, error)
+}
+
+// EventLogStore persists the history of fired events.
+type EventLogStore interface {
+	// CreateEventLog returns ErrEventLogExists when an event log with the same ID exists.
+	CreateEventLog(ctx context.Context, eventLog *models.EventLog) error
+	UpdateEventLogStatus(ctx context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error
+	// GetEventLog returns nil (and no error) for unknown IDs.
+	GetEventLog(ctx context.Context, eventID string) (*models.EventLog, error)
+	// ListEventLogs filters by retention status (default "active"), returns one page (newest fired_at first) and the total count.
+	ListEventLogs(ctx context.Context, query models.ListEventsQuery) ([]models.EventLog, int64, error)
+	// GetRunningEventLog returns the trigger's most recently fired running event, or nil (and no error) when none is running.
+	GetRunningEventLog(ctx context.Context, triggerID string) (*models.EventLog, error)
+	// ReplaceRunningEventLogs marks the trigger's running events as replaced, with reason as their
+	// error message, and returns how many there were.
+	ReplaceRunningEventLogs(ctx context.Context, triggerID string, reason string) (int, error)
+	// ExpireRunningEventLogs marks the trigger's running events fired at or before firedBefore as
+	// failed, with reason as their error message, and returns how many there were.
+	ExpireRunningEventLogs(ctx context.Context, triggerID string, firedBefore time.Time, reason string) (int, error)
+	// AcknowledgeEventLog records the outcome (success or failure) of a running event and when it was
+	// acknowledged. Returns ErrEventLogNotRunning when the event is not running or does not exist.
+	AcknowledgeEventLog(ctx context.Context, eventID string, status models.ExecutionStatus, errorMessage *string) error
+	// RecordEventDelivery stores the outcome of the dispatcher's HTTP call for an event, replacing any
+	// earlier one, and sets delivery.CompletedAt to the current time. Returns ErrEventLogNotFound for unknown IDs.
+	RecordEventDelivery(ctx context.Context, eventID string, delivery *models.EventDelivery) error
+}
+
+// CalendarStore persists the business calendars recurring triggers reference by name.
+type CalendarStore interface {
+	// CreateCalendar returns ErrCalendarNameTaken when another calendar has the same name.
+	CreateCalendar(ctx context.Context, calendar *models.Calendar) error
+	// GetCalendar returns ErrCalendarNotFound for unknown IDs.
+	GetCalendar(ctx context.Context, calendarID string) (*models.Calendar, error)
+	// GetCalendarByName returns ErrCalendarNotFound for unknown names.
+	GetCalendarByName(ctx context.Context, name string) (*models.Calendar, error)
+	// ListCalendars returns every calendar, ordered by name.
+	ListCalendars(ctx context.Context) ([]models.Calendar, error)
+	// UpdateCalendar replaces the timezone, holidays and windows of an existing calendar.
+	UpdateCalendar(ctx context.Context, calendar *models.Calendar) error
+	DeleteCalendar(ctx context.Context, calendarID string) error
+}
+
+// PauseStore persists pausing and resuming triggers, along with their pause history.
+type PauseStore interface {
+	// PauseTrigger deactivates an active trigger, cancels its pending schedules (backfill ones excepted) and
+	// records event. Returns ErrTriggerNotFound, or ErrTriggerStatusConflict when the trigger is not active.
+	PauseTrigger(ctx context.Context, triggerID string, event *models.TriggerPauseEvent) error
+	// ResumeTrigger reactivates an inactive trigger, replaces its pending schedules (backfill ones excepted)
+	// with schedules and records event. Returns ErrTriggerNotFound, or ErrTriggerStatusConflict when the
+	// trigger is not inactive.
+	ResumeTrigger(ctx context.Context, triggerID string, schedules []models.TriggerSchedule, event *models.TriggerPauseEvent) error
+	// ListTriggerPauseEvents returns a trigger's pause history, newest first.
+	ListTriggerPauseEvents(ctx context.Context, triggerID string) ([]models.TriggerPauseEvent, error)
+}
+
+// OutboxStore persists the transactional outbox of trigger events. A message is pending until
+// Kafka accepts it; whoever delivers it holds a lease on it first (ClaimOutboxMessages, or the
+// claim RecordEvent hands to the firing process), so a crashed deliverer's messages are retried.
+type OutboxStore interface {
+	// RecordEvent inserts eventLog and its outbox message, claimed by message.ClaimedBy until
+	// message.LeaseExpiresAt, in one transaction. With completion set, the same transaction completes
+	// the claimed schedule that fired the event; when its owner no longer holds it, nothing is written
+	// and ErrScheduleLeaseLost is returned. ErrEventLogExists means an event with the same ID was
+	// recorded already, and nothing is written either.
+	RecordEvent(ctx context.Context, eventLog *models.EventLog, message *models.OutboxMessage, completion *ScheduleCompletion) error
+	// ClaimOutboxMessages leases up to limit pending messages that are due (next_attempt_at passed,
+	// no live lease) to owner, oldest first.
+	ClaimOutboxMessages(ctx context.Context, owner string, limit int, lease time.Duration) ([]models.OutboxMessage, error)
+	// MarkOutboxMessageSent records that Kafka accepted a message claimed by owner, and stamps its
+	// event log's published_at. Returns ErrOutboxLeaseLost when owner no longer holds the message.
+	MarkOutboxMessageSent(ctx context.Context, messageID int64, owner string) error
+	// RetryOutboxMessage releases a message claimed by owner after a failed delivery, to be claimed
+	// again from nextAttemptAt. Returns ErrOutboxLeaseLost when owner no longer holds the message.
+	RetryOutboxMessage(ctx context.Context, messageID int64, owner string, nextAttemptAt time.Time, lastError string) error
+}
+
+// IdempotencyStore binds jobs (e.g. a schedule) to the event they fire, so a retried job reuses its
+// original event ID instead of producing a second event. Keys are deleted after IdempotencyKeyDeleteAfter.
+type IdempotencyStore interface {
+	// ReserveIdempotencyKey binds jobID to eventID unless it is bound already, and returns the event
+	// ID jobID is bound to: eventID, or the one reserved by an earlier attempt.
+	ReserveIdempotencyKey(ctx context.Context, jobID, eventID string) (string, error)
+	// GetIdempotencyKey returns the event ID bound to jobID, or "" (and no error) when none is.
+	GetIdempotencyKey(ctx context.Context, jobID string) (string, error)
+	// RebindIdempotencyKey binds jobID to eventID, as if reserved now, when it is still bound to
+	// staleEventID by a reservation made before reservedBefore. It returns the event ID jobID is bound
+	// to afterwards, or "" when none is.
+	RebindIdempotencyKey(ctx context.Context, jobID, staleEventID, eventID string, reservedBefore time.Time) (string, error)
+}
+
+// LeaderStore persists the scheduler leader lease (leader-election mode). Leases are compared
+// against the store's clock, so all instances agree on when one has expired.
+type LeaderStore interface {
+	// AcquireLeadership makes holder the leader of name for ttl when the lease is free, expired or
+	// already held by holder, issuing a new fencing token. It returns the lease as it stands afterwards
+	// and whether holder now holds it.
+	AcquireLeadership(ctx context.Context, name, holder string, ttl time.Duration) (*models.SchedulerLease, bool, error)
+	// RenewLeadership extends the lease held by holder under token to now + ttl. Returns
+	// ErrLeadershipLost when the lease expired or another holder took it over.
+	RenewLeadership(ctx context.Context, name, holder string, token int64, ttl time.Duration) (*models.SchedulerLease, error)
+	// ReleaseLeadership expires the lease held by holder under token, so a standby can take over
+	// without waiting for it to run out. Releasing a lease that was lost already is not an error.
+	ReleaseLeadership(ctx context.Context, name, holder string, token int64) error
+	// GetLeader returns the lease of name (which may have expired), or nil when it was never acquired.
+	GetLeader(ctx context.Context, name string) (*models.SchedulerLease, error)
+}
+
+// Store is the complete persistence layer. MySQLClient is the production implementation;
+// internal/storage/sqlite is an embedded one for single-node deployments and
+// internal/storage/memory an in-process one, all with the same semantics.
+type Store interface {
+	TriggerStore
+	ScheduleStore
+	EventLogStore
+	CalendarStore
+	PauseStore
+	LeaderStore
+	OutboxStore
+	IdempotencyStore
+}
+
+var _ Store = (*MySQLClient)(nil)
@@ -43,15 +43,20 @@ func (c *MySQLClient) CreateTrigger(ctx context.Context, trigger *models.Trigger
 	if schedule != nil {
 		if _, err = tx.ExecContext(
 			ctx,
-			`INSERT INTO trigger_schedules (id, trigger_id, fire_at, status, attempt_count) VALUES (?, ?, ?, ?, ?)`,
+			`INSERT INTO trigger_schedules (id, trigger_id, fire_at, scheduled_for, status, attempt_count) VALUES (?, ?, ?, ?, ?, ?)`,
 			schedule.ID,
 			trigger.ID,
 			schedule.FireAt,
+			schedule.ScheduledFor,
 			schedule.Status,
 			0,
 		); err != nil {
 			return fmt.Errorf("insert trigger schedule: %w", err)
 		}
+
+		if err = insertScheduleNotification(ctx, tx, schedule.FireAt); err != nil {
+			return err
+		}
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -65,14 +70,14 @@ func (c *MySQLClient) CreateTrigger(ctx context.Context, trigger *models.Trigger
 func (c *MySQLClient) GetTrigger(ctx context.Context, triggerID string) (*models.Trigger, *time.Time, error) {
 	row := c.db.QueryRowContext(
 		ctx,
-		`SELECT id, name, type, status, config, created_at, updated_at
+		`SELECT id, name, type, status, config, fire_count, created_at, updated_at
 		 FROM triggers WHERE id = ?`,
 		triggerID,
 	)
 
 	var t models.Trigger
 	var config string
-	if err := row.Scan(&t.ID, &t.Name, &t.Type, &t.Status, &config, &t.CreatedAt, &t.UpdatedAt); err != nil {
+	if err := row.Scan(&t.ID, &t.Name, &t.Type, &t.Status, &config, &t.FireCount, &t.CreatedAt, &t.UpdatedAt); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil, ErrTriggerNotFound
 		}
@@ -119,11 +124,12 @@ func (c *MySQLClient) ListTriggers(ctx context.Context, query models.ListTrigger
 	argsWithPagination := append(append([]interface{}{}, args...), query.Limit, offset)
 
 	dataQuery := fmt.Sprintf(`
-		SELECT id, name, type, status, config, created_at, updated_at,
+		SELECT id, name, type, status, config, fire_count, created_at, updated_at,
 			(
 				SELECT fire_at FROM trigger_schedules
 				WHERE trigger_id = triggers.id
 				  AND status IN ('pending', 'processing')
+				  AND backfill = FALSE
 				ORDER BY fire_at ASC
 				LIMIT 1
 			) AS next_fire_at
@@ -145,7 +151,7 @@ func (c *MySQLClient) ListTriggers(ctx context.Context, query models.ListTrigger
 		var trigger models.Trigger
 		var config string
 		var nextFire sql.NullTime
-		if err := rows.Scan(&trigger.ID, &trigger.Name, &trigger.Type, &trigger.Status, &config, &trigger.CreatedAt, &trigger.UpdatedAt, &nextFire); err != nil {
+		if err := rows.Scan(&trigger.ID, &trigger.Name, &trigger.Type, &trigger.Status, &config, &trigger.FireCount, &trigger.CreatedAt, &trigger.UpdatedAt, &nextFire); err != nil {
 			return nil, nil, 0, fmt.Errorf("scan trigger row: %w", err)
 		}
 		trigger.Config = jsonRawMessage(config)
@@ -180,8 +186,8 @@ func (c *MySQLClient) UpdateTrigger(ctx context.Context, triggerID string, updat
 		args = append(args, value)
 	}
 
-	setParts = append(setParts, "updated_at = NOW()")
-	args = append(args, triggerID)
+	setParts = append(setParts, "updated_at = ?")
+	args = append(args, c.now(), triggerID)
 
 	query := fmt.Sprintf("UPDATE triggers SET %s WHERE id = ?", strings.Join(setParts, ", "))
 	res, err := c.db.ExecContext(ctx, query, args...)
@@ -221,6 +227,7 @@ func (c *MySQLClient) DeleteTrigger(ctx context.Context, triggerID string) error
 }
 
 // UpsertTriggerSchedule replaces all pending schedules for a trigger and inserts the provided one.
+// Backfill schedules are left alone: they replay past occurrences, whatever the trigger's schedule.
 func (c *MySQLClient) UpsertTriggerSchedule(ctx context.Context, triggerID string, schedule *models.TriggerSchedule) error {
 	tx, err := c.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -236,8 +243,9 @@ func (c *MySQLClient) UpsertTriggerSchedule(ctx context.Context, triggerID strin
 	if _, err = tx.ExecContext(
 		ctx,
 		`UPDATE trigger_schedules
-		 SET status = 'cancelled', updated_at = NOW()
-		 WHERE trigger_id = ? AND status IN ('pending', 'processing')`,
+		 SET status = 'cancelled', updated_at = ?
+		 WHERE trigger_id = ? AND status IN ('pending', 'processing') AND backfill = FALSE`,
+		c.now(),
 		triggerID,
 	); err != nil {
 		return fmt.Errorf("cancel existing schedules: %w", err)
@@ -252,16 +260,21 @@ func (c *MySQLClient) UpsertTriggerSchedule(ctx context.Context, triggerID strin
 
 	if _, err = tx.ExecContext(
 		ctx,
-		`INSERT INTO trigger_schedules (id, trigger_id, fire_at, status, attempt_count)
-		 VALUES (?, ?, ?, ?, 0)`,
+		`INSERT INTO trigger_schedules (id, trigger_id, fire_at, scheduled_for, status, attempt_count)
+		 VALUES (?, ?, ?, ?, ?, 0)`,
 		schedule.ID,
 		triggerID,
 		schedule.FireAt,
+		schedule.ScheduledFor,
 		schedule.Status,
 	); err != nil {
 		return fmt.Errorf("insert schedule: %w", err)
 	}
 
+	if err = insertScheduleNotification(ctx, tx, schedule.FireAt); err != nil {
+		return err
+	}
+
 	if err = tx.Commit(); err != nil {
 		return fmt.Errorf("commit transaction: %w", err)
 	}
@@ -274,7 +287,7 @@ func (c *MySQLClient) getNextSchedule(ctx context.Context, triggerID string) (*t
 		ctx,
 		`SELECT fire_at
 		 FROM trigger_schedules
-		 WHERE trigger_id = ? AND status IN ('pending', 'processing')
+		 WHERE trigger_id = ? AND status IN ('pending', 'processing') AND backfill = FALSE
 		 ORDER BY fire_at ASC
 		 LIMIT 1`,
 		triggerID,
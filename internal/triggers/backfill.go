@@ -0,0 +1,108 @@
+package triggers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/google/uuid"
+)
+
+// DefaultBackfillRate is how many backfill schedules fire per minute when no rate is given.
+const DefaultBackfillRate = 60
+
+// MaxBackfillRate is the fastest backfill rate: schedules fire at whole seconds, so a faster rate
+// would put several of them on the same second.
+const MaxBackfillRate = 60
+
+// MaxBackfillOccurrences bounds how many occurrences one backfill request may enqueue.
+const MaxBackfillOccurrences = 1000
+
+// Backfill enqueues the occurrences a recurring trigger would have fired in [req.From, req.To) as
+// backfill schedules, spaced to fire req.RatePerMinute per minute starting now. The trigger's
+// active window and calendars apply as they do to its regular schedule; max_fires does not, and
+// backfill fires are not counted against it. The resulting events have source "backfill" and carry
+// the replayed occurrence as scheduled_for.
+func (s *Service) Backfill(ctx context.Context, triggerID string, req models.BackfillRequest) (*models.BackfillResponse, error) {
+	trigger, _, err := s.store.GetTrigger(ctx, triggerID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTriggerNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get trigger: %w", err)
+	}
+
+	if !IsRecurring(trigger.Type) {
+		return nil, NewValidationError("%s triggers cannot be backfilled: only recurring triggers have past occurrences", trigger.Type)
+	}
+	if trigger.Status != models.TriggerStatusActive {
+		return nil, NewValidationError("trigger is inactive: backfill schedules only fire for active triggers")
+	}
+
+	now := s.clock.Now().UTC()
+	from, to := req.From.UTC(), req.To.UTC()
+	if !from.Before(to) {
+		return nil, NewValidationError("from must be before to")
+	}
+	if to.After(now) {
+		return nil, NewValidationError("to must not be in the future")
+	}
+
+	rate := req.RatePerMinute
+	if rate <= 0 {
+		rate = DefaultBackfillRate
+	}
+	if rate > MaxBackfillRate {
+		return nil, NewValidationError("rate_per_minute must be at most %d", MaxBackfillRate)
+	}
+
+	// recurringOccurrences lists occurrences in (now, until]; shift both ends to get [from, to)
+	entries, truncated, err := s.recurringOccurrences(ctx, trigger, from.Add(-time.Nanosecond), to.Add(-time.Nanosecond), MaxBackfillOccurrences+1, false)
+	if err != nil {
+		return nil, err
+	}
+	if truncated || len(entries) > MaxBackfillOccurrences {
+		return nil, NewValidationError("the range holds more than %d occurrences; split it into several backfills", MaxBackfillOccurrences)
+	}
+
+	interval := time.Minute / time.Duration(rate)
+	schedules := make([]models.TriggerSchedule, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Status == models.OccurrenceStatusSkipped {
+			continue
+		}
+		scheduledFor := entry.ScheduledFor
+		schedules = append(schedules, models.TriggerSchedule{
+			ID:           uuid.New().String(),
+			TriggerID:    trigger.ID,
+			FireAt:       now.Add(time.Duration(len(schedules)) * interval),
+			ScheduledFor: &scheduledFor,
+			Backfill:     true,
+			Status:       models.ScheduleStatusPending,
+		})
+	}
+
+	if err := s.store.CreateBackfillSchedules(ctx, schedules); err != nil {
+		return nil, fmt.Errorf("create backfill schedules: %w", err)
+	}
+
+	resp := &models.BackfillResponse{
+		TriggerID:     trigger.ID,
+		From:          from,
+		To:            to,
+		RatePerMinute: rate,
+		Schedules:     make([]models.BackfillScheduleResponse, 0, len(schedules)),
+	}
+	for _, schedule := range schedules {
+		resp.Schedules = append(resp.Schedules, models.BackfillScheduleResponse{
+			ID:           schedule.ID,
+			ScheduledFor: *schedule.ScheduledFor,
+			FireAt:       schedule.FireAt,
+		})
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,159 @@
+package triggers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"go.uber.org/zap"
+)
+
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(utc(2025, 1, 1, 0, 0))
+	store := memory.NewStore(clk)
+	service := triggers.NewService(store, clk, zap.NewNop())
+
+	trigger, err := service.CreateTrigger(ctx, models.CreateTriggerRequest{
+		Name:   "minutely",
+		Type:   models.TriggerTypeCronScheduled,
+		Config: json.RawMessage(`{"cron":"* * * * *","endpoint":"https://example.com/hook"}`),
+	})
+	if err != nil {
+		t.Fatalf("CreateTrigger: %v", err)
+	}
+	now := utc(2025, 1, 3, 0, 0).Add(30 * time.Second)
+	clk.Set(now)
+
+	cases := []struct {
+		name     string
+		rate     int
+		from, to time.Time
+		wantRate int
+		wantGap  time.Duration // between consecutive fire_at
+	}{
+		{name: "default rate", from: utc(2025, 1, 1, 9, 0), to: utc(2025, 1, 1, 9, 5), wantRate: triggers.DefaultBackfillRate, wantGap: time.Second},
+		{name: "slower rate", rate: 20, from: utc(2025, 1, 1, 10, 0), to: utc(2025, 1, 1, 10, 5), wantRate: 20, wantGap: 3 * time.Second},
+		{name: "maximum rate", rate: triggers.MaxBackfillRate, from: utc(2025, 1, 1, 11, 0), to: utc(2025, 1, 1, 11, 5), wantRate: 60, wantGap: time.Second},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := service.Backfill(ctx, trigger.ID, models.BackfillRequest{From: tc.from, To: tc.to, RatePerMinute: tc.rate})
+			if err != nil {
+				t.Fatalf("Backfill: %v", err)
+			}
+			if resp.RatePerMinute != tc.wantRate {
+				t.Errorf("rate_per_minute = %d, want %d", resp.RatePerMinute, tc.wantRate)
+			}
+			// from is inclusive and to exclusive: five minutes hold five occurrences
+			if len(resp.Schedules) != 5 {
+				t.Fatalf("got %d schedules, want 5", len(resp.Schedules))
+			}
+			for i, schedule := range resp.Schedules {
+				if want := tc.from.Add(time.Duration(i) * time.Minute); !schedule.ScheduledFor.Equal(want) {
+					t.Errorf("schedule %d scheduled_for = %s, want %s", i, schedule.ScheduledFor, want)
+				}
+				if want := now.Add(time.Duration(i) * tc.wantGap); !schedule.FireAt.Equal(want) {
+					t.Errorf("schedule %d fire_at = %s, want %s", i, schedule.FireAt, want)
+				}
+			}
+		})
+	}
+
+	backfills := 0
+	for _, schedule := range store.Schedules(trigger.ID) {
+		if schedule.Backfill {
+			backfills++
+		}
+	}
+	if backfills != 15 {
+		t.Errorf("stored %d backfill schedules, want 15", backfills)
+	}
+}
+
+func TestBackfillRejects(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(utc(2025, 1, 1, 0, 0))
+	store := memory.NewStore(clk)
+	service := triggers.NewService(store, clk, zap.NewNop())
+
+	create := func(name string, typ models.TriggerType, config string) string {
+		trigger, err := service.CreateTrigger(ctx, models.CreateTriggerRequest{Name: name, Type: typ, Config: json.RawMessage(config)})
+		if err != nil {
+			t.Fatalf("CreateTrigger %s: %v", name, err)
+		}
+		return trigger.ID
+	}
+	minutely := create("minutely", models.TriggerTypeCronScheduled, `{"cron":"* * * * *","endpoint":"https://example.com/hook"}`)
+	webhook := create("webhook", models.TriggerTypeWebhook, `{"endpoint":"https://example.com/hook"}`)
+	clk.Set(utc(2025, 1, 3, 0, 0))
+
+	start := utc(2025, 1, 1, 0, 0)
+	cases := []struct {
+		name      string
+		triggerID string
+		req       models.BackfillRequest
+		wantErr   string
+	}{
+		{
+			name:      "more than the maximum occurrences",
+			triggerID: minutely,
+			req:       models.BackfillRequest{From: start, To: start.Add((triggers.MaxBackfillOccurrences + 1) * time.Minute)},
+			wantErr:   "more than 1000 occurrences",
+		},
+		{
+			name:      "rate above the maximum",
+			triggerID: minutely,
+			req:       models.BackfillRequest{From: start, To: start.Add(time.Hour), RatePerMinute: triggers.MaxBackfillRate + 1},
+			wantErr:   "rate_per_minute must be at most 60",
+		},
+		{
+			name:      "empty range",
+			triggerID: minutely,
+			req:       models.BackfillRequest{From: start, To: start},
+			wantErr:   "from must be before to",
+		},
+		{
+			name:      "range in the future",
+			triggerID: minutely,
+			req:       models.BackfillRequest{From: start, To: utc(2025, 1, 4, 0, 0)},
+			wantErr:   "must not be in the future",
+		},
+		{
+			name:      "webhook trigger",
+			triggerID: webhook,
+			req:       models.BackfillRequest{From: start, To: start.Add(time.Hour)},
+			wantErr:   "cannot be backfilled",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.Backfill(ctx, tc.triggerID, tc.req)
+			var validationErr triggers.ValidationError
+			if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("Backfill err = %v, want a validation error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+
+	// Exactly the maximum is accepted
+	resp, err := service.Backfill(ctx, minutely, models.BackfillRequest{From: start, To: start.Add(triggers.MaxBackfillOccurrences * time.Minute)})
+	if err != nil {
+		t.Fatalf("Backfill of %d occurrences: %v", triggers.MaxBackfillOccurrences, err)
+	}
+	if len(resp.Schedules) != triggers.MaxBackfillOccurrences {
+		t.Errorf("got %d schedules, want %d", len(resp.Schedules), triggers.MaxBackfillOccurrences)
+	}
+	if last := resp.Schedules[len(resp.Schedules)-1].FireAt; !last.Equal(utc(2025, 1, 3, 0, 0).Add((triggers.MaxBackfillOccurrences - 1) * time.Second)) {
+		t.Errorf("last fire_at = %s, want one second per occurrence after now", last)
+	}
+}
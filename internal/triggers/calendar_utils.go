@@ -0,0 +1,293 @@
+package triggers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+// holidayLayout is the format of calendar holidays.
+const holidayLayout = "2006-01-02"
+
+// maxWindowScan bounds how many overlapping occurrences of a recurring window are examined
+// for a single instant.
+const maxWindowScan = 1000
+
+// Exclusion is a period during which a calendar suppresses occurrences, [Start, End).
+type Exclusion struct {
+	Start  time.Time
+	End    time.Time
+	Reason string // e.g. "holiday 2025-12-25 in calendar us-holidays"
+}
+
+// CalendarSet evaluates the exclusions of the calendars a recurring trigger references.
+type CalendarSet struct {
+	calendars []compiledCalendar
+}
+
+type compiledCalendar struct {
+	name     string
+	location *time.Location
+	holidays map[string]bool
+	windows  []compiledWindow
+}
+
+// compiledWindow is either a fixed [start, end) window or a recurrence whose occurrences each
+// open a window lasting duration.
+type compiledWindow struct {
+	name       string
+	start, end time.Time
+	recurrence *RRuleSchedule
+	duration   time.Duration
+}
+
+// NewCalendarSet parses stored calendars. Calendars are validated on write (see
+// NormalizeCalendar), so errors mean the stored definition was tampered with.
+func NewCalendarSet(calendars []models.Calendar) (*CalendarSet, error) {
+	set := &CalendarSet{calendars: make([]compiledCalendar, 0, len(calendars))}
+	for _, calendar := range calendars {
+		compiled, err := compileCalendar(calendar)
+		if err != nil {
+			return nil, fmt.Errorf("calendar %s: %w", calendar.Name, err)
+		}
+		set.calendars = append(set.calendars, compiled)
+	}
+	return set, nil
+}
+
+func compileCalendar(calendar models.Calendar) (compiledCalendar, error) {
+	loc, err := resolveTimezone(calendar.Timezone)
+	if err != nil {
+		return compiledCalendar{}, err
+	}
+
+	compiled := compiledCalendar{
+		name:     calendar.Name,
+		location: loc,
+		holidays: make(map[string]bool, len(calendar.Holidays)),
+	}
+	for _, holiday := range calendar.Holidays {
+		compiled.holidays[holiday] = true
+	}
+
+	for i, window := range calendar.Windows {
+		if window.RRule == "" {
+			start, err := time.Parse(time.RFC3339, window.Start)
+			if err != nil {
+				return compiledCalendar{}, fmt.Errorf("windows[%d]: invalid start: %w", i, err)
+			}
+			end, err := time.Parse(time.RFC3339, window.End)
+			if err != nil {
+				return compiledCalendar{}, fmt.Errorf("windows[%d]: invalid end: %w", i, err)
+			}
+			compiled.windows = append(compiled.windows, compiledWindow{name: window.Name, start: start, end: end})
+			continue
+		}
+
+		recurrence, err := NewRRuleSchedule(window.RRule, loc.String(), calendar.CreatedAt)
+		if err != nil {
+			return compiledCalendar{}, fmt.Errorf("windows[%d]: invalid rrule: %w", i, err)
+		}
+		duration, err := time.ParseDuration(window.Duration)
+		if err != nil {
+			return compiledCalendar{}, fmt.Errorf("windows[%d]: invalid duration: %w", i, err)
+		}
+		compiled.windows = append(compiled.windows, compiledWindow{name: window.Name, recurrence: recurrence, duration: duration})
+	}
+
+	return compiled, nil
+}
+
+// ExclusionAt returns the exclusion containing t, or nil when no calendar excludes t.
+// When several overlap, the one ending last is returned so a deferred occurrence clears all of them.
+func (s *CalendarSet) ExclusionAt(t time.Time) *Exclusion {
+	var found *Exclusion
+	for _, calendar := range s.calendars {
+		for _, exclusion := range calendar.exclusionsAt(t) {
+			if found == nil || exclusion.End.After(found.End) {
+				found = &exclusion
+			}
+		}
+	}
+	return found
+}
+
+// exclusionsAt lists the holiday and windows of the calendar that contain t.
+func (c compiledCalendar) exclusionsAt(t time.Time) []Exclusion {
+	var exclusions []Exclusion
+
+	local := t.In(c.location)
+	if day := local.Format(holidayLayout); c.holidays[day] {
+		year, month, date := local.Date()
+		exclusions = append(exclusions, Exclusion{
+			Start:  time.Date(year, month, date, 0, 0, 0, 0, c.location).UTC(),
+			End:    time.Date(year, month, date+1, 0, 0, 0, 0, c.location).UTC(),
+			Reason: fmt.Sprintf("holiday %s in calendar %s", day, c.name),
+		})
+	}
+
+	for _, window := range c.windows {
+		start, end, ok := window.containing(t)
+		if !ok {
+			continue
+		}
+
+		label := window.name
+		if label == "" {
+			label = start.In(c.location).Format(time.RFC3339) + "/" + end.In(c.location).Format(time.RFC3339)
+		}
+		exclusions = append(exclusions, Exclusion{
+			Start:  start.UTC(),
+			End:    end.UTC(),
+			Reason: fmt.Sprintf("blackout window %s in calendar %s", label, c.name),
+		})
+	}
+
+	return exclusions
+}
+
+// containing returns the [start, end) instance of the window that contains t. Of overlapping
+// instances of a recurring window, the one that ends last is returned.
+func (w compiledWindow) containing(t time.Time) (time.Time, time.Time, bool) {
+	if w.recurrence == nil {
+		return w.start, w.end, !t.Before(w.start) && t.Before(w.end)
+	}
+
+	// Instances containing t started in (t - duration, t]
+	var start time.Time
+	next := w.recurrence.Next(t.Add(-w.duration))
+	for i := 0; i < maxWindowScan && !next.IsZero() && !next.After(t); i++ {
+		start = next
+		next = w.recurrence.Next(next)
+	}
+	if start.IsZero() {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(w.duration), true
+}
+
+// NormalizeCalendar validates a calendar definition and brings it into its stored form:
+// the timezone is resolved, holidays are sorted and de-duplicated, fixed windows are
+// RFC 3339 and recurring windows get a DTSTART (midnight of now's day) when they lack one.
+func NormalizeCalendar(calendar *models.Calendar, now time.Time) error {
+	loc, err := resolveLocation(calendar.Timezone)
+	if err != nil {
+		return err
+	}
+	calendar.Timezone = loc.String()
+
+	holidays := make([]string, 0, len(calendar.Holidays))
+	seen := make(map[string]bool, len(calendar.Holidays))
+	for _, holiday := range calendar.Holidays {
+		day, err := time.Parse(holidayLayout, strings.TrimSpace(holiday))
+		if err != nil {
+			return NewValidationError("invalid holiday %q: must be YYYY-MM-DD", holiday)
+		}
+		if value := day.Format(holidayLayout); !seen[value] {
+			seen[value] = true
+			holidays = append(holidays, value)
+		}
+	}
+	sort.Strings(holidays)
+	calendar.Holidays = holidays
+
+	windows := make([]models.ExclusionWindow, 0, len(calendar.Windows))
+	for i, window := range calendar.Windows {
+		if err := normalizeExclusionWindow(&window, loc, now); err != nil {
+			return NewValidationError("windows[%d]: %v", i, err)
+		}
+		windows = append(windows, window)
+	}
+	calendar.Windows = windows
+
+	return nil
+}
+
+// normalizeExclusionWindow validates one window of a calendar in the calendar's timezone.
+func normalizeExclusionWindow(window *models.ExclusionWindow, loc *time.Location, now time.Time) error {
+	window.Name = strings.TrimSpace(window.Name)
+
+	if window.RRule == "" {
+		if window.Duration != "" {
+			return fmt.Errorf("duration is only used with rrule")
+		}
+		if window.Start == "" || window.End == "" {
+			return fmt.Errorf("start and end (or rrule and duration) are required")
+		}
+
+		start, err := time.Parse(time.RFC3339, window.Start)
+		if err != nil {
+			return fmt.Errorf("invalid start: %v", err)
+		}
+		end, err := time.Parse(time.RFC3339, window.End)
+		if err != nil {
+			return fmt.Errorf("invalid end: %v", err)
+		}
+		if !end.After(start) {
+			return fmt.Errorf("end must be after start")
+		}
+
+		window.Start = start.Format(time.RFC3339)
+		window.End = end.Format(time.RFC3339)
+		return nil
+	}
+
+	if window.Start != "" || window.End != "" {
+		return fmt.Errorf("start and end cannot be combined with rrule")
+	}
+	if window.Duration == "" {
+		return fmt.Errorf("duration is required with rrule")
+	}
+
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return fmt.Errorf("invalid duration: %v", err)
+	}
+	if duration < time.Second {
+		return fmt.Errorf("duration must be at least 1s")
+	}
+
+	window.RRule = pinDTStart(window.RRule, now, loc)
+	if _, err := NewRRuleSchedule(window.RRule, loc.String(), now); err != nil {
+		return fmt.Errorf("invalid rrule: %v", err)
+	}
+
+	window.Duration = duration.String()
+	return nil
+}
+
+// normalizeCalendarOptions validates the calendar references of a recurring trigger and fills
+// in the default policy. Whether the calendars exist is checked by the service.
+func normalizeCalendarOptions(config *RecurrenceOptions) error {
+	names := make([]string, 0, len(config.Calendars))
+	for _, name := range config.Calendars {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return NewValidationError("calendars must not contain empty names")
+		}
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		config.Calendars = nil
+		config.CalendarPolicy = ""
+		return nil
+	}
+	config.Calendars = names
+
+	switch config.CalendarPolicy {
+	case "":
+		config.CalendarPolicy = models.CalendarPolicySkip
+	case models.CalendarPolicySkip, models.CalendarPolicyDefer:
+	default:
+		return NewValidationError("invalid calendar_policy %q: must be one of skip, defer", config.CalendarPolicy)
+	}
+
+	return nil
+}
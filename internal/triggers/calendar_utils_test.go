@@ -0,0 +1,197 @@
+package triggers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"go.uber.org/zap"
+)
+
+func TestCalendarSetExclusionAt(t *testing.T) {
+	calendars := []models.Calendar{
+		{Name: "tokyo", Timezone: "Asia/Tokyo", Holidays: []string{"2025-01-01"}},
+		// Both are DST transition days: 2025-03-09 lasts 23 hours, 2025-11-02 lasts 25
+		{Name: "new-york", Timezone: "America/New_York", Holidays: []string{"2025-03-09", "2025-11-02"}},
+		{
+			Name:     "london",
+			Timezone: "Europe/London",
+			Windows: []models.ExclusionWindow{
+				// 22:00 to 02:00 London time every night
+				{Name: "nightly maintenance", RRule: "DTSTART;TZID=Europe/London:20250101T220000\nRRULE:FREQ=DAILY", Duration: "4h0m0s"},
+				{Name: "migration", Start: "2025-02-01T23:00:00Z", End: "2025-02-02T06:00:00Z"},
+			},
+			CreatedAt: utc(2025, 1, 1, 0, 0),
+		},
+	}
+	set, err := triggers.NewCalendarSet(calendars)
+	if err != nil {
+		t.Fatalf("NewCalendarSet: %v", err)
+	}
+
+	const (
+		tokyoNewYear = "holiday 2025-01-01 in calendar tokyo"
+		nySpring     = "holiday 2025-03-09 in calendar new-york"
+		nyFall       = "holiday 2025-11-02 in calendar new-york"
+		nightly      = "blackout window nightly maintenance in calendar london"
+		migration    = "blackout window migration in calendar london"
+	)
+
+	cases := []struct {
+		name string
+		at   time.Time
+		want *triggers.Exclusion // nil when at is not excluded
+	}{
+		{name: "before a holiday in the calendar's timezone", at: utc(2024, 12, 31, 14, 59)},
+		{name: "holiday starts at local midnight", at: utc(2024, 12, 31, 15, 0), want: &triggers.Exclusion{Start: utc(2024, 12, 31, 15, 0), End: utc(2025, 1, 1, 15, 0), Reason: tokyoNewYear}},
+		{name: "holiday still excluded", at: utc(2025, 1, 1, 14, 59), want: &triggers.Exclusion{Start: utc(2024, 12, 31, 15, 0), End: utc(2025, 1, 1, 15, 0), Reason: tokyoNewYear}},
+		{name: "holiday ends at the next local midnight", at: utc(2025, 1, 1, 15, 0)},
+
+		{name: "before a spring-forward holiday", at: utc(2025, 3, 9, 4, 59)},
+		{name: "spring-forward holiday lasts 23 hours", at: utc(2025, 3, 9, 5, 0), want: &triggers.Exclusion{Start: utc(2025, 3, 9, 5, 0), End: utc(2025, 3, 10, 4, 0), Reason: nySpring}},
+		{name: "end of a spring-forward holiday", at: utc(2025, 3, 10, 3, 59), want: &triggers.Exclusion{Start: utc(2025, 3, 9, 5, 0), End: utc(2025, 3, 10, 4, 0), Reason: nySpring}},
+		{name: "after a spring-forward holiday", at: utc(2025, 3, 10, 4, 0)},
+		{name: "fall-back holiday lasts 25 hours", at: utc(2025, 11, 3, 4, 30), want: &triggers.Exclusion{Start: utc(2025, 11, 2, 4, 0), End: utc(2025, 11, 3, 5, 0), Reason: nyFall}},
+		{name: "after a fall-back holiday", at: utc(2025, 11, 3, 5, 0)},
+
+		{name: "before a window crossing midnight", at: utc(2025, 1, 20, 21, 59)},
+		{name: "window crossing midnight, before midnight", at: utc(2025, 1, 20, 23, 30), want: &triggers.Exclusion{Start: utc(2025, 1, 20, 22, 0), End: utc(2025, 1, 21, 2, 0), Reason: nightly}},
+		{name: "window crossing midnight, after midnight", at: utc(2025, 1, 21, 1, 59), want: &triggers.Exclusion{Start: utc(2025, 1, 20, 22, 0), End: utc(2025, 1, 21, 2, 0), Reason: nightly}},
+		{name: "window crossing midnight ends", at: utc(2025, 1, 21, 2, 0)},
+		{name: "window follows the calendar's summer time", at: utc(2025, 7, 10, 21, 0), want: &triggers.Exclusion{Start: utc(2025, 7, 10, 21, 0), End: utc(2025, 7, 11, 1, 0), Reason: nightly}},
+		{name: "overlapping windows yield the one ending last", at: utc(2025, 2, 2, 1, 0), want: &triggers.Exclusion{Start: utc(2025, 2, 1, 23, 0), End: utc(2025, 2, 2, 6, 0), Reason: migration}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := set.ExclusionAt(tc.at)
+			switch {
+			case tc.want == nil && got != nil:
+				t.Errorf("ExclusionAt(%s) = %+v, want none", tc.at, *got)
+			case tc.want != nil && got == nil:
+				t.Errorf("ExclusionAt(%s) = none, want %+v", tc.at, *tc.want)
+			case tc.want != nil && (!got.Start.Equal(tc.want.Start) || !got.End.Equal(tc.want.End) || got.Reason != tc.want.Reason):
+				t.Errorf("ExclusionAt(%s) = %+v, want %+v", tc.at, *got, *tc.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeCalendar(t *testing.T) {
+	now := utc(2025, 6, 15, 12, 0)
+	calendar := &models.Calendar{
+		Name:     "us-holidays",
+		Timezone: "America/New_York",
+		Holidays: []string{"2025-12-25", " 2025-07-04", "2025-12-25"},
+		Windows: []models.ExclusionWindow{
+			{Name: " weekend ", RRule: "RRULE:FREQ=WEEKLY;BYDAY=SA", Duration: "48h"},
+			{Start: "2025-12-22T00:00:00-05:00", End: "2026-01-02T00:00:00-05:00"},
+		},
+	}
+	if err := triggers.NormalizeCalendar(calendar, now); err != nil {
+		t.Fatalf("NormalizeCalendar: %v", err)
+	}
+	if got := strings.Join(calendar.Holidays, ","); got != "2025-07-04,2025-12-25" {
+		t.Errorf("holidays = %s, want them sorted and de-duplicated", got)
+	}
+	weekend := calendar.Windows[0]
+	if weekend.Name != "weekend" || weekend.Duration != "48h0m0s" {
+		t.Errorf("recurring window = %+v, want its name trimmed and duration normalized", weekend)
+	}
+	if !strings.HasPrefix(weekend.RRule, "DTSTART;TZID=America/New_York:20250615T000000\n") {
+		t.Errorf("recurring window rrule = %q, want a DTSTART pinned to midnight of today in the calendar's timezone", weekend.RRule)
+	}
+
+	invalid := []struct {
+		name    string
+		holiday string
+		window  models.ExclusionWindow
+		wantErr string
+	}{
+		{name: "holiday", holiday: "12/25/2025", wantErr: "invalid holiday"},
+		{name: "window ending before it starts", window: models.ExclusionWindow{Start: "2025-12-22T00:00:00Z", End: "2025-12-21T00:00:00Z"}, wantErr: "end must be after start"},
+		{name: "window without an end", window: models.ExclusionWindow{Start: "2025-12-22T00:00:00Z"}, wantErr: "are required"},
+		{name: "recurring window without a duration", window: models.ExclusionWindow{RRule: "FREQ=DAILY"}, wantErr: "duration is required"},
+		{name: "recurring window shorter than a second", window: models.ExclusionWindow{RRule: "FREQ=DAILY", Duration: "500ms"}, wantErr: "at least 1s"},
+		{name: "recurring window with an invalid rrule", window: models.ExclusionWindow{RRule: "FREQ=FORTNIGHTLY", Duration: "1h"}, wantErr: "invalid rrule"},
+	}
+	for _, tc := range invalid {
+		t.Run(tc.name, func(t *testing.T) {
+			calendar := &models.Calendar{Name: "invalid", Timezone: "UTC"}
+			if tc.holiday != "" {
+				calendar.Holidays = []string{tc.holiday}
+			} else {
+				calendar.Windows = []models.ExclusionWindow{tc.window}
+			}
+
+			err := triggers.NormalizeCalendar(calendar, now)
+			var validationErr triggers.ValidationError
+			if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("NormalizeCalendar err = %v, want a validation error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestTriggerCalendarReferences(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(utc(2025, 1, 1, 0, 0))
+	service := triggers.NewService(memory.NewStore(clk), clk, zap.NewNop())
+
+	if _, err := service.CreateCalendar(ctx, models.CreateCalendarRequest{Name: "us-holidays", Holidays: []string{"2025-12-25"}}); err != nil {
+		t.Fatalf("CreateCalendar: %v", err)
+	}
+
+	create := func(config string) (*models.TriggerResponse, error) {
+		return service.CreateTrigger(ctx, models.CreateTriggerRequest{
+			Name:   "reports",
+			Type:   models.TriggerTypeCronScheduled,
+			Config: json.RawMessage(`{"cron":"0 9 * * *","endpoint":"https://example.com/hook",` + config + `}`),
+		})
+	}
+
+	trigger, err := create(`"calendars":["us-holidays"," us-holidays"]`)
+	if err != nil {
+		t.Fatalf("CreateTrigger with a known calendar: %v", err)
+	}
+	var config struct {
+		Calendars      []string              `json:"calendars"`
+		CalendarPolicy models.CalendarPolicy `json:"calendar_policy"`
+	}
+	if err := json.Unmarshal(trigger.Config, &config); err != nil {
+		t.Fatalf("unmarshal config: %v", err)
+	}
+	if strings.Join(config.Calendars, ",") != "us-holidays" || config.CalendarPolicy != models.CalendarPolicySkip {
+		t.Errorf("calendars = %v with policy %q, want [us-holidays] with the default skip", config.Calendars, config.CalendarPolicy)
+	}
+
+	for _, tc := range []struct {
+		config  string
+		wantErr string
+	}{
+		{config: `"calendars":["eu-holidays"]`, wantErr: `unknown calendar "eu-holidays"`},
+		{config: `"calendars":["us-holidays",""]`, wantErr: "empty names"},
+		{config: `"calendars":["us-holidays"],"calendar_policy":"next_business_day"`, wantErr: "invalid calendar_policy"},
+	} {
+		_, err := create(tc.config)
+		var validationErr triggers.ValidationError
+		if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("CreateTrigger with %s: err = %v, want a validation error containing %q", tc.config, err, tc.wantErr)
+		}
+	}
+
+	// Updates are checked too
+	unknown := json.RawMessage(`{"cron":"0 9 * * *","endpoint":"https://example.com/hook","calendars":["eu-holidays"]}`)
+	_, err = service.UpdateTrigger(ctx, trigger.ID, models.UpdateTriggerRequest{Config: unknown})
+	var validationErr triggers.ValidationError
+	if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), "unknown calendar") {
+		t.Errorf("UpdateTrigger to an unknown calendar: err = %v, want a validation error", err)
+	}
+}
@@ -0,0 +1,133 @@
+package triggers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/google/uuid"
+)
+
+// CreateCalendar validates and stores a business calendar.
+func (s *Service) CreateCalendar(ctx context.Context, req models.CreateCalendarRequest) (*models.CalendarResponse, error) {
+	calendar := models.Calendar{
+		ID:       uuid.New().String(),
+		Name:     strings.TrimSpace(req.Name),
+		Timezone: req.Timezone,
+		Holidays: req.Holidays,
+		Windows:  req.Windows,
+	}
+	if calendar.Name == "" {
+		return nil, NewValidationError("name is required")
+	}
+
+	if err := NormalizeCalendar(&calendar, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.store.CreateCalendar(ctx, &calendar); err != nil {
+		return nil, err
+	}
+
+	return s.GetCalendar(ctx, calendar.ID)
+}
+
+// ListCalendars returns every calendar, ordered by name.
+func (s *Service) ListCalendars(ctx context.Context) (models.CalendarListResponse, error) {
+	calendars, err := s.store.ListCalendars(ctx)
+	if err != nil {
+		return models.CalendarListResponse{}, err
+	}
+
+	responses := make([]models.CalendarResponse, 0, len(calendars))
+	for i := range calendars {
+		responses = append(responses, buildCalendarResponse(&calendars[i]))
+	}
+
+	return models.CalendarListResponse{Calendars: responses}, nil
+}
+
+// GetCalendar fetches a calendar by ID.
+func (s *Service) GetCalendar(ctx context.Context, calendarID string) (*models.CalendarResponse, error) {
+	calendar, err := s.store.GetCalendar(ctx, calendarID)
+	if err != nil {
+		if errors.Is(err, storage.ErrCalendarNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get calendar: %w", err)
+	}
+
+	resp := buildCalendarResponse(calendar)
+	return &resp, nil
+}
+
+// UpdateCalendar replaces the timezone, holidays or windows of a calendar. Triggers referencing
+// it pick the change up the next time one of their occurrences is due.
+func (s *Service) UpdateCalendar(ctx context.Context, calendarID string, req models.UpdateCalendarRequest) (*models.CalendarResponse, error) {
+	calendar, err := s.store.GetCalendar(ctx, calendarID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Timezone != nil {
+		calendar.Timezone = *req.Timezone
+	}
+	if req.Holidays != nil {
+		calendar.Holidays = req.Holidays
+	}
+	if req.Windows != nil {
+		calendar.Windows = req.Windows
+	}
+
+	if err := NormalizeCalendar(calendar, s.clock.Now()); err != nil {
+		return nil, err
+	}
+	if err := s.store.UpdateCalendar(ctx, calendar); err != nil {
+		return nil, err
+	}
+
+	return s.GetCalendar(ctx, calendarID)
+}
+
+// DeleteCalendar removes a calendar. Triggers still naming it fire as if it had no exclusions.
+func (s *Service) DeleteCalendar(ctx context.Context, calendarID string) error {
+	return s.store.DeleteCalendar(ctx, calendarID)
+}
+
+// checkCalendars verifies that the calendars a recurring trigger references exist.
+func (s *Service) checkCalendars(ctx context.Context, trigger *models.Trigger) error {
+	if !IsRecurring(trigger.Type) {
+		return nil
+	}
+
+	_, options, err := ParseRecurrence(trigger)
+	if err != nil {
+		return err
+	}
+
+	for _, name := range options.Calendars {
+		_, err := s.store.GetCalendarByName(ctx, name)
+		if errors.Is(err, storage.ErrCalendarNotFound) {
+			return NewValidationError("unknown calendar %q", name)
+		}
+		if err != nil {
+			return fmt.Errorf("get calendar %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func buildCalendarResponse(calendar *models.Calendar) models.CalendarResponse {
+	return models.CalendarResponse{
+		ID:        calendar.ID,
+		Name:      calendar.Name,
+		Timezone:  calendar.Timezone,
+		Holidays:  calendar.Holidays,
+		Windows:   calendar.Windows,
+		CreatedAt: calendar.CreatedAt,
+		UpdatedAt: calendar.UpdatedAt,
+	}
+}
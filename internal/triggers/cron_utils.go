@@ -5,62 +5,136 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/dhima/event-trigger-platform/internal/models"
 	"github.com/robfig/cron/v3"
 )
 
 // CronConfig represents the CRON configuration extracted from a trigger.
+// The schedule is either a CRON expression or an RFC 5545 recurrence set (RRule).
 type CronConfig struct {
-	Cron       string                 `json:"cron"`
-	Timezone   string                 `json:"timezone,omitempty"`
-	Endpoint   string                 `json:"endpoint"`
-	HTTPMethod string                 `json:"http_method"`
-	Headers    map[string]string      `json:"headers,omitempty"`
-	Payload    map[string]interface{} `json:"payload,omitempty"`
+	Cron        string                 `json:"cron,omitempty"`
+	RRule       string                 `json:"rrule,omitempty"`
+	Timezone    string                 `json:"timezone,omitempty"`
+	Endpoint    string                 `json:"endpoint"`
+	HTTPMethod  string                 `json:"http_method"`
+	Headers     map[string]string      `json:"headers,omitempty"`
+	Payload     map[string]interface{} `json:"payload,omitempty"`
+	RetryPolicy *models.RetryPolicy    `json:"retry_policy,omitempty"`
+	DSTPolicy   *models.DSTPolicy      `json:"dst_policy,omitempty"` // Only used with Cron
+	RecurrenceOptions
 }
 
-// CalculateNextFireTime calculates the next fire time for a CRON expression.
+// Schedule parses the trigger's CRON expression or recurrence set. Stored recurrence sets always
+// carry a DTSTART (see prepareCronSchedule), so the fallback start is never used for them.
+func (c *CronConfig) Schedule() (Recurrence, error) {
+	if c.RRule != "" {
+		schedule, err := NewRRuleSchedule(c.RRule, c.Timezone, time.Unix(0, 0))
+		if err != nil {
+			return nil, err
+		}
+		return schedule, nil
+	}
+
+	schedule, err := NewCronSchedule(c.Cron, c.Timezone, c.DSTPolicy)
+	if err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// CronSchedule is a parsed CRON expression bound to a timezone and a DST policy.
+type CronSchedule struct {
+	schedule  cron.Schedule
+	location  *time.Location
+	dstPolicy models.DSTPolicy
+	// wallClock is set when the expression is matched against local wall-clock time, which is
+	// how the DST policy is applied (see dst_utils.go). Expressions carrying their own TZ= and
+	// @every intervals are left to robfig/cron.
+	wallClock bool
+}
+
+// NewCronSchedule parses a CRON expression and resolves its timezone (empty string defaults to UTC).
+// A nil DST policy, or one with fields unset, falls back to skip / run_both.
+func NewCronSchedule(cronExpr string, timezone string, dstPolicy *models.DSTPolicy) (*CronSchedule, error) {
+	loc, err := resolveTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
+	schedule, err := parser.Parse(cronExpr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression: %w", err)
+	}
+
+	var policy models.DSTPolicy
+	if dstPolicy != nil {
+		policy = *dstPolicy
+	}
+	if err := normalizeDSTPolicy(&policy); err != nil {
+		return nil, err
+	}
+
+	spec, ok := schedule.(*cron.SpecSchedule)
+	return &CronSchedule{
+		schedule:  schedule,
+		location:  loc,
+		dstPolicy: policy,
+		wallClock: ok && spec.Location == time.Local,
+	}, nil
+}
+
+// Next returns the first occurrence strictly after from, in UTC. Local times skipped or repeated
+// by a DST transition are handled per the schedule's DST policy.
+func (s *CronSchedule) Next(from time.Time) time.Time {
+	if s.wallClock {
+		return s.nextOnWallClock(from)
+	}
+	return s.schedule.Next(from.In(s.location)).UTC()
+}
+
+// CalculateNextFireTime calculates the next occurrence of a CRON trigger and when it fires.
 // This function is shared between TriggerService (for trigger creation)
 // and Scheduler (for calculating next occurrence after firing).
 //
 // Parameters:
-//   - cronExpr: CRON expression (e.g., "0 9 * * *" for daily at 9am)
-//   - timezone: Timezone name (e.g., "America/New_York"), empty string defaults to UTC
-//   - from: Calculate next fire time from this timestamp
+//   - config: CRON configuration (expression or recurrence set, timezone, jitter or spread)
+//   - triggerID: ID of the trigger, which determines its spread offset
+//   - from: Calculate the next occurrence strictly after this timestamp
+//   - random: Random number source in [0, 1) for jitter
 //
 // Returns:
-//   - Next fire time in UTC
-//   - Error if CRON expression is invalid or timezone is invalid
-func CalculateNextFireTime(cronExpr string, timezone string, from time.Time) (time.Time, error) {
-	// Resolve timezone
-	loc, err := resolveTimezone(timezone)
+//   - Next occurrence and its (offset) fire time, in UTC; zero when a recurrence set is exhausted or past end_at
+//   - Error if CRON expression, recurrence set or timezone is invalid
+func CalculateNextFireTime(config *CronConfig, triggerID string, from time.Time, random func() float64) (FireTime, error) {
+	schedule, err := config.Schedule()
 	if err != nil {
-		return time.Time{}, err
+		return FireTime{}, err
 	}
 
-	// Parse CRON expression
-	parser := cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	schedule, err := parser.Parse(cronExpr)
-	if err != nil {
-		return time.Time{}, fmt.Errorf("invalid cron expression: %w", err)
+	next := config.Bound(schedule).Next(from)
+	if next.IsZero() {
+		return FireTime{}, nil
 	}
 
 	// Calculate next run time in the specified timezone, then convert to UTC
-	nextRun := schedule.Next(from.In(loc)).UTC()
-	return nextRun, nil
+	return config.FireTimeFor(next, triggerID, random), nil
 }
 
 // ParseCronConfig extracts CRON configuration from a trigger's config JSON.
-// This is used by the scheduler to get CRON expression and timezone for calculating next fire time.
+// This is used by the scheduler to get the schedule and timezone for calculating next fire time.
 func ParseCronConfig(configJSON json.RawMessage) (*CronConfig, error) {
 	var config CronConfig
 	if err := json.Unmarshal(configJSON, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse cron config: %w", err)
 	}
 
-	if config.Cron == "" {
-		return nil, fmt.Errorf("cron expression is required")
+	if config.Cron == "" && config.RRule == "" {
+		return nil, fmt.Errorf("cron expression or rrule is required")
 	}
 
+	config.applyDefaults()
+
 	return &config, nil
 }
 
@@ -0,0 +1,254 @@
+package triggers
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+// maxWallClockScan bounds how many wall-clock matches of a CRON expression are examined per call.
+const maxWallClockScan = 100000
+
+// DSTAdjustment is an occurrence of a CRON trigger that a daylight saving transition moved,
+// repeated or dropped.
+type DSTAdjustment struct {
+	At      time.Time // When the occurrence runs; for a skipped one, when it would have run if shifted
+	Skipped bool      // Dropped by the spring_forward skip policy
+	Note    string    // e.g. "02:30 does not exist on 2026-03-08 in America/New_York (DST starts); skipped"
+}
+
+// dstKind tells how a DST transition affected an occurrence.
+type dstKind int
+
+const (
+	dstUnaffected dstKind = iota
+	dstSkipped
+	dstShifted
+	dstFirstOfTwo
+	dstSecondOfTwo
+	dstRunOnce
+)
+
+// wallOccurrence is a match of a CRON expression on the local wall clock and the instant it runs at.
+type wallOccurrence struct {
+	wall time.Time // local date and time, expressed in UTC so DST transitions do not affect matching
+	at   time.Time
+	kind dstKind
+}
+
+// normalizeDSTPolicy validates a DST policy and fills in the defaults, which keep the behavior
+// CRON triggers had before the policy existed: skip nonexistent times, run repeated ones twice.
+func normalizeDSTPolicy(policy *models.DSTPolicy) error {
+	switch policy.SpringForward {
+	case "":
+		policy.SpringForward = models.DSTGapSkip
+	case models.DSTGapSkip, models.DSTGapShiftForward:
+	default:
+		return NewValidationError("invalid dst_policy.spring_forward %q: must be one of skip, shift_forward", policy.SpringForward)
+	}
+
+	switch policy.FallBack {
+	case "":
+		policy.FallBack = models.DSTOverlapRunBoth
+	case models.DSTOverlapRunBoth, models.DSTOverlapRunOnce:
+	default:
+		return NewValidationError("invalid dst_policy.fall_back %q: must be one of run_both, run_once", policy.FallBack)
+	}
+
+	return nil
+}
+
+// zoneOffset returns the UTC offset of loc at t.
+func zoneOffset(t time.Time, loc *time.Location) time.Duration {
+	_, offset := t.In(loc).Zone()
+	return time.Duration(offset) * time.Second
+}
+
+// offsetRange returns the smallest and largest UTC offsets loc uses within a day of t.
+func offsetRange(t time.Time, loc *time.Location) (time.Duration, time.Duration) {
+	low, high := zoneOffset(t, loc), zoneOffset(t, loc)
+	for _, around := range []time.Time{t.Add(-24 * time.Hour), t.Add(24 * time.Hour)} {
+		offset := zoneOffset(around, loc)
+		low, high = min(low, offset), max(high, offset)
+	}
+	return low, high
+}
+
+// resolve maps a wall-clock match to the instants it runs at under the DST policy. A local time
+// inside a spring-forward gap has no instant and one inside a fall-back overlap has two.
+func (s *CronSchedule) resolve(wall time.Time) []wallOccurrence {
+	before := zoneOffset(wall.Add(-24*time.Hour), s.location)
+	after := zoneOffset(wall.Add(24*time.Hour), s.location)
+
+	var instants []time.Time
+	for _, offset := range []time.Duration{before, after} {
+		at := wall.Add(-offset)
+		if zoneOffset(at, s.location) == offset && !slices.ContainsFunc(instants, at.Equal) {
+			instants = append(instants, at)
+		}
+	}
+
+	switch len(instants) {
+	case 0:
+		// Shifted by the length of the gap: the instant the local time denotes on the old offset
+		kind := dstSkipped
+		if s.dstPolicy.SpringForward == models.DSTGapShiftForward {
+			kind = dstShifted
+		}
+		return []wallOccurrence{{wall: wall, at: wall.Add(-before), kind: kind}}
+	case 1:
+		return []wallOccurrence{{wall: wall, at: instants[0]}}
+	}
+
+	// Clocks fell back from the before offset to the smaller after offset, so the first instant
+	// is the one on the before offset
+	if s.dstPolicy.FallBack == models.DSTOverlapRunOnce {
+		return []wallOccurrence{{wall: wall, at: instants[0], kind: dstRunOnce}}
+	}
+	return []wallOccurrence{
+		{wall: wall, at: instants[0], kind: dstFirstOfTwo},
+		{wall: wall, at: instants[1], kind: dstSecondOfTwo},
+	}
+}
+
+// occurrencesBetween lists the occurrences with an instant in (after, until], in order, including
+// the ones dropped by the skip policy.
+func (s *CronSchedule) occurrencesBetween(after, until time.Time) []wallOccurrence {
+	// An instant lies its UTC offset behind its wall-clock time, so every instant in (after, until]
+	// is matched on the wall clock in (after+low, until+high]
+	low, _ := offsetRange(after, s.location)
+	_, high := offsetRange(until, s.location)
+	wall, end := after.Add(low).UTC(), until.Add(high).UTC()
+
+	var occurrences []wallOccurrence
+	for i := 0; i < maxWallClockScan; i++ {
+		wall = s.schedule.Next(wall)
+		if wall.IsZero() || wall.After(end) {
+			break
+		}
+		for _, occurrence := range s.resolve(wall) {
+			if occurrence.at.After(after) && !occurrence.at.After(until) {
+				occurrences = append(occurrences, occurrence)
+			}
+		}
+	}
+
+	// A time shifted out of a gap can land on one the expression matches anyway; it runs once
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		if occurrences[i].at.Equal(occurrences[j].at) {
+			return occurrences[i].kind < occurrences[j].kind
+		}
+		return occurrences[i].at.Before(occurrences[j].at)
+	})
+	return slices.CompactFunc(occurrences, func(a, b wallOccurrence) bool { return a.at.Equal(b.at) })
+}
+
+// nextOnWallClock returns the first occurrence strictly after from under the DST policy.
+func (s *CronSchedule) nextOnWallClock(from time.Time) time.Time {
+	low, _ := offsetRange(from, s.location)
+	wall := from.Add(low).UTC()
+	for i := 0; i < maxWallClockScan; i++ {
+		wall = s.schedule.Next(wall)
+		if wall.IsZero() {
+			return time.Time{}
+		}
+
+		// Whatever instants the match resolves to are no later than until
+		lowAtWall, _ := offsetRange(wall, s.location)
+		until := wall.Add(-lowAtWall)
+		for _, occurrence := range s.occurrencesBetween(from, until) {
+			if occurrence.kind != dstSkipped {
+				return occurrence.at
+			}
+		}
+		if until.After(from) {
+			from = until
+		}
+	}
+	return time.Time{}
+}
+
+// DSTAdjustments lists the occurrences in (after, until] that a daylight saving transition moved,
+// repeated or dropped, in order.
+func (s *CronSchedule) DSTAdjustments(after, until time.Time) []DSTAdjustment {
+	if !s.wallClock {
+		return nil
+	}
+
+	var adjustments []DSTAdjustment
+	for _, occurrence := range s.occurrencesBetween(after, until) {
+		if occurrence.kind == dstUnaffected {
+			continue
+		}
+		adjustments = append(adjustments, DSTAdjustment{
+			At:      occurrence.at,
+			Skipped: occurrence.kind == dstSkipped,
+			Note:    s.describe(occurrence),
+		})
+	}
+	return adjustments
+}
+
+// describe explains how a DST transition affected an occurrence.
+func (s *CronSchedule) describe(occurrence wallOccurrence) string {
+	layout := "15:04"
+	if occurrence.wall.Second() != 0 {
+		layout = "15:04:05"
+	}
+	local := occurrence.wall.Format(layout)
+	day := occurrence.wall.Format(time.DateOnly)
+	at := occurrence.at.In(s.location).Format(layout + " MST")
+
+	gap := fmt.Sprintf("%s does not exist on %s in %s (DST starts)", local, day, s.location)
+	overlap := fmt.Sprintf("%s occurs twice on %s in %s (DST ends)", local, day, s.location)
+	switch occurrence.kind {
+	case dstSkipped:
+		return gap + "; skipped"
+	case dstShifted:
+		return gap + "; shifted forward to " + at
+	case dstFirstOfTwo:
+		return overlap + "; first of two runs, at " + at
+	case dstSecondOfTwo:
+		return overlap + "; second of two runs, at " + at
+	case dstRunOnce:
+		return overlap + "; run once, at " + at
+	}
+	return ""
+}
+
+// TriggerDSTAdjustments lists the DST adjustments of a CRON trigger's occurrences in (after, until].
+// Triggers on recurrence sets and other trigger types have none. Configs are validated on write,
+// so an unparsable config means no adjustments.
+func TriggerDSTAdjustments(trigger *models.Trigger, after, until time.Time) []DSTAdjustment {
+	if trigger.Type != models.TriggerTypeCronScheduled {
+		return nil
+	}
+
+	config, err := ParseCronConfig(trigger.Config)
+	if err != nil {
+		return nil
+	}
+	schedule, err := config.Schedule()
+	if err != nil {
+		return nil
+	}
+	cronSchedule, ok := schedule.(*CronSchedule)
+	if !ok {
+		return nil
+	}
+
+	return cronSchedule.DSTAdjustments(after, until)
+}
+
+// DSTNote returns how a DST transition affected an occurrence of a trigger, or nil when it did not.
+func DSTNote(trigger *models.Trigger, occurrence time.Time) *string {
+	for _, adjustment := range TriggerDSTAdjustments(trigger, occurrence.Add(-time.Nanosecond), occurrence) {
+		if !adjustment.Skipped {
+			return &adjustment.Note
+		}
+	}
+	return nil
+}
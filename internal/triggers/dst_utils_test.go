@@ -0,0 +1,325 @@
+package triggers_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+)
+
+// dstExpectation is what a CRON schedule yields across a transition under one DST policy.
+type dstExpectation struct {
+	next        []time.Time // occurrences in (after, until], as returned by successive Next calls
+	adjustments []triggers.DSTAdjustment
+}
+
+func utc(year int, month time.Month, day, hour, minute int) time.Time {
+	return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+}
+
+func TestCronScheduleDST(t *testing.T) {
+	const (
+		nyGap     = "02:30 does not exist on 2025-03-09 in America/New_York (DST starts)"
+		nyOverlap = "01:30 occurs twice on 2025-11-02 in America/New_York (DST ends)"
+		lhGap     = "02:15 does not exist on 2025-10-05 in Australia/Lord_Howe (DST starts)"
+		lhOverlap = "01:45 occurs twice on 2025-04-06 in Australia/Lord_Howe (DST ends)"
+		ukGap     = "01:30 does not exist on 2025-03-30 in Europe/London (DST starts)"
+		ukOverlap = "01:30 occurs twice on 2025-10-26 in Europe/London (DST ends)"
+		syGap     = "02:30 does not exist on 2025-10-05 in Australia/Sydney (DST starts)"
+		syOverlap = "02:30 occurs twice on 2025-04-06 in Australia/Sydney (DST ends)"
+	)
+
+	cases := []struct {
+		name     string
+		cron     string
+		timezone string
+		after    time.Time
+		until    time.Time
+		// expect returns the expectation under a policy; each transition only depends on
+		// spring_forward (gaps) or fall_back (overlaps)
+		expect func(policy models.DSTPolicy) dstExpectation
+	}{
+		{
+			name:     "New York spring forward",
+			cron:     "30 2 * * *",
+			timezone: "America/New_York",
+			after:    utc(2025, 3, 8, 12, 0),
+			until:    utc(2025, 3, 10, 12, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.SpringForward == models.DSTGapShiftForward {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 3, 9, 7, 30), utc(2025, 3, 10, 6, 30)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 3, 9, 7, 30), Note: nyGap + "; shifted forward to 03:30 EDT"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 3, 10, 6, 30)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 3, 9, 7, 30), Skipped: true, Note: nyGap + "; skipped"},
+					},
+				}
+			},
+		},
+		{
+			name:     "New York fall back",
+			cron:     "30 1 * * *",
+			timezone: "America/New_York",
+			after:    utc(2025, 11, 1, 12, 0),
+			until:    utc(2025, 11, 3, 12, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.FallBack == models.DSTOverlapRunOnce {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 11, 2, 5, 30), utc(2025, 11, 3, 6, 30)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 11, 2, 5, 30), Note: nyOverlap + "; run once, at 01:30 EDT"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 11, 2, 5, 30), utc(2025, 11, 2, 6, 30), utc(2025, 11, 3, 6, 30)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 11, 2, 5, 30), Note: nyOverlap + "; first of two runs, at 01:30 EDT"},
+						{At: utc(2025, 11, 2, 6, 30), Note: nyOverlap + "; second of two runs, at 01:30 EST"},
+					},
+				}
+			},
+		},
+		{
+			// Lord Howe Island moves its clocks by 30 minutes: +10:30 in winter, +11 in summer
+			name:     "Lord Howe spring forward",
+			cron:     "15 2 * * *",
+			timezone: "Australia/Lord_Howe",
+			after:    utc(2025, 10, 3, 12, 0),
+			until:    utc(2025, 10, 6, 12, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.SpringForward == models.DSTGapShiftForward {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 10, 3, 15, 45), utc(2025, 10, 4, 15, 45), utc(2025, 10, 5, 15, 15)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 10, 4, 15, 45), Note: lhGap + "; shifted forward to 02:45 +11"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 10, 3, 15, 45), utc(2025, 10, 5, 15, 15)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 10, 4, 15, 45), Skipped: true, Note: lhGap + "; skipped"},
+					},
+				}
+			},
+		},
+		{
+			name:     "Lord Howe fall back",
+			cron:     "45 1 * * *",
+			timezone: "Australia/Lord_Howe",
+			after:    utc(2025, 4, 4, 0, 0),
+			until:    utc(2025, 4, 7, 0, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.FallBack == models.DSTOverlapRunOnce {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 4, 4, 14, 45), utc(2025, 4, 5, 14, 45), utc(2025, 4, 6, 15, 15)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 4, 5, 14, 45), Note: lhOverlap + "; run once, at 01:45 +11"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 4, 4, 14, 45), utc(2025, 4, 5, 14, 45), utc(2025, 4, 5, 15, 15), utc(2025, 4, 6, 15, 15)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 4, 5, 14, 45), Note: lhOverlap + "; first of two runs, at 01:45 +11"},
+						{At: utc(2025, 4, 5, 15, 15), Note: lhOverlap + "; second of two runs, at 01:45 +1030"},
+					},
+				}
+			},
+		},
+		{
+			name:     "London spring forward",
+			cron:     "30 1 * * *",
+			timezone: "Europe/London",
+			after:    utc(2025, 3, 29, 12, 0),
+			until:    utc(2025, 3, 31, 12, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.SpringForward == models.DSTGapShiftForward {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 3, 30, 1, 30), utc(2025, 3, 31, 0, 30)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 3, 30, 1, 30), Note: ukGap + "; shifted forward to 02:30 BST"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 3, 31, 0, 30)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 3, 30, 1, 30), Skipped: true, Note: ukGap + "; skipped"},
+					},
+				}
+			},
+		},
+		{
+			name:     "London fall back",
+			cron:     "30 1 * * *",
+			timezone: "Europe/London",
+			after:    utc(2025, 10, 25, 12, 0),
+			until:    utc(2025, 10, 27, 12, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.FallBack == models.DSTOverlapRunOnce {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 10, 26, 0, 30), utc(2025, 10, 27, 1, 30)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 10, 26, 0, 30), Note: ukOverlap + "; run once, at 01:30 BST"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 10, 26, 0, 30), utc(2025, 10, 26, 1, 30), utc(2025, 10, 27, 1, 30)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 10, 26, 0, 30), Note: ukOverlap + "; first of two runs, at 01:30 BST"},
+						{At: utc(2025, 10, 26, 1, 30), Note: ukOverlap + "; second of two runs, at 01:30 GMT"},
+					},
+				}
+			},
+		},
+		{
+			// Southern hemisphere: clocks go forward in October and back in April
+			name:     "Sydney spring forward",
+			cron:     "30 2 * * *",
+			timezone: "Australia/Sydney",
+			after:    utc(2025, 10, 3, 12, 0),
+			until:    utc(2025, 10, 6, 0, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.SpringForward == models.DSTGapShiftForward {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 10, 3, 16, 30), utc(2025, 10, 4, 16, 30), utc(2025, 10, 5, 15, 30)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 10, 4, 16, 30), Note: syGap + "; shifted forward to 03:30 AEDT"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 10, 3, 16, 30), utc(2025, 10, 5, 15, 30)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 10, 4, 16, 30), Skipped: true, Note: syGap + "; skipped"},
+					},
+				}
+			},
+		},
+		{
+			name:     "Sydney fall back",
+			cron:     "30 2 * * *",
+			timezone: "Australia/Sydney",
+			after:    utc(2025, 4, 4, 12, 0),
+			until:    utc(2025, 4, 7, 0, 0),
+			expect: func(policy models.DSTPolicy) dstExpectation {
+				if policy.FallBack == models.DSTOverlapRunOnce {
+					return dstExpectation{
+						next: []time.Time{utc(2025, 4, 4, 15, 30), utc(2025, 4, 5, 15, 30), utc(2025, 4, 6, 16, 30)},
+						adjustments: []triggers.DSTAdjustment{
+							{At: utc(2025, 4, 5, 15, 30), Note: syOverlap + "; run once, at 02:30 AEDT"},
+						},
+					}
+				}
+				return dstExpectation{
+					next: []time.Time{utc(2025, 4, 4, 15, 30), utc(2025, 4, 5, 15, 30), utc(2025, 4, 5, 16, 30), utc(2025, 4, 6, 16, 30)},
+					adjustments: []triggers.DSTAdjustment{
+						{At: utc(2025, 4, 5, 15, 30), Note: syOverlap + "; first of two runs, at 02:30 AEDT"},
+						{At: utc(2025, 4, 5, 16, 30), Note: syOverlap + "; second of two runs, at 02:30 AEST"},
+					},
+				}
+			},
+		},
+		{
+			// A half-hour offset without DST: 02:30 IST is 21:00 UTC the day before
+			name:     "Kolkata has no transitions",
+			cron:     "30 2 * * *",
+			timezone: "Asia/Kolkata",
+			after:    utc(2025, 3, 8, 12, 0),
+			until:    utc(2025, 3, 10, 12, 0),
+			expect: func(models.DSTPolicy) dstExpectation {
+				return dstExpectation{next: []time.Time{utc(2025, 3, 8, 21, 0), utc(2025, 3, 9, 21, 0)}}
+			},
+		},
+		{
+			name:     "UTC has no transitions",
+			cron:     "30 2 * * *",
+			timezone: "UTC",
+			after:    utc(2025, 3, 8, 12, 0),
+			until:    utc(2025, 3, 10, 12, 0),
+			expect: func(models.DSTPolicy) dstExpectation {
+				return dstExpectation{next: []time.Time{utc(2025, 3, 9, 2, 30), utc(2025, 3, 10, 2, 30)}}
+			},
+		},
+	}
+
+	policies := []models.DSTPolicy{
+		{SpringForward: models.DSTGapSkip, FallBack: models.DSTOverlapRunBoth},
+		{SpringForward: models.DSTGapSkip, FallBack: models.DSTOverlapRunOnce},
+		{SpringForward: models.DSTGapShiftForward, FallBack: models.DSTOverlapRunBoth},
+		{SpringForward: models.DSTGapShiftForward, FallBack: models.DSTOverlapRunOnce},
+	}
+
+	for _, tc := range cases {
+		for _, policy := range policies {
+			t.Run(fmt.Sprintf("%s/%s,%s", tc.name, policy.SpringForward, policy.FallBack), func(t *testing.T) {
+				schedule, err := triggers.NewCronSchedule(tc.cron, tc.timezone, &policy)
+				if err != nil {
+					t.Fatalf("NewCronSchedule: %v", err)
+				}
+				want := tc.expect(policy)
+
+				var next []time.Time
+				for at := schedule.Next(tc.after); !at.IsZero() && !at.After(tc.until); at = schedule.Next(at) {
+					next = append(next, at)
+				}
+				if len(next) != len(want.next) {
+					t.Fatalf("Next yielded %v, want %v", next, want.next)
+				}
+				for i := range next {
+					if !next[i].Equal(want.next[i]) {
+						t.Errorf("occurrence %d = %s, want %s", i, next[i], want.next[i])
+					}
+				}
+
+				adjustments := schedule.DSTAdjustments(tc.after, tc.until)
+				if len(adjustments) != len(want.adjustments) {
+					t.Fatalf("DSTAdjustments = %+v, want %+v", adjustments, want.adjustments)
+				}
+				for i, got := range adjustments {
+					expected := want.adjustments[i]
+					if !got.At.Equal(expected.At) || got.Skipped != expected.Skipped || got.Note != expected.Note {
+						t.Errorf("adjustment %d = %+v, want %+v", i, got, expected)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestDSTNote(t *testing.T) {
+	trigger := &models.Trigger{
+		Type:   models.TriggerTypeCronScheduled,
+		Config: []byte(`{"cron":"30 1 * * *","timezone":"America/New_York","endpoint":"https://example.com"}`),
+	}
+
+	cases := []struct {
+		occurrence time.Time
+		want       string
+	}{
+		{utc(2025, 11, 2, 5, 30), "01:30 occurs twice on 2025-11-02 in America/New_York (DST ends); first of two runs, at 01:30 EDT"},
+		{utc(2025, 11, 2, 6, 30), "01:30 occurs twice on 2025-11-02 in America/New_York (DST ends); second of two runs, at 01:30 EST"},
+		{utc(2025, 11, 3, 6, 30), ""},
+	}
+
+	for _, tc := range cases {
+		note := triggers.DSTNote(trigger, tc.occurrence)
+		switch {
+		case tc.want == "" && note != nil:
+			t.Errorf("DSTNote(%s) = %q, want none", tc.occurrence, *note)
+		case tc.want != "" && (note == nil || *note != tc.want):
+			t.Errorf("DSTNote(%s) = %v, want %q", tc.occurrence, note, tc.want)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package triggers
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+// MinInterval is the shortest `every` an interval_scheduled trigger may configure.
+const MinInterval = time.Second
+
+// IntervalConfig represents the fixed-interval configuration extracted from a trigger.
+type IntervalConfig struct {
+	Every       string                 `json:"every"`
+	Anchor      string                 `json:"anchor,omitempty"`
+	Endpoint    string                 `json:"endpoint"`
+	HTTPMethod  string                 `json:"http_method"`
+	Headers     map[string]string      `json:"headers,omitempty"`
+	Payload     map[string]interface{} `json:"payload,omitempty"`
+	RetryPolicy *models.RetryPolicy    `json:"retry_policy,omitempty"`
+	RecurrenceOptions
+}
+
+// Schedule parses the every and anchor fields. Stored configs always carry an anchor
+// (the creation time when none was given).
+func (c *IntervalConfig) Schedule() (*IntervalSchedule, error) {
+	every, err := time.ParseDuration(c.Every)
+	if err != nil {
+		return nil, fmt.Errorf("invalid every: %w", err)
+	}
+
+	anchor, err := time.Parse(time.RFC3339, c.Anchor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid anchor: %w", err)
+	}
+
+	return NewIntervalSchedule(every, anchor)
+}
+
+// IntervalSchedule fires at anchor, anchor+every, anchor+2*every, ...
+type IntervalSchedule struct {
+	every  time.Duration
+	anchor time.Time
+}
+
+// NewIntervalSchedule creates a schedule of whole-second intervals starting at anchor.
+func NewIntervalSchedule(every time.Duration, anchor time.Time) (*IntervalSchedule, error) {
+	if every < MinInterval {
+		return nil, fmt.Errorf("every must be at least %s", MinInterval)
+	}
+	if every%time.Second != 0 {
+		return nil, fmt.Errorf("every must be a whole number of seconds")
+	}
+
+	return &IntervalSchedule{every: every, anchor: anchor.UTC()}, nil
+}
+
+// Next returns the first occurrence strictly after from, in UTC. Occurrences before the anchor
+// do not exist: until then, the next occurrence is the anchor itself.
+func (s *IntervalSchedule) Next(from time.Time) time.Time {
+	if from.Before(s.anchor) {
+		return s.anchor
+	}
+
+	elapsed := from.Sub(s.anchor)
+	return s.anchor.Add((elapsed/s.every + 1) * s.every)
+}
+
+// ParseIntervalConfig extracts the fixed-interval configuration from a trigger's config JSON.
+func ParseIntervalConfig(configJSON json.RawMessage) (*IntervalConfig, error) {
+	var config IntervalConfig
+	if err := json.Unmarshal(configJSON, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse interval config: %w", err)
+	}
+
+	if config.Every == "" {
+		return nil, fmt.Errorf("every is required")
+	}
+
+	config.applyDefaults()
+
+	return &config, nil
+}
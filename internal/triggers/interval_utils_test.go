@@ -0,0 +1,63 @@
+package triggers_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+)
+
+func TestIntervalScheduleNext(t *testing.T) {
+	anchor := utc(2025, 1, 1, 0, 0).Add(10 * time.Second)
+	schedule, err := triggers.NewIntervalSchedule(90*time.Second, anchor)
+	if err != nil {
+		t.Fatalf("NewIntervalSchedule: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		from time.Time
+		want time.Time
+	}{
+		{name: "long before the anchor", from: utc(2024, 6, 1, 0, 0), want: anchor},
+		{name: "just before the anchor", from: anchor.Add(-time.Nanosecond), want: anchor},
+		{name: "at the anchor", from: anchor, want: anchor.Add(90 * time.Second)},
+		{name: "between occurrences", from: anchor.Add(time.Second), want: anchor.Add(90 * time.Second)},
+		{name: "at an occurrence", from: anchor.Add(180 * time.Second), want: anchor.Add(270 * time.Second)},
+		// 86405s after the anchor is 960 intervals and 5 seconds
+		{name: "a day later", from: anchor.Add(24*time.Hour + 5*time.Second), want: anchor.Add(961 * 90 * time.Second)},
+		{name: "from in another timezone", from: anchor.Add(time.Second).In(time.FixedZone("UTC+2", 2*60*60)), want: anchor.Add(90 * time.Second)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := schedule.Next(tc.from)
+			if !got.Equal(tc.want) {
+				t.Errorf("Next(%s) = %s, want %s", tc.from, got, tc.want)
+			}
+			if got.Location() != time.UTC {
+				t.Errorf("Next(%s) is in %s, want UTC", tc.from, got.Location())
+			}
+		})
+	}
+}
+
+func TestNewIntervalScheduleRejectsInvalidIntervals(t *testing.T) {
+	cases := []struct {
+		every   time.Duration
+		wantErr string
+	}{
+		{every: 0, wantErr: "at least 1s"},
+		{every: -time.Minute, wantErr: "at least 1s"},
+		{every: 500 * time.Millisecond, wantErr: "at least 1s"},
+		{every: 1500 * time.Millisecond, wantErr: "whole number of seconds"},
+	}
+
+	for _, tc := range cases {
+		_, err := triggers.NewIntervalSchedule(tc.every, utc(2025, 1, 1, 0, 0))
+		if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+			t.Errorf("NewIntervalSchedule(%s) err = %v, want one containing %q", tc.every, err, tc.wantErr)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package triggers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+)
+
+// DefaultOccurrenceCount is how many occurrences are previewed when no count is given.
+const DefaultOccurrenceCount = 10
+
+// MaxOccurrenceCount is the most occurrences one preview lists.
+const MaxOccurrenceCount = 100
+
+// maxOccurrenceScan bounds how many occurrences a preview walks, so that a trigger whose
+// occurrences are all skipped by its calendars still returns.
+const maxOccurrenceScan = 10000
+
+// ListOccurrences computes the upcoming occurrences of a trigger from its stored config: at most
+// query.Count of them (up to MaxOccurrenceCount), none after query.Until. Calendar exclusions are applied the way the scheduler
+// applies them, and max_fires stops the list once the remaining fires are used up.
+// Inactive triggers and webhook triggers have no upcoming occurrences.
+func (s *Service) ListOccurrences(ctx context.Context, triggerID string, query models.ListOccurrencesQuery) (*models.OccurrenceListResponse, error) {
+	trigger, _, err := s.store.GetTrigger(ctx, triggerID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTriggerNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get trigger: %w", err)
+	}
+
+	if query.Count <= 0 {
+		query.Count = DefaultOccurrenceCount
+	}
+	query.Count = min(query.Count, MaxOccurrenceCount)
+	var until time.Time
+	if query.Until != "" {
+		if until, err = time.Parse(time.RFC3339, query.Until); err != nil {
+			return nil, NewValidationError("invalid until: %v", err)
+		}
+	}
+
+	resp := &models.OccurrenceListResponse{TriggerID: trigger.ID, Occurrences: []models.OccurrenceResponse{}}
+	if trigger.Status != models.TriggerStatusActive {
+		return resp, nil
+	}
+
+	now := s.clock.Now().UTC()
+	switch {
+	case trigger.Type == models.TriggerTypeTimeScheduled:
+		var config models.TimeScheduledTriggerConfig
+		if err := json.Unmarshal(trigger.Config, &config); err != nil {
+			return nil, fmt.Errorf("parse time_scheduled config: %w", err)
+		}
+		runAt := config.RunAt.UTC()
+		if runAt.After(now) && (until.IsZero() || !runAt.After(until)) {
+			resp.Occurrences = append(resp.Occurrences, models.OccurrenceResponse{
+				ScheduledFor: runAt,
+				FireAt:       runAt,
+				Status:       models.OccurrenceStatusScheduled,
+			})
+		}
+	case IsRecurring(trigger.Type):
+		resp.Occurrences, _, err = s.recurringOccurrences(ctx, trigger, now, until, query.Count, true)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, NewValidationError("%s triggers have no scheduled occurrences", trigger.Type)
+	}
+
+	return resp, nil
+}
+
+// recurringOccurrences walks a recurring trigger's occurrences after now. Skipped occurrences are
+// listed but do not use up max_fires (when applied); the occurrences deferred to the end of one
+// exclusion are folded into a single entry, as the scheduler folds them into a single fire.
+// truncated reports that occurrences up to until were left out, because count or maxOccurrenceScan
+// was reached first.
+func (s *Service) recurringOccurrences(ctx context.Context, trigger *models.Trigger, now, until time.Time, count int, applyMaxFires bool) (occurrences []models.OccurrenceResponse, truncated bool, err error) {
+	recurrence, options, err := ParseRecurrence(trigger)
+	if err != nil {
+		return nil, false, fmt.Errorf("parse recurrence: %w", err)
+	}
+
+	calendars, err := s.loadCalendars(ctx, options.Calendars)
+	if err != nil {
+		return nil, false, err
+	}
+
+	remaining := -1
+	if applyMaxFires && options.MaxFires > 0 {
+		remaining = max(options.MaxFires-trigger.FireCount, 0)
+	}
+
+	offset := options.Offset(trigger.ID, func() float64 { return 0 })
+	occurrences = make([]models.OccurrenceResponse, 0, count)
+	occurrence := recurrence.Next(now)
+	for i := 0; ; i++ {
+		if occurrence.IsZero() || (!until.IsZero() && occurrence.After(until)) || remaining == 0 {
+			return occurrences, false, nil
+		}
+		if i == maxOccurrenceScan || len(occurrences) == count {
+			return occurrences, true, nil
+		}
+
+		entry := models.OccurrenceResponse{
+			ScheduledFor: occurrence,
+			FireAt:       occurrence.Add(offset),
+			Status:       models.OccurrenceStatusScheduled,
+		}
+		following := recurrence.Next(occurrence)
+
+		if exclusion := calendars.ExclusionAt(entry.FireAt); exclusion != nil {
+			reason := exclusion.Reason
+			entry.Reason = &reason
+
+			if options.CalendarPolicy == models.CalendarPolicyDefer {
+				for ; !following.IsZero() && following.Before(exclusion.End); following = recurrence.Next(following) {
+					entry.ScheduledFor = following
+				}
+				// An occurrence right at the end of the exclusion stands in for the deferred ones
+				if following.Equal(exclusion.End) {
+					occurrence = following
+					continue
+				}
+				entry.FireAt = exclusion.End.Add(offset)
+				entry.Status = models.OccurrenceStatusDeferred
+			} else {
+				entry.Status = models.OccurrenceStatusSkipped
+			}
+		}
+
+		occurrences = append(occurrences, entry)
+		if entry.Status != models.OccurrenceStatusSkipped && remaining > 0 {
+			remaining--
+		}
+		occurrence = following
+	}
+}
+
+// loadCalendars builds the calendar set of a recurring trigger. Calendars deleted since the
+// trigger was configured are ignored, as the scheduler ignores them.
+func (s *Service) loadCalendars(ctx context.Context, names []string) (*CalendarSet, error) {
+	calendars := make([]models.Calendar, 0, len(names))
+	for _, name := range names {
+		calendar, err := s.store.GetCalendarByName(ctx, name)
+		if errors.Is(err, storage.ErrCalendarNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get calendar %s: %w", name, err)
+		}
+		calendars = append(calendars, *calendar)
+	}
+
+	set, err := NewCalendarSet(calendars)
+	if err != nil {
+		return nil, fmt.Errorf("parse calendars: %w", err)
+	}
+	return set, nil
+}
+
+// PreviewCron validates a CRON expression, timezone and DST policy and lists the expression's next
+// runs. Runs are annotated with their UTC offset, and the runs around a daylight saving change
+// explain it: which local times were skipped, shifted or repeated under the policy.
+func (s *Service) PreviewCron(req models.CronPreviewRequest) (*models.CronPreviewResponse, error) {
+	loc, err := resolveLocation(req.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := NewCronSchedule(req.Cron, loc.String(), req.DSTPolicy)
+	if err != nil {
+		var validationErr ValidationError
+		if errors.As(err, &validationErr) {
+			return nil, err
+		}
+		return nil, NewValidationError("%v", err)
+	}
+
+	count := req.Count
+	if count <= 0 {
+		count = DefaultOccurrenceCount
+	}
+	count = min(count, MaxOccurrenceCount)
+
+	resp := &models.CronPreviewResponse{
+		Cron:      req.Cron,
+		Timezone:  loc.String(),
+		DSTPolicy: schedule.dstPolicy,
+		Runs:      make([]models.CronPreviewRun, 0, count),
+	}
+	from := s.clock.Now().UTC()
+	_, previousOffset := from.In(loc).Zone()
+	for len(resp.Runs) < count {
+		next := schedule.Next(from)
+		if next.IsZero() {
+			break
+		}
+
+		// Notes cover this run and the occurrences skipped since the previous one
+		var notes []string
+		for _, adjustment := range schedule.DSTAdjustments(from, next) {
+			notes = append(notes, adjustment.Note)
+		}
+
+		local := next.In(loc)
+		_, offset := local.Zone()
+		if len(notes) == 0 && offset != previousOffset {
+			notes = append(notes, dstNote(previousOffset, offset))
+		}
+
+		resp.Runs = append(resp.Runs, models.CronPreviewRun{
+			Time:      next,
+			LocalTime: local.Format(time.RFC3339),
+			UTCOffset: local.Format("-07:00"),
+			IsDST:     local.IsDST(),
+			DSTNote:   strings.Join(notes, "; "),
+		})
+
+		previousOffset = offset
+		from = next
+	}
+
+	return resp, nil
+}
+
+// dstNote describes a change of UTC offset (in seconds east of UTC) between two runs.
+func dstNote(previousOffset, offset int) string {
+	from, to := formatUTCOffset(previousOffset), formatUTCOffset(offset)
+	shift := time.Duration(offset-previousOffset) * time.Second
+	if shift > 0 {
+		return fmt.Sprintf("DST starts before this run: UTC offset changes from %s to %s, clocks go forward %s", from, to, shift)
+	}
+	return fmt.Sprintf("DST ends before this run: UTC offset changes from %s to %s, clocks go back %s", from, to, -shift)
+}
+
+// formatUTCOffset renders an offset in seconds east of UTC as ±hh:mm.
+func formatUTCOffset(offset int) string {
+	return time.Unix(0, 0).In(time.FixedZone("", offset)).Format("-07:00")
+}
@@ -0,0 +1,242 @@
+package triggers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"go.uber.org/zap"
+)
+
+func TestListOccurrences(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(utc(2025, 1, 1, 0, 0))
+	service := triggers.NewService(memory.NewStore(clk), clk, zap.NewNop())
+
+	create := func(name string, typ models.TriggerType, config string) string {
+		trigger, err := service.CreateTrigger(ctx, models.CreateTriggerRequest{Name: name, Type: typ, Config: json.RawMessage(config)})
+		if err != nil {
+			t.Fatalf("CreateTrigger %s: %v", name, err)
+		}
+		return trigger.ID
+	}
+	minutely := create("minutely", models.TriggerTypeCronScheduled, `{"cron":"* * * * *","endpoint":"https://example.com/hook"}`)
+	windowed := create("windowed", models.TriggerTypeIntervalScheduled, `{"every":"1h","endpoint":"https://example.com/hook",`+
+		`"start_at":"2025-01-01T03:00:00Z","end_at":"2025-01-01T05:00:00Z"}`)
+	limited := create("limited", models.TriggerTypeCronScheduled, `{"cron":"0 * * * *","endpoint":"https://example.com/hook","max_fires":2}`)
+	once := create("once", models.TriggerTypeTimeScheduled, `{"run_at":"2025-01-02T00:00:00Z","endpoint":"https://example.com/hook"}`)
+	webhook := create("webhook", models.TriggerTypeWebhook, `{"endpoint":"https://example.com/hook"}`)
+
+	cases := []struct {
+		name      string
+		triggerID string
+		query     models.ListOccurrencesQuery
+		wantCount int
+		wantFirst time.Time
+		wantLast  time.Time
+	}{
+		{name: "default count", triggerID: minutely, wantCount: triggers.DefaultOccurrenceCount, wantFirst: utc(2025, 1, 1, 0, 1), wantLast: utc(2025, 1, 1, 0, 10)},
+		{name: "count", triggerID: minutely, query: models.ListOccurrencesQuery{Count: 3}, wantCount: 3, wantFirst: utc(2025, 1, 1, 0, 1), wantLast: utc(2025, 1, 1, 0, 3)},
+		{name: "count above the cap", triggerID: minutely, query: models.ListOccurrencesQuery{Count: 1000}, wantCount: triggers.MaxOccurrenceCount, wantFirst: utc(2025, 1, 1, 0, 1), wantLast: utc(2025, 1, 1, 1, 40)},
+		{name: "until is inclusive", triggerID: minutely, query: models.ListOccurrencesQuery{Count: 50, Until: "2025-01-01T00:05:00Z"}, wantCount: 5, wantFirst: utc(2025, 1, 1, 0, 1), wantLast: utc(2025, 1, 1, 0, 5)},
+		{name: "active window", triggerID: windowed, query: models.ListOccurrencesQuery{Count: 50}, wantCount: 3, wantFirst: utc(2025, 1, 1, 3, 0), wantLast: utc(2025, 1, 1, 5, 0)},
+		{name: "max_fires", triggerID: limited, query: models.ListOccurrencesQuery{Count: 50}, wantCount: 2, wantFirst: utc(2025, 1, 1, 1, 0), wantLast: utc(2025, 1, 1, 2, 0)},
+		{name: "one-time trigger", triggerID: once, wantCount: 1, wantFirst: utc(2025, 1, 2, 0, 0), wantLast: utc(2025, 1, 2, 0, 0)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := service.ListOccurrences(ctx, tc.triggerID, tc.query)
+			if err != nil {
+				t.Fatalf("ListOccurrences: %v", err)
+			}
+			occurrences := resp.Occurrences
+			if len(occurrences) != tc.wantCount {
+				t.Fatalf("got %d occurrences, want %d", len(occurrences), tc.wantCount)
+			}
+			if first := occurrences[0].ScheduledFor; !first.Equal(tc.wantFirst) {
+				t.Errorf("first occurrence = %s, want %s", first, tc.wantFirst)
+			}
+			if last := occurrences[len(occurrences)-1].ScheduledFor; !last.Equal(tc.wantLast) {
+				t.Errorf("last occurrence = %s, want %s", last, tc.wantLast)
+			}
+		})
+	}
+
+	if _, err := service.ListOccurrences(ctx, minutely, models.ListOccurrencesQuery{Until: "tomorrow"}); err == nil || !strings.Contains(err.Error(), "invalid until") {
+		t.Errorf("ListOccurrences with an invalid until: err = %v, want a validation error", err)
+	}
+	_, err := service.ListOccurrences(ctx, webhook, models.ListOccurrencesQuery{})
+	var validationErr triggers.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("ListOccurrences of a webhook trigger: err = %v, want a validation error", err)
+	}
+
+	// Inactive triggers have none
+	inactive := models.TriggerStatusInactive
+	if _, err := service.UpdateTrigger(ctx, minutely, models.UpdateTriggerRequest{Status: &inactive}); err != nil {
+		t.Fatalf("UpdateTrigger: %v", err)
+	}
+	resp, err := service.ListOccurrences(ctx, minutely, models.ListOccurrencesQuery{})
+	if err != nil {
+		t.Fatalf("ListOccurrences of an inactive trigger: %v", err)
+	}
+	if len(resp.Occurrences) != 0 {
+		t.Errorf("inactive trigger has %d occurrences, want none", len(resp.Occurrences))
+	}
+}
+
+func TestListOccurrencesCalendars(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(utc(2025, 1, 1, 0, 0))
+	service := triggers.NewService(memory.NewStore(clk), clk, zap.NewNop())
+
+	// 2025-01-02 in New York is 05:00Z to 05:00Z the next day
+	if _, err := service.CreateCalendar(ctx, models.CreateCalendarRequest{Name: "closed", Timezone: "America/New_York", Holidays: []string{"2025-01-02"}}); err != nil {
+		t.Fatalf("CreateCalendar: %v", err)
+	}
+
+	type occurrence struct {
+		scheduledFor, fireAt time.Time
+		status               models.OccurrenceStatus
+	}
+	cases := []struct {
+		policy models.CalendarPolicy
+		want   []occurrence
+	}{
+		{
+			policy: models.CalendarPolicySkip,
+			want: []occurrence{
+				{utc(2025, 1, 2, 0, 0), utc(2025, 1, 2, 0, 0), models.OccurrenceStatusScheduled},
+				{utc(2025, 1, 2, 8, 0), utc(2025, 1, 2, 8, 0), models.OccurrenceStatusSkipped},
+				{utc(2025, 1, 2, 16, 0), utc(2025, 1, 2, 16, 0), models.OccurrenceStatusSkipped},
+				{utc(2025, 1, 3, 0, 0), utc(2025, 1, 3, 0, 0), models.OccurrenceStatusSkipped},
+				{utc(2025, 1, 3, 8, 0), utc(2025, 1, 3, 8, 0), models.OccurrenceStatusScheduled},
+			},
+		},
+		{
+			// The three excluded occurrences fold into one fire when the holiday ends
+			policy: models.CalendarPolicyDefer,
+			want: []occurrence{
+				{utc(2025, 1, 2, 0, 0), utc(2025, 1, 2, 0, 0), models.OccurrenceStatusScheduled},
+				{utc(2025, 1, 3, 0, 0), utc(2025, 1, 3, 5, 0), models.OccurrenceStatusDeferred},
+				{utc(2025, 1, 3, 8, 0), utc(2025, 1, 3, 8, 0), models.OccurrenceStatusScheduled},
+				{utc(2025, 1, 3, 16, 0), utc(2025, 1, 3, 16, 0), models.OccurrenceStatusScheduled},
+				{utc(2025, 1, 4, 0, 0), utc(2025, 1, 4, 0, 0), models.OccurrenceStatusScheduled},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.policy), func(t *testing.T) {
+			trigger, err := service.CreateTrigger(ctx, models.CreateTriggerRequest{
+				Name: "every-8h-" + string(tc.policy),
+				Type: models.TriggerTypeCronScheduled,
+				Config: json.RawMessage(`{"cron":"0 */8 * * *","endpoint":"https://example.com/hook",` +
+					`"start_at":"2025-01-02T00:00:00Z","calendars":["closed"],"calendar_policy":"` + string(tc.policy) + `"}`),
+			})
+			if err != nil {
+				t.Fatalf("CreateTrigger: %v", err)
+			}
+
+			resp, err := service.ListOccurrences(ctx, trigger.ID, models.ListOccurrencesQuery{Count: len(tc.want)})
+			if err != nil {
+				t.Fatalf("ListOccurrences: %v", err)
+			}
+			if len(resp.Occurrences) != len(tc.want) {
+				t.Fatalf("got %d occurrences, want %d", len(resp.Occurrences), len(tc.want))
+			}
+			for i, got := range resp.Occurrences {
+				want := tc.want[i]
+				if !got.ScheduledFor.Equal(want.scheduledFor) || !got.FireAt.Equal(want.fireAt) || got.Status != want.status {
+					t.Errorf("occurrence %d = %s firing at %s (%s), want %s firing at %s (%s)",
+						i, got.ScheduledFor, got.FireAt, got.Status, want.scheduledFor, want.fireAt, want.status)
+				}
+				if excluded := got.Status != models.OccurrenceStatusScheduled; excluded != (got.Reason != nil) {
+					t.Errorf("occurrence %d (%s) has reason %v", i, got.Status, got.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestPreviewCron(t *testing.T) {
+	clk := clock.NewManual(utc(2025, 3, 7, 12, 0))
+	service := triggers.NewService(memory.NewStore(clk), clk, zap.NewNop())
+
+	t.Run("timezone rendering", func(t *testing.T) {
+		resp, err := service.PreviewCron(models.CronPreviewRequest{Cron: "30 2 * * *", Timezone: "America/New_York", Count: 3})
+		if err != nil {
+			t.Fatalf("PreviewCron: %v", err)
+		}
+		if resp.Timezone != "America/New_York" {
+			t.Errorf("timezone = %q, want America/New_York", resp.Timezone)
+		}
+
+		// 02:30 does not exist on 2025-03-09; the default policy skips it
+		want := []models.CronPreviewRun{
+			{Time: utc(2025, 3, 8, 7, 30), LocalTime: "2025-03-08T02:30:00-05:00", UTCOffset: "-05:00"},
+			{Time: utc(2025, 3, 10, 6, 30), LocalTime: "2025-03-10T02:30:00-04:00", UTCOffset: "-04:00", IsDST: true,
+				DSTNote: "02:30 does not exist on 2025-03-09 in America/New_York (DST starts); skipped"},
+			{Time: utc(2025, 3, 11, 6, 30), LocalTime: "2025-03-11T02:30:00-04:00", UTCOffset: "-04:00", IsDST: true},
+		}
+		if len(resp.Runs) != len(want) {
+			t.Fatalf("got %d runs, want %d", len(resp.Runs), len(want))
+		}
+		for i, run := range resp.Runs {
+			if !run.Time.Equal(want[i].Time) || run.LocalTime != want[i].LocalTime || run.UTCOffset != want[i].UTCOffset ||
+				run.IsDST != want[i].IsDST || run.DSTNote != want[i].DSTNote {
+				t.Errorf("run %d = %+v, want %+v", i, run, want[i])
+			}
+		}
+	})
+
+	t.Run("half-hour offset", func(t *testing.T) {
+		resp, err := service.PreviewCron(models.CronPreviewRequest{Cron: "0 9 * * *", Timezone: "Asia/Kolkata", Count: 1})
+		if err != nil {
+			t.Fatalf("PreviewCron: %v", err)
+		}
+		if run := resp.Runs[0]; !run.Time.Equal(utc(2025, 3, 8, 3, 30)) || run.LocalTime != "2025-03-08T09:00:00+05:30" || run.UTCOffset != "+05:30" {
+			t.Errorf("run = %+v, want 09:00 at +05:30", run)
+		}
+	})
+
+	t.Run("defaults and count cap", func(t *testing.T) {
+		resp, err := service.PreviewCron(models.CronPreviewRequest{Cron: "* * * * *"})
+		if err != nil {
+			t.Fatalf("PreviewCron: %v", err)
+		}
+		if resp.Timezone != "UTC" || len(resp.Runs) != triggers.DefaultOccurrenceCount {
+			t.Errorf("got %d runs in %q, want %d in UTC", len(resp.Runs), resp.Timezone, triggers.DefaultOccurrenceCount)
+		}
+
+		resp, err = service.PreviewCron(models.CronPreviewRequest{Cron: "* * * * *", Count: 1000})
+		if err != nil {
+			t.Fatalf("PreviewCron: %v", err)
+		}
+		if len(resp.Runs) != triggers.MaxOccurrenceCount {
+			t.Errorf("got %d runs, want the cap of %d", len(resp.Runs), triggers.MaxOccurrenceCount)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, req := range []models.CronPreviewRequest{
+			{Cron: "every minute"},
+			{Cron: "* * * * *", Timezone: "Mars/Olympus_Mons"},
+			{Cron: "* * * * *", DSTPolicy: &models.DSTPolicy{SpringForward: "sometimes"}},
+		} {
+			_, err := service.PreviewCron(req)
+			var validationErr triggers.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("PreviewCron(%+v) err = %v, want a validation error", req, err)
+			}
+		}
+	})
+}
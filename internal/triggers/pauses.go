@@ -0,0 +1,280 @@
+package triggers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PauseTrigger deactivates an active trigger and cancels its pending schedule, so nothing stale is
+// left to fire when it is resumed. Backfill schedules are kept and wait for the resume.
+func (s *Service) PauseTrigger(ctx context.Context, triggerID string, req models.PauseTriggerRequest) (*models.TriggerPauseResponse, error) {
+	trigger, _, err := s.store.GetTrigger(ctx, triggerID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTriggerNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get trigger: %w", err)
+	}
+	if trigger.Status != models.TriggerStatusActive {
+		return nil, fmt.Errorf("trigger is already inactive: %w", storage.ErrTriggerStatusConflict)
+	}
+
+	event, err := s.pause(ctx, trigger, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildPauseResponse(ctx, triggerID, event)
+}
+
+// ResumeTrigger reactivates an inactive trigger and schedules it again. The resume policy decides
+// what happens to the occurrences that came due while it was paused: fire_once fires the most recent
+// of them right away, fire_all_missed fires up to misfire_catchup_limit of them one after the other,
+// skip (the default) continues with the next future occurrence.
+func (s *Service) ResumeTrigger(ctx context.Context, triggerID string, req models.ResumeTriggerRequest) (*models.TriggerPauseResponse, error) {
+	trigger, _, err := s.store.GetTrigger(ctx, triggerID)
+	if err != nil {
+		if errors.Is(err, storage.ErrTriggerNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get trigger: %w", err)
+	}
+	if trigger.Status != models.TriggerStatusInactive {
+		return nil, fmt.Errorf("trigger is already active: %w", storage.ErrTriggerStatusConflict)
+	}
+
+	pausedAt, err := s.pausedSince(ctx, trigger)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.resume(ctx, trigger, req.ResumePolicy, req.Reason, pausedAt)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildPauseResponse(ctx, triggerID, event)
+}
+
+// ListPauseHistory returns a trigger's pause/resume history, newest first.
+func (s *Service) ListPauseHistory(ctx context.Context, triggerID string) (*models.PauseHistoryResponse, error) {
+	if _, _, err := s.store.GetTrigger(ctx, triggerID); err != nil {
+		if errors.Is(err, storage.ErrTriggerNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("get trigger: %w", err)
+	}
+
+	events, err := s.store.ListTriggerPauseEvents(ctx, triggerID)
+	if err != nil {
+		return nil, fmt.Errorf("list pause events: %w", err)
+	}
+
+	resp := &models.PauseHistoryResponse{TriggerID: triggerID, Events: make([]models.PauseEventResponse, 0, len(events))}
+	for i := range events {
+		resp.Events = append(resp.Events, buildPauseEventResponse(&events[i]))
+	}
+	return resp, nil
+}
+
+// pause records the pause of an active trigger.
+func (s *Service) pause(ctx context.Context, trigger *models.Trigger, reason string) (*models.TriggerPauseEvent, error) {
+	event := &models.TriggerPauseEvent{
+		ID:     uuid.New().String(),
+		Action: models.PauseActionPause,
+		Reason: optionalReason(reason),
+	}
+	if err := s.store.PauseTrigger(ctx, trigger.ID, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// resume computes the schedule of an inactive trigger under the resume policy and reactivates it.
+func (s *Service) resume(ctx context.Context, trigger *models.Trigger, policy models.ResumePolicy, reason string, pausedAt time.Time) (*models.TriggerPauseEvent, error) {
+	switch policy {
+	case "":
+		policy = models.ResumePolicySkip
+	case models.ResumePolicyFireOnce, models.ResumePolicyFireAllMissed, models.ResumePolicySkip:
+	default:
+		return nil, NewValidationError("invalid resume_policy %q: must be one of fire_once, fire_all_missed, skip", policy)
+	}
+
+	schedules, missed, err := s.resumeSchedules(ctx, trigger, policy, pausedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &models.TriggerPauseEvent{
+		ID:                uuid.New().String(),
+		Action:            models.PauseActionResume,
+		ResumePolicy:      policy,
+		MissedOccurrences: missed,
+		Reason:            optionalReason(reason),
+	}
+	if len(schedules) > 0 {
+		event.NextFireAt = &schedules[0].FireAt
+	}
+
+	if err := s.store.ResumeTrigger(ctx, trigger.ID, schedules, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
+
+// resumeSchedules returns the schedules a trigger resumes with, earliest first (none for webhook
+// triggers), and how many of its occurrences came due in (pausedAt, now].
+func (s *Service) resumeSchedules(ctx context.Context, trigger *models.Trigger, policy models.ResumePolicy, pausedAt time.Time) ([]models.TriggerSchedule, int, error) {
+	now := s.clock.Now().UTC()
+
+	switch {
+	case trigger.Type == models.TriggerTypeWebhook:
+		return nil, 0, nil
+
+	case trigger.Type == models.TriggerTypeTimeScheduled:
+		var config models.TimeScheduledTriggerConfig
+		if err := json.Unmarshal(trigger.Config, &config); err != nil {
+			return nil, 0, fmt.Errorf("parse time_scheduled config: %w", err)
+		}
+		runAt := config.RunAt.UTC()
+
+		switch {
+		case runAt.After(now):
+			return []models.TriggerSchedule{*newPendingSchedule(trigger.ID, runAt, runAt)}, 0, nil
+		case !runAt.After(pausedAt):
+			return nil, 0, NewValidationError("trigger has nothing left to run: run_at %s passed before it was paused", runAt.Format(time.RFC3339))
+		case policy != models.ResumePolicySkip:
+			return []models.TriggerSchedule{*newPendingSchedule(trigger.ID, now, runAt)}, 1, nil
+		default:
+			return nil, 0, NewValidationError("run_at passed while the trigger was paused; resume with resume_policy fire_once or fire_all_missed to fire it")
+		}
+
+	case IsRecurring(trigger.Type):
+		recurrence, options, err := ParseRecurrence(trigger)
+		if err != nil {
+			return nil, 0, fmt.Errorf("parse recurrence: %w", err)
+		}
+		if options.MaxFires > 0 && trigger.FireCount >= options.MaxFires {
+			return nil, 0, NewValidationError("trigger has used up its max_fires (%d)", options.MaxFires)
+		}
+
+		// The occurrences that would have fired while paused, as the scheduler would have fired them
+		entries, truncated, err := s.recurringOccurrences(ctx, trigger, pausedAt, now, maxOccurrenceScan, true)
+		if err != nil {
+			return nil, 0, err
+		}
+		var missed []models.OccurrenceResponse
+		for _, entry := range entries {
+			if entry.Status != models.OccurrenceStatusSkipped {
+				missed = append(missed, entry)
+			}
+		}
+		if truncated {
+			// The most recent missed occurrences are beyond the scan: only skip can do without them
+			if policy != models.ResumePolicySkip {
+				return nil, 0, NewValidationError("more than %d occurrences came due while the trigger was paused; resume with resume_policy skip", maxOccurrenceScan)
+			}
+			s.logger.Warn("too many occurrences came due while the trigger was paused to count them all",
+				zap.String("trigger_id", trigger.ID),
+				zap.Time("paused_at", pausedAt),
+				zap.Int("counted", len(missed)))
+		}
+
+		if policy != models.ResumePolicySkip && len(missed) > 0 {
+			return replaySchedules(trigger.ID, missed, policy, options.MisfireCatchupLimit, now), len(missed), nil
+		}
+
+		schedule := firstRecurringSchedule(trigger.ID, recurrence, options, now, rand.Float64)
+		if schedule == nil {
+			return nil, 0, NewValidationError("trigger has no occurrence after now: its end_at or recurrence has run out")
+		}
+		return []models.TriggerSchedule{*schedule}, len(missed), nil
+
+	default:
+		return nil, 0, NewValidationError("unsupported trigger type: %s", trigger.Type)
+	}
+}
+
+// replaySchedules builds the schedules firing a resumed trigger's missed occurrences: the most recent
+// one for fire_once, the most recent catchupLimit ones for fire_all_missed, one second apart from
+// now. The last replay is a regular schedule, after which the scheduler continues with the next
+// future occurrence; the earlier ones are backfill schedules, which schedule nothing further.
+func replaySchedules(triggerID string, missed []models.OccurrenceResponse, policy models.ResumePolicy, catchupLimit int, now time.Time) []models.TriggerSchedule {
+	limit := 1
+	if policy == models.ResumePolicyFireAllMissed {
+		limit = catchupLimit
+	}
+	replayed := missed[max(len(missed)-limit, 0):]
+
+	schedules := make([]models.TriggerSchedule, 0, len(replayed))
+	for i, entry := range replayed {
+		schedule := newPendingSchedule(triggerID, now.Add(time.Duration(i)*time.Second), entry.ScheduledFor)
+		schedule.Backfill = i < len(replayed)-1
+		schedules = append(schedules, *schedule)
+	}
+	return schedules
+}
+
+// pausedSince returns when an inactive trigger was paused: the time of its last history entry when
+// that is a pause, otherwise (deactivated by the scheduler or before pause history existed) its last update.
+func (s *Service) pausedSince(ctx context.Context, trigger *models.Trigger) (time.Time, error) {
+	events, err := s.store.ListTriggerPauseEvents(ctx, trigger.ID)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("list pause events: %w", err)
+	}
+	if len(events) > 0 && events[0].Action == models.PauseActionPause {
+		return events[0].CreatedAt, nil
+	}
+	return trigger.UpdatedAt, nil
+}
+
+func (s *Service) buildPauseResponse(ctx context.Context, triggerID string, event *models.TriggerPauseEvent) (*models.TriggerPauseResponse, error) {
+	trigger, next, err := s.store.GetTrigger(ctx, triggerID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TriggerPauseResponse{
+		Trigger: buildTriggerResponse(ctx, trigger, next),
+		Event:   buildPauseEventResponse(event),
+	}, nil
+}
+
+// newPendingSchedule builds a schedule firing at fireAt for the given occurrence.
+func newPendingSchedule(triggerID string, fireAt, occurrence time.Time) *models.TriggerSchedule {
+	return &models.TriggerSchedule{
+		ID:           uuid.New().String(),
+		TriggerID:    triggerID,
+		FireAt:       fireAt,
+		ScheduledFor: &occurrence,
+		Status:       models.ScheduleStatusPending,
+	}
+}
+
+// optionalReason returns nil for a blank reason.
+func optionalReason(reason string) *string {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil
+	}
+	return &reason
+}
+
+func buildPauseEventResponse(event *models.TriggerPauseEvent) models.PauseEventResponse {
+	return models.PauseEventResponse{
+		ID:                event.ID,
+		Action:            event.Action,
+		ResumePolicy:      event.ResumePolicy,
+		MissedOccurrences: event.MissedOccurrences,
+		NextFireAt:        event.NextFireAt,
+		Reason:            event.Reason,
+		CreatedAt:         event.CreatedAt,
+	}
+}
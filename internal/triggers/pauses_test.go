@@ -0,0 +1,249 @@
+package triggers_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/clock"
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/storage/memory"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+	"go.uber.org/zap"
+)
+
+// wantSchedule is a pending schedule expected after resuming.
+type wantSchedule struct {
+	fireAt       time.Time
+	scheduledFor time.Time
+	backfill     bool
+}
+
+func TestResumeTriggerPolicies(t *testing.T) {
+	ctx := context.Background()
+	start := utc(2025, 1, 1, 0, 0)
+	pausedAt := start.Add(30 * time.Second)
+	// Ten minutely occurrences, 00:01 to 00:10, come due while paused
+	resumedAt := utc(2025, 1, 1, 0, 10).Add(30 * time.Second)
+
+	const (
+		minutely = `{"cron":"* * * * *","endpoint":"https://example.com/hook"}`
+		capped   = `{"cron":"* * * * *","endpoint":"https://example.com/hook","misfire_policy":"fire_all_missed","misfire_catchup_limit":3}`
+		once     = `{"run_at":"2025-01-01T00:05:00Z","endpoint":"https://example.com/hook"}`
+	)
+
+	cases := []struct {
+		name       string
+		typ        models.TriggerType
+		config     string
+		policy     models.ResumePolicy
+		wantMissed int
+		want       []wantSchedule
+		wantErr    string // validation error instead of resuming
+	}{
+		{
+			name:       "skip is the default",
+			typ:        models.TriggerTypeCronScheduled,
+			config:     minutely,
+			wantMissed: 10,
+			want:       []wantSchedule{{fireAt: utc(2025, 1, 1, 0, 11), scheduledFor: utc(2025, 1, 1, 0, 11)}},
+		},
+		{
+			name:       "skip",
+			typ:        models.TriggerTypeCronScheduled,
+			config:     minutely,
+			policy:     models.ResumePolicySkip,
+			wantMissed: 10,
+			want:       []wantSchedule{{fireAt: utc(2025, 1, 1, 0, 11), scheduledFor: utc(2025, 1, 1, 0, 11)}},
+		},
+		{
+			name:       "fire_once fires the most recent missed occurrence",
+			typ:        models.TriggerTypeCronScheduled,
+			config:     minutely,
+			policy:     models.ResumePolicyFireOnce,
+			wantMissed: 10,
+			want:       []wantSchedule{{fireAt: resumedAt, scheduledFor: utc(2025, 1, 1, 0, 10)}},
+		},
+		{
+			name:       "fire_all_missed up to the default catch-up limit",
+			typ:        models.TriggerTypeCronScheduled,
+			config:     minutely,
+			policy:     models.ResumePolicyFireAllMissed,
+			wantMissed: 10,
+			want: func() []wantSchedule {
+				var want []wantSchedule
+				for i := range 10 {
+					want = append(want, wantSchedule{
+						fireAt:       resumedAt.Add(time.Duration(i) * time.Second),
+						scheduledFor: utc(2025, 1, 1, 0, i+1),
+						backfill:     i < 9,
+					})
+				}
+				return want
+			}(),
+		},
+		{
+			name:       "fire_all_missed keeps the most recent misfire_catchup_limit",
+			typ:        models.TriggerTypeCronScheduled,
+			config:     capped,
+			policy:     models.ResumePolicyFireAllMissed,
+			wantMissed: 10,
+			want: []wantSchedule{
+				{fireAt: resumedAt, scheduledFor: utc(2025, 1, 1, 0, 8), backfill: true},
+				{fireAt: resumedAt.Add(time.Second), scheduledFor: utc(2025, 1, 1, 0, 9), backfill: true},
+				{fireAt: resumedAt.Add(2 * time.Second), scheduledFor: utc(2025, 1, 1, 0, 10)},
+			},
+		},
+		{
+			name:       "fire_once of a passed run_at",
+			typ:        models.TriggerTypeTimeScheduled,
+			config:     once,
+			policy:     models.ResumePolicyFireOnce,
+			wantMissed: 1,
+			want:       []wantSchedule{{fireAt: resumedAt, scheduledFor: utc(2025, 1, 1, 0, 5)}},
+		},
+		{
+			name:       "fire_all_missed of a passed run_at",
+			typ:        models.TriggerTypeTimeScheduled,
+			config:     once,
+			policy:     models.ResumePolicyFireAllMissed,
+			wantMissed: 1,
+			want:       []wantSchedule{{fireAt: resumedAt, scheduledFor: utc(2025, 1, 1, 0, 5)}},
+		},
+		{
+			name:    "skip of a passed run_at",
+			typ:     models.TriggerTypeTimeScheduled,
+			config:  once,
+			policy:  models.ResumePolicySkip,
+			wantErr: "run_at passed while the trigger was paused",
+		},
+		{
+			name:    "unknown policy",
+			typ:     models.TriggerTypeCronScheduled,
+			config:  minutely,
+			policy:  "fire_twice",
+			wantErr: "invalid resume_policy",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			clk := clock.NewManual(start)
+			store := memory.NewStore(clk)
+			service := triggers.NewService(store, clk, zap.NewNop())
+
+			trigger, err := service.CreateTrigger(ctx, models.CreateTriggerRequest{Name: "paused", Type: tc.typ, Config: json.RawMessage(tc.config)})
+			if err != nil {
+				t.Fatalf("CreateTrigger: %v", err)
+			}
+			clk.Set(pausedAt)
+			if _, err := service.PauseTrigger(ctx, trigger.ID, models.PauseTriggerRequest{}); err != nil {
+				t.Fatalf("PauseTrigger: %v", err)
+			}
+			clk.Set(resumedAt)
+
+			resp, err := service.ResumeTrigger(ctx, trigger.ID, models.ResumeTriggerRequest{ResumePolicy: tc.policy})
+			if tc.wantErr != "" {
+				var validationErr triggers.ValidationError
+				if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("ResumeTrigger err = %v, want a validation error containing %q", err, tc.wantErr)
+				}
+				if pending := pendingSchedules(store, trigger.ID); len(pending) != 0 {
+					t.Errorf("%d pending schedules after a rejected resume, want none", len(pending))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResumeTrigger: %v", err)
+			}
+
+			if resp.Trigger.Status != models.TriggerStatusActive {
+				t.Errorf("status = %s, want active", resp.Trigger.Status)
+			}
+			wantPolicy := tc.policy
+			if wantPolicy == "" {
+				wantPolicy = models.ResumePolicySkip
+			}
+			if resp.Event.ResumePolicy != wantPolicy {
+				t.Errorf("resume_policy = %s, want %s", resp.Event.ResumePolicy, wantPolicy)
+			}
+			if resp.Event.MissedOccurrences != tc.wantMissed {
+				t.Errorf("missed_occurrences = %d, want %d", resp.Event.MissedOccurrences, tc.wantMissed)
+			}
+			if resp.Event.NextFireAt == nil || !resp.Event.NextFireAt.Equal(tc.want[0].fireAt) {
+				t.Errorf("next_fire_at = %v, want %s", resp.Event.NextFireAt, tc.want[0].fireAt)
+			}
+
+			pending := pendingSchedules(store, trigger.ID)
+			if len(pending) != len(tc.want) {
+				t.Fatalf("got %d pending schedules, want %d", len(pending), len(tc.want))
+			}
+			for i, want := range tc.want {
+				got := pending[i]
+				if !got.FireAt.Equal(want.fireAt) || got.ScheduledFor == nil || !got.ScheduledFor.Equal(want.scheduledFor) || got.Backfill != want.backfill {
+					t.Errorf("schedule %d fires at %s for %v (backfill %t), want %s for %s (backfill %t)",
+						i, got.FireAt, got.ScheduledFor, got.Backfill, want.fireAt, want.scheduledFor, want.backfill)
+				}
+			}
+		})
+	}
+}
+
+func TestResumeTriggerAfterTooManyOccurrences(t *testing.T) {
+	ctx := context.Background()
+	clk := clock.NewManual(utc(2025, 1, 1, 0, 0))
+	store := memory.NewStore(clk)
+	service := triggers.NewService(store, clk, zap.NewNop())
+
+	trigger, err := service.CreateTrigger(ctx, models.CreateTriggerRequest{
+		Name:   "minutely",
+		Type:   models.TriggerTypeCronScheduled,
+		Config: json.RawMessage(`{"cron":"* * * * *","endpoint":"https://example.com/hook"}`),
+	})
+	if err != nil {
+		t.Fatalf("CreateTrigger: %v", err)
+	}
+	clk.Set(utc(2025, 1, 1, 0, 0).Add(30 * time.Second))
+	if _, err := service.PauseTrigger(ctx, trigger.ID, models.PauseTriggerRequest{}); err != nil {
+		t.Fatalf("PauseTrigger: %v", err)
+	}
+	// Eight days of minutely occurrences are more than the resume scan covers
+	clk.Set(utc(2025, 1, 9, 0, 0).Add(30 * time.Second))
+
+	// The most recent missed occurrences are unknown, so they cannot be fired
+	for _, policy := range []models.ResumePolicy{models.ResumePolicyFireOnce, models.ResumePolicyFireAllMissed} {
+		_, err := service.ResumeTrigger(ctx, trigger.ID, models.ResumeTriggerRequest{ResumePolicy: policy})
+		var validationErr triggers.ValidationError
+		if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), "resume with resume_policy skip") {
+			t.Errorf("ResumeTrigger with %s: err = %v, want a validation error", policy, err)
+		}
+	}
+
+	resp, err := service.ResumeTrigger(ctx, trigger.ID, models.ResumeTriggerRequest{ResumePolicy: models.ResumePolicySkip})
+	if err != nil {
+		t.Fatalf("ResumeTrigger with skip: %v", err)
+	}
+	// The count stops at the scan's limit
+	if resp.Event.MissedOccurrences != 10000 {
+		t.Errorf("missed_occurrences = %d, want 10000", resp.Event.MissedOccurrences)
+	}
+	if want := utc(2025, 1, 9, 0, 1); resp.Event.NextFireAt == nil || !resp.Event.NextFireAt.Equal(want) {
+		t.Errorf("next_fire_at = %v, want %s", resp.Event.NextFireAt, want)
+	}
+}
+
+// pendingSchedules returns a trigger's pending schedules in fire order.
+func pendingSchedules(store *memory.Store, triggerID string) []models.TriggerSchedule {
+	var pending []models.TriggerSchedule
+	for _, schedule := range store.Schedules(triggerID) {
+		if schedule.Status == models.ScheduleStatusPending {
+			pending = append(pending, schedule)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].FireAt.Before(pending[j].FireAt) })
+	return pending
+}
@@ -0,0 +1,186 @@
+package triggers
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+// DefaultMisfireCatchupLimit caps how many missed occurrences fire_all_missed replays when unset.
+const DefaultMisfireCatchupLimit = 10
+
+// MaxMisfireCatchupLimit is the largest catch-up cap a trigger may configure.
+const MaxMisfireCatchupLimit = 1000
+
+// Recurrence computes the occurrences of a recurring trigger.
+type Recurrence interface {
+	// Next returns the first occurrence strictly after from, in UTC, or the zero time when
+	// the recurrence has no further occurrence.
+	Next(from time.Time) time.Time
+}
+
+// RecurrenceOptions are the config fields shared by recurring (cron_scheduled, interval_scheduled) triggers.
+type RecurrenceOptions struct {
+	MisfirePolicy       models.MisfirePolicy `json:"misfire_policy,omitempty"`
+	MisfireCatchupLimit int                  `json:"misfire_catchup_limit,omitempty"`
+	Jitter              string               `json:"jitter,omitempty"`
+	Spread              string               `json:"spread,omitempty"`
+	// Calendars name the business calendars whose exclusions the trigger honours (see CalendarSet).
+	Calendars      []string              `json:"calendars,omitempty"`
+	CalendarPolicy models.CalendarPolicy `json:"calendar_policy,omitempty"`
+	// StartAt and EndAt (RFC 3339, inclusive) bound the occurrences; after MaxFires scheduled
+	// fires the trigger is deactivated. Zero values mean unbounded.
+	StartAt  string `json:"start_at,omitempty"`
+	EndAt    string `json:"end_at,omitempty"`
+	MaxFires int    `json:"max_fires,omitempty"`
+	// ConcurrencyPolicy decides whether an occurrence fires while the previous event is running.
+	ConcurrencyPolicy models.ConcurrencyPolicy `json:"concurrency_policy,omitempty"`
+	// RunningTimeout (forbid and replace only) is how long an event may stay running without an
+	// acknowledgement before it is marked failed and stops counting as running.
+	RunningTimeout string `json:"running_timeout,omitempty"`
+}
+
+// applyDefaults fills in the misfire and concurrency settings of configs stored without them.
+func (o *RecurrenceOptions) applyDefaults() {
+	if o.MisfirePolicy == "" {
+		o.MisfirePolicy = models.MisfirePolicyFireOnce
+	}
+	if o.MisfireCatchupLimit <= 0 {
+		o.MisfireCatchupLimit = DefaultMisfireCatchupLimit
+	}
+	if o.ConcurrencyPolicy == "" {
+		o.ConcurrencyPolicy = models.ConcurrencyPolicyAllow
+	}
+}
+
+// RunningTimeoutDuration returns the parsed running_timeout, or 0 when the trigger has none.
+// Configs are validated on write (see normalizeConcurrencyPolicy), so unparsable values mean none.
+func (o *RecurrenceOptions) RunningTimeoutDuration() time.Duration {
+	timeout, err := time.ParseDuration(o.RunningTimeout)
+	if err != nil || timeout <= 0 {
+		return 0
+	}
+	return timeout
+}
+
+// ActiveWindow returns the parsed start_at and end_at; unset bounds are zero. Configs are
+// validated on write (see normalizeActiveWindow), so unparsable values mean no bound.
+func (o *RecurrenceOptions) ActiveWindow() (time.Time, time.Time) {
+	start, _ := time.Parse(time.RFC3339, o.StartAt)
+	end, _ := time.Parse(time.RFC3339, o.EndAt)
+	return start, end
+}
+
+// Bound restricts a recurrence to the trigger's active window.
+func (o *RecurrenceOptions) Bound(recurrence Recurrence) Recurrence {
+	start, end := o.ActiveWindow()
+	if start.IsZero() && end.IsZero() {
+		return recurrence
+	}
+	return &boundedRecurrence{recurrence: recurrence, start: start, end: end}
+}
+
+// boundedRecurrence yields the occurrences of a recurrence within [start, end]; zero bounds are open.
+type boundedRecurrence struct {
+	recurrence Recurrence
+	start, end time.Time
+}
+
+// Next returns the first occurrence strictly after from that is not before start, or the zero
+// time once the next occurrence would be after end.
+func (b *boundedRecurrence) Next(from time.Time) time.Time {
+	if !b.start.IsZero() && from.Before(b.start) {
+		from = b.start.Add(-time.Nanosecond)
+	}
+
+	next := b.recurrence.Next(from)
+	if next.IsZero() || (!b.end.IsZero() && next.After(b.end)) {
+		return time.Time{}
+	}
+	return next
+}
+
+// FireTime is an occurrence of a recurring trigger and the instant it is actually fired at.
+type FireTime struct {
+	ScheduledFor time.Time // occurrence computed from the recurrence
+	FireAt       time.Time // ScheduledFor shifted by the trigger's jitter or spread offset
+}
+
+// Offset returns how long after an occurrence the trigger fires: a random delay in [0, jitter)
+// drawn from random (in [0, 1)), or the trigger's fixed slot in [0, spread) so that triggers
+// sharing a schedule are spread over the window instead of firing together.
+// Offsets are whole seconds (schedules are stored with second precision). Configs are
+// validated on write (see normalizeRecurrenceOffset), so unparsable values mean no offset.
+func (o *RecurrenceOptions) Offset(triggerID string, random func() float64) time.Duration {
+	if jitter, err := time.ParseDuration(o.Jitter); err == nil && jitter > 0 {
+		return time.Duration(random() * float64(jitter)).Truncate(time.Second)
+	}
+	if spread, err := time.ParseDuration(o.Spread); err == nil && spread > 0 {
+		return SpreadOffset(triggerID, spread)
+	}
+	return 0
+}
+
+// FireTimeFor applies the trigger's offset to an occurrence.
+func (o *RecurrenceOptions) FireTimeFor(occurrence time.Time, triggerID string, random func() float64) FireTime {
+	return FireTime{
+		ScheduledFor: occurrence,
+		FireAt:       occurrence.Add(o.Offset(triggerID, random)),
+	}
+}
+
+// SpreadOffset deterministically maps a trigger ID into [0, window), in whole seconds.
+func SpreadOffset(triggerID string, window time.Duration) time.Duration {
+	seconds := uint64(window / time.Second)
+	if seconds == 0 {
+		return 0
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(triggerID))
+	return time.Duration(h.Sum64()%seconds) * time.Second
+}
+
+// IsRecurring reports whether triggers of the given type create a new schedule after each occurrence.
+func IsRecurring(triggerType models.TriggerType) bool {
+	return triggerType == models.TriggerTypeCronScheduled || triggerType == models.TriggerTypeIntervalScheduled
+}
+
+// ConcurrencyPolicyOf returns the concurrency policy of a trigger: allow for non-recurring triggers
+// and configs stored without one.
+func ConcurrencyPolicyOf(trigger *models.Trigger) models.ConcurrencyPolicy {
+	options := recurrenceOptionsOf(trigger)
+	options.applyDefaults()
+	return options.ConcurrencyPolicy
+}
+
+// ParseRecurrence extracts the recurrence, bounded by its active window, and the shared recurrence
+// options from a recurring trigger's config. This is used by the scheduler to plan the occurrence after a fired schedule.
+func ParseRecurrence(trigger *models.Trigger) (Recurrence, *RecurrenceOptions, error) {
+	switch trigger.Type {
+	case models.TriggerTypeCronScheduled:
+		config, err := ParseCronConfig(trigger.Config)
+		if err != nil {
+			return nil, nil, err
+		}
+		schedule, err := config.Schedule()
+		if err != nil {
+			return nil, nil, err
+		}
+		return config.Bound(schedule), &config.RecurrenceOptions, nil
+	case models.TriggerTypeIntervalScheduled:
+		config, err := ParseIntervalConfig(trigger.Config)
+		if err != nil {
+			return nil, nil, err
+		}
+		schedule, err := config.Schedule()
+		if err != nil {
+			return nil, nil, err
+		}
+		return config.Bound(schedule), &config.RecurrenceOptions, nil
+	default:
+		return nil, nil, fmt.Errorf("trigger type %s does not recur", trigger.Type)
+	}
+}
@@ -0,0 +1,213 @@
+package triggers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+)
+
+func TestRunningTimeoutValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  string
+		want    string // normalized running_timeout
+		wantErr bool
+	}{
+		{name: "forbid", config: `"concurrency_policy":"forbid","running_timeout":"90s"`, want: "1m30s"},
+		{name: "replace", config: `"concurrency_policy":"replace","running_timeout":"2h"`, want: "2h0m0s"},
+		{name: "unset", config: `"concurrency_policy":"forbid"`},
+		{name: "allow", config: `"concurrency_policy":"allow","running_timeout":"90s"`, wantErr: true},
+		{name: "default policy", config: `"running_timeout":"90s"`, wantErr: true},
+		{name: "below one second", config: `"concurrency_policy":"forbid","running_timeout":"500ms"`, wantErr: true},
+		{name: "not a duration", config: `"concurrency_policy":"forbid","running_timeout":"soon"`, wantErr: true},
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := json.RawMessage(`{"cron":"*/5 * * * *","endpoint":"https://example.com",` + tc.config + `}`)
+			normalized, _, err := triggers.PrepareConfig(models.TriggerTypeCronScheduled, "trigger", raw, now, func() float64 { return 0 })
+
+			if tc.wantErr {
+				var validationErr triggers.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Fatalf("PrepareConfig err = %v, want a validation error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PrepareConfig: %v", err)
+			}
+
+			var config struct {
+				RunningTimeout string `json:"running_timeout"`
+			}
+			if err := json.Unmarshal(normalized, &config); err != nil {
+				t.Fatalf("unmarshal normalized config: %v", err)
+			}
+			if config.RunningTimeout != tc.want {
+				t.Errorf("running_timeout = %q, want %q", config.RunningTimeout, tc.want)
+			}
+		})
+	}
+}
+
+func TestBoundedRecurrenceNext(t *testing.T) {
+	hourly, err := triggers.NewIntervalSchedule(time.Hour, utc(2025, 1, 1, 0, 0))
+	if err != nil {
+		t.Fatalf("NewIntervalSchedule: %v", err)
+	}
+
+	cases := []struct {
+		name    string
+		startAt string
+		endAt   string
+		from    time.Time
+		want    time.Time // zero when the window is over
+	}{
+		{name: "before start", startAt: "2025-01-01T03:00:00Z", from: utc(2025, 1, 1, 0, 30), want: utc(2025, 1, 1, 3, 0)},
+		{name: "just before start", startAt: "2025-01-01T03:00:00Z", from: utc(2025, 1, 1, 3, 0).Add(-time.Second), want: utc(2025, 1, 1, 3, 0)},
+		{name: "start between occurrences", startAt: "2025-01-01T03:30:00Z", from: utc(2025, 1, 1, 0, 0), want: utc(2025, 1, 1, 4, 0)},
+		{name: "after start", startAt: "2025-01-01T03:00:00Z", from: utc(2025, 1, 1, 3, 0), want: utc(2025, 1, 1, 4, 0)},
+		{name: "exactly at end is included", endAt: "2025-01-01T06:00:00Z", from: utc(2025, 1, 1, 5, 0), want: utc(2025, 1, 1, 6, 0)},
+		{name: "next occurrence after end", endAt: "2025-01-01T06:00:00Z", from: utc(2025, 1, 1, 6, 0)},
+		{name: "end between occurrences", endAt: "2025-01-01T05:30:00Z", from: utc(2025, 1, 1, 5, 0)},
+		{name: "from after end", endAt: "2025-01-01T06:00:00Z", from: utc(2025, 1, 2, 0, 0)},
+		{name: "before a window of one occurrence", startAt: "2025-01-01T03:00:00Z", endAt: "2025-01-01T03:00:00Z", from: utc(2025, 1, 1, 0, 0), want: utc(2025, 1, 1, 3, 0)},
+		{name: "after a window of one occurrence", startAt: "2025-01-01T03:00:00Z", endAt: "2025-01-01T03:00:00Z", from: utc(2025, 1, 1, 3, 0)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			options := triggers.RecurrenceOptions{StartAt: tc.startAt, EndAt: tc.endAt}
+			if got := options.Bound(hourly).Next(tc.from); !got.Equal(tc.want) {
+				t.Errorf("Next(%s) = %s, want %s", tc.from, got, tc.want)
+			}
+		})
+	}
+
+	// Without bounds the recurrence is returned as is
+	if options := (triggers.RecurrenceOptions{}); options.Bound(hourly) != triggers.Recurrence(hourly) {
+		t.Error("Bound without start_at and end_at wrapped the recurrence")
+	}
+}
+
+func TestOffsetJitter(t *testing.T) {
+	cases := []struct {
+		jitter string
+		random float64
+		want   time.Duration
+	}{
+		{jitter: "10s", random: 0, want: 0},
+		{jitter: "10s", random: 0.5, want: 5 * time.Second},
+		{jitter: "10s", random: 0.9999, want: 9 * time.Second},
+		{jitter: "1m30s", random: 0.123, want: 11 * time.Second}, // 11.07s
+		{jitter: "1.5s", random: 0.9, want: time.Second},         // 1.35s
+		{jitter: "", random: 0.5, want: 0},
+	}
+
+	for _, tc := range cases {
+		options := triggers.RecurrenceOptions{Jitter: tc.jitter}
+		if got := options.Offset("trigger", func() float64 { return tc.random }); got != tc.want {
+			t.Errorf("Offset with jitter %q and random %v = %s, want %s", tc.jitter, tc.random, got, tc.want)
+		}
+	}
+
+	// Every draw is a whole number of seconds in [0, jitter)
+	options := triggers.RecurrenceOptions{Jitter: "7s"}
+	for i := 0; i < 1000; i++ {
+		random := float64(i) / 1000
+		got := options.Offset("trigger", func() float64 { return random })
+		if got < 0 || got >= 7*time.Second || got%time.Second != 0 {
+			t.Fatalf("Offset with random %v = %s, want whole seconds in [0, 7s)", random, got)
+		}
+	}
+}
+
+func TestSpreadOffset(t *testing.T) {
+	const window = 5 * time.Minute
+
+	seen := make(map[time.Duration]bool)
+	for i := 0; i < 100; i++ {
+		triggerID := fmt.Sprintf("trigger-%d", i)
+		offset := triggers.SpreadOffset(triggerID, window)
+		if offset < 0 || offset >= window || offset%time.Second != 0 {
+			t.Fatalf("SpreadOffset(%s) = %s, want whole seconds in [0, %s)", triggerID, offset, window)
+		}
+		if again := triggers.SpreadOffset(triggerID, window); again != offset {
+			t.Fatalf("SpreadOffset(%s) = %s, then %s; want the same slot every time", triggerID, offset, again)
+		}
+		seen[offset] = true
+	}
+	if len(seen) < 50 {
+		t.Errorf("100 triggers share %d slots, want them spread over the window", len(seen))
+	}
+
+	// Offset uses the trigger's slot, whatever random returns
+	options := triggers.RecurrenceOptions{Spread: window.String()}
+	if got, want := options.Offset("trigger-7", func() float64 { return 0.99 }), triggers.SpreadOffset("trigger-7", window); got != want {
+		t.Errorf("Offset with spread = %s, want the trigger's slot %s", got, want)
+	}
+
+	for _, window := range []time.Duration{0, 500 * time.Millisecond, time.Second - time.Nanosecond} {
+		if got := triggers.SpreadOffset("trigger-7", window); got != 0 {
+			t.Errorf("SpreadOffset over %s = %s, want 0", window, got)
+		}
+	}
+	if got := triggers.SpreadOffset("trigger-7", time.Second); got != 0 {
+		t.Errorf("SpreadOffset over 1s = %s, want 0, the only slot", got)
+	}
+}
+
+func TestRecurrenceOffsetValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		config  string
+		jitter  string // normalized jitter
+		spread  string // normalized spread
+		wantErr string
+	}{
+		{name: "jitter", config: `"jitter":"90s"`, jitter: "1m30s"},
+		{name: "spread", config: `"spread":"2m"`, spread: "2m0s"},
+		{name: "neither", config: `"misfire_policy":"skip"`},
+		{name: "both", config: `"jitter":"10s","spread":"1m"`, wantErr: "mutually exclusive"},
+		{name: "unparsable jitter", config: `"jitter":"soon"`, wantErr: "invalid jitter"},
+		{name: "unparsable spread", config: `"spread":"often"`, wantErr: "invalid spread"},
+		{name: "jitter below one second", config: `"jitter":"500ms"`, wantErr: "jitter must be at least 1s"},
+		{name: "negative spread", config: `"spread":"-1m"`, wantErr: "spread must be at least 1s"},
+		{name: "spread as long as the interval", config: `"spread":"5m"`, wantErr: "shorter than the interval between occurrences (5m0s)"},
+	}
+
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := json.RawMessage(`{"cron":"*/5 * * * *","endpoint":"https://example.com",` + tc.config + `}`)
+			normalized, _, err := triggers.PrepareConfig(models.TriggerTypeCronScheduled, "trigger", raw, now, func() float64 { return 0 })
+
+			if tc.wantErr != "" {
+				var validationErr triggers.ValidationError
+				if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("PrepareConfig err = %v, want a validation error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("PrepareConfig: %v", err)
+			}
+
+			var config triggers.RecurrenceOptions
+			if err := json.Unmarshal(normalized, &config); err != nil {
+				t.Fatalf("unmarshal normalized config: %v", err)
+			}
+			if config.Jitter != tc.jitter || config.Spread != tc.spread {
+				t.Errorf("jitter, spread = %q, %q; want %q, %q", config.Jitter, config.Spread, tc.jitter, tc.spread)
+			}
+		})
+	}
+}
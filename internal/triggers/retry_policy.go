@@ -0,0 +1,88 @@
+package triggers
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+)
+
+const (
+	// DefaultRetryMaxAttempts is how many times a schedule is attempted before it is cancelled.
+	DefaultRetryMaxAttempts = 5
+	// DefaultRetryBaseDelay is the delay before the first retry.
+	DefaultRetryBaseDelay = 5 * time.Second
+	// DefaultRetryMaxDelay caps the delay between two retries.
+	DefaultRetryMaxDelay = 10 * time.Minute
+
+	// MaxRetryAttempts is the largest max_attempts a trigger may configure.
+	MaxRetryAttempts = 100
+	// MaxRetryDelay is the largest max_delay a trigger may configure.
+	MaxRetryDelay = 24 * time.Hour
+)
+
+// RetryPolicy is the resolved retry configuration of a scheduled trigger.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultRetryPolicy returns the policy used by triggers without a retry_policy block.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: DefaultRetryMaxAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+	}
+}
+
+// Backoff returns the delay before the next attempt after `attempt` failed attempts (1-based).
+// The delay doubles with every attempt from BaseDelay up to MaxDelay; the upper half of it is
+// randomized by jitter (in [0, 1)) so retries of triggers that failed together (e.g. during a
+// Kafka outage) spread out.
+func (p RetryPolicy) Backoff(attempt int, jitter float64) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+
+	half := delay / 2
+	return half + time.Duration(jitter*float64(delay-half))
+}
+
+// RetryPolicyFromConfig resolves the retry_policy block of a scheduled trigger's config JSON.
+// Missing or unparsable fields fall back to the defaults; configs are validated on write
+// (see normalizeRetryPolicy), so this never fails at fire time.
+func RetryPolicyFromConfig(config json.RawMessage) RetryPolicy {
+	policy := DefaultRetryPolicy()
+
+	var payload struct {
+		RetryPolicy *models.RetryPolicy `json:"retry_policy"`
+	}
+	if err := json.Unmarshal(config, &payload); err != nil || payload.RetryPolicy == nil {
+		return policy
+	}
+
+	if payload.RetryPolicy.MaxAttempts > 0 {
+		policy.MaxAttempts = payload.RetryPolicy.MaxAttempts
+	}
+	if d, err := time.ParseDuration(payload.RetryPolicy.BaseDelay); err == nil && d > 0 {
+		policy.BaseDelay = d
+	}
+	if d, err := time.ParseDuration(payload.RetryPolicy.MaxDelay); err == nil && d > 0 {
+		policy.MaxDelay = d
+	}
+	if policy.MaxDelay < policy.BaseDelay {
+		policy.MaxDelay = policy.BaseDelay
+	}
+
+	return policy
+}
@@ -0,0 +1,102 @@
+package triggers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/models"
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+)
+
+func TestRetryPolicyValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		policy  string
+		want    models.RetryPolicy
+		wantErr string
+	}{
+		{name: "defaults", policy: `{}`, want: models.RetryPolicy{MaxAttempts: 5, BaseDelay: "5s", MaxDelay: "10m0s"}},
+		{name: "one attempt", policy: `{"max_attempts":1}`, want: models.RetryPolicy{MaxAttempts: 1, BaseDelay: "5s", MaxDelay: "10m0s"}},
+		{name: "most attempts", policy: `{"max_attempts":100}`, want: models.RetryPolicy{MaxAttempts: 100, BaseDelay: "5s", MaxDelay: "10m0s"}},
+		{name: "too many attempts", policy: `{"max_attempts":101}`, wantErr: "max_attempts must be between 1 and 100"},
+		{name: "negative attempts", policy: `{"max_attempts":-1}`, wantErr: "max_attempts must be between 1 and 100"},
+
+		{name: "delays are normalized", policy: `{"base_delay":"90s","max_delay":"1h"}`, want: models.RetryPolicy{MaxAttempts: 5, BaseDelay: "1m30s", MaxDelay: "1h0m0s"}},
+		{name: "longest max_delay", policy: `{"max_delay":"24h"}`, want: models.RetryPolicy{MaxAttempts: 5, BaseDelay: "5s", MaxDelay: "24h0m0s"}},
+		{name: "unparsable base_delay", policy: `{"base_delay":"soon"}`, wantErr: "invalid retry_policy.base_delay"},
+		{name: "unparsable max_delay", policy: `{"max_delay":"10"}`, wantErr: "invalid retry_policy.max_delay"},
+		{name: "negative base_delay", policy: `{"base_delay":"-5s"}`, wantErr: "retry_policy.base_delay must be positive"},
+		{name: "zero max_delay", policy: `{"max_delay":"0s"}`, wantErr: "retry_policy.max_delay must be positive"},
+		{name: "max_delay above the maximum", policy: `{"max_delay":"25h"}`, wantErr: "at most 24h0m0s"},
+
+		{name: "max_delay equal to base_delay", policy: `{"base_delay":"1m","max_delay":"1m"}`, want: models.RetryPolicy{MaxAttempts: 5, BaseDelay: "1m0s", MaxDelay: "1m0s"}},
+		{name: "default max_delay raised to base_delay", policy: `{"base_delay":"15m"}`, want: models.RetryPolicy{MaxAttempts: 5, BaseDelay: "15m0s", MaxDelay: "15m0s"}},
+		{name: "max_delay shorter than base_delay", policy: `{"base_delay":"15m","max_delay":"10m"}`, wantErr: "max_delay must not be shorter than retry_policy.base_delay"},
+		{name: "max_delay shorter than the default base_delay", policy: `{"max_delay":"1s"}`, wantErr: "max_delay must not be shorter"},
+	}
+
+	// Every scheduled trigger type validates its retry_policy the same way
+	configs := []struct {
+		typ    models.TriggerType
+		config string
+	}{
+		{typ: models.TriggerTypeCronScheduled, config: `"cron":"*/5 * * * *"`},
+		{typ: models.TriggerTypeIntervalScheduled, config: `"every":"5m"`},
+		{typ: models.TriggerTypeTimeScheduled, config: `"run_at":"2025-01-02T00:00:00Z"`},
+	}
+
+	now := utc(2025, 1, 1, 0, 0)
+	for _, tc := range cases {
+		for _, c := range configs {
+			t.Run(tc.name+"/"+string(c.typ), func(t *testing.T) {
+				raw := json.RawMessage(`{` + c.config + `,"endpoint":"https://example.com/hook","retry_policy":` + tc.policy + `}`)
+				normalized, _, err := triggers.PrepareConfig(c.typ, "trigger", raw, now, func() float64 { return 0 })
+
+				if tc.wantErr != "" {
+					var validationErr triggers.ValidationError
+					if !errors.As(err, &validationErr) || !strings.Contains(err.Error(), tc.wantErr) {
+						t.Fatalf("PrepareConfig err = %v, want a validation error containing %q", err, tc.wantErr)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("PrepareConfig: %v", err)
+				}
+
+				var config struct {
+					RetryPolicy *models.RetryPolicy `json:"retry_policy"`
+				}
+				if err := json.Unmarshal(normalized, &config); err != nil {
+					t.Fatalf("unmarshal normalized config: %v", err)
+				}
+				if config.RetryPolicy == nil || *config.RetryPolicy != tc.want {
+					t.Fatalf("retry_policy = %+v, want %+v", config.RetryPolicy, tc.want)
+				}
+
+				// The scheduler resolves the stored policy to the same values
+				resolved := triggers.RetryPolicyFromConfig(normalized)
+				baseDelay, _ := time.ParseDuration(tc.want.BaseDelay)
+				maxDelay, _ := time.ParseDuration(tc.want.MaxDelay)
+				if want := (triggers.RetryPolicy{MaxAttempts: tc.want.MaxAttempts, BaseDelay: baseDelay, MaxDelay: maxDelay}); resolved != want {
+					t.Errorf("RetryPolicyFromConfig = %+v, want %+v", resolved, want)
+				}
+			})
+		}
+	}
+
+	// Without a retry_policy block nothing is stored and the defaults apply
+	raw := json.RawMessage(`{"cron":"*/5 * * * *","endpoint":"https://example.com/hook"}`)
+	normalized, _, err := triggers.PrepareConfig(models.TriggerTypeCronScheduled, "trigger", raw, now, func() float64 { return 0 })
+	if err != nil {
+		t.Fatalf("PrepareConfig: %v", err)
+	}
+	if strings.Contains(string(normalized), "retry_policy") {
+		t.Errorf("normalized config %s has a retry_policy, want none", normalized)
+	}
+	if resolved := triggers.RetryPolicyFromConfig(normalized); resolved != triggers.DefaultRetryPolicy() {
+		t.Errorf("RetryPolicyFromConfig = %+v, want the defaults", resolved)
+	}
+}
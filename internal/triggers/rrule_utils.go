@@ -0,0 +1,190 @@
+package triggers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/teambition/rrule-go"
+)
+
+// maxExcludedOccurrences bounds how many consecutive occurrences EXRULEs may remove before
+// the recurrence is treated as exhausted (e.g. an EXRULE that excludes every occurrence).
+const maxExcludedOccurrences = 10000
+
+// RRuleSchedule is a parsed RFC 5545 recurrence set: DTSTART, one RRULE, any number of EXRULE
+// lines, and RDATE/EXDATE lists. Wall-clock rule parts (BYHOUR, BYDAY, ...) are evaluated in
+// the trigger's timezone.
+type RRuleSchedule struct {
+	set      *rrule.Set
+	exrules  []*rrule.RRule
+	dtstart  time.Time
+	location *time.Location
+}
+
+// NewRRuleSchedule parses a recurrence set, one property per line:
+//
+//	DTSTART;TZID=Europe/Berlin:20250101T090000
+//	RRULE:FREQ=MONTHLY;BYDAY=2TU
+//	EXRULE:FREQ=YEARLY;BYMONTH=12;BYDAY=2TU
+//	EXDATE:20250708T090000
+//
+// A bare "FREQ=..." line is read as an RRULE. Floating times (no Z suffix or TZID) are in
+// timezone (empty string defaults to UTC, see resolveTimezone). Without a DTSTART line the set
+// starts at defaultStart.
+func NewRRuleSchedule(spec string, timezone string, defaultStart time.Time) (*RRuleSchedule, error) {
+	loc, err := resolveTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := rruleLines(spec)
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("rrule is empty")
+	}
+
+	schedule := &RRuleSchedule{set: &rrule.Set{}, dtstart: defaultStart, location: loc}
+	var rules, exrules []string
+	var rdates, exdates []time.Time
+
+	for _, line := range lines {
+		name, value := rruleProperty(line)
+		switch name {
+		case "DTSTART":
+			if schedule.dtstart, err = rrule.StrToDtStart(value, loc); err != nil {
+				return nil, fmt.Errorf("invalid DTSTART %q: %w", value, err)
+			}
+		case "RRULE":
+			rules = append(rules, value)
+		case "EXRULE":
+			exrules = append(exrules, value)
+		case "RDATE", "EXDATE":
+			dates, err := rrule.StrToDatesInLoc(value, loc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q: %w", name, value, err)
+			}
+			if name == "RDATE" {
+				rdates = append(rdates, dates...)
+			} else {
+				exdates = append(exdates, dates...)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rrule property %q", name)
+		}
+	}
+
+	if len(rules) > 1 {
+		return nil, fmt.Errorf("only one RRULE is supported")
+	}
+	if len(rules) == 0 && len(rdates) == 0 {
+		return nil, fmt.Errorf("rrule needs an RRULE or RDATE line")
+	}
+
+	// Rules iterate in the location of DTSTART, so move it to the trigger's timezone
+	schedule.dtstart = schedule.dtstart.In(loc).Truncate(time.Second)
+	schedule.set.DTStart(schedule.dtstart)
+
+	if len(rules) == 1 {
+		rule, err := schedule.parseRule(rules[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid RRULE: %w", err)
+		}
+		schedule.set.RRule(rule)
+	}
+	for _, value := range exrules {
+		rule, err := schedule.parseRule(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EXRULE: %w", err)
+		}
+		schedule.exrules = append(schedule.exrules, rule)
+	}
+	schedule.set.SetRDates(rdates)
+	schedule.set.SetExDates(exdates)
+
+	return schedule, nil
+}
+
+func (s *RRuleSchedule) parseRule(value string) (*rrule.RRule, error) {
+	option, err := rrule.StrToROptionInLocation(value, s.location)
+	if err != nil {
+		return nil, err
+	}
+	option.Dtstart = s.dtstart
+	return rrule.NewRRule(*option)
+}
+
+// Next returns the first occurrence strictly after from, in UTC, or the zero time when the
+// recurrence set has no further occurrence (COUNT/UNTIL reached, RDATEs used up).
+func (s *RRuleSchedule) Next(from time.Time) time.Time {
+	next := s.set.After(from, false)
+	for i := 0; i < maxExcludedOccurrences && !next.IsZero(); i++ {
+		if !s.excluded(next) {
+			return next.UTC()
+		}
+		next = s.set.After(next, false)
+	}
+	return time.Time{}
+}
+
+// excluded reports whether t is an occurrence of one of the EXRULEs.
+func (s *RRuleSchedule) excluded(t time.Time) bool {
+	for _, rule := range s.exrules {
+		if rule.After(t, true).Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatDTStart renders a DTSTART line for t in the trigger's timezone, used to pin the start
+// of a recurrence set that was created without one.
+func formatDTStart(t time.Time, loc *time.Location) string {
+	return fmt.Sprintf("DTSTART;TZID=%s:%s", loc.String(), t.In(loc).Format(rrule.LocalDateTimeFormat))
+}
+
+// pinDTStart prepends a DTSTART at midnight of now's day (in loc) to a recurrence set without
+// one, so its occurrences do not move when the set is parsed again later.
+func pinDTStart(spec string, now time.Time, loc *time.Location) string {
+	if hasDTStart(spec) {
+		return spec
+	}
+	year, month, day := now.In(loc).Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	return formatDTStart(midnight, loc) + "\n" + strings.TrimSpace(spec)
+}
+
+// rruleLines splits a recurrence set into its non-empty property lines.
+func rruleLines(spec string) []string {
+	var lines []string
+	for _, line := range strings.Split(spec, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// rruleProperty splits "NAME;PARAMS:VALUE" or "NAME:VALUE" into the upper-cased name and
+// everything after the name's separator (parameters included, as the rrule parsers expect).
+func rruleProperty(line string) (name, value string) {
+	if strings.HasPrefix(strings.ToUpper(line), "FREQ=") {
+		return "RRULE", line
+	}
+
+	end := strings.IndexAny(line, ";:")
+	if end < 0 {
+		return strings.ToUpper(line), ""
+	}
+
+	return strings.ToUpper(line[:end]), line[end+1:]
+}
+
+// hasDTStart reports whether a recurrence set carries its own DTSTART line.
+func hasDTStart(spec string) bool {
+	for _, line := range rruleLines(spec) {
+		if name, _ := rruleProperty(line); name == "DTSTART" {
+			return true
+		}
+	}
+	return false
+}
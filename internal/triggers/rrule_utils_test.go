@@ -0,0 +1,139 @@
+package triggers_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dhima/event-trigger-platform/internal/triggers"
+)
+
+func TestRRuleScheduleNext(t *testing.T) {
+	defaultStart := utc(2025, 1, 1, 0, 0)
+
+	cases := []struct {
+		name      string
+		spec      string
+		timezone  string
+		from      time.Time
+		want      []time.Time // successive occurrences after from
+		exhausted bool        // Next returns zero after the last wanted occurrence
+	}{
+		{
+			// 09:00 in Berlin is 07:00 UTC in summer time and 08:00 UTC after 2025-10-26
+			name: "second Tuesday of the month, except December",
+			spec: "DTSTART;TZID=Europe/Berlin:20250101T090000\n" +
+				"RRULE:FREQ=MONTHLY;BYDAY=2TU\n" +
+				"EXRULE:FREQ=YEARLY;BYMONTH=12;BYDAY=2TU",
+			timezone: "Europe/Berlin",
+			from:     utc(2025, 10, 1, 0, 0),
+			want:     []time.Time{utc(2025, 10, 14, 7, 0), utc(2025, 11, 11, 8, 0), utc(2026, 1, 13, 8, 0), utc(2026, 2, 10, 8, 0)},
+		},
+		{
+			// DTSTART's TZID fixes the first instant; the rule repeats in the trigger's timezone
+			name:     "TZID other than the trigger's timezone",
+			spec:     "DTSTART;TZID=Asia/Tokyo:20250101T090000\nRRULE:FREQ=DAILY",
+			timezone: "UTC",
+			from:     utc(2024, 12, 31, 0, 0),
+			want:     []time.Time{utc(2025, 1, 1, 0, 0), utc(2025, 1, 2, 0, 0)},
+		},
+		{
+			name: "EXDATE removes one occurrence",
+			spec: "DTSTART:20250101T090000Z\nRRULE:FREQ=DAILY\nEXDATE:20250103T090000Z",
+			from: utc(2025, 1, 1, 8, 0),
+			want: []time.Time{utc(2025, 1, 1, 9, 0), utc(2025, 1, 2, 9, 0), utc(2025, 1, 4, 9, 0), utc(2025, 1, 5, 9, 0)},
+		},
+		{
+			// Floating times are in the trigger's timezone; 08:00 stays 08:00 across the DST change
+			name:     "floating DTSTART and EXDATE in the trigger's timezone",
+			spec:     "DTSTART:20250301T080000\nRRULE:FREQ=DAILY\nEXDATE:20250309T080000",
+			timezone: "America/New_York",
+			from:     utc(2025, 3, 7, 14, 0),
+			want:     []time.Time{utc(2025, 3, 8, 13, 0), utc(2025, 3, 10, 12, 0), utc(2025, 3, 11, 12, 0)},
+		},
+		{
+			name:      "COUNT runs out",
+			spec:      "DTSTART:20250101T000000Z\nRRULE:FREQ=WEEKLY;COUNT=3",
+			from:      utc(2024, 12, 31, 0, 0),
+			want:      []time.Time{utc(2025, 1, 1, 0, 0), utc(2025, 1, 8, 0, 0), utc(2025, 1, 15, 0, 0)},
+			exhausted: true,
+		},
+		{
+			name:      "UNTIL is inclusive and runs out",
+			spec:      "DTSTART:20250101T120000Z\nRRULE:FREQ=DAILY;UNTIL=20250103T120000Z",
+			from:      utc(2025, 1, 1, 0, 0),
+			want:      []time.Time{utc(2025, 1, 1, 12, 0), utc(2025, 1, 2, 12, 0), utc(2025, 1, 3, 12, 0)},
+			exhausted: true,
+		},
+		{
+			name: "bare rule starts at the default start",
+			spec: "FREQ=HOURLY;INTERVAL=6",
+			from: utc(2025, 1, 1, 5, 0),
+			want: []time.Time{utc(2025, 1, 1, 6, 0), utc(2025, 1, 1, 12, 0), utc(2025, 1, 1, 18, 0)},
+		},
+		{
+			name:      "RDATEs only",
+			spec:      "DTSTART:20250101T000000Z\nRDATE:20250105T100000Z,20250110T100000Z",
+			from:      utc(2025, 1, 1, 0, 0),
+			want:      []time.Time{utc(2025, 1, 5, 10, 0), utc(2025, 1, 10, 10, 0)},
+			exhausted: true,
+		},
+		{
+			name:      "EXRULE excluding every occurrence",
+			spec:      "DTSTART:20250101T000000Z\nRRULE:FREQ=DAILY;COUNT=5\nEXRULE:FREQ=DAILY",
+			from:      utc(2024, 12, 31, 0, 0),
+			exhausted: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := triggers.NewRRuleSchedule(tc.spec, tc.timezone, defaultStart)
+			if err != nil {
+				t.Fatalf("NewRRuleSchedule: %v", err)
+			}
+
+			at := tc.from
+			for i, want := range tc.want {
+				at = schedule.Next(at)
+				if !at.Equal(want) {
+					t.Fatalf("occurrence %d = %s, want %s", i, at, want)
+				}
+				if at.Location() != time.UTC {
+					t.Errorf("occurrence %d is in %s, want UTC", i, at.Location())
+				}
+			}
+			if next := schedule.Next(at); tc.exhausted && !next.IsZero() {
+				t.Errorf("Next after the last occurrence = %s, want zero", next)
+			}
+		})
+	}
+}
+
+func TestNewRRuleScheduleRejectsInvalidSpecs(t *testing.T) {
+	cases := []struct {
+		name     string
+		spec     string
+		timezone string
+		wantErr  string
+	}{
+		{name: "empty", spec: " \n ", wantErr: "rrule is empty"},
+		{name: "unknown frequency", spec: "FREQ=SOMETIMES", wantErr: "invalid RRULE"},
+		{name: "two RRULEs", spec: "RRULE:FREQ=DAILY\nRRULE:FREQ=WEEKLY", wantErr: "only one RRULE"},
+		{name: "no rule", spec: "DTSTART:20250101T000000Z\nEXDATE:20250102T000000Z", wantErr: "needs an RRULE or RDATE"},
+		{name: "invalid DTSTART", spec: "DTSTART:tomorrow\nRRULE:FREQ=DAILY", wantErr: "invalid DTSTART"},
+		{name: "invalid EXDATE", spec: "RRULE:FREQ=DAILY\nEXDATE:yesterday", wantErr: "invalid EXDATE"},
+		{name: "invalid EXRULE", spec: "RRULE:FREQ=DAILY\nEXRULE:FREQ=DAILY;BYDAY=XX", wantErr: "invalid EXRULE"},
+		{name: "unsupported property", spec: "RRULE:FREQ=DAILY\nSUMMARY:standup", wantErr: `unsupported rrule property "SUMMARY"`},
+		{name: "unknown timezone", spec: "RRULE:FREQ=DAILY", timezone: "Mars/Olympus_Mons", wantErr: "Mars/Olympus_Mons"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := triggers.NewRRuleSchedule(tc.spec, tc.timezone, utc(2025, 1, 1, 0, 0))
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("NewRRuleSchedule(%q) err = %v, want one containing %q", tc.spec, err, tc.wantErr)
+			}
+		})
+	}
+}
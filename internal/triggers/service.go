@@ -5,24 +5,31 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/dhima/event-trigger-platform/internal/clock"
 	"github.com/dhima/event-trigger-platform/internal/models"
 	"github.com/dhima/event-trigger-platform/internal/storage"
 	"github.com/google/uuid"
-	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
 )
 
 // Service encapsulates trigger business logic.
 type Service struct {
-	store *storage.MySQLClient
+	store  storage.Store
+	clock  clock.Clock
+	logger *zap.Logger
 }
 
 // NewService creates a trigger service.
-func NewService(store *storage.MySQLClient) *Service {
+func NewService(store storage.Store, clk clock.Clock, logger *zap.Logger) *Service {
 	return &Service{
-		store: store,
+		store:  store,
+		clock:  clk,
+		logger: logger,
 	}
 }
 
@@ -41,22 +48,15 @@ func (s *Service) CreateTrigger(ctx context.Context, req models.CreateTriggerReq
 		Config: req.Config,
 	}
 
-	var schedule *models.TriggerSchedule
-	var err error
-	switch req.Type {
-	case models.TriggerTypeWebhook:
-		trigger.Config, err = normalizeWebhookConfig(req.Config)
-	case models.TriggerTypeTimeScheduled:
-		trigger.Config, schedule, err = s.prepareTimeSchedule(trigger.ID, req.Config)
-	case models.TriggerTypeCronScheduled:
-		trigger.Config, schedule, err = s.prepareCronSchedule(trigger.ID, req.Config)
-	default:
-		return nil, NewValidationError("unsupported trigger type: %s", req.Type)
-	}
+	config, schedule, err := PrepareConfig(req.Type, trigger.ID, req.Config, s.clock.Now(), rand.Float64)
 	if err != nil {
 		return nil, err
 	}
 
+	trigger.Config = config
+	if err = s.checkCalendars(ctx, &trigger); err != nil {
+		return nil, err
+	}
 	if err = s.store.CreateTrigger(ctx, &trigger, schedule); err != nil {
 		return nil, err
 	}
@@ -120,7 +120,8 @@ func (s *Service) GetTrigger(ctx context.Context, triggerID string) (*models.Tri
 	return &resp, nil
 }
 
-// UpdateTrigger updates metadata/config for a trigger.
+// UpdateTrigger updates metadata/config for a trigger. A status change pauses or resumes the
+// trigger (see PauseTrigger and ResumeTrigger; a resume skips the occurrences missed meanwhile).
 func (s *Service) UpdateTrigger(ctx context.Context, triggerID string, req models.UpdateTriggerRequest) (*models.TriggerResponse, error) {
 	current, _, err := s.store.GetTrigger(ctx, triggerID)
 	if err != nil {
@@ -130,6 +131,15 @@ func (s *Service) UpdateTrigger(ctx context.Context, triggerID string, req model
 	updates := make(map[string]interface{})
 	var schedule *models.TriggerSchedule
 
+	// Read before the update below moves updated_at
+	statusChange := req.Status != nil && *req.Status != current.Status
+	var pausedAt time.Time
+	if statusChange && *req.Status == models.TriggerStatusActive {
+		if pausedAt, err = s.pausedSince(ctx, current); err != nil {
+			return nil, err
+		}
+	}
+
 	if req.Name != nil {
 		name := strings.TrimSpace(*req.Name)
 		if name == "" {
@@ -139,25 +149,18 @@ func (s *Service) UpdateTrigger(ctx context.Context, triggerID string, req model
 		current.Name = name
 	}
 
-	if req.Status != nil {
-		updates["status"] = *req.Status
-		current.Status = *req.Status
-	}
-
 	if len(req.Config) > 0 {
-		switch current.Type {
-		case models.TriggerTypeWebhook:
-			current.Config, err = normalizeWebhookConfig(req.Config)
-		case models.TriggerTypeTimeScheduled:
-			current.Config, schedule, err = s.prepareTimeSchedule(current.ID, req.Config)
-		case models.TriggerTypeCronScheduled:
-			current.Config, schedule, err = s.prepareCronSchedule(current.ID, req.Config)
-		default:
-			err = NewValidationError("unsupported trigger type: %s", current.Type)
-		}
+		current.Config, schedule, err = PrepareConfig(current.Type, current.ID, req.Config, s.clock.Now(), rand.Float64)
 		if err != nil {
 			return nil, err
 		}
+		if err = s.checkCalendars(ctx, current); err != nil {
+			return nil, err
+		}
+		if options := recurrenceOptionsOf(current); options.MaxFires > 0 && options.MaxFires <= current.FireCount {
+			// Fires are counted across config updates
+			return nil, NewValidationError("max_fires must be greater than the %d fires so far", current.FireCount)
+		}
 		updates["config"] = string(current.Config)
 	}
 
@@ -167,9 +170,22 @@ func (s *Service) UpdateTrigger(ctx context.Context, triggerID string, req model
 		}
 	}
 
-	// Only update schedules when config changes (not on status changes)
-	// The scheduler will check trigger.status before firing, so we don't need to cancel schedules
-	if schedule != nil {
+	switch {
+	case statusChange && *req.Status == models.TriggerStatusInactive:
+		if _, err := s.pause(ctx, current, ""); err != nil {
+			return nil, err
+		}
+	case statusChange:
+		// Scheduled from the updated config
+		if _, err := s.resume(ctx, current, models.ResumePolicySkip, "", pausedAt); err != nil {
+			return nil, err
+		}
+	case schedule != nil && current.Status != models.TriggerStatusActive:
+		// A paused trigger gets its schedule when resumed; drop the one computed from the old config
+		if err := s.store.UpsertTriggerSchedule(ctx, current.ID, nil); err != nil {
+			return nil, err
+		}
+	case schedule != nil:
 		if err := s.store.UpsertTriggerSchedule(ctx, current.ID, schedule); err != nil {
 			return nil, err
 		}
@@ -189,14 +205,33 @@ func (s *Service) DeleteTrigger(ctx context.Context, triggerID string) error {
 	return s.store.DeleteTrigger(ctx, triggerID)
 }
 
-func (s *Service) prepareTimeSchedule(triggerID string, config json.RawMessage) (json.RawMessage, *models.TriggerSchedule, error) {
+// PrepareConfig validates and normalizes a trigger config. For scheduled trigger types it also
+// builds the first schedule, relative to now; random (in [0, 1)) draws the jitter offset of recurring triggers.
+func PrepareConfig(triggerType models.TriggerType, triggerID string, config json.RawMessage, now time.Time, random func() float64) (json.RawMessage, *models.TriggerSchedule, error) {
+	switch triggerType {
+	case models.TriggerTypeWebhook:
+		normalized, err := normalizeWebhookConfig(config)
+		return normalized, nil, err
+	case models.TriggerTypeTimeScheduled:
+		return prepareTimeSchedule(triggerID, config, now)
+	case models.TriggerTypeCronScheduled:
+		return prepareCronSchedule(triggerID, config, now, random)
+	case models.TriggerTypeIntervalScheduled:
+		return prepareIntervalSchedule(triggerID, config, now, random)
+	default:
+		return nil, nil, NewValidationError("unsupported trigger type: %s", triggerType)
+	}
+}
+
+func prepareTimeSchedule(triggerID string, config json.RawMessage, now time.Time) (json.RawMessage, *models.TriggerSchedule, error) {
 	var payload struct {
-		RunAt      string                 `json:"run_at"`
-		Endpoint   string                 `json:"endpoint"`
-		HTTPMethod string                 `json:"http_method"`
-		Headers    map[string]string      `json:"headers,omitempty"`
-		Payload    map[string]interface{} `json:"payload,omitempty"`
-		Timezone   string                 `json:"timezone,omitempty"`
+		RunAt       string                 `json:"run_at"`
+		Endpoint    string                 `json:"endpoint"`
+		HTTPMethod  string                 `json:"http_method"`
+		Headers     map[string]string      `json:"headers,omitempty"`
+		Payload     map[string]interface{} `json:"payload,omitempty"`
+		Timezone    string                 `json:"timezone,omitempty"`
+		RetryPolicy *models.RetryPolicy    `json:"retry_policy,omitempty"`
 	}
 	if err := json.Unmarshal(config, &payload); err != nil {
 		return nil, nil, fmt.Errorf("invalid time_scheduled config: %w", err)
@@ -212,6 +247,10 @@ func (s *Service) prepareTimeSchedule(triggerID string, config json.RawMessage)
 		payload.HTTPMethod = "POST"
 	}
 
+	if err := normalizeRetryPolicy(payload.RetryPolicy); err != nil {
+		return nil, nil, err
+	}
+
 	loc, err := resolveLocation(payload.Timezone)
 	if err != nil {
 		return nil, nil, err
@@ -222,7 +261,7 @@ func (s *Service) prepareTimeSchedule(triggerID string, config json.RawMessage)
 		return nil, nil, fmt.Errorf("invalid run_at: %w", err)
 	}
 
-	if runAt.Before(time.Now().Add(-1 * time.Minute)) {
+	if runAt.Before(now.Add(-1 * time.Minute)) {
 		return nil, nil, NewValidationError("run_at must be in the future")
 	}
 
@@ -240,21 +279,17 @@ func (s *Service) prepareTimeSchedule(triggerID string, config json.RawMessage)
 	}, nil
 }
 
-func (s *Service) prepareCronSchedule(triggerID string, config json.RawMessage) (json.RawMessage, *models.TriggerSchedule, error) {
-	var payload struct {
-		Cron       string                 `json:"cron"`
-		Timezone   string                 `json:"timezone,omitempty"`
-		Endpoint   string                 `json:"endpoint"`
-		HTTPMethod string                 `json:"http_method"`
-		Headers    map[string]string      `json:"headers,omitempty"`
-		Payload    map[string]interface{} `json:"payload,omitempty"`
-	}
+func prepareCronSchedule(triggerID string, config json.RawMessage, now time.Time, random func() float64) (json.RawMessage, *models.TriggerSchedule, error) {
+	var payload CronConfig
 	if err := json.Unmarshal(config, &payload); err != nil {
 		return nil, nil, fmt.Errorf("invalid cron_scheduled config: %w", err)
 	}
 
-	if payload.Cron == "" {
-		return nil, nil, NewValidationError("cron expression is required")
+	if payload.Cron == "" && payload.RRule == "" {
+		return nil, nil, NewValidationError("cron expression or rrule is required")
+	}
+	if payload.Cron != "" && payload.RRule != "" {
+		return nil, nil, NewValidationError("cron and rrule are mutually exclusive")
 	}
 	if payload.Endpoint == "" {
 		return nil, nil, NewValidationError("endpoint is required for cron_scheduled triggers")
@@ -263,38 +298,330 @@ func (s *Service) prepareCronSchedule(triggerID string, config json.RawMessage)
 		payload.HTTPMethod = "POST"
 	}
 
+	if err := normalizeRecurringConfig(&payload.RecurrenceOptions, payload.RetryPolicy); err != nil {
+		return nil, nil, err
+	}
+
+	// Recurrence sets resolve local times through rrule-go, which has no DST policy of its own
+	if payload.RRule != "" && payload.DSTPolicy != nil {
+		return nil, nil, NewValidationError("dst_policy is only supported with cron expressions")
+	}
+	if payload.Cron != "" {
+		if payload.DSTPolicy == nil {
+			payload.DSTPolicy = &models.DSTPolicy{}
+		}
+		if err := normalizeDSTPolicy(payload.DSTPolicy); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	loc, err := resolveLocation(payload.Timezone)
 	if err != nil {
 		return nil, nil, err
 	}
 	payload.Timezone = loc.String()
 
-	parser := cron.NewParser(cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow)
-	schedule, err := parser.Parse(payload.Cron)
+	// Recurrence sets without a DTSTART start at midnight of the creation day; pin it so
+	// occurrences do not move when the config is read back later
+	if payload.RRule != "" {
+		payload.RRule = pinDTStart(payload.RRule, now, loc)
+	}
+
+	schedule, err := payload.Schedule()
 	if err != nil {
-		return nil, nil, fmt.Errorf("invalid cron expression: %w", err)
+		if payload.RRule != "" {
+			return nil, nil, NewValidationError("invalid rrule: %v", err)
+		}
+		return nil, nil, err
+	}
+	if err := normalizeRecurrenceOffset(&payload.RecurrenceOptions, schedule, now); err != nil {
+		return nil, nil, err
+	}
+
+	first := firstRecurringSchedule(triggerID, payload.Bound(schedule), &payload.RecurrenceOptions, now, random)
+	if first == nil {
+		if payload.EndAt != "" {
+			return nil, nil, NewValidationError("no occurrence after now is within end_at")
+		}
+		return nil, nil, NewValidationError("rrule has no occurrence after now")
 	}
 
-	nextRun := schedule.Next(time.Now().In(loc)).UTC()
 	normalized, err := json.Marshal(payload)
 	if err != nil {
 		return nil, nil, fmt.Errorf("marshal cron_scheduled config: %w", err)
 	}
 
-	return normalized, &models.TriggerSchedule{
-		ID:        uuid.New().String(),
-		TriggerID: triggerID,
-		FireAt:    nextRun,
-		Status:    models.ScheduleStatusPending,
-	}, nil
+	return normalized, first, nil
+}
+
+func prepareIntervalSchedule(triggerID string, config json.RawMessage, now time.Time, random func() float64) (json.RawMessage, *models.TriggerSchedule, error) {
+	var payload IntervalConfig
+	if err := json.Unmarshal(config, &payload); err != nil {
+		return nil, nil, fmt.Errorf("invalid interval_scheduled config: %w", err)
+	}
+
+	if payload.Every == "" {
+		return nil, nil, NewValidationError("every is required for interval_scheduled triggers")
+	}
+	if payload.Endpoint == "" {
+		return nil, nil, NewValidationError("endpoint is required for interval_scheduled triggers")
+	}
+	if payload.HTTPMethod == "" {
+		payload.HTTPMethod = "POST"
+	}
+
+	if err := normalizeRecurringConfig(&payload.RecurrenceOptions, payload.RetryPolicy); err != nil {
+		return nil, nil, err
+	}
+
+	every, err := time.ParseDuration(payload.Every)
+	if err != nil {
+		return nil, nil, NewValidationError("invalid every: %v", err)
+	}
+
+	// Without an anchor the interval counts from creation (whole seconds, like fire_at)
+	anchor := now.UTC().Truncate(time.Second)
+	if payload.Anchor != "" {
+		if anchor, err = time.Parse(time.RFC3339, payload.Anchor); err != nil {
+			return nil, nil, NewValidationError("invalid anchor: %v", err)
+		}
+	}
+
+	schedule, err := NewIntervalSchedule(every, anchor)
+	if err != nil {
+		return nil, nil, NewValidationError("%v", err)
+	}
+	if err := normalizeRecurrenceOffset(&payload.RecurrenceOptions, schedule, now); err != nil {
+		return nil, nil, err
+	}
+
+	first := firstRecurringSchedule(triggerID, payload.Bound(schedule), &payload.RecurrenceOptions, now, random)
+	if first == nil {
+		return nil, nil, NewValidationError("no occurrence after now is within end_at")
+	}
+
+	payload.Every = every.String()
+	payload.Anchor = anchor.UTC().Format(time.RFC3339)
+	normalized, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal interval_scheduled config: %w", err)
+	}
+
+	return normalized, first, nil
+}
+
+// normalizeRecurringConfig validates the settings shared by recurring triggers (misfire,
+// concurrency, calendars, active window and retry policy) and fills in their defaults.
+func normalizeRecurringConfig(options *RecurrenceOptions, retryPolicy *models.RetryPolicy) error {
+	if err := normalizeMisfirePolicy(options); err != nil {
+		return err
+	}
+	if err := normalizeConcurrencyPolicy(options); err != nil {
+		return err
+	}
+	if err := normalizeCalendarOptions(options); err != nil {
+		return err
+	}
+	if err := normalizeActiveWindow(options); err != nil {
+		return err
+	}
+	return normalizeRetryPolicy(retryPolicy)
+}
+
+// firstRecurringSchedule builds the schedule of a recurring trigger's first occurrence after now,
+// or returns nil when the recurrence has none.
+func firstRecurringSchedule(triggerID string, recurrence Recurrence, options *RecurrenceOptions, now time.Time, random func() float64) *models.TriggerSchedule {
+	occurrence := recurrence.Next(now)
+	if occurrence.IsZero() {
+		return nil
+	}
+
+	next := options.FireTimeFor(occurrence, triggerID, random)
+	return &models.TriggerSchedule{
+		ID:           uuid.New().String(),
+		TriggerID:    triggerID,
+		FireAt:       next.FireAt,
+		ScheduledFor: &next.ScheduledFor,
+		Status:       models.ScheduleStatusPending,
+	}
+}
+
+// offsetCheckOccurrences is how many upcoming occurrences a jitter or spread window is checked against.
+const offsetCheckOccurrences = 16
+
+// normalizeRecurrenceOffset validates the optional jitter and spread settings of a recurring trigger.
+// They are mutually exclusive, at least one second, and must be shorter than the gap between
+// consecutive occurrences so that offset fire times never overtake the next occurrence.
+func normalizeRecurrenceOffset(config *RecurrenceOptions, schedule Recurrence, now time.Time) error {
+	if config.Jitter != "" && config.Spread != "" {
+		return NewValidationError("jitter and spread are mutually exclusive")
+	}
+
+	field, value := "jitter", &config.Jitter
+	if config.Spread != "" {
+		field, value = "spread", &config.Spread
+	}
+	if *value == "" {
+		return nil
+	}
+
+	window, err := time.ParseDuration(*value)
+	if err != nil {
+		return NewValidationError("invalid %s: %v", field, err)
+	}
+	if window < time.Second {
+		return NewValidationError("%s must be at least 1s", field)
+	}
+
+	previous := schedule.Next(now)
+	for i := 1; i < offsetCheckOccurrences && !previous.IsZero(); i++ {
+		next := schedule.Next(previous)
+		if next.IsZero() {
+			break
+		}
+		if gap := next.Sub(previous); window >= gap {
+			return NewValidationError("%s must be shorter than the interval between occurrences (%s)", field, gap)
+		}
+		previous = next
+	}
+
+	*value = window.String()
+	return nil
+}
+
+// normalizeMisfirePolicy validates the misfire settings of a recurring trigger and fills in defaults.
+func normalizeMisfirePolicy(config *RecurrenceOptions) error {
+	switch config.MisfirePolicy {
+	case "":
+		config.MisfirePolicy = models.MisfirePolicyFireOnce
+	case models.MisfirePolicyFireOnce, models.MisfirePolicyFireAllMissed, models.MisfirePolicySkip:
+	default:
+		return NewValidationError("invalid misfire_policy %q: must be one of fire_once, fire_all_missed, skip", config.MisfirePolicy)
+	}
+
+	if config.MisfireCatchupLimit < 0 || config.MisfireCatchupLimit > MaxMisfireCatchupLimit {
+		return NewValidationError("misfire_catchup_limit must be between 1 and %d", MaxMisfireCatchupLimit)
+	}
+	if config.MisfirePolicy != models.MisfirePolicyFireAllMissed {
+		config.MisfireCatchupLimit = 0
+	} else if config.MisfireCatchupLimit == 0 {
+		config.MisfireCatchupLimit = DefaultMisfireCatchupLimit
+	}
+
+	return nil
+}
+
+// normalizeConcurrencyPolicy validates the concurrency policy and running timeout of a recurring
+// trigger and fills in the default policy.
+func normalizeConcurrencyPolicy(config *RecurrenceOptions) error {
+	switch config.ConcurrencyPolicy {
+	case "":
+		config.ConcurrencyPolicy = models.ConcurrencyPolicyAllow
+	case models.ConcurrencyPolicyAllow, models.ConcurrencyPolicyForbid, models.ConcurrencyPolicyReplace:
+	default:
+		return NewValidationError("invalid concurrency_policy %q: must be one of allow, forbid, replace", config.ConcurrencyPolicy)
+	}
+
+	if config.RunningTimeout == "" {
+		return nil
+	}
+	if !config.ConcurrencyPolicy.RequiresAck() {
+		return NewValidationError("running_timeout requires concurrency_policy forbid or replace")
+	}
+	timeout, err := time.ParseDuration(config.RunningTimeout)
+	if err != nil {
+		return NewValidationError("invalid running_timeout: %v", err)
+	}
+	if timeout < time.Second {
+		return NewValidationError("running_timeout must be at least 1s")
+	}
+	config.RunningTimeout = timeout.String()
+
+	return nil
+}
+
+// normalizeActiveWindow validates the optional start_at, end_at and max_fires of a recurring trigger.
+// Bounds are stored as RFC 3339 in UTC.
+func normalizeActiveWindow(config *RecurrenceOptions) error {
+	var start, end time.Time
+	var err error
+	if config.StartAt != "" {
+		if start, err = time.Parse(time.RFC3339, config.StartAt); err != nil {
+			return NewValidationError("invalid start_at: %v", err)
+		}
+		config.StartAt = start.UTC().Format(time.RFC3339)
+	}
+	if config.EndAt != "" {
+		if end, err = time.Parse(time.RFC3339, config.EndAt); err != nil {
+			return NewValidationError("invalid end_at: %v", err)
+		}
+		config.EndAt = end.UTC().Format(time.RFC3339)
+	}
+	if !start.IsZero() && !end.IsZero() && !end.After(start) {
+		return NewValidationError("end_at must be after start_at")
+	}
+
+	if config.MaxFires < 0 {
+		return NewValidationError("max_fires must be positive")
+	}
+	return nil
+}
+
+// normalizeRetryPolicy validates an optional retry_policy block and fills in defaults for unset fields.
+func normalizeRetryPolicy(policy *models.RetryPolicy) error {
+	if policy == nil {
+		return nil
+	}
+
+	if policy.MaxAttempts == 0 {
+		policy.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if policy.MaxAttempts < 1 || policy.MaxAttempts > MaxRetryAttempts {
+		return NewValidationError("retry_policy.max_attempts must be between 1 and %d", MaxRetryAttempts)
+	}
+
+	baseDelay, err := parseRetryDelay("base_delay", policy.BaseDelay, DefaultRetryBaseDelay)
+	if err != nil {
+		return err
+	}
+	maxDelay, err := parseRetryDelay("max_delay", policy.MaxDelay, DefaultRetryMaxDelay)
+	if err != nil {
+		return err
+	}
+	if policy.MaxDelay == "" && maxDelay < baseDelay {
+		maxDelay = baseDelay
+	}
+	if maxDelay < baseDelay {
+		return NewValidationError("retry_policy.max_delay must not be shorter than retry_policy.base_delay")
+	}
+
+	policy.BaseDelay = baseDelay.String()
+	policy.MaxDelay = maxDelay.String()
+	return nil
+}
+
+func parseRetryDelay(field, value string, fallback time.Duration) (time.Duration, error) {
+	if value == "" {
+		return fallback, nil
+	}
+
+	delay, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, NewValidationError("invalid retry_policy.%s: %v", field, err)
+	}
+	if delay <= 0 || delay > MaxRetryDelay {
+		return 0, NewValidationError("retry_policy.%s must be positive and at most %s", field, MaxRetryDelay)
+	}
+	return delay, nil
 }
 
 func normalizeWebhookConfig(config json.RawMessage) (json.RawMessage, error) {
 	var payload struct {
-		Schema     map[string]interface{} `json:"schema"`
-		Endpoint   string                 `json:"endpoint"`
-		HTTPMethod string                 `json:"http_method"`
-		Headers    map[string]string      `json:"headers,omitempty"`
+		Schema              map[string]interface{} `json:"schema"`
+		Endpoint            string                 `json:"endpoint"`
+		HTTPMethod          string                 `json:"http_method"`
+		Headers             map[string]string      `json:"headers,omitempty"`
+		IdempotencyKeyField string                 `json:"idempotency_key_field,omitempty"`
 	}
 	if err := json.Unmarshal(config, &payload); err != nil {
 		return nil, fmt.Errorf("invalid webhook config: %w", err)
@@ -303,6 +630,10 @@ func normalizeWebhookConfig(config json.RawMessage) (json.RawMessage, error) {
 	if payload.Endpoint == "" {
 		return nil, NewValidationError("endpoint is required for webhook triggers")
 	}
+	payload.IdempotencyKeyField = strings.TrimSpace(payload.IdempotencyKeyField)
+	if field := payload.IdempotencyKeyField; field != "" && slices.Contains(strings.Split(field, "."), "") {
+		return nil, NewValidationError("invalid idempotency_key_field %q: must be a dot-separated path of payload fields", field)
+	}
 	if payload.HTTPMethod == "" {
 		payload.HTTPMethod = "POST"
 	}
@@ -326,9 +657,29 @@ func buildTriggerResponse(_ context.Context, trigger *models.Trigger, next *time
 		CreatedAt:        trigger.CreatedAt,
 		UpdatedAt:        trigger.UpdatedAt,
 	}
+
+	options := recurrenceOptionsOf(trigger)
+	if options.MaxFires > 0 {
+		remaining := max(options.MaxFires-trigger.FireCount, 0)
+		resp.RemainingFires = &remaining
+	}
+	if _, end := options.ActiveWindow(); !end.IsZero() {
+		resp.ExpiresAt = &end
+	}
+
 	return resp
 }
 
+// recurrenceOptionsOf reads the recurrence options of a recurring trigger's config; other trigger
+// types have none.
+func recurrenceOptionsOf(trigger *models.Trigger) RecurrenceOptions {
+	var options RecurrenceOptions
+	if IsRecurring(trigger.Type) {
+		_ = json.Unmarshal(trigger.Config, &options)
+	}
+	return options
+}
+
 func resolveLocation(tz string) (*time.Location, error) {
 	if tz == "" {
 		return time.UTC, nil
@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // App holds runtime configuration derived from env vars or files.
@@ -10,6 +12,7 @@ type App struct {
 	// Database & Queue
 	DatabaseURL  string
 	KafkaBrokers string
+	PublisherURL string // event sink; empty for Kafka at KafkaBrokers (see platform/events.Open)
 
 	// Server
 	APIPort     string
@@ -21,6 +24,31 @@ type App struct {
 
 	// CORS
 	CORSOrigins []string
+
+	// Scheduler
+	SchedulerInstanceID    string        // unique per replica; defaults to hostname + random suffix
+	SchedulerLeaseDuration time.Duration // how long a claimed schedule survives without a heartbeat
+	SchedulerWorkers       int           // schedules fired in parallel per instance
+	SchedulerInterval      time.Duration // longest sleep between polls for due schedules
+	SchedulerBatchSize     int           // schedules claimed per query
+	SchedulerWakeInterval  time.Duration // how often new-schedule notifications are checked
+
+	// Scheduler leader election (only the elected instance runs the engine)
+	SchedulerLeaderElection      bool
+	SchedulerLeaderLease         time.Duration // how long the leader's lease survives without renewal
+	SchedulerLeaderRenewInterval time.Duration // how often the lease is renewed (and standbys retry); defaults to a third of the lease
+
+	// Outbox relay (runs in every scheduler instance, leader or not)
+	OutboxRelayInterval  time.Duration // how often the outbox is polled for events left unpublished
+	OutboxRelayBatchSize int           // outbox messages claimed per query
+
+	// Dispatcher (delivers trigger events to their endpoints)
+	DispatcherGroupID        string        // Kafka consumer group shared by the dispatcher instances
+	DispatcherWorkers        int           // consumers per instance; each handles its partitions one event at a time
+	DispatcherTimeout        time.Duration // bound on each HTTP call
+	DispatcherMaxAttempts    int           // HTTP calls per event before its delivery fails
+	DispatcherRetryBaseDelay time.Duration // delay before the first retry, doubling per retry
+	DispatcherRetryMaxDelay  time.Duration // longest delay between two retries
 }
 
 // FromEnv loads the application configuration from environment variables.
@@ -28,11 +56,33 @@ func FromEnv() App {
 	return App{
 		DatabaseURL:  getEnv("DATABASE_URL", ""),
 		KafkaBrokers: getEnv("KAFKA_BROKERS", "localhost:9092"),
+		PublisherURL: getEnv("PUBLISHER_URL", ""),
 		APIPort:      getEnv("API_PORT", "8080"),
 		Environment:  getEnv("ENVIRONMENT", "production"),
 		LogLevel:     getEnv("LOG_LEVEL", "info"),
 		LogEncoding:  getEnv("LOG_ENCODING", "json"),
 		CORSOrigins:  getCORSOrigins(),
+
+		SchedulerInstanceID:    getEnv("SCHEDULER_INSTANCE_ID", ""),
+		SchedulerLeaseDuration: getDurationEnv("SCHEDULER_LEASE_DURATION", 30*time.Second),
+		SchedulerWorkers:       getIntEnv("SCHEDULER_WORKERS", 8),
+		SchedulerInterval:      getDurationEnv("SCHEDULER_INTERVAL", 5*time.Second),
+		SchedulerBatchSize:     getIntEnv("SCHEDULER_BATCH_SIZE", 100),
+		SchedulerWakeInterval:  getDurationEnv("SCHEDULER_WAKE_INTERVAL", 500*time.Millisecond),
+
+		SchedulerLeaderElection:      getBoolEnv("SCHEDULER_LEADER_ELECTION", false),
+		SchedulerLeaderLease:         getDurationEnv("SCHEDULER_LEADER_LEASE", 15*time.Second),
+		SchedulerLeaderRenewInterval: getDurationEnv("SCHEDULER_LEADER_RENEW_INTERVAL", 0),
+
+		OutboxRelayInterval:  getDurationEnv("OUTBOX_RELAY_INTERVAL", time.Second),
+		OutboxRelayBatchSize: getIntEnv("OUTBOX_RELAY_BATCH_SIZE", 100),
+
+		DispatcherGroupID:        getEnv("DISPATCHER_GROUP_ID", "trigger-dispatcher"),
+		DispatcherWorkers:        getIntEnv("DISPATCHER_WORKERS", 4),
+		DispatcherTimeout:        getDurationEnv("DISPATCHER_TIMEOUT", 10*time.Second),
+		DispatcherMaxAttempts:    getIntEnv("DISPATCHER_MAX_ATTEMPTS", 3),
+		DispatcherRetryBaseDelay: getDurationEnv("DISPATCHER_RETRY_BASE_DELAY", time.Second),
+		DispatcherRetryMaxDelay:  getDurationEnv("DISPATCHER_RETRY_MAX_DELAY", 30*time.Second),
 	}
 }
 
@@ -45,6 +95,36 @@ func getEnv(key, defaultValue string) string {
 	return value
 }
 
+// getDurationEnv parses a Go duration (e.g. "30s") from an environment variable.
+// Missing or invalid values fall back to the default.
+func getDurationEnv(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getIntEnv parses a positive integer from an environment variable.
+// Missing or invalid values fall back to the default.
+func getIntEnv(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil || value <= 0 {
+		return defaultValue
+	}
+	return value
+}
+
+// getBoolEnv parses a boolean ("true", "1", "false", ...) from an environment variable.
+// Missing or invalid values fall back to the default.
+func getBoolEnv(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
 // getCORSOrigins parses CORS origins from environment variable.
 // Expected format: comma-separated list (e.g., "http://localhost:3000,https://app.example.com")
 func getCORSOrigins() []string {
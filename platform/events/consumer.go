@@ -0,0 +1,132 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// consumerRetryDelay is how long a KafkaConsumer waits before handing a message back to a handler
+// that failed it, and before fetching again after a fetch error.
+const consumerRetryDelay = 5 * time.Second
+
+// Handler processes one trigger event. An error means the event was not handled and must be retried.
+type Handler func(ctx context.Context, event TriggerEvent) error
+
+// KafkaConsumer reads trigger events from a Kafka topic as a member of a consumer group. Messages
+// are handled one at a time, in partition order, and committed once handled; several consumers
+// with the same group ID share the topic's partitions.
+type KafkaConsumer struct {
+	reader *kafka.Reader
+	logger *zap.Logger
+}
+
+// NewKafkaConsumer creates a consumer of topic in consumer group groupID. A group without committed
+// offsets starts from the oldest retained message.
+func NewKafkaConsumer(brokers []string, topic, groupID string, logger *zap.Logger) *KafkaConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     brokers,
+		Topic:       topic,
+		GroupID:     groupID,
+		StartOffset: kafka.FirstOffset,
+		MinBytes:    1,
+		MaxBytes:    10e6, // 10MB
+		MaxWait:     time.Second,
+	})
+
+	return &KafkaConsumer{
+		reader: reader,
+		logger: logger,
+	}
+}
+
+// Run hands each message to handle until the context is cancelled. A message handle fails is handed
+// to it again after a delay, so delivery is at-least-once and never skips ahead within a partition.
+// Messages that are not trigger events are logged and committed.
+func (c *KafkaConsumer) Run(ctx context.Context, handle Handler) error {
+	for {
+		message, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			c.logger.Error("failed to fetch message from Kafka", zap.Error(err))
+			if !sleep(ctx, consumerRetryDelay) {
+				return ctx.Err()
+			}
+			continue
+		}
+
+		if err := c.handle(ctx, message, handle); err != nil {
+			return err
+		}
+
+		if err := c.reader.CommitMessages(ctx, message); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// The message is handled again after a rebalance or restart; handlers deduplicate
+			c.logger.Error("failed to commit Kafka message",
+				zap.Int("partition", message.Partition),
+				zap.Int64("offset", message.Offset),
+				zap.Error(err))
+		}
+	}
+}
+
+// handle runs handle on one message until it succeeds. It only returns an error when the context is cancelled.
+func (c *KafkaConsumer) handle(ctx context.Context, message kafka.Message, handle Handler) error {
+	event, err := Message{Key: string(message.Key), Value: message.Value}.Event()
+	if err != nil {
+		c.logger.Error("skipping Kafka message that is not a trigger event",
+			zap.Int("partition", message.Partition),
+			zap.Int64("offset", message.Offset),
+			zap.Error(err))
+		return nil
+	}
+
+	for {
+		err := handle(ctx, event)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		c.logger.Error("failed to handle trigger event, will retry",
+			zap.String("event_id", event.EventID),
+			zap.String("trigger_id", event.TriggerID),
+			zap.Duration("retry_in", consumerRetryDelay),
+			zap.Error(err))
+		if !sleep(ctx, consumerRetryDelay) {
+			return ctx.Err()
+		}
+	}
+}
+
+// Close leaves the consumer group and closes the connections.
+func (c *KafkaConsumer) Close() error {
+	if err := c.reader.Close(); err != nil && !errors.Is(err, context.Canceled) {
+		c.logger.Error("failed to close Kafka reader", zap.Error(err))
+		return fmt.Errorf("failed to close Kafka reader: %w", err)
+	}
+	c.logger.Info("Kafka consumer closed successfully")
+	return nil
+}
+
+// sleep waits for d, returning false if the context is cancelled first.
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
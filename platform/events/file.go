@@ -0,0 +1,91 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// FilePublisher appends trigger events to a JSON Lines file, one event per line, for local
+// development without a broker (tail -f it, or read it with jq). Each event is written with a
+// single append, so processes sharing the file do not interleave lines.
+type FilePublisher struct {
+	mu     sync.Mutex
+	file   *os.File
+	path   string
+	logger *zap.Logger
+}
+
+var _ Publisher = (*FilePublisher)(nil)
+
+// NewFilePublisher opens (creating it and its directory if needed) the JSONL file at path for appending.
+func NewFilePublisher(path string, logger *zap.Logger) (*FilePublisher, error) {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create event file directory: %w", err)
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open event file: %w", err)
+	}
+
+	return &FilePublisher{file: file, path: path, logger: logger}, nil
+}
+
+// PublishMessage appends the event as one line and syncs it to disk.
+func (p *FilePublisher) PublishMessage(_ context.Context, eventID, triggerID string, value []byte) error {
+	var line bytes.Buffer
+	if err := json.Compact(&line, value); err != nil {
+		return fmt.Errorf("invalid event %s: %w", eventID, err)
+	}
+	line.WriteByte('\n')
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.file == nil {
+		return ErrPublisherClosed
+	}
+
+	if _, err := p.file.Write(line.Bytes()); err != nil {
+		p.logger.Error("failed to write trigger event to file",
+			zap.String("event_id", eventID),
+			zap.String("trigger_id", triggerID),
+			zap.String("path", p.path),
+			zap.Error(err))
+		return fmt.Errorf("failed to write event file: %w", err)
+	}
+	if err := p.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync event file: %w", err)
+	}
+
+	p.logger.Info("trigger event written to file",
+		zap.String("event_id", eventID),
+		zap.String("trigger_id", triggerID))
+
+	return nil
+}
+
+// Close closes the file.
+func (p *FilePublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.file == nil {
+		return nil
+	}
+	err := p.file.Close()
+	p.file = nil
+	if err != nil {
+		return fmt.Errorf("failed to close event file: %w", err)
+	}
+	return nil
+}
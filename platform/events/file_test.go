@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestFilePublisher(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "nested", "events.jsonl")
+
+	publisher, err := NewFilePublisher(path, zap.NewNop())
+	if err != nil {
+		t.Fatalf("NewFilePublisher: %v", err)
+	}
+	if err := publisher.PublishMessage(ctx, "event-1", "trigger-1", []byte("{\n  \"event_id\": \"event-1\"\n}")); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	if err := publisher.PublishMessage(ctx, "event-2", "trigger-1", []byte("not json")); err == nil {
+		t.Error("PublishMessage accepted an invalid event")
+	}
+	if err := publisher.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := publisher.PublishMessage(ctx, "event-2", "trigger-1", []byte(`{}`)); !errors.Is(err, ErrPublisherClosed) {
+		t.Errorf("PublishMessage after Close err = %v, want %v", err, ErrPublisherClosed)
+	}
+	if err := publisher.Close(); err != nil {
+		t.Errorf("second Close: %v", err)
+	}
+
+	// Reopening appends to the existing file
+	publisher, err = NewFilePublisher(path, zap.NewNop())
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if err := publisher.PublishMessage(ctx, "event-3", "trigger-2", []byte(`{"event_id": "event-3", "payload": {"n": 1}}`)); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	if err := publisher.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read event file: %v", err)
+	}
+	want := `{"event_id":"event-1"}` + "\n" + `{"event_id":"event-3","payload":{"n":1}}` + "\n"
+	if string(data) != want {
+		t.Errorf("event file =\n%s\nwant\n%s", data, want)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("event file has %d lines, want 2", lines)
+	}
+}
@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"go.uber.org/zap"
+)
+
+// KafkaPublisher emits trigger execution jobs to Kafka.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+	logger *zap.Logger
+}
+
+var _ Publisher = (*KafkaPublisher)(nil)
+
+// NewKafkaPublisher creates a Kafka publisher with production-ready configuration.
+func NewKafkaPublisher(brokers []string, topic string, logger *zap.Logger) *KafkaPublisher {
+	writer := &kafka.Writer{
+		Addr:     kafka.TCP(brokers...),
+		Topic:    topic,
+		Balancer: &kafka.LeastBytes{},
+		// Production settings for durability
+		RequiredAcks: kafka.RequireAll, // acks=all - wait for all in-sync replicas
+		Compression:  kafka.Snappy,     // Compression for network efficiency
+		MaxAttempts:  3,                // Retry up to 3 times
+		BatchSize:    1,                // Low latency - publish immediately
+		BatchTimeout: 10 * time.Millisecond,
+		WriteTimeout: 10 * time.Second,
+		ReadTimeout:  10 * time.Second,
+	}
+
+	return &KafkaPublisher{
+		writer: writer,
+		logger: logger,
+	}
+}
+
+// PublishMessage sends an already serialized trigger event to the Kafka topic, keyed by trigger
+// ID for partition ordering.
+func (p *KafkaPublisher) PublishMessage(ctx context.Context, eventID, triggerID string, value []byte) error {
+	// Create Kafka message
+	msg := kafka.Message{
+		Key:   []byte(triggerID), // Key by trigger_id for partition ordering
+		Value: value,
+		Time:  time.Now(),
+	}
+
+	// Publish with context timeout
+	publishCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+
+	err := p.writer.WriteMessages(publishCtx, msg)
+	if err != nil {
+		p.logger.Error("failed to publish trigger event to Kafka",
+			zap.String("event_id", eventID),
+			zap.String("trigger_id", triggerID),
+			zap.String("topic", p.writer.Topic),
+			zap.Error(err))
+		return fmt.Errorf("failed to publish to Kafka: %w", err)
+	}
+
+	p.logger.Info("trigger event published to Kafka",
+		zap.String("event_id", eventID),
+		zap.String("trigger_id", triggerID))
+
+	return nil
+}
+
+// Close gracefully shuts down the Kafka writer.
+func (p *KafkaPublisher) Close() error {
+	if p.writer != nil {
+		err := p.writer.Close()
+		if err != nil {
+			p.logger.Error("failed to close Kafka writer", zap.Error(err))
+			return fmt.Errorf("failed to close Kafka writer: %w", err)
+		}
+		p.logger.Info("Kafka publisher closed successfully")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultMemoryBuffer is how many messages a MemoryPublisher holds before publishing blocks.
+const DefaultMemoryBuffer = 1024
+
+// MemoryPublisher delivers trigger events to an in-process channel. It is meant for tests and
+// embedded consumers: messages nobody receives are lost with the process, and once the buffer is
+// full publishing blocks until a message is received or the context ends.
+type MemoryPublisher struct {
+	mu       sync.RWMutex
+	messages chan Message
+	closed   bool
+}
+
+var _ Publisher = (*MemoryPublisher)(nil)
+
+// NewMemoryPublisher creates an in-memory publisher buffering up to buffer messages
+// (DefaultMemoryBuffer when not positive).
+func NewMemoryPublisher(buffer int) *MemoryPublisher {
+	if buffer <= 0 {
+		buffer = DefaultMemoryBuffer
+	}
+	return &MemoryPublisher{messages: make(chan Message, buffer)}
+}
+
+// Messages returns the channel published messages are delivered on, in publish order. It is
+// closed by Close.
+func (p *MemoryPublisher) Messages() <-chan Message {
+	return p.messages
+}
+
+// PublishMessage hands a message to the channel, waiting for buffer space until ctx ends.
+func (p *MemoryPublisher) PublishMessage(ctx context.Context, eventID, triggerID string, value []byte) error {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		return ErrPublisherClosed
+	}
+
+	msg := Message{EventID: eventID, Key: triggerID, Value: append([]byte(nil), value...)}
+	select {
+	case p.messages <- msg:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close closes the message channel; messages still buffered can be received until it drains.
+// It waits for publishes in progress.
+func (p *MemoryPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !p.closed {
+		p.closed = true
+		close(p.messages)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryPublisher(t *testing.T) {
+	ctx := context.Background()
+	publisher := NewMemoryPublisher(2)
+
+	value := []byte(`{"event_id":"event-1"}`)
+	if err := publisher.PublishMessage(ctx, "event-1", "trigger-1", value); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+	copy(value, "XXXX") // the published message keeps its own copy
+	if err := publisher.PublishMessage(ctx, "event-2", "trigger-2", []byte(`{"event_id":"event-2"}`)); err != nil {
+		t.Fatalf("PublishMessage: %v", err)
+	}
+
+	// The buffer is full, so the next publish waits until its context ends
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := publisher.PublishMessage(timeoutCtx, "event-3", "trigger-3", []byte(`{}`)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("PublishMessage on a full buffer err = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if err := publisher.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if err := publisher.Close(); err != nil {
+		t.Fatalf("second Close: %v", err)
+	}
+	if err := publisher.PublishMessage(ctx, "event-4", "trigger-4", []byte(`{}`)); !errors.Is(err, ErrPublisherClosed) {
+		t.Fatalf("PublishMessage after Close err = %v, want %v", err, ErrPublisherClosed)
+	}
+
+	// Messages buffered before Close are still delivered, in order
+	var got []Message
+	for message := range publisher.Messages() {
+		got = append(got, message)
+	}
+	want := []Message{
+		{EventID: "event-1", Key: "trigger-1", Value: []byte(`{"event_id":"event-1"}`)},
+		{EventID: "event-2", Key: "trigger-2", Value: []byte(`{"event_id":"event-2"}`)},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("received %d messages, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i].EventID != want[i].EventID || got[i].Key != want[i].Key || string(got[i].Value) != string(want[i].Value) {
+			t.Errorf("message %d = %+v, want %+v", i, got[i], want[i])
+		}
+		event, err := got[i].Event()
+		if err != nil {
+			t.Fatalf("decode message %d: %v", i, err)
+		}
+		if event.EventID != want[i].EventID {
+			t.Errorf("message %d decodes to event %q, want %q", i, event.EventID, want[i].EventID)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package events
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// Open creates the publisher selected by publisherURL:
+//
+//	(empty) or kafka://                       Kafka at kafkaBrokers (KAFKA_BROKERS), topic trigger-events
+//	kafka://host1:9092,host2:9092/my-topic    Kafka at the given brokers and topic
+//	file://events.jsonl                       JSON Lines file (relative path)
+//	file:///var/log/trigger-events.jsonl      JSON Lines file (absolute path)
+//	redis://:password@host:6379/0?stream=trigger-events&maxlen=100000
+//
+// memory:// is rejected: nothing in the scheduler or API reads an in-process channel, so publishing
+// would block once its buffer filled. Tests use NewMemoryPublisher directly.
+//
+// Open returns a description of the sink for logging.
+func Open(publisherURL string, kafkaBrokers []string, logger *zap.Logger) (Publisher, string, error) {
+	publisherURL, scheme, rest, err := splitPublisherURL(publisherURL)
+	if err != nil {
+		return nil, "", err
+	}
+
+	switch scheme {
+	case "kafka":
+		brokers, topic, err := KafkaTarget(publisherURL, kafkaBrokers)
+		if err != nil {
+			return nil, "", err
+		}
+		return NewKafkaPublisher(brokers, topic, logger), fmt.Sprintf("kafka (brokers %s, topic %s)", strings.Join(brokers, ","), topic), nil
+
+	case "file":
+		if rest == "" {
+			return nil, "", fmt.Errorf("invalid PUBLISHER_URL %q: no file path", publisherURL)
+		}
+		publisher, err := NewFilePublisher(rest, logger)
+		if err != nil {
+			return nil, "", err
+		}
+		return publisher, "file " + rest, nil
+
+	case "redis":
+		config, err := parseRedisURL(publisherURL)
+		if err != nil {
+			return nil, "", err
+		}
+		return NewRedisPublisher(config, logger), fmt.Sprintf("redis (%s, stream %s)", config.Addr, config.Stream), nil
+
+	case "memory":
+		return nil, "", fmt.Errorf("unsupported PUBLISHER_URL %q: nothing would consume an in-process channel; use file:// to keep events locally", publisherURL)
+
+	default:
+		return nil, "", fmt.Errorf("unsupported PUBLISHER_URL scheme %q", scheme)
+	}
+}
+
+// KafkaTarget returns the brokers and topic a kafka:// publisherURL (or an empty one) publishes to,
+// as Open resolves them, so consumers read the topic the publisher writes. It fails for any other sink.
+func KafkaTarget(publisherURL string, kafkaBrokers []string) ([]string, string, error) {
+	publisherURL, scheme, rest, err := splitPublisherURL(publisherURL)
+	if err != nil {
+		return nil, "", err
+	}
+	if scheme != "kafka" {
+		return nil, "", fmt.Errorf("PUBLISHER_URL %q is not a Kafka sink", publisherURL)
+	}
+
+	brokersPart, topic, _ := strings.Cut(rest, "/")
+	brokers := kafkaBrokers
+	if brokersPart != "" {
+		brokers = strings.Split(brokersPart, ",")
+		for i, broker := range brokers {
+			brokers[i] = strings.TrimSpace(broker)
+		}
+	}
+	if topic == "" {
+		topic = DefaultTopic
+	}
+	return brokers, topic, nil
+}
+
+// splitPublisherURL splits publisherURL into its scheme and the rest, defaulting to kafka://.
+func splitPublisherURL(publisherURL string) (string, string, string, error) {
+	if publisherURL == "" {
+		publisherURL = "kafka://"
+	}
+	scheme, rest, found := strings.Cut(publisherURL, "://")
+	if !found {
+		return "", "", "", fmt.Errorf("invalid PUBLISHER_URL %q: expected scheme://...", publisherURL)
+	}
+	return publisherURL, scheme, rest, nil
+}
+
+// parseRedisURL reads a redis://[[user]:password@]host[:port][/db][?stream=name&maxlen=n] URL.
+func parseRedisURL(redisURL string) (RedisConfig, error) {
+	parsed, err := url.Parse(redisURL)
+	if err != nil {
+		return RedisConfig{}, fmt.Errorf("invalid PUBLISHER_URL: %w", err)
+	}
+
+	config := RedisConfig{
+		Addr:   parsed.Host,
+		Stream: parsed.Query().Get("stream"),
+	}
+	if config.Stream == "" {
+		config.Stream = DefaultTopic
+	}
+	if config.Addr == "" {
+		config.Addr = "localhost:6379"
+	} else if parsed.Port() == "" {
+		config.Addr = net.JoinHostPort(parsed.Hostname(), "6379")
+	}
+	if parsed.User != nil {
+		config.Username = parsed.User.Username()
+		config.Password, _ = parsed.User.Password()
+	}
+	if db := strings.Trim(parsed.Path, "/"); db != "" {
+		if config.DB, err = strconv.Atoi(db); err != nil || config.DB < 0 {
+			return RedisConfig{}, fmt.Errorf("invalid PUBLISHER_URL database %q: must be a number", db)
+		}
+	}
+	if maxLen := parsed.Query().Get("maxlen"); maxLen != "" {
+		if config.MaxLen, err = strconv.ParseInt(maxLen, 10, 64); err != nil || config.MaxLen < 0 {
+			return RedisConfig{}, fmt.Errorf("invalid PUBLISHER_URL maxlen %q: must be a non-negative integer", maxLen)
+		}
+	}
+
+	return config, nil
+}
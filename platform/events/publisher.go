@@ -3,104 +3,67 @@ package events
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
-
-	"github.com/segmentio/kafka-go"
-	"go.uber.org/zap"
 )
 
+// DefaultTopic is the Kafka topic (and Redis stream) trigger events are published to.
+const DefaultTopic = "trigger-events"
+
+// ErrPublisherClosed is returned when publishing through a publisher that was closed.
+var ErrPublisherClosed = errors.New("publisher closed")
+
 // TriggerEvent represents the event structure published to Kafka.
 type TriggerEvent struct {
 	EventID   string                 `json:"event_id"`
 	TriggerID string                 `json:"trigger_id"`
-	Type      string                 `json:"type"` // webhook, time_scheduled, cron_scheduled
+	Type      string                 `json:"type"` // webhook, time_scheduled, cron_scheduled, interval_scheduled
 	Payload   map[string]interface{} `json:"payload"`
 	FiredAt   time.Time              `json:"fired_at"`
-	Source    string                 `json:"source"` // webhook, scheduler, manual-test
+	Source    string                 `json:"source"` // webhook, scheduler, manual-test, backfill
+
+	// ScheduledFor is the occurrence a scheduler or backfill event corresponds to (nil for webhook/manual events).
+	ScheduledFor *time.Time `json:"scheduled_for,omitempty"`
+	// AckRequired is set on events of triggers with concurrency_policy forbid or replace: they stay
+	// running until acknowledged via POST /api/v1/events/{event_id}/ack.
+	AckRequired bool `json:"ack_required,omitempty"`
 }
 
-// Publisher emits trigger execution jobs to Kafka.
-type Publisher struct {
-	writer *kafka.Writer
-	logger *zap.Logger
+// Publisher emits trigger events to a sink: Kafka in production (KafkaPublisher), or one of the
+// alternatives selected by PUBLISHER_URL (see Open).
+type Publisher interface {
+	// PublishMessage sends an already serialized trigger event, keyed by trigger ID. The outbox
+	// stores events in this form, so a relayed message is byte-for-byte the one first attempted.
+	// Publishing is at-least-once: the same event_id may be published more than once, and consumers
+	// are responsible for deduplication.
+	PublishMessage(ctx context.Context, eventID, triggerID string, value []byte) error
+	// Close flushes and releases the sink.
+	Close() error
 }
 
-// NewPublisher creates a Kafka publisher with production-ready configuration.
-func NewPublisher(brokers []string, topic string, logger *zap.Logger) *Publisher {
-	writer := &kafka.Writer{
-		Addr:     kafka.TCP(brokers...),
-		Topic:    topic,
-		Balancer: &kafka.LeastBytes{},
-		// Production settings for durability
-		RequiredAcks: kafka.RequireAll, // acks=all - wait for all in-sync replicas
-		Compression:  kafka.Snappy,     // Compression for network efficiency
-		MaxAttempts:  3,                // Retry up to 3 times
-		BatchSize:    1,                // Low latency - publish immediately
-		BatchTimeout: 10 * time.Millisecond,
-		WriteTimeout: 10 * time.Second,
-		ReadTimeout:  10 * time.Second,
-	}
+// Message is a trigger event as the in-memory sink delivers it.
+type Message struct {
+	EventID string
+	Key     string // the trigger ID
+	Value   []byte // the JSON-encoded TriggerEvent
+}
 
-	return &Publisher{
-		writer: writer,
-		logger: logger,
+// Event decodes the message value.
+func (m Message) Event() (TriggerEvent, error) {
+	var event TriggerEvent
+	if err := json.Unmarshal(m.Value, &event); err != nil {
+		return TriggerEvent{}, fmt.Errorf("failed to unmarshal event: %w", err)
 	}
+	return event, nil
 }
 
-// Publish sends a trigger event to the Kafka topic.
-// This method is idempotent - the same event_id can be published multiple times.
-// Consumer-level deduplication is the responsibility of external consumers.
-func (p *Publisher) Publish(ctx context.Context, event TriggerEvent) error {
-	// Serialize event to JSON
+// Publish serializes a trigger event and sends it through publisher.
+func Publish(ctx context.Context, publisher Publisher, event TriggerEvent) error {
 	messageBytes, err := json.Marshal(event)
 	if err != nil {
-		p.logger.Error("failed to marshal trigger event",
-			zap.String("event_id", event.EventID),
-			zap.Error(err))
 		return fmt.Errorf("failed to marshal event: %w", err)
 	}
 
-	// Create Kafka message
-	msg := kafka.Message{
-		Key:   []byte(event.TriggerID), // Key by trigger_id for partition ordering
-		Value: messageBytes,
-		Time:  time.Now(),
-	}
-
-	// Publish with context timeout
-	publishCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-	defer cancel()
-
-	err = p.writer.WriteMessages(publishCtx, msg)
-	if err != nil {
-		p.logger.Error("failed to publish trigger event to Kafka",
-			zap.String("event_id", event.EventID),
-			zap.String("trigger_id", event.TriggerID),
-			zap.String("topic", p.writer.Topic),
-			zap.Error(err))
-		return fmt.Errorf("failed to publish to Kafka: %w", err)
-	}
-
-	p.logger.Info("trigger event published to Kafka",
-		zap.String("event_id", event.EventID),
-		zap.String("trigger_id", event.TriggerID),
-		zap.String("type", event.Type),
-		zap.String("source", event.Source),
-		zap.Time("fired_at", event.FiredAt))
-
-	return nil
-}
-
-// Close gracefully shuts down the Kafka writer.
-func (p *Publisher) Close() error {
-	if p.writer != nil {
-		err := p.writer.Close()
-		if err != nil {
-			p.logger.Error("failed to close Kafka writer", zap.Error(err))
-			return fmt.Errorf("failed to close Kafka writer: %w", err)
-		}
-		p.logger.Info("Kafka publisher closed successfully")
-	}
-	return nil
+	return publisher.PublishMessage(ctx, event.EventID, event.TriggerID, messageBytes)
 }
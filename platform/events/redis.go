@@ -0,0 +1,224 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// redisTimeout bounds connecting to Redis and each command when the context has no earlier deadline.
+const redisTimeout = 10 * time.Second
+
+// RedisConfig configures a RedisPublisher.
+type RedisConfig struct {
+	// Addr is the server's host:port.
+	Addr string
+	// Username and Password authenticate the connection (AUTH) when Password is set.
+	Username string
+	Password string
+	// DB is the logical database selected after connecting.
+	DB int
+	// Stream is the stream events are added to; defaults to DefaultTopic.
+	Stream string
+	// MaxLen caps the stream at about this many entries (XADD MAXLEN ~); 0 keeps every entry.
+	MaxLen int64
+}
+
+// RedisPublisher appends trigger events to a Redis stream (XADD), one entry per event with the
+// fields event_id, trigger_id and event (the JSON-encoded TriggerEvent). Consumers read it with
+// XREAD or consumer groups (XREADGROUP). It speaks the Redis protocol (RESP) directly over one
+// connection, re-established after network errors.
+type RedisPublisher struct {
+	config RedisConfig
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *bufio.Reader
+	closed bool
+}
+
+var _ Publisher = (*RedisPublisher)(nil)
+
+// NewRedisPublisher creates a Redis Streams publisher. It connects on first publish.
+func NewRedisPublisher(config RedisConfig, logger *zap.Logger) *RedisPublisher {
+	if config.Stream == "" {
+		config.Stream = DefaultTopic
+	}
+	return &RedisPublisher{config: config, logger: logger}
+}
+
+// PublishMessage adds the event to the stream.
+func (p *RedisPublisher) PublishMessage(ctx context.Context, eventID, triggerID string, value []byte) error {
+	args := []string{"XADD", p.config.Stream}
+	if p.config.MaxLen > 0 {
+		args = append(args, "MAXLEN", "~", strconv.FormatInt(p.config.MaxLen, 10))
+	}
+	args = append(args, "*", "event_id", eventID, "trigger_id", triggerID, "event", string(value))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return ErrPublisherClosed
+	}
+
+	entryID, err := p.do(ctx, args...)
+	if err != nil {
+		p.logger.Error("failed to publish trigger event to Redis",
+			zap.String("event_id", eventID),
+			zap.String("trigger_id", triggerID),
+			zap.String("stream", p.config.Stream),
+			zap.Error(err))
+		return fmt.Errorf("failed to publish to Redis: %w", err)
+	}
+
+	p.logger.Info("trigger event published to Redis",
+		zap.String("event_id", eventID),
+		zap.String("trigger_id", triggerID),
+		zap.String("entry_id", entryID))
+
+	return nil
+}
+
+// Close closes the connection.
+func (p *RedisPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Close()
+	p.conn, p.reader = nil, nil
+	if err != nil {
+		return fmt.Errorf("failed to close Redis connection: %w", err)
+	}
+	p.logger.Info("Redis publisher closed successfully")
+	return nil
+}
+
+// do runs one command, connecting first if needed, and returns its reply. A connection that failed
+// mid-command is dropped, since its replies can no longer be matched to commands. Callers must hold p.mu.
+func (p *RedisPublisher) do(ctx context.Context, args ...string) (string, error) {
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return "", err
+		}
+	}
+
+	reply, err := p.roundTrip(ctx, args...)
+	var redisErr redisError
+	if err != nil && !errors.As(err, &redisErr) {
+		_ = p.conn.Close()
+		p.conn, p.reader = nil, nil
+	}
+	return reply, err
+}
+
+// connect dials the server, authenticates and selects the database. Callers must hold p.mu.
+func (p *RedisPublisher) connect(ctx context.Context) error {
+	dialCtx, cancel := context.WithTimeout(ctx, redisTimeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(dialCtx, "tcp", p.config.Addr)
+	if err != nil {
+		return fmt.Errorf("connect to Redis: %w", err)
+	}
+	p.conn, p.reader = conn, bufio.NewReader(conn)
+
+	var setup [][]string
+	if p.config.Password != "" {
+		if p.config.Username != "" {
+			setup = append(setup, []string{"AUTH", p.config.Username, p.config.Password})
+		} else {
+			setup = append(setup, []string{"AUTH", p.config.Password})
+		}
+	}
+	if p.config.DB != 0 {
+		setup = append(setup, []string{"SELECT", strconv.Itoa(p.config.DB)})
+	}
+	for _, args := range setup {
+		if _, err := p.roundTrip(ctx, args...); err != nil {
+			_ = conn.Close()
+			p.conn, p.reader = nil, nil
+			return fmt.Errorf("redis %s: %w", args[0], err)
+		}
+	}
+
+	return nil
+}
+
+// roundTrip writes one command and reads its reply. Callers must hold p.mu.
+func (p *RedisPublisher) roundTrip(ctx context.Context, args ...string) (string, error) {
+	deadline := time.Now().Add(redisTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err := p.conn.SetDeadline(deadline); err != nil {
+		return "", err
+	}
+
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if _, err := io.WriteString(p.conn, command.String()); err != nil {
+		return "", err
+	}
+
+	return readRedisReply(p.reader)
+}
+
+// redisError is an error reply from the server; the connection stays usable after one.
+type redisError string
+
+func (e redisError) Error() string {
+	return string(e)
+}
+
+// readRedisReply reads a simple string, error, integer or bulk string reply.
+func readRedisReply(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return "", fmt.Errorf("empty Redis reply")
+	}
+
+	switch line[0] {
+	case '+', ':':
+		return line[1:], nil
+	case '-':
+		return "", redisError(line[1:])
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return "", fmt.Errorf("invalid Redis bulk reply %q", line)
+		}
+		if size < 0 {
+			return "", nil
+		}
+		data := make([]byte, size+2) // with the trailing CRLF
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return "", err
+		}
+		return string(data[:size]), nil
+	default:
+		return "", fmt.Errorf("unexpected Redis reply %q", line)
+	}
+}
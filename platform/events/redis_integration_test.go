@@ -0,0 +1,169 @@
+//go:build integration
+
+package events
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TestRedisPublisherIntegration publishes through the server in REDIS_URL and reads the entries back
+// with XRANGE. It writes to a stream of its own, deleted afterwards:
+//
+//	REDIS_URL='redis://localhost:6379/0' go test -tags=integration ./platform/events/...
+func TestRedisPublisherIntegration(t *testing.T) {
+	redisURL := os.Getenv("REDIS_URL")
+	if redisURL == "" {
+		t.Skip("REDIS_URL not set")
+	}
+
+	parsed, err := url.Parse(redisURL)
+	if err != nil {
+		t.Fatalf("parse REDIS_URL: %v", err)
+	}
+	stream := fmt.Sprintf("trigger-events-test-%d", time.Now().UnixNano())
+	query := parsed.Query()
+	query.Set("stream", stream)
+	query.Set("maxlen", "1000")
+	parsed.RawQuery = query.Encode()
+
+	publisher, desc, err := Open(parsed.String(), nil, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer publisher.Close()
+	if !strings.Contains(desc, "stream "+stream) {
+		t.Errorf("Open description = %q, want the stream %s", desc, stream)
+	}
+
+	config, err := parseRedisURL(parsed.String())
+	if err != nil {
+		t.Fatalf("parseRedisURL: %v", err)
+	}
+	reader := NewRedisPublisher(config, zap.NewNop())
+	defer reader.Close()
+	t.Cleanup(func() {
+		if _, err := redisCommand(reader, "DEL", stream); err != nil {
+			t.Errorf("DEL %s: %v", stream, err)
+		}
+	})
+
+	ctx := context.Background()
+	firedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	published := []TriggerEvent{
+		{EventID: "event-1", TriggerID: "trigger-1", Type: "webhook", Payload: map[string]interface{}{"n": 1.0}, FiredAt: firedAt, Source: "webhook"},
+		{EventID: "event-2", TriggerID: "trigger-2", Type: "cron_scheduled", FiredAt: firedAt.Add(time.Minute), Source: "scheduler", ScheduledFor: &firedAt},
+	}
+	for _, event := range published {
+		if err := Publish(ctx, publisher, event); err != nil {
+			t.Fatalf("Publish(%s): %v", event.EventID, err)
+		}
+	}
+
+	reply, err := redisCommand(reader, "XRANGE", stream, "-", "+")
+	if err != nil {
+		t.Fatalf("XRANGE: %v", err)
+	}
+	entries, ok := reply.([]interface{})
+	if !ok || len(entries) != len(published) {
+		t.Fatalf("XRANGE = %#v, want %d entries", reply, len(published))
+	}
+	for i, entry := range entries {
+		fields := entryFields(t, entry)
+		want := published[i]
+		if fields["event_id"] != want.EventID || fields["trigger_id"] != want.TriggerID {
+			t.Errorf("entry %d fields = %v, want event_id %s and trigger_id %s", i, fields, want.EventID, want.TriggerID)
+		}
+
+		got, err := Message{EventID: fields["event_id"], Key: fields["trigger_id"], Value: []byte(fields["event"])}.Event()
+		if err != nil {
+			t.Fatalf("entry %d: %v", i, err)
+		}
+		if got.EventID != want.EventID || got.Type != want.Type || !got.FiredAt.Equal(want.FiredAt) || (got.ScheduledFor == nil) != (want.ScheduledFor == nil) {
+			t.Errorf("entry %d event = %+v, want %+v", i, got, want)
+		}
+	}
+}
+
+// redisCommand runs a command on the publisher's connection and returns its reply, arrays included.
+func redisCommand(p *RedisPublisher, args ...string) (interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ctx := context.Background()
+	if p.conn == nil {
+		if err := p.connect(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var command strings.Builder
+	fmt.Fprintf(&command, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&command, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	if err := p.conn.SetDeadline(time.Now().Add(redisTimeout)); err != nil {
+		return nil, err
+	}
+	if _, err := p.conn.Write([]byte(command.String())); err != nil {
+		return nil, err
+	}
+	return readRedisValue(p.reader)
+}
+
+// readRedisValue reads a reply like readRedisReply, and arrays of such replies.
+func readRedisValue(reader *bufio.Reader) (interface{}, error) {
+	first, err := reader.Peek(1)
+	if err != nil {
+		return nil, err
+	}
+	if first[0] != '*' {
+		return readRedisReply(reader)
+	}
+
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	count, err := strconv.Atoi(strings.TrimSuffix(header[1:], "\r\n"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis array reply %q", header)
+	}
+	values := make([]interface{}, 0, max(count, 0))
+	for range count {
+		value, err := readRedisValue(reader)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// entryFields returns the fields of an XRANGE entry: [id, [field, value, ...]].
+func entryFields(t *testing.T, entry interface{}) map[string]string {
+	t.Helper()
+
+	parts, ok := entry.([]interface{})
+	if !ok || len(parts) != 2 {
+		t.Fatalf("stream entry = %#v, want an ID and its fields", entry)
+	}
+	pairs, ok := parts[1].([]interface{})
+	if !ok || len(pairs)%2 != 0 {
+		t.Fatalf("stream entry fields = %#v, want field/value pairs", parts[1])
+	}
+	fields := map[string]string{}
+	for i := 0; i < len(pairs); i += 2 {
+		fields[pairs[i].(string)] = pairs[i+1].(string)
+	}
+	return fields
+}
@@ -0,0 +1,343 @@
+package events
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+)
+
+func TestReadRedisReply(t *testing.T) {
+	cases := []struct {
+		name     string
+		input    string
+		want     string
+		wantErr  error  // matched with errors.Is, or
+		redisErr string // the server error reply
+		errText  string // a substring of any other error
+	}{
+		{name: "simple string", input: "+OK\r\n", want: "OK"},
+		{name: "integer", input: ":42\r\n", want: "42"},
+		{name: "bulk string", input: "$15\r\n1700000000000-0\r\n", want: "1700000000000-0"},
+		{name: "bulk string with CRLF", input: "$4\r\na\r\nb\r\n", want: "a\r\nb"},
+		{name: "empty bulk string", input: "$0\r\n\r\n", want: ""},
+		{name: "null bulk string", input: "$-1\r\n", want: ""},
+		{name: "error", input: "-WRONGPASS invalid username-password pair\r\n", redisErr: "WRONGPASS invalid username-password pair"},
+		{name: "array", input: "*1\r\n$2\r\nOK\r\n", errText: "unexpected Redis reply"},
+		{name: "empty line", input: "\r\n", errText: "empty Redis reply"},
+		{name: "invalid bulk length", input: "$abc\r\n", errText: "invalid Redis bulk reply"},
+		{name: "truncated bulk string", input: "$10\r\nshort\r\n", wantErr: io.ErrUnexpectedEOF},
+		{name: "connection closed", input: "", wantErr: io.EOF},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := readRedisReply(bufio.NewReader(strings.NewReader(tc.input)))
+
+			var redisErr redisError
+			switch {
+			case tc.wantErr != nil:
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("err = %v, want %v", err, tc.wantErr)
+				}
+			case tc.redisErr != "":
+				if !errors.As(err, &redisErr) || string(redisErr) != tc.redisErr {
+					t.Fatalf("err = %#v, want redisError %q", err, tc.redisErr)
+				}
+			case tc.errText != "":
+				if err == nil || !strings.Contains(err.Error(), tc.errText) {
+					t.Fatalf("err = %v, want one containing %q", err, tc.errText)
+				}
+				if errors.As(err, &redisErr) {
+					t.Fatalf("err = %#v, want a protocol error rather than a server error", err)
+				}
+			case err != nil:
+				t.Fatalf("readRedisReply: %v", err)
+			case got != tc.want:
+				t.Fatalf("reply = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRedisPublisherPublishMessage(t *testing.T) {
+	xadd := func(stream string, maxLen []string, eventID string) []string {
+		args := append([]string{"XADD", stream}, maxLen...)
+		return append(args, "*", "event_id", eventID, "trigger_id", "trigger-1", "event", `{"event_id":"`+eventID+`"}`)
+	}
+
+	cases := []struct {
+		name   string
+		config RedisConfig
+		want   []string // commands received, each joined with spaces
+	}{
+		{
+			name:   "defaults",
+			config: RedisConfig{},
+			want: []string{
+				strings.Join(xadd(DefaultTopic, nil, "event-1"), " "),
+				strings.Join(xadd(DefaultTopic, nil, "event-2"), " "),
+			},
+		},
+		{
+			name:   "password, database and maxlen",
+			config: RedisConfig{Password: "secret", DB: 2, Stream: "orders", MaxLen: 1000},
+			want: []string{
+				"AUTH secret",
+				"SELECT 2",
+				strings.Join(xadd("orders", []string{"MAXLEN", "~", "1000"}, "event-1"), " "),
+				strings.Join(xadd("orders", []string{"MAXLEN", "~", "1000"}, "event-2"), " "),
+			},
+		},
+		{
+			name:   "ACL user",
+			config: RedisConfig{Username: "publisher", Password: "secret"},
+			want: []string{
+				"AUTH publisher secret",
+				strings.Join(xadd(DefaultTopic, nil, "event-1"), " "),
+				strings.Join(xadd(DefaultTopic, nil, "event-2"), " "),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := newFakeRedis(t, nil)
+			tc.config.Addr = server.addr()
+			publisher := NewRedisPublisher(tc.config, zap.NewNop())
+			defer publisher.Close()
+
+			for _, eventID := range []string{"event-1", "event-2"} {
+				if err := publisher.PublishMessage(context.Background(), eventID, "trigger-1", []byte(`{"event_id":"`+eventID+`"}`)); err != nil {
+					t.Fatalf("PublishMessage(%s): %v", eventID, err)
+				}
+			}
+
+			if got := server.commandLines(); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("commands =\n%q\nwant\n%q", got, tc.want)
+			}
+			if conns := server.connections(); conns != 1 {
+				t.Errorf("opened %d connections, want 1", conns)
+			}
+		})
+	}
+}
+
+func TestRedisPublisherReconnects(t *testing.T) {
+	// The first XADD drops the connection without replying, as a restarting server would
+	dropped := false
+	server := newFakeRedis(t, func(args []string) string {
+		if args[0] == "XADD" && !dropped {
+			dropped = true
+			return ""
+		}
+		return defaultRedisReply(args)
+	})
+	publisher := NewRedisPublisher(RedisConfig{Addr: server.addr(), Password: "secret"}, zap.NewNop())
+	defer publisher.Close()
+
+	ctx := context.Background()
+	if err := publisher.PublishMessage(ctx, "event-1", "trigger-1", []byte(`{}`)); err == nil {
+		t.Fatal("PublishMessage on a dropped connection succeeded")
+	}
+	if err := publisher.PublishMessage(ctx, "event-1", "trigger-1", []byte(`{}`)); err != nil {
+		t.Fatalf("PublishMessage after reconnecting: %v", err)
+	}
+
+	if conns := server.connections(); conns != 2 {
+		t.Errorf("opened %d connections, want 2", conns)
+	}
+	auths := 0
+	for _, line := range server.commandLines() {
+		if line == "AUTH secret" {
+			auths++
+		}
+	}
+	if auths != 2 {
+		t.Errorf("authenticated %d times, want once per connection", auths)
+	}
+}
+
+func TestRedisPublisherErrorReplies(t *testing.T) {
+	t.Run("command error keeps the connection", func(t *testing.T) {
+		failed := false
+		server := newFakeRedis(t, func(args []string) string {
+			if args[0] == "XADD" && !failed {
+				failed = true
+				return "-OOM command not allowed when used memory > 'maxmemory'\r\n"
+			}
+			return defaultRedisReply(args)
+		})
+		publisher := NewRedisPublisher(RedisConfig{Addr: server.addr()}, zap.NewNop())
+		defer publisher.Close()
+
+		ctx := context.Background()
+		err := publisher.PublishMessage(ctx, "event-1", "trigger-1", []byte(`{}`))
+		if err == nil || !strings.Contains(err.Error(), "OOM") {
+			t.Fatalf("PublishMessage err = %v, want the OOM reply", err)
+		}
+		if err := publisher.PublishMessage(ctx, "event-1", "trigger-1", []byte(`{}`)); err != nil {
+			t.Fatalf("PublishMessage after an error reply: %v", err)
+		}
+		if conns := server.connections(); conns != 1 {
+			t.Errorf("opened %d connections, want 1", conns)
+		}
+	})
+
+	t.Run("authentication failure", func(t *testing.T) {
+		server := newFakeRedis(t, func(args []string) string {
+			if args[0] == "AUTH" {
+				return "-WRONGPASS invalid username-password pair\r\n"
+			}
+			return defaultRedisReply(args)
+		})
+		publisher := NewRedisPublisher(RedisConfig{Addr: server.addr(), Password: "wrong"}, zap.NewNop())
+		defer publisher.Close()
+
+		err := publisher.PublishMessage(context.Background(), "event-1", "trigger-1", []byte(`{}`))
+		if err == nil || !strings.Contains(err.Error(), "redis AUTH: WRONGPASS") {
+			t.Fatalf("PublishMessage err = %v, want the AUTH failure", err)
+		}
+		for _, line := range server.commandLines() {
+			if strings.HasPrefix(line, "XADD") {
+				t.Errorf("sent %q after authentication failed", line)
+			}
+		}
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		server := newFakeRedis(t, nil)
+		publisher := NewRedisPublisher(RedisConfig{Addr: server.addr()}, zap.NewNop())
+		if err := publisher.PublishMessage(context.Background(), "event-1", "trigger-1", []byte(`{}`)); err != nil {
+			t.Fatalf("PublishMessage: %v", err)
+		}
+		if err := publisher.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		if err := publisher.PublishMessage(context.Background(), "event-2", "trigger-1", []byte(`{}`)); !errors.Is(err, ErrPublisherClosed) {
+			t.Fatalf("PublishMessage after Close err = %v, want %v", err, ErrPublisherClosed)
+		}
+	})
+}
+
+// fakeRedis is a RESP server on a local listener that records every command it receives and answers
+// it with reply. An empty reply closes the connection instead.
+type fakeRedis struct {
+	listener net.Listener
+	reply    func(args []string) string
+
+	mu       sync.Mutex
+	commands [][]string
+	conns    int
+}
+
+func newFakeRedis(t *testing.T, reply func(args []string) string) *fakeRedis {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	if reply == nil {
+		reply = defaultRedisReply
+	}
+	server := &fakeRedis{listener: listener, reply: reply}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.mu.Lock()
+			server.conns++
+			server.mu.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+// defaultRedisReply acknowledges AUTH and SELECT and returns a stream entry ID for XADD.
+func defaultRedisReply(args []string) string {
+	if args[0] == "XADD" {
+		return "$15\r\n1700000000000-0\r\n"
+	}
+	return "+OK\r\n"
+}
+
+func (s *fakeRedis) addr() string {
+	return s.listener.Addr().String()
+}
+
+func (s *fakeRedis) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		args, err := readRedisCommand(reader)
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		s.commands = append(s.commands, args)
+		reply := s.reply(args)
+		s.mu.Unlock()
+		if reply == "" {
+			return
+		}
+		if _, err := io.WriteString(conn, reply); err != nil {
+			return
+		}
+	}
+}
+
+// commandLines returns the commands received so far, each joined with spaces.
+func (s *fakeRedis) commandLines() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lines := make([]string, len(s.commands))
+	for i, args := range s.commands {
+		lines[i] = strings.Join(args, " ")
+	}
+	return lines
+}
+
+func (s *fakeRedis) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.conns
+}
+
+// readRedisCommand reads one command sent as an array of bulk strings.
+func readRedisCommand(reader *bufio.Reader) ([]string, error) {
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(header, "*") {
+		return nil, fmt.Errorf("unexpected command header %q", header)
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(header[1:]))
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]string, count)
+	for i := range args {
+		if args[i], err = readRedisReply(reader); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}